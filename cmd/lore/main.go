@@ -2,36 +2,62 @@
 // @version		1.0
 // @description	REST API for the Lore Stellar blockchain token explorer. Provides access to MTLAP (Persons), MTLAC (Companies), and MTLAX (Synthetic) accounts, relationships, reputation scores, and statistics.
 // @BasePath		/
+// @securityDefinitions.apikey	BearerAuth
+// @in								header
+// @name							Authorization
+// @description					Optional API key as "Bearer <token>". Keyed requests use the key's rate limit and daily quota instead of the per-IP limit.
 
 package main
 
 import (
+	"archive/zip"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"regexp"
+	"strconv"
+	"strings"
 	"syscall"
+	"text/tabwriter"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/mtlprog/lore/internal/api"
 	_ "github.com/mtlprog/lore/internal/api/docs"
+	"github.com/mtlprog/lore/internal/apikey"
+	"github.com/mtlprog/lore/internal/cache"
 	"github.com/mtlprog/lore/internal/config"
 	"github.com/mtlprog/lore/internal/database"
+	"github.com/mtlprog/lore/internal/dump"
+	"github.com/mtlprog/lore/internal/events"
+	"github.com/mtlprog/lore/internal/export"
 	"github.com/mtlprog/lore/internal/handler"
+	"github.com/mtlprog/lore/internal/lint"
 	"github.com/mtlprog/lore/internal/logger"
 	"github.com/mtlprog/lore/internal/middleware"
+	"github.com/mtlprog/lore/internal/relation"
 	"github.com/mtlprog/lore/internal/repository"
 	"github.com/mtlprog/lore/internal/reputation"
 	"github.com/mtlprog/lore/internal/service"
 	"github.com/mtlprog/lore/internal/static"
 	"github.com/mtlprog/lore/internal/sync"
 	"github.com/mtlprog/lore/internal/template"
+	"github.com/samber/lo"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
 )
 
+// relationTypeNameRe matches ManageData key prefixes accepted as relation types.
+// Digits are not allowed because they would be parsed as the relation index.
+var relationTypeNameRe = regexp.MustCompile(`^[A-Za-z]+$`)
+
 func main() {
 	app := &cli.App{
 		Name:  "lore",
@@ -76,6 +102,11 @@ func main() {
 						Usage:   "HTTP server port",
 						EnvVars: []string{"PORT"},
 					},
+					&cli.StringFlag{
+						Name:    "grpc-port",
+						Usage:   "gRPC server port (empty = gRPC disabled); the gRPC API has no rate limit or API keys, keep it on an internal network",
+						EnvVars: []string{"GRPC_PORT"},
+					},
 					&cli.IntFlag{
 						Name:    "rate-limit",
 						Aliases: []string{"r"},
@@ -83,6 +114,57 @@ func main() {
 						Usage:   "Maximum requests per minute per IP address",
 						EnvVars: []string{"RATE_LIMIT"},
 					},
+					&cli.StringFlag{
+						Name:    "sep7-origin-domain",
+						Usage:   "Domain serving this instance; set with --sep7-signing-key to sign SEP-0007 wallet URIs",
+						EnvVars: []string{"SEP7_ORIGIN_DOMAIN"},
+					},
+					&cli.StringFlag{
+						Name:    "sep7-signing-key",
+						Usage:   "Secret seed of URI_REQUEST_SIGNING_KEY published in /.well-known/stellar.toml",
+						EnvVars: []string{"SEP7_SIGNING_KEY"},
+					},
+					&cli.StringFlag{
+						Name:    "sep7-callback",
+						Usage:   "HTTPS URL wallets POST signed transactions to instead of submitting them",
+						EnvVars: []string{"SEP7_CALLBACK"},
+					},
+					&cli.StringFlag{
+						Name:    "fee-strategy",
+						Value:   string(service.DefaultFeeStrategy),
+						Usage:   "Base fee of generated transactions from Horizon fee_stats: min, p50, p70, p90 or p99",
+						EnvVars: []string{"FEE_STRATEGY"},
+					},
+					&cli.Int64Flag{
+						Name:    "max-base-fee",
+						Value:   service.DefaultMaxBaseFee,
+						Usage:   "Upper bound of the base fee per operation in stroops (0 = no bound)",
+						EnvVars: []string{"MAX_BASE_FEE"},
+					},
+					&cli.DurationFlag{
+						Name:    "tx-validity",
+						Value:   service.DefaultTxValidity,
+						Usage:   "How long generated transactions can be submitted (0 = no time bound)",
+						EnvVars: []string{"TX_VALIDITY"},
+					},
+					&cli.IntFlag{
+						Name:    "council-size",
+						Value:   config.DefaultCouncilSize,
+						Usage:   "Number of council seats the issuer's signers are reconciled against",
+						EnvVars: []string{"COUNCIL_SIZE"},
+					},
+					&cli.IntFlag{
+						Name:    "cache-size",
+						Value:   config.DefaultCacheSize,
+						Usage:   "Memory bound of the response cache in megabytes, emptied when a sync run completes",
+						EnvVars: []string{"CACHE_SIZE"},
+					},
+					&cli.IntFlag{
+						Name:    "events-max-connections",
+						Value:   config.DefaultEventsMaxConnections,
+						Usage:   "Maximum open /api/v1/events streams; further clients get 503",
+						EnvVars: []string{"EVENTS_MAX_CONNECTIONS"},
+					},
 				},
 				Action: runServe,
 			},
@@ -97,6 +179,118 @@ func main() {
 				},
 				Action: runSync,
 			},
+			{
+				Name:  "lint",
+				Usage: "Check relationships and metadata for inconsistencies",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "account",
+						Aliases: []string{"a"},
+						Usage:   "Lint a single account instead of all synced accounts",
+					},
+					&cli.StringFlag{
+						Name:  "format",
+						Value: "text",
+						Usage: "Output format (text, json)",
+					},
+					&cli.BoolFlag{
+						Name:  "strict",
+						Usage: "Exit with an error if any error-level issue is found",
+					},
+				},
+				Action: runLint,
+			},
+			{
+				Name:  "export",
+				Usage: "Export the synced dataset with a manifest, one file per table",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "format",
+						Value: string(export.FormatCSV),
+						Usage: "File format (csv, jsonl, parquet)",
+					},
+					&cli.StringFlag{
+						Name:     "out",
+						Aliases:  []string{"o"},
+						Required: true,
+						Usage:    "Output directory (created if missing)",
+					},
+					&cli.BoolFlag{
+						Name:  "exclude-personal",
+						Usage: "Leave out contact handles and membership contracts",
+					},
+				},
+				Action: runExport,
+			},
+			{
+				Name:      "dump",
+				Usage:     "Write a compressed snapshot of all synced tables for restore",
+				ArgsUsage: "<file>",
+				Action:    runDump,
+			},
+			{
+				Name:      "restore",
+				Usage:     "Load a dump into an empty database and migrate it to the current schema",
+				ArgsUsage: "<file>",
+				Action:    runRestore,
+			},
+			{
+				Name:  "relation-types",
+				Usage: "Manage BSN relation types (restart serve to apply changes)",
+				Subcommands: []*cli.Command{
+					{
+						Name:   "list",
+						Usage:  "List relation types",
+						Action: runRelationTypesList,
+					},
+					{
+						Name:      "set",
+						Usage:     "Add a relation type or edit an existing one",
+						ArgsUsage: "<name>",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "category", Usage: "Display category (e.g. WORK); empty hides the type from grouping"},
+							&cli.StringFlag{Name: "color", Usage: "CSS color of the category (e.g. #58a6ff)"},
+							&cli.StringFlag{Name: "paired-with", Usage: "Counterpart type that confirms this one"},
+							&cli.BoolFlag{Name: "requires-confirmation", Usage: "Relation is only confirmed when the counterpart is declared"},
+							&cli.BoolFlag{Name: "symmetric", Usage: "Both sides must declare the same type"},
+							&cli.BoolFlag{Name: "source-is-owner", Usage: "The declaring account owns the target (OWNERSHIP types such as Owner)"},
+							&cli.StringFlag{Name: "conflicts-with", Usage: "Comma-separated types that cannot point at the same account (empty clears)"},
+							&cli.StringFlag{Name: "display-name", Usage: "Human-readable name"},
+							&cli.StringFlag{Name: "description", Usage: "Description"},
+							&cli.IntFlag{Name: "sort-order", Usage: "Position in listings and categories"},
+						},
+						Action: runRelationTypesSet,
+					},
+				},
+			},
+			{
+				Name:  "apikey",
+				Usage: "Manage API keys (clients send them as Authorization: Bearer <key>)",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "create",
+						Usage:     "Create an API key and print its token",
+						ArgsUsage: "<name>",
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "scopes", Value: string(apikey.ScopeRead), Usage: "Comma-separated scopes (read, export; webhooks is reserved)"},
+							&cli.IntFlag{Name: "rate-limit", Usage: "Requests per minute (0 uses the serve --rate-limit)"},
+							&cli.IntFlag{Name: "daily-quota", Usage: "Requests per UTC day (0 is unlimited)"},
+						},
+						Action: runAPIKeyCreate,
+					},
+					{
+						Name:      "revoke",
+						Usage:     "Revoke an API key (running servers stop accepting it within a minute)",
+						ArgsUsage: "<id>",
+						Action:    runAPIKeyRevoke,
+					},
+					{
+						Name:   "list",
+						Usage:  "List API keys with today's usage",
+						Action: runAPIKeyList,
+					},
+				},
+			},
 		},
 		Action: runServe,
 	}
@@ -127,6 +321,11 @@ func runServe(c *cli.Context) error {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	relationTypes, err := loadRelationTypes(ctx, db.Pool())
+	if err != nil {
+		return err
+	}
+
 	tmpl, err := template.New()
 	if err != nil {
 		return fmt.Errorf("failed to load templates: %w", err)
@@ -138,23 +337,123 @@ func runServe(c *cli.Context) error {
 		return fmt.Errorf("failed to create account repository: %w", err)
 	}
 
+	// Hot reads and pages are cached until the next sync run or account resync is published
+	responses, err := cache.New(int64(c.Int("cache-size")) << 20)
+	if err != nil {
+		return fmt.Errorf("invalid cache size: %w", err)
+	}
+	cachedAccounts, err := repository.NewCachedAccountRepository(accounts, responses)
+	if err != nil {
+		return fmt.Errorf("failed to create cached account repository: %w", err)
+	}
+
 	// Create reputation service (optional, non-critical feature)
 	repService, err := reputation.NewService(db.Pool())
 	if err != nil {
 		slog.Warn("failed to create reputation service, feature will be disabled", "error", err)
 	}
 
-	h, err := handler.New(stellar, accounts, repService, tmpl)
+	// Create relationship linter (optional, non-critical feature)
+	linter, err := lint.NewService(db.Pool(), relationTypes)
+	if err != nil {
+		slog.Warn("failed to create linter, issues endpoint will be disabled", "error", err)
+	}
+
+	txURIs, err := service.NewTxURIBuilder(service.TxURIConfig{
+		OriginDomain: c.String("sep7-origin-domain"),
+		SigningSeed:  c.String("sep7-signing-key"),
+		Callback:     c.String("sep7-callback"),
+	})
+	if err != nil {
+		return fmt.Errorf("invalid SEP-0007 configuration: %w", err)
+	}
+
+	feeStrategy, err := service.ParseFeeStrategy(c.String("fee-strategy"))
+	if err != nil {
+		return fmt.Errorf("invalid fee strategy: %w", err)
+	}
+	txSettings := service.TxSettings{
+		FeeStrategy: feeStrategy,
+		MaxBaseFee:  c.Int64("max-base-fee"),
+		Validity:    c.Duration("tx-validity"),
+	}
+	if c.Int("council-size") < 1 {
+		return fmt.Errorf("invalid council size %d: must be at least 1", c.Int("council-size"))
+	}
+
+	// Submitted transactions are followed by a background resync of the source account
+	syncer, err := sync.New(db.Pool(), horizonURL, sync.WithRelationTypes(relationTypes))
+	if err != nil {
+		return fmt.Errorf("failed to create syncer: %w", err)
+	}
+	generated, err := repository.NewGeneratedTxRepository(db.Pool())
+	if err != nil {
+		return fmt.Errorf("failed to create generated transaction repository: %w", err)
+	}
+	submitter, err := service.NewTxSubmitter(stellar, generated, syncer)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction submitter: %w", err)
+	}
+	defer submitter.Close()
+
+	collections, err := repository.NewCollectionRepository(db.Pool())
+	if err != nil {
+		return fmt.Errorf("failed to create collection repository: %w", err)
+	}
+
+	h, err := handler.New(stellar, cachedAccounts, repService, tmpl,
+		handler.WithTxURIBuilder(txURIs),
+		handler.WithTxSubmitter(submitter),
+		handler.WithTxSettings(txSettings),
+		handler.WithCollectionStore(collections),
+		handler.WithCouncilSize(c.Int("council-size")),
+		handler.WithRelationTypes(relationTypes),
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create handler: %w", err)
 	}
 
+	exporter, err := export.NewExporter(db.Pool())
+	if err != nil {
+		return fmt.Errorf("failed to create exporter: %w", err)
+	}
+
+	// Live events are loaded from the events table when sync announces them
+	eventRepo, err := events.NewRepository(db.Pool())
+	if err != nil {
+		return fmt.Errorf("failed to create event repository: %w", err)
+	}
+	eventHub, err := events.NewHub(eventRepo, c.Int("events-max-connections"))
+	if err != nil {
+		return fmt.Errorf("failed to create event hub: %w", err)
+	}
+	if err := eventHub.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start event hub: %w", err)
+	}
+
 	// Create API handler
-	apiHandler, err := api.New(accounts, repService)
+	apiHandler, err := api.New(cachedAccounts, repService, linter, submitter,
+		api.WithExporter(exporter),
+		api.WithEvents(eventHub),
+		api.WithRelationTypes(relationTypes),
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create API handler: %w", err)
 	}
 
+	// gRPC mirrors the read API for internal services on its own port
+	var grpcServer *grpc.Server
+	var grpcAPI *api.GRPCServer
+	grpcPort := c.String("grpc-port")
+	if grpcPort != "" {
+		grpcAPI, err = api.NewGRPCServer(cachedAccounts, repService, api.WithRelationTypes(relationTypes))
+		if err != nil {
+			return fmt.Errorf("failed to create gRPC server: %w", err)
+		}
+		grpcServer = grpc.NewServer(grpc.ChainUnaryInterceptor(api.RecoverUnary))
+		grpcAPI.Register(grpcServer)
+	}
+
 	mux := http.NewServeMux()
 	h.RegisterRoutes(mux)
 	apiHandler.RegisterRoutes(mux)
@@ -179,14 +478,61 @@ func runServe(c *cli.Context) error {
 	if rateLimit <= 0 {
 		rateLimit = config.DefaultRateLimit
 	}
-	limiter, err := middleware.New(rateLimit, staticPaths)
+	keys, err := apikey.NewRepository(db.Pool())
+	if err != nil {
+		return fmt.Errorf("failed to create API key repository: %w", err)
+	}
+	authenticator, err := apikey.NewAuthenticator(keys)
+	if err != nil {
+		return fmt.Errorf("failed to create API key authenticator: %w", err)
+	}
+	defer authenticator.Close()
+	limiter, err := middleware.New(rateLimit, staticPaths,
+		middleware.WithAPIKeys(authenticator),
+		middleware.WithPathScope("/api/v1/export", apikey.ScopeExport),
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create rate limiter: %w", err)
 	}
 	defer limiter.Close()
 
-	// Apply middleware chain: Cache-Control -> Rate Limiter -> Router
-	handler := middleware.CacheControl(limiter.Middleware(mux))
+	etags, err := middleware.NewETags(accounts)
+	if err != nil {
+		return fmt.Errorf("failed to create ETag middleware: %w", err)
+	}
+
+	pages, err := middleware.NewPageCache(responses)
+	if err != nil {
+		return fmt.Errorf("failed to create page cache: %w", err)
+	}
+
+	// Drop cached data on every replica as soon as a sync run is published.
+	// Single-account resyncs after a submission are published as runs too.
+	listenCtx, stopListening := context.WithCancel(ctx)
+	defer stopListening()
+	go func() {
+		err := cache.Listen(listenCtx, db.Pool(), sync.RunsChannel, func() {
+			slog.Info("sync run or account resync published, invalidating caches")
+			responses.Invalidate()
+			etags.Invalidate()
+		})
+		if err != nil {
+			slog.Error("cache invalidation listener failed", "error", err)
+		}
+	}()
+	go func() {
+		err := cache.Listen(listenCtx, db.Pool(), events.Channel, func() {
+			if err := eventHub.Poll(listenCtx); err != nil {
+				slog.Error("failed to load new events", "error", err)
+			}
+		})
+		if err != nil {
+			slog.Error("event listener failed", "error", err)
+		}
+	}()
+
+	// Apply middleware chain: Cache-Control -> Rate Limiter -> ETags -> Page Cache -> Router
+	handler := middleware.CacheControl(limiter.Middleware(etags.Middleware(pages.Middleware(mux))))
 
 	server := &http.Server{
 		Addr:         ":" + port,
@@ -195,8 +541,10 @@ func runServe(c *cli.Context) error {
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	// Event streams never finish on their own; end them so Shutdown can complete
+	server.RegisterOnShutdown(eventHub.Close)
 
-	serverErr := make(chan error, 1)
+	serverErr := make(chan error, 2)
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
 	defer signal.Stop(done)
@@ -208,6 +556,19 @@ func runServe(c *cli.Context) error {
 		}
 	}()
 
+	if grpcServer != nil {
+		lis, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			return fmt.Errorf("failed to listen on gRPC port: %w", err)
+		}
+		go func() {
+			slog.Info("starting gRPC server", "grpc_addr", lis.Addr().String())
+			if err := grpcServer.Serve(lis); err != nil {
+				serverErr <- fmt.Errorf("gRPC: %w", err)
+			}
+		}()
+	}
+
 	select {
 	case err := <-serverErr:
 		return fmt.Errorf("server error: %w", err)
@@ -218,6 +579,10 @@ func runServe(c *cli.Context) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	if grpcServer != nil {
+		grpcAPI.Shutdown()
+		stopGRPC(ctx, grpcServer)
+	}
 	if err := server.Shutdown(ctx); err != nil {
 		return fmt.Errorf("server shutdown failed: %w", err)
 	}
@@ -226,6 +591,21 @@ func runServe(c *cli.Context) error {
 	return nil
 }
 
+// stopGRPC waits for in-flight RPCs to finish, or stops the server when ctx
+// expires first.
+func stopGRPC(ctx context.Context, srv *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		srv.Stop()
+	}
+}
+
 func runSync(c *cli.Context) error {
 	ctx := c.Context
 
@@ -261,3 +641,443 @@ func runSync(c *cli.Context) error {
 
 	return nil
 }
+
+func runLint(c *cli.Context) error {
+	ctx := c.Context
+
+	databaseURL := c.String("database-url")
+	accountID := c.String("account")
+	format := c.String("format")
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported format %q (expected text or json)", format)
+	}
+
+	db, err := database.New(ctx, databaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	if err := database.RunMigrations(ctx, db.Pool()); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	relationTypes, err := loadRelationTypes(ctx, db.Pool())
+	if err != nil {
+		return err
+	}
+
+	linter, err := lint.NewService(db.Pool(), relationTypes)
+	if err != nil {
+		return fmt.Errorf("failed to create linter: %w", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	var total, errorCount int
+	report := func(id string, findings []lint.Finding) error {
+		for _, f := range findings {
+			total++
+			if f.Severity == lint.SeverityError {
+				errorCount++
+			}
+			if format == "json" {
+				if err := enc.Encode(f); err != nil {
+					return fmt.Errorf("encode finding: %w", err)
+				}
+				continue
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\n\tfix: %s (%s)\n", id, f.Severity, f.Rule, f.Message, f.Suggestion, f.FixURL)
+		}
+		return nil
+	}
+
+	if accountID != "" {
+		findings, exists, err := linter.LintAccount(ctx, accountID)
+		if err != nil {
+			return fmt.Errorf("lint failed: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("account %s not found", accountID)
+		}
+		if err := report(accountID, findings); err != nil {
+			return err
+		}
+	} else if err := linter.LintAll(ctx, report); err != nil {
+		return fmt.Errorf("lint failed: %w", err)
+	}
+
+	slog.Info("lint completed", "issues", total, "errors", errorCount)
+
+	if c.Bool("strict") && errorCount > 0 {
+		return fmt.Errorf("found %d error-level issues", errorCount)
+	}
+	return nil
+}
+
+func runExport(c *cli.Context) error {
+	ctx := c.Context
+
+	format, err := export.ParseFormat(c.String("format"))
+	if err != nil {
+		return err
+	}
+
+	db, err := database.New(ctx, c.String("database-url"))
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	if err := database.RunMigrations(ctx, db.Pool()); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	exporter, err := export.NewExporter(db.Pool())
+	if err != nil {
+		return fmt.Errorf("failed to create exporter: %w", err)
+	}
+
+	sink, err := export.NewDirSink(c.String("out"))
+	if err != nil {
+		return err
+	}
+
+	manifest, err := exporter.Export(ctx, export.Options{
+		Format:          format,
+		ExcludePersonal: c.Bool("exclude-personal"),
+	}, sink)
+	if closeErr := sink.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("close export file: %w", closeErr)
+	}
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	for _, t := range manifest.Tables {
+		slog.Info("exported table", "table", t.Name, "file", t.File, "rows", t.Rows)
+	}
+	slog.Info("export completed",
+		"dir", c.String("out"),
+		"format", manifest.Format,
+		"schema_version", manifest.SchemaVersion,
+		"sync_run_id", manifest.SyncRunID,
+	)
+	return nil
+}
+
+func runDump(c *cli.Context) error {
+	ctx := c.Context
+
+	path := c.Args().First()
+	if path == "" {
+		return errors.New("dump file is required")
+	}
+
+	db, err := database.New(ctx, c.String("database-url"))
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	if err := database.RunMigrations(ctx, db.Pool()); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	dumper, err := dump.NewDumper(db.Pool())
+	if err != nil {
+		return fmt.Errorf("failed to create dumper: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create dump file: %w", err)
+	}
+	manifest, err := dumper.Dump(ctx, f)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return fmt.Errorf("dump failed: %w", err)
+	}
+
+	var rows int64
+	for _, t := range manifest.Tables {
+		rows += t.Rows
+	}
+	slog.Info("dump completed",
+		"file", path,
+		"schema_version", manifest.SchemaVersion,
+		"tables", len(manifest.Tables),
+		"rows", rows,
+	)
+	return nil
+}
+
+func runRestore(c *cli.Context) error {
+	ctx := c.Context
+
+	path := c.Args().First()
+	if path == "" {
+		return errors.New("dump file is required")
+	}
+
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("failed to open dump: %w", err)
+	}
+	defer archive.Close()
+
+	db, err := database.New(ctx, c.String("database-url"))
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	restorer, err := dump.NewRestorer(db.Pool())
+	if err != nil {
+		return fmt.Errorf("failed to create restorer: %w", err)
+	}
+
+	manifest, err := restorer.Restore(ctx, &archive.Reader)
+	if err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+
+	attrs := []any{"file", path, "schema_version", manifest.SchemaVersion, "dumped_at", manifest.CreatedAt}
+	if manifest.SyncRun != nil {
+		attrs = append(attrs, "sync_run_id", manifest.SyncRun.ID, "synced_at", manifest.SyncRun.FinishedAt)
+	}
+	slog.Info("restore completed", attrs...)
+	return nil
+}
+
+// loadRelationTypes loads relation_type_settings into the registry passed to
+// the handlers, the syncer and the linter.
+func loadRelationTypes(ctx context.Context, pool *pgxpool.Pool) (*relation.Registry, error) {
+	repo, err := relation.NewRepository(pool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create relation type repository: %w", err)
+	}
+	types, err := repo.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load relation types: %w", err)
+	}
+	slog.Info("loaded relation types", "count", len(types.Types()), "categories", len(types.Categories()))
+	return types, nil
+}
+
+func openRelationTypes(c *cli.Context) (*database.DB, *relation.Repository, error) {
+	db, err := database.New(c.Context, c.String("database-url"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if err := database.RunMigrations(c.Context, db.Pool()); err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	repo, err := relation.NewRepository(db.Pool())
+	if err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("failed to create relation type repository: %w", err)
+	}
+	return db, repo, nil
+}
+
+func runRelationTypesList(c *cli.Context) error {
+	db, repo, err := openRelationTypes(c)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	types, err := repo.List(c.Context)
+	if err != nil {
+		return fmt.Errorf("failed to list relation types: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCATEGORY\tCOLOR\tPAIRED WITH\tCONFIRM\tSYMMETRIC\tOWNER\tORDER\tDISPLAY NAME\tCONFLICTS WITH")
+	for _, t := range types {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\t%t\t%t\t%d\t%s\t%s\n",
+			t.Name, t.Category, t.Color, t.PairedWith, t.RequiresConfirmation, t.Symmetric, t.SourceIsOwner, t.SortOrder, t.DisplayName,
+			strings.Join(t.ConflictsWith, ","))
+	}
+	return w.Flush()
+}
+
+func runRelationTypesSet(c *cli.Context) error {
+	name := c.Args().First()
+	if name == "" {
+		return errors.New("relation type name is required")
+	}
+	if !relationTypeNameRe.MatchString(name) {
+		return fmt.Errorf("invalid relation type name %q: must start with a letter and contain only letters", name)
+	}
+
+	db, repo, err := openRelationTypes(c)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	types, err := repo.Load(c.Context)
+	if err != nil {
+		return fmt.Errorf("failed to load relation types: %w", err)
+	}
+
+	// Start from the existing settings so only the given flags change
+	t, exists := types.Get(name)
+	if !exists {
+		t = relation.Type{Name: name}
+	}
+	if c.IsSet("category") {
+		t.Category = c.String("category")
+	}
+	if c.IsSet("color") {
+		t.Color = c.String("color")
+	}
+	if c.IsSet("paired-with") {
+		t.PairedWith = c.String("paired-with")
+	}
+	if c.IsSet("requires-confirmation") {
+		t.RequiresConfirmation = c.Bool("requires-confirmation")
+	}
+	if c.IsSet("symmetric") {
+		t.Symmetric = c.Bool("symmetric")
+	}
+	if c.IsSet("source-is-owner") {
+		t.SourceIsOwner = c.Bool("source-is-owner")
+	}
+	if c.IsSet("conflicts-with") {
+		t.ConflictsWith = nil
+		for _, other := range strings.Split(c.String("conflicts-with"), ",") {
+			if other = strings.TrimSpace(other); other != "" {
+				t.ConflictsWith = append(t.ConflictsWith, other)
+			}
+		}
+	}
+	if c.IsSet("display-name") {
+		t.DisplayName = c.String("display-name")
+	}
+	if c.IsSet("description") {
+		t.Description = c.String("description")
+	}
+	if c.IsSet("sort-order") {
+		t.SortOrder = c.Int("sort-order")
+	}
+
+	if t.PairedWith != "" && t.PairedWith != name && !types.IsKnown(t.PairedWith) {
+		return fmt.Errorf("paired type %q does not exist", t.PairedWith)
+	}
+	for _, other := range t.ConflictsWith {
+		if other == name {
+			return errors.New("a relation type cannot conflict with itself")
+		}
+		if !types.IsKnown(other) {
+			return fmt.Errorf("conflicting type %q does not exist", other)
+		}
+	}
+
+	if err := repo.Upsert(c.Context, t); err != nil {
+		return fmt.Errorf("failed to save relation type: %w", err)
+	}
+
+	slog.Info("relation type saved", "name", name, "created", !exists)
+	return nil
+}
+
+func openAPIKeys(c *cli.Context) (*database.DB, *apikey.Repository, error) {
+	db, err := database.New(c.Context, c.String("database-url"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if err := database.RunMigrations(c.Context, db.Pool()); err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	repo, err := apikey.NewRepository(db.Pool())
+	if err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("failed to create API key repository: %w", err)
+	}
+	return db, repo, nil
+}
+
+func runAPIKeyCreate(c *cli.Context) error {
+	name := c.Args().First()
+	if name == "" {
+		return errors.New("API key name is required")
+	}
+	scopes, err := apikey.ParseScopes(c.String("scopes"))
+	if err != nil {
+		return fmt.Errorf("invalid scopes: %w", err)
+	}
+
+	db, repo, err := openAPIKeys(c)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	key, token, err := repo.Create(c.Context, name, scopes, c.Int("rate-limit"), c.Int("daily-quota"))
+	if err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	slog.Info("API key created", "id", key.ID, "name", key.Name, "scopes", scopes)
+	// The token is not stored and cannot be shown again
+	fmt.Println(token)
+	return nil
+}
+
+func runAPIKeyRevoke(c *cli.Context) error {
+	id, err := strconv.ParseInt(c.Args().First(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid API key id %q", c.Args().First())
+	}
+
+	db, repo, err := openAPIKeys(c)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := repo.Revoke(c.Context, id); err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	slog.Info("API key revoked", "id", id)
+	return nil
+}
+
+func runAPIKeyList(c *cli.Context) error {
+	db, repo, err := openAPIKeys(c)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	keys, err := repo.List(c.Context)
+	if err != nil {
+		return fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tPREFIX\tSCOPES\tRATE/MIN\tDAILY QUOTA\tUSED TODAY\tCREATED\tSTATUS")
+	for _, k := range keys {
+		status := "active"
+		if k.Revoked() {
+			status = "revoked " + k.RevokedAt.UTC().Format(time.DateOnly)
+		}
+		scopes := lo.Map(k.Scopes, func(s apikey.Scope, _ int) string { return string(s) })
+		fmt.Fprintf(w, "%d\t%s\t%s...\t%s\t%d\t%d\t%d\t%s\t%s\n",
+			k.ID, k.Name, k.Prefix, strings.Join(scopes, ","), k.RateLimit, k.DailyQuota, k.UsedToday,
+			k.CreatedAt.UTC().Format(time.DateOnly), status)
+	}
+	return w.Flush()
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 	"github.com/mtlprog/lore/internal/model"
 	"github.com/mtlprog/lore/internal/repository"
 	"github.com/mtlprog/lore/internal/reputation"
+	"github.com/mtlprog/lore/internal/service"
 	"github.com/samber/lo"
 )
 
@@ -21,12 +23,15 @@ const (
 // ListAccounts handles GET /api/v1/accounts.
 //
 //	@Summary		List accounts
-//	@Description	Returns a paginated list of accounts, optionally filtered by type
+//	@Description	Returns a paginated list of accounts, optionally filtered by type.
+//	@Description	Pass cursor (empty for the first page) for keyset pagination: pages stay stable while
+//	@Description	accounts are re-synced and total is not counted. Follow pagination.next and pagination.prev.
 //	@Tags			accounts
 //	@Produce		json
 //	@Param			type	query		string	false	"Account type filter"	Enums(person, corporate, synthetic)
 //	@Param			limit	query		int		false	"Number of results"		default(20)	maximum(100)
 //	@Param			offset	query		int		false	"Offset for pagination"	default(0)
+//	@Param			cursor	query		string	false	"Page cursor from pagination.next or pagination.prev"
 //	@Success		200		{object}	PaginatedResponse
 //	@Failure		400		{object}	ErrorResponse
 //	@Failure		500		{object}	ErrorResponse
@@ -36,26 +41,31 @@ func (h *Handler) ListAccounts(w http.ResponseWriter, r *http.Request) {
 
 	accountType := r.URL.Query().Get("type")
 	limit := parseIntParam(r, "limit", defaultLimit, maxLimit)
-	offset := parseIntParam(r, "offset", 0, 0)
-
-	var items []AccountListItem
-	var total int
-	var err error
-
-	switch accountType {
-	case "person":
-		items, total, err = h.listPersons(ctx, limit, offset)
-	case "corporate":
-		items, total, err = h.listCorporate(ctx, limit, offset)
-	case "synthetic":
-		items, total, err = h.listSynthetic(ctx, limit, offset)
-	case "":
-		items, total, err = h.listAll(ctx, limit, offset)
-	default:
+	if accountType != "" && accountType != "person" && accountType != "corporate" && accountType != "synthetic" {
 		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid type: %s (valid: person, corporate, synthetic)", accountType))
 		return
 	}
 
+	if r.URL.Query().Has("cursor") {
+		items, page, err := h.listAccountsPage(ctx, accountType, r.URL.Query().Get("cursor"), limit)
+		if err != nil {
+			if errors.Is(err, repository.ErrInvalidCursor) {
+				h.writeError(w, http.StatusBadRequest, "invalid cursor")
+				return
+			}
+			slog.Error("api: failed to list accounts", "type", accountType, "error", err)
+			h.writeError(w, http.StatusInternalServerError, "failed to list accounts")
+			return
+		}
+		h.writeJSON(w, http.StatusOK, PaginatedResponse{
+			Data:       items,
+			Pagination: cursorPagination(r, limit, page),
+		})
+		return
+	}
+
+	offset := parseIntParam(r, "offset", 0, 0)
+	items, total, err := h.listAccounts(ctx, accountType, limit, offset)
 	if err != nil {
 		slog.Error("api: failed to list accounts", "type", accountType, "error", err)
 		h.writeError(w, http.StatusInternalServerError, "failed to list accounts")
@@ -63,15 +73,44 @@ func (h *Handler) ListAccounts(w http.ResponseWriter, r *http.Request) {
 	}
 
 	h.writeJSON(w, http.StatusOK, PaginatedResponse{
-		Data: items,
-		Pagination: Pagination{
-			Limit:  limit,
-			Offset: offset,
-			Total:  total,
-		},
+		Data:       items,
+		Pagination: offsetPagination(r, limit, offset, total),
 	})
 }
 
+// listAccounts returns an offset page of accounts of the given type (empty
+// for all) and the number of such accounts.
+func (h *Handler) listAccounts(ctx context.Context, accountType string, limit, offset int) ([]AccountListItem, int, error) {
+	switch accountType {
+	case "person":
+		return h.listPersons(ctx, limit, offset)
+	case "corporate":
+		return h.listCorporate(ctx, limit, offset)
+	case "synthetic":
+		return h.listSynthetic(ctx, limit, offset)
+	default:
+		return h.listAll(ctx, limit, offset)
+	}
+}
+
+// listAccountsPage returns a keyset page of accounts of the given type.
+func (h *Handler) listAccountsPage(ctx context.Context, accountType, cursor string, limit int) ([]AccountListItem, repository.KeysetPage, error) {
+	switch accountType {
+	case "person":
+		rows, page, err := h.accounts.GetPersonsPage(ctx, cursor, limit)
+		return personItems(rows), page, err
+	case "corporate":
+		rows, page, err := h.accounts.GetCorporatePage(ctx, cursor, limit)
+		return corporateItems(rows), page, err
+	case "synthetic":
+		rows, page, err := h.accounts.GetSyntheticPage(ctx, cursor, limit)
+		return syntheticItems(rows), page, err
+	default:
+		rows, page, err := h.accounts.GetAllAccountsPage(ctx, cursor, limit)
+		return allAccountItems(rows), page, err
+	}
+}
+
 func (h *Handler) listPersons(ctx context.Context, limit, offset int) ([]AccountListItem, int, error) {
 	persons, err := h.accounts.GetPersons(ctx, limit, offset)
 	if err != nil {
@@ -83,18 +122,7 @@ func (h *Handler) listPersons(ctx context.Context, limit, offset int) ([]Account
 		return nil, 0, fmt.Errorf("count persons: %w", err)
 	}
 
-	items := lo.Map(persons, func(p repository.PersonRow, _ int) AccountListItem {
-		return AccountListItem{
-			ID:             p.AccountID,
-			Name:           p.Name,
-			Type:           "person",
-			MTLAPBalance:   p.MTLAPBalance,
-			IsCouncilReady: p.IsCouncilReady,
-			ReceivedVotes:  p.ReceivedVotes,
-		}
-	})
-
-	return items, total, nil
+	return personItems(persons), total, nil
 }
 
 func (h *Handler) listCorporate(ctx context.Context, limit, offset int) ([]AccountListItem, int, error) {
@@ -108,17 +136,7 @@ func (h *Handler) listCorporate(ctx context.Context, limit, offset int) ([]Accou
 		return nil, 0, fmt.Errorf("count corporate: %w", err)
 	}
 
-	items := lo.Map(corporate, func(c repository.CorporateRow, _ int) AccountListItem {
-		return AccountListItem{
-			ID:            c.AccountID,
-			Name:          c.Name,
-			Type:          "corporate",
-			MTLACBalance:  c.MTLACBalance,
-			TotalXLMValue: c.TotalXLMValue,
-		}
-	})
-
-	return items, total, nil
+	return corporateItems(corporate), total, nil
 }
 
 func (h *Handler) listSynthetic(ctx context.Context, limit, offset int) ([]AccountListItem, int, error) {
@@ -132,7 +150,51 @@ func (h *Handler) listSynthetic(ctx context.Context, limit, offset int) ([]Accou
 		return nil, 0, fmt.Errorf("count synthetic: %w", err)
 	}
 
-	items := lo.Map(synthetic, func(s repository.SyntheticRow, _ int) AccountListItem {
+	return syntheticItems(synthetic), total, nil
+}
+
+func (h *Handler) listAll(ctx context.Context, limit, offset int) ([]AccountListItem, int, error) {
+	stats, err := h.accounts.GetStats(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("get stats: %w", err)
+	}
+	total := stats.TotalAccounts
+
+	rows, err := h.accounts.GetAllAccounts(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("get all accounts: %w", err)
+	}
+
+	return allAccountItems(rows), total, nil
+}
+
+func personItems(persons []repository.PersonRow) []AccountListItem {
+	return lo.Map(persons, func(p repository.PersonRow, _ int) AccountListItem {
+		return AccountListItem{
+			ID:             p.AccountID,
+			Name:           p.Name,
+			Type:           "person",
+			MTLAPBalance:   p.MTLAPBalance,
+			IsCouncilReady: p.IsCouncilReady,
+			ReceivedVotes:  p.ReceivedVotes,
+		}
+	})
+}
+
+func corporateItems(corporate []repository.CorporateRow) []AccountListItem {
+	return lo.Map(corporate, func(c repository.CorporateRow, _ int) AccountListItem {
+		return AccountListItem{
+			ID:            c.AccountID,
+			Name:          c.Name,
+			Type:          "corporate",
+			MTLACBalance:  c.MTLACBalance,
+			TotalXLMValue: c.TotalXLMValue,
+		}
+	})
+}
+
+func syntheticItems(synthetic []repository.SyntheticRow) []AccountListItem {
+	return lo.Map(synthetic, func(s repository.SyntheticRow, _ int) AccountListItem {
 		grade := ""
 		if s.ReputationScore > 0 {
 			grade = reputation.ScoreToGrade(s.ReputationScore)
@@ -146,23 +208,10 @@ func (h *Handler) listSynthetic(ctx context.Context, limit, offset int) ([]Accou
 			ReputationGrade: grade,
 		}
 	})
-
-	return items, total, nil
 }
 
-func (h *Handler) listAll(ctx context.Context, limit, offset int) ([]AccountListItem, int, error) {
-	stats, err := h.accounts.GetStats(ctx)
-	if err != nil {
-		return nil, 0, fmt.Errorf("get stats: %w", err)
-	}
-	total := stats.TotalAccounts
-
-	rows, err := h.accounts.GetAllAccounts(ctx, limit, offset)
-	if err != nil {
-		return nil, 0, fmt.Errorf("get all accounts: %w", err)
-	}
-
-	items := lo.Map(rows, func(a repository.AllAccountRow, _ int) AccountListItem {
+func allAccountItems(rows []repository.AllAccountRow) []AccountListItem {
+	return lo.Map(rows, func(a repository.AllAccountRow, _ int) AccountListItem {
 		grade := ""
 		if a.ReputationScore > 0 {
 			grade = reputation.ScoreToGrade(a.ReputationScore)
@@ -182,8 +231,6 @@ func (h *Handler) listAll(ctx context.Context, limit, offset int) ([]AccountList
 			ReceivedVotes:   a.ReceivedVotes,
 		}
 	})
-
-	return items, total, nil
 }
 
 // GetAccount handles GET /api/v1/accounts/{id}.
@@ -199,38 +246,41 @@ func (h *Handler) listAll(ctx context.Context, limit, offset int) ([]AccountList
 //	@Failure		500	{object}	ErrorResponse
 //	@Router			/api/v1/accounts/{id} [get]
 func (h *Handler) GetAccount(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
 	accountID, ok := h.validateAccountID(w, r)
 	if !ok {
 		return
 	}
 
-	// Check if account exists
-	exists, err := h.accounts.AccountExists(ctx, accountID)
+	resp, err := h.accountDetail(r.Context(), accountID)
 	if err != nil {
-		slog.Error("api: failed to check account existence", "account_id", accountID, "error", err)
+		if errors.Is(err, errAccountNotFound) {
+			h.writeError(w, http.StatusNotFound, "account not found")
+			return
+		}
+		slog.Error("api: failed to fetch account", "account_id", accountID, "error", err)
 		h.writeError(w, http.StatusInternalServerError, "failed to fetch account")
 		return
 	}
-	if !exists {
-		h.writeError(w, http.StatusNotFound, "account not found")
-		return
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// accountDetail loads the account profile. Missing optional parts (trustlines,
+// ratings, LP shares, reputation, relationships) are logged and left out.
+func (h *Handler) accountDetail(ctx context.Context, accountID string) (*AccountDetailResponse, error) {
+	if err := h.requireAccount(ctx, accountID); err != nil {
+		return nil, err
 	}
 
 	// Fetch account metadata
 	meta, err := h.accounts.GetAccountMetadata(ctx, accountID)
 	if err != nil {
-		slog.Error("api: failed to fetch account metadata", "account_id", accountID, "error", err)
-		h.writeError(w, http.StatusInternalServerError, "failed to fetch account")
-		return
+		return nil, fmt.Errorf("get account metadata: %w", err)
 	}
 
 	// Fetch account info (balances, XLM value)
 	accountInfo, err := h.accounts.GetAccountInfo(ctx, accountID)
 	if err != nil {
-		slog.Error("api: failed to fetch account info", "account_id", accountID, "error", err)
-		h.writeError(w, http.StatusInternalServerError, "failed to fetch account")
-		return
+		return nil, fmt.Errorf("get account info: %w", err)
 	}
 
 	name := meta.Name
@@ -244,6 +294,7 @@ func (h *Handler) GetAccount(w http.ResponseWriter, r *http.Request) {
 		About:         meta.About,
 		Websites:      meta.Websites,
 		Tags:          meta.Tags,
+		Identity:      convertIdentity(meta),
 		IsCorporate:   accountInfo.MTLACBalance > 0,
 		TotalXLMValue: accountInfo.TotalXLMValue,
 	}
@@ -341,11 +392,11 @@ func (h *Handler) GetAccount(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if len(relationships) > 0 {
-		categories := bsn.GroupRelationships(accountID, relationships, confirmed)
+		categories := bsn.GroupRelationships(h.relations, accountID, relationships, confirmed)
 		resp.Categories = convertCategories(categories)
 	}
 
-	h.writeJSON(w, http.StatusOK, resp)
+	return &resp, nil
 }
 
 func convertCategories(categories []model.RelationshipCategory) []RelationshipCategoryResponse {
@@ -363,6 +414,7 @@ func convertCategories(categories []model.RelationshipCategory) []RelationshipCa
 func convertRelationship(rel model.Relationship) RelationshipResponse {
 	return RelationshipResponse{
 		Type:        rel.Type,
+		TypeName:    rel.TypeName,
 		TargetID:    rel.TargetID,
 		TargetName:  rel.TargetName,
 		Direction:   rel.Direction,
@@ -387,3 +439,23 @@ func convertReputationScore(score *model.ReputationScore) *ReputationResponse {
 		TotalWeight:   score.TotalWeight,
 	}
 }
+
+// convertIdentity maps identity metadata to the API response, or nil if none is set.
+func convertIdentity(meta *repository.AccountMetadata) *IdentityResponse {
+	identity := IdentityResponse{
+		TelegramUserID:     meta.TelegramUserID,
+		TimeTokenCode:      meta.TimeTokenCode,
+		TimeTokenIssuer:    meta.TimeTokenIssuer,
+		TimeTokenDesc:      meta.TimeTokenDesc,
+		TimeTokenOfferIPFS: meta.TimeTokenOfferIPFS,
+		TimeTokenOfferURL:  service.IPFSURL(meta.TimeTokenOfferIPFS),
+		TelegramPartChatID: meta.TelegramPartChatID,
+		ContractIPFS:       meta.ContractIPFS,
+		ContractURL:        service.IPFSURL(meta.ContractIPFS),
+		PIIStandard:        meta.PIIStandard,
+	}
+	if identity == (IdentityResponse{}) {
+		return nil
+	}
+	return &identity
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/mtlprog/lore/internal/repository"
+	"github.com/samber/lo"
+)
+
+const (
+	// maxBatchIDs limits the accounts of one bulk lookup.
+	maxBatchIDs = 500
+	// maxBatchBodyBytes fits maxBatchIDs quoted account IDs with room for whitespace.
+	maxBatchBodyBytes = 64 << 10
+)
+
+// BatchAccounts handles POST /api/v1/accounts:batch.
+//
+//	@Summary		Look up accounts in bulk
+//	@Description	Returns the list summary and reputation of up to 500 accounts in request order. IDs that are not tracked are listed in not_found instead of failing the request.
+//	@Tags			accounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		BatchAccountsRequest	true	"Account IDs"
+//	@Success		200		{object}	BatchAccountsResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/api/v1/accounts:batch [post]
+func (h *Handler) BatchAccounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req BatchAccountsRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodyBytes)).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	ids := lo.Uniq(req.IDs)
+	if len(ids) == 0 {
+		h.writeError(w, http.StatusBadRequest, "ids is required")
+		return
+	}
+	if len(ids) > maxBatchIDs {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("at most %d ids are allowed", maxBatchIDs))
+		return
+	}
+	if invalid, found := lo.Find(ids, func(id string) bool { return !isValidStellarID(id) }); found {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid Stellar account ID format: %q", invalid))
+		return
+	}
+
+	rows, err := h.accounts.GetAccountsByIDs(ctx, ids)
+	if err != nil {
+		slog.Error("api: failed to fetch accounts", "lookup_count", len(ids), "error", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to fetch accounts")
+		return
+	}
+	byID := lo.SliceToMap(allAccountItems(rows), func(item AccountListItem) (string, AccountListItem) {
+		return item.ID, item
+	})
+
+	resp := BatchAccountsResponse{
+		Data:     make([]BatchAccountItem, 0, len(byID)),
+		NotFound: []string{},
+	}
+	for _, id := range ids {
+		item, ok := byID[id]
+		if !ok {
+			resp.NotFound = append(resp.NotFound, id)
+			continue
+		}
+		resp.Data = append(resp.Data, BatchAccountItem{AccountListItem: item})
+	}
+
+	if h.reputation != nil && len(resp.Data) > 0 {
+		scores, err := h.reputation.GetScores(ctx, lo.Map(rows, func(a repository.AllAccountRow, _ int) string { return a.AccountID }))
+		if err != nil {
+			slog.Warn("api: failed to fetch reputation scores", "lookup_count", len(rows), "error", err)
+		} else {
+			for i := range resp.Data {
+				resp.Data[i].Reputation = convertReputationScore(scores[resp.Data[i].ID])
+			}
+		}
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
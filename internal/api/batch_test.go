@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchAccounts serves the accounts in its map and records each lookup.
+// Methods the tests do not reach panic through the nil interface.
+type batchAccounts struct {
+	accountQuerierBase
+	rows    map[string]repository.AllAccountRow
+	lookups [][]string
+}
+
+func (f *batchAccounts) GetAccountsByIDs(_ context.Context, accountIDs []string) ([]repository.AllAccountRow, error) {
+	f.lookups = append(f.lookups, accountIDs)
+	var rows []repository.AllAccountRow
+	for _, id := range accountIDs {
+		if row, ok := f.rows[id]; ok {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// batchReputation grades every account it is asked about.
+type batchReputation struct {
+	reputationQuerierBase
+}
+
+func (batchReputation) GetScores(_ context.Context, accountIDs []string) (map[string]*model.ReputationScore, error) {
+	scores := make(map[string]*model.ReputationScore, len(accountIDs))
+	for _, id := range accountIDs {
+		scores[id] = &model.ReputationScore{WeightedScore: 3.2, Grade: "B+", TotalRatings: 4}
+	}
+	return scores, nil
+}
+
+func batchAccountID(n int) string {
+	id := fmt.Sprintf("G%d", n)
+	return id + strings.Repeat("A", 56-len(id))
+}
+
+func TestBatchAccounts(t *testing.T) {
+	alice, bob, missing := batchAccountID(1), batchAccountID(2), batchAccountID(3)
+
+	post := func(t *testing.T, accounts *batchAccounts, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		h, err := New(accounts, batchReputation{}, nil, nil)
+		require.NoError(t, err)
+		mux := http.NewServeMux()
+		h.RegisterRoutes(mux)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/accounts:batch", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	newAccounts := func() *batchAccounts {
+		return &batchAccounts{rows: map[string]repository.AllAccountRow{
+			alice: {AccountID: alice, Name: "Alice", MTLAPBalance: 5},
+			bob:   {AccountID: bob, Name: "Bob", MTLACBalance: 2},
+		}}
+	}
+
+	t.Run("request order, duplicates and not found", func(t *testing.T) {
+		accounts := newAccounts()
+		w := post(t, accounts, fmt.Sprintf(`{"ids":[%q,%q,%q,%q]}`, bob, missing, alice, bob))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp BatchAccountsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 2)
+		assert.Equal(t, bob, resp.Data[0].ID)
+		assert.Equal(t, alice, resp.Data[1].ID)
+		assert.Equal(t, "Alice", resp.Data[1].Name)
+		require.NotNil(t, resp.Data[1].Reputation)
+		assert.Equal(t, "B+", resp.Data[1].Reputation.Grade)
+		assert.Equal(t, []string{missing}, resp.NotFound)
+
+		require.Len(t, accounts.lookups, 1)
+		assert.Equal(t, []string{bob, missing, alice}, accounts.lookups[0], "duplicates are looked up once")
+	})
+
+	t.Run("nothing found", func(t *testing.T) {
+		w := post(t, newAccounts(), fmt.Sprintf(`{"ids":[%q]}`, missing))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, fmt.Sprintf(`{"data":[],"not_found":[%q]}`, missing), w.Body.String())
+	})
+
+	t.Run("500 ids are allowed", func(t *testing.T) {
+		ids := make([]string, maxBatchIDs)
+		for i := range ids {
+			ids[i] = batchAccountID(i)
+		}
+		body, err := json.Marshal(BatchAccountsRequest{IDs: ids})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, post(t, newAccounts(), string(body)).Code)
+	})
+
+	t.Run("duplicates do not count towards the limit", func(t *testing.T) {
+		ids := make([]string, maxBatchIDs+1)
+		for i := range ids {
+			ids[i] = batchAccountID(i % maxBatchIDs)
+		}
+		body, err := json.Marshal(BatchAccountsRequest{IDs: ids})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, post(t, newAccounts(), string(body)).Code)
+	})
+
+	badRequests := []struct {
+		name string
+		body func() string
+	}{
+		{"malformed body", func() string { return `{"ids":` }},
+		{"missing ids", func() string { return `{}` }},
+		{"empty ids", func() string { return `{"ids":[]}` }},
+		{"invalid id", func() string { return fmt.Sprintf(`{"ids":[%q,"GSHORT"]}`, alice) }},
+		{"more than 500 ids", func() string {
+			ids := make([]string, maxBatchIDs+1)
+			for i := range ids {
+				ids[i] = batchAccountID(i)
+			}
+			body, _ := json.Marshal(BatchAccountsRequest{IDs: ids})
+			return string(body)
+		}},
+	}
+	for _, tt := range badRequests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounts := newAccounts()
+			w := post(t, accounts, tt.body())
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Empty(t, accounts.lookups, "invalid requests do not reach the database")
+		})
+	}
+}
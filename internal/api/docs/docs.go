@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/api/v1/accounts": {
             "get": {
-                "description": "Returns a paginated list of accounts, optionally filtered by type",
+                "description": "Returns a paginated list of accounts, optionally filtered by type.\nPass cursor (empty for the first page) for keyset pagination: pages stay stable while\naccounts are re-synced and total is not counted. Follow pagination.next and pagination.prev.",
                 "produces": [
                     "application/json"
                 ],
@@ -51,6 +51,12 @@ const docTemplate = `{
                         "description": "Offset for pagination",
                         "name": "offset",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page cursor from pagination.next or pagination.prev",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -122,16 +128,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/accounts/{id}/relationships": {
+        "/api/v1/accounts/{id}/flows": {
             "get": {
-                "description": "Returns relationships grouped by category (family, work, network, ownership, social)",
+                "description": "Returns the tracked accounts this account exchanged the most value with, per asset.\nBuilt from indexed payments and path payments; path payments count the delivered amount.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "relationships"
+                    "flows"
                 ],
-                "summary": "Get account relationships",
+                "summary": "Get account payment flows",
                 "parameters": [
                     {
                         "type": "string",
@@ -142,20 +148,28 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Filter by relationship type (e.g. Spouse, Employer)",
-                        "name": "type",
+                        "description": "Asset code (XLM for native), or CODE:ISSUER for one issuer",
+                        "name": "asset",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "Filter to only confirmed relationships",
-                        "name": "confirmed",
+                        "type": "string",
+                        "description": "Start date, YYYY-MM-DD or RFC 3339 (inclusive)",
+                        "name": "since",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "Filter to only mutual relationships",
-                        "name": "mutual",
+                        "type": "string",
+                        "description": "End date, YYYY-MM-DD (inclusive) or RFC 3339 (exclusive)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of counterparties",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
@@ -165,7 +179,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/api.RelationshipCategoryResponse"
+                                "$ref": "#/definitions/api.CounterpartyFlowResponse"
                             }
                         }
                     },
@@ -190,16 +204,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/accounts/{id}/reputation": {
+        "/api/v1/accounts/{id}/issues": {
             "get": {
-                "description": "Returns the reputation graph including direct raters and raters of raters",
+                "description": "Returns data-quality findings for the account's relationships and ManageData (contradictions, missing confirmations, self-references, duplicates, unknown targets, malformed entries). Each issue links to the init form where it can be fixed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reputation"
+                    "relationships"
                 ],
-                "summary": "Get reputation graph",
+                "summary": "Get relationship issues",
                 "parameters": [
                     {
                         "type": "string",
@@ -207,13 +221,25 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by severity (error, warning, info)",
+                        "name": "severity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category (contradiction, confirmation, self_reference, duplicate, unknown_target, malformed)",
+                        "name": "category",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.ReputationGraphResponse"
+                            "$ref": "#/definitions/api.IssuesResponse"
                         }
                     },
                     "400": {
@@ -243,38 +269,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/search": {
+        "/api/v1/accounts/{id}/operations": {
             "get": {
-                "description": "Search accounts by name or account ID, optionally filtered by tags",
+                "description": "Returns operations of a tracked account from the local index, newest first.\nSpam (claimable balances, XLM payments below 1) is excluded. Works while Horizon is unavailable.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "search"
+                    "accounts"
                 ],
-                "summary": "Search accounts",
+                "summary": "Get account operations",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search query (min 2 chars)",
-                        "name": "q",
+                        "description": "Stellar account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Operation type (e.g. payment, manage_data)",
+                        "name": "type",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Comma-separated tag names",
-                        "name": "tags",
+                        "description": "Asset code (XLM for native)",
+                        "name": "asset",
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            "balance",
-                            "reputation"
-                        ],
                         "type": "string",
-                        "default": "balance",
-                        "description": "Sort order",
-                        "name": "sort",
+                        "description": "Other account involved",
+                        "name": "counterparty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date, YYYY-MM-DD or RFC 3339 (inclusive)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date, YYYY-MM-DD (inclusive) or RFC 3339 (exclusive)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
                         "in": "query"
                     },
                     {
@@ -284,12 +330,70 @@ const docTemplate = `{
                         "description": "Number of results",
                         "name": "limit",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.OperationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/accounts/{id}/relationships": {
+            "get": {
+                "description": "Returns relationships grouped by category (family, work, network, ownership, social)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "relationships"
+                ],
+                "summary": "Get account relationships",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stellar account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset for pagination",
-                        "name": "offset",
+                        "type": "string",
+                        "description": "Filter by relationship type (e.g. Spouse, Employer)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter to only confirmed relationships",
+                        "name": "confirmed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter to only mutual relationships",
+                        "name": "mutual",
                         "in": "query"
                     }
                 ],
@@ -297,7 +401,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.PaginatedResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.RelationshipCategoryResponse"
+                            }
                         }
                     },
                     "400": {
@@ -306,6 +413,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -315,21 +428,94 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/stats": {
+        "/api/v1/accounts/{id}/reputation": {
             "get": {
-                "description": "Returns aggregate statistics for accounts, persons, companies, and synthetic tokens",
+                "description": "Returns the reputation graph including direct raters and raters of raters",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "stats"
+                    "reputation"
+                ],
+                "summary": "Get reputation graph",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stellar account ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get aggregate statistics",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.StatsResponse"
+                            "$ref": "#/definitions/api.ReputationGraphResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/accounts:batch": {
+            "post": {
+                "description": "Returns the list summary and reputation of up to 500 accounts in request order. IDs that are not tracked are listed in not_found instead of failing the request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Look up accounts in bulk",
+                "parameters": [
+                    {
+                        "description": "Account IDs",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.BatchAccountsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.BatchAccountsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "500": {
@@ -340,76 +526,875 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "api.AccountDetailResponse": {
+        },
+        "/api/v1/events": {
+            "get": {
+                "description": "Server-Sent Events stream of changes published by sync runs: account.updated, relationship.added, relationship.removed, council.votes_changed, reputation.changed, member.joined and member.left. Each event carries its ID, so clients resume after a disconnect with the Last-Event-ID header (sent automatically by EventSource) or the last_event_id parameter; events are kept for 30 days. The number of open streams is bounded; opening a stream counts as one request against the rate limit.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Stream live changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated account IDs; events about or related to any of them",
+                        "name": "account",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated event types",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resume after this event ID (Last-Event-ID header takes precedence)",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resume after this event ID",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of events; each data line is one EventResponse",
+                        "schema": {
+                            "$ref": "#/definitions/api.EventResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams accounts, balances, metadata, relationships, association tags, liquidity pools, LP shares and reputation scores as a ZIP archive with one file per table and a manifest.json naming the sync run and schema version. Requires an API key with the export scope.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Export the dataset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format: csv, jsonl or parquet",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Leave out Telegram IDs and contract links from account_metadata",
+                        "name": "exclude_personal",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/flows": {
+            "get": {
+                "description": "Returns monthly payment volume between tracked accounts per asset, and the largest\nflows between pairs of tracked accounts. Each payment is counted once.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "flows"
+                ],
+                "summary": "Get the internal economy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Asset code (XLM for native), or CODE:ISSUER for one issuer",
+                        "name": "asset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date, YYYY-MM-DD or RFC 3339 (inclusive)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date, YYYY-MM-DD (inclusive) or RFC 3339 (exclusive)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of flows",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.FlowsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/init/submit": {
+            "post": {
+                "description": "Validates a signed envelope produced by the init forms (ManageData operations on the source account only; when unsigned_xdr is given the envelope must be exactly that transaction), checks that Lore generated the transaction and it has not expired, checks that its sequence number and time bounds are still current, submits it to Horizon and starts refreshing the account in Lore. Outdated, expired or out-of-order transactions return 409. Rejections return Horizon result codes with a human-readable message.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "init"
+                ],
+                "summary": "Submit a signed init transaction",
+                "parameters": [
+                    {
+                        "description": "Signed transaction",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SubmitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.SubmitResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/api.SubmitErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search": {
+            "get": {
+                "description": "Search accounts by name or account ID, optionally filtered by tags.\nPass cursor (empty for the first page) for keyset pagination without counting total.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search accounts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query (min 2 chars)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tag names",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "balance",
+                            "reputation"
+                        ],
+                        "type": "string",
+                        "default": "balance",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of results",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page cursor from pagination.next or pagination.prev",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stats": {
+            "get": {
+                "description": "Returns aggregate statistics for accounts, persons, companies, and synthetic tokens,\nplus monthly membership growth over the last year indexed from issuer operations",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get aggregate statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.StatsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/xdr/decode": {
+            "post": {
+                "description": "Decodes a TransactionEnvelope or FeeBumpTransactionEnvelope. Each operation gets a plain-language action; ManageData entries are read as BSN actions (relations, tags, delegation) and operations that change account control (signers, thresholds, master weight, flags, merges) carry a risk note. Involved accounts are resolved to names known to Lore.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "xdr"
+                ],
+                "summary": "Decode a transaction envelope",
+                "parameters": [
+                    {
+                        "description": "Transaction envelope",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.DecodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.DecodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.AccountDetailResponse": {
+            "type": "object",
+            "properties": {
+                "about": {
+                    "type": "string"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.RelationshipCategoryResponse"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "identity": {
+                    "$ref": "#/definitions/api.IdentityResponse"
+                },
+                "is_corporate": {
+                    "type": "boolean"
+                },
+                "lp_shares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.LPShareResponse"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reputation": {
+                    "$ref": "#/definitions/api.ReputationResponse"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_xlm_value": {
+                    "type": "number"
+                },
+                "trust_rating": {
+                    "$ref": "#/definitions/api.TrustRatingResponse"
+                },
+                "trustlines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TrustlineResponse"
+                    }
+                },
+                "websites": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.BatchAccountItem": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "is_council_ready": {
+                    "type": "boolean"
+                },
+                "mtlac_balance": {
+                    "type": "number"
+                },
+                "mtlap_balance": {
+                    "type": "number"
+                },
+                "mtlax_balance": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "received_votes": {
+                    "type": "integer"
+                },
+                "reputation": {
+                    "$ref": "#/definitions/api.ReputationResponse"
+                },
+                "reputation_grade": {
+                    "type": "string"
+                },
+                "reputation_score": {
+                    "type": "number"
+                },
+                "total_xlm_value": {
+                    "type": "number"
+                },
+                "type": {
+                    "description": "\"person\", \"corporate\", \"synthetic\"",
+                    "type": "string"
+                }
+            }
+        },
+        "api.BatchAccountsRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "description": "Stellar account IDs, duplicates are ignored",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.BatchAccountsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.BatchAccountItem"
+                    }
+                },
+                "not_found": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.CounterpartyFlowResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "asset_code": {
+                    "type": "string"
+                },
+                "asset_issuer": {
+                    "description": "Empty for native",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "payments": {
+                    "type": "integer"
+                },
+                "received": {
+                    "type": "number"
+                },
+                "sent": {
+                    "type": "number"
+                },
+                "volume": {
+                    "type": "number"
+                }
+            }
+        },
+        "api.DecodeRequest": {
+            "type": "object",
+            "properties": {
+                "xdr": {
+                    "description": "TransactionEnvelope or FeeBumpTransactionEnvelope (base64)",
+                    "type": "string"
+                }
+            }
+        },
+        "api.DecodeResponse": {
+            "type": "object",
+            "properties": {
+                "account_names": {
+                    "description": "Names of involved accounts known to Lore",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "fee_account": {
+                    "type": "string"
+                },
+                "fee_bump": {
+                    "type": "boolean"
+                },
+                "hash": {
+                    "description": "Envelope hash on the public network",
+                    "type": "string"
+                },
+                "inner_hash": {
+                    "description": "Inner transaction hash (fee bump only)",
+                    "type": "string"
+                },
+                "max_fee": {
+                    "description": "In XLM",
+                    "type": "string"
+                },
+                "memo": {
+                    "type": "string"
+                },
+                "memo_type": {
+                    "type": "string"
+                },
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.DecodedOperationResponse"
+                    }
+                },
+                "risk_count": {
+                    "description": "Operations that change account control",
+                    "type": "integer"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "signature_count": {
+                    "type": "integer"
+                },
+                "source_account": {
+                    "type": "string"
+                },
+                "valid_after": {
+                    "type": "string"
+                },
+                "valid_before": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.DecodedOperationResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Plain-language summary, e.g. \"adds Employer relation to\"",
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "string"
+                },
+                "asset_code": {
+                    "type": "string"
+                },
+                "asset_issuer": {
+                    "type": "string"
+                },
+                "data_name": {
+                    "type": "string"
+                },
+                "data_value": {
+                    "type": "string"
+                },
+                "risk": {
+                    "description": "Why the operation deserves attention",
+                    "type": "string"
+                },
+                "source_account": {
+                    "type": "string"
+                },
+                "target_account": {
+                    "type": "string"
+                },
+                "target_value": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Horizon operation type, e.g. \"manage_data\"",
+                    "type": "string"
+                },
+                "type_display": {
+                    "description": "e.g. \"Manage Data\"",
+                    "type": "string"
+                }
+            }
+        },
+        "api.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.EventResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "Type-specific details, e.g. {\"from\":2,\"to\":5}",
+                    "type": "object"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "related_account_id": {
+                    "description": "Relationship target",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "member.joined"
+                }
+            }
+        },
+        "api.FlowEdgeResponse": {
             "type": "object",
             "properties": {
-                "about": {
+                "asset_code": {
                     "type": "string"
                 },
-                "categories": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/api.RelationshipCategoryResponse"
-                    }
+                "asset_issuer": {
+                    "description": "Empty for native",
+                    "type": "string"
                 },
-                "id": {
+                "from": {
                     "type": "string"
                 },
-                "is_corporate": {
-                    "type": "boolean"
+                "from_name": {
+                    "type": "string"
                 },
-                "lp_shares": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/api.LPShareResponse"
-                    }
+                "payments": {
+                    "type": "integer"
                 },
-                "name": {
+                "to": {
                     "type": "string"
                 },
-                "reputation": {
-                    "$ref": "#/definitions/api.ReputationResponse"
-                },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "to_name": {
+                    "type": "string"
                 },
-                "total_xlm_value": {
+                "volume": {
                     "type": "number"
+                }
+            }
+        },
+        "api.FlowPeriodResponse": {
+            "type": "object",
+            "properties": {
+                "asset_code": {
+                    "type": "string"
                 },
-                "trust_rating": {
-                    "$ref": "#/definitions/api.TrustRatingResponse"
+                "asset_issuer": {
+                    "description": "Empty for native",
+                    "type": "string"
                 },
-                "trustlines": {
+                "month": {
+                    "description": "YYYY-MM",
+                    "type": "string"
+                },
+                "payers": {
+                    "type": "integer"
+                },
+                "payments": {
+                    "type": "integer"
+                },
+                "volume": {
+                    "type": "number"
+                }
+            }
+        },
+        "api.FlowsResponse": {
+            "type": "object",
+            "properties": {
+                "edges": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/api.TrustlineResponse"
+                        "$ref": "#/definitions/api.FlowEdgeResponse"
                     }
                 },
-                "websites": {
+                "periods": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/api.FlowPeriodResponse"
                     }
                 }
             }
         },
-        "api.ErrorResponse": {
+        "api.IdentityResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
+                "contract_ipfs": {
+                    "type": "string"
                 },
-                "error": {
+                "contract_url": {
+                    "type": "string"
+                },
+                "pii_standard": {
+                    "type": "boolean"
+                },
+                "telegram_part_chat_id": {
+                    "type": "string"
+                },
+                "telegram_user_id": {
+                    "type": "string"
+                },
+                "time_token_code": {
+                    "type": "string"
+                },
+                "time_token_desc": {
+                    "type": "string"
+                },
+                "time_token_issuer": {
+                    "type": "string"
+                },
+                "time_token_offer_ipfs": {
+                    "type": "string"
+                },
+                "time_token_offer_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.IssueResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "\"contradiction\", \"confirmation\", \"self_reference\", \"duplicate\", \"unknown_target\", \"malformed\"",
+                    "type": "string"
+                },
+                "fix_url": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "relation_type": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                },
+                "severity": {
+                    "description": "\"error\", \"warning\", \"info\"",
+                    "type": "string"
+                },
+                "suggestion": {
+                    "type": "string"
+                },
+                "target_id": {
                     "type": "string"
                 }
             }
         },
+        "api.IssuesResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.IssueResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "api.LPShareResponse": {
             "type": "object",
             "properties": {
@@ -433,6 +1418,119 @@ const docTemplate = `{
                 }
             }
         },
+        "api.MembershipGrowthResponse": {
+            "type": "object",
+            "properties": {
+                "asset_code": {
+                    "type": "string"
+                },
+                "joined": {
+                    "type": "integer"
+                },
+                "left": {
+                    "type": "integer"
+                },
+                "month": {
+                    "description": "YYYY-MM",
+                    "type": "string"
+                },
+                "net": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.OperationResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "asset_code": {
+                    "type": "string"
+                },
+                "asset_issuer": {
+                    "type": "string"
+                },
+                "buying": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "data_name": {
+                    "type": "string"
+                },
+                "data_value": {
+                    "type": "string"
+                },
+                "dest_amount": {
+                    "type": "string"
+                },
+                "dest_asset": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "offer_id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "selling": {
+                    "type": "string"
+                },
+                "source_account": {
+                    "type": "string"
+                },
+                "source_amount": {
+                    "type": "string"
+                },
+                "source_asset": {
+                    "type": "string"
+                },
+                "starting_balance": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "transaction_hash": {
+                    "type": "string"
+                },
+                "trust_limit": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "type_display": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.OperationsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.OperationResponse"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "description": "Pass as cursor to get the next page",
+                    "type": "string"
+                }
+            }
+        },
         "api.PaginatedResponse": {
             "type": "object",
             "properties": {
@@ -448,9 +1546,15 @@ const docTemplate = `{
                 "limit": {
                     "type": "integer"
                 },
+                "next": {
+                    "type": "string"
+                },
                 "offset": {
                     "type": "integer"
                 },
+                "prev": {
+                    "type": "string"
+                },
                 "total": {
                     "type": "integer"
                 }
@@ -493,6 +1597,9 @@ const docTemplate = `{
                 },
                 "type": {
                     "type": "string"
+                },
+                "type_name": {
+                    "type": "string"
                 }
             }
         },
@@ -600,6 +1707,13 @@ const docTemplate = `{
         "api.StatsResponse": {
             "type": "object",
             "properties": {
+                "growth": {
+                    "description": "Membership growth per month and token over the last year, oldest month first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.MembershipGrowthResponse"
+                    }
+                },
                 "total_accounts": {
                     "type": "integer"
                 },
@@ -617,6 +1731,55 @@ const docTemplate = `{
                 }
             }
         },
+        "api.SubmitErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "result_codes": {
+                    "description": "Horizon result codes (transaction code first)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.SubmitRequest": {
+            "type": "object",
+            "properties": {
+                "unsigned_xdr": {
+                    "description": "Unsigned XDR from the init form; when set, the signed envelope must match it",
+                    "type": "string"
+                },
+                "xdr": {
+                    "description": "Signed transaction envelope (base64)",
+                    "type": "string"
+                }
+            }
+        },
+        "api.SubmitResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "ledger": {
+                    "type": "integer"
+                },
+                "refreshing": {
+                    "description": "Account data in Lore is being refreshed in the background",
+                    "type": "boolean"
+                }
+            }
+        },
         "api.TrustRatingResponse": {
             "type": "object",
             "properties": {
@@ -660,6 +1823,14 @@ const docTemplate = `{
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Optional API key as \"Bearer \u003ctoken\u003e\". Keyed requests use the key's rate limit and daily quota instead of the per-IP limit.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mtlprog/lore/internal/events"
+)
+
+const (
+	// eventsHeartbeat keeps idle streams open through proxies.
+	eventsHeartbeat = 25 * time.Second
+	// eventsRetryMillis is the reconnection delay suggested to clients.
+	eventsRetryMillis = 5000
+	// eventsReplayBatch is how many stored events are loaded per query on resume.
+	eventsReplayBatch = 500
+	// maxEventAccounts limits the accounts of one filter.
+	maxEventAccounts = 100
+)
+
+// Events handles GET /api/v1/events.
+//
+//	@Summary		Stream live changes
+//	@Description	Server-Sent Events stream of changes published by sync runs: account.updated, relationship.added, relationship.removed, council.votes_changed, reputation.changed, member.joined and member.left. Each event carries its ID, so clients resume after a disconnect with the Last-Event-ID header (sent automatically by EventSource) or the last_event_id parameter; events are kept for 30 days. The number of open streams is bounded; opening a stream counts as one request against the rate limit.
+//	@Tags			events
+//	@Produce		text/event-stream
+//	@Param			account			query		string	false	"Comma-separated account IDs; events about or related to any of them"
+//	@Param			type			query		string	false	"Comma-separated event types"
+//	@Param			last_event_id	query		int		false	"Resume after this event ID (Last-Event-ID header takes precedence)"
+//	@Param			Last-Event-ID	header		int		false	"Resume after this event ID"
+//	@Success		200				{object}	EventResponse	"Stream of events; each data line is one EventResponse"
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		503				{object}	ErrorResponse
+//	@Router			/api/v1/events [get]
+func (h *Handler) Events(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	lastID, resume, err := parseLastEventID(r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Subscribe before replaying, so no event falls between the two; events
+	// delivered twice are skipped by ID below
+	sub, err := h.events.Subscribe(filter)
+	if err != nil {
+		if errors.Is(err, events.ErrTooManySubscribers) {
+			w.Header().Set("Retry-After", strconv.Itoa(eventsRetryMillis/1000))
+		}
+		h.writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	defer sub.Close()
+
+	rc := http.NewResponseController(w)
+	// Streams outlive the server's write timeout
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		slog.Warn("api: failed to clear event stream write deadline", "error", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", eventsRetryMillis)
+
+	send := func(e events.Event) error {
+		if err := writeEvent(w, e); err != nil {
+			return err
+		}
+		lastID = e.ID
+		return rc.Flush()
+	}
+
+	for resume {
+		batch, err := h.events.Replay(ctx, lastID, filter, eventsReplayBatch)
+		if err != nil {
+			slog.Error("api: failed to replay events", "after", lastID, "error", err)
+			return
+		}
+		for _, e := range batch {
+			if err := send(e); err != nil {
+				return
+			}
+		}
+		resume = len(batch) == eventsReplayBatch
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				// Lagging behind or shutting down; the client reconnects and resumes
+				return
+			}
+			if e.ID <= lastID {
+				continue
+			}
+			if err := send(e); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// parseEventFilter reads the account and type query parameters.
+func parseEventFilter(r *http.Request) (events.Filter, error) {
+	var filter events.Filter
+	q := r.URL.Query()
+
+	for _, id := range strings.Split(q.Get("account"), ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if !isValidStellarID(id) {
+			return filter, fmt.Errorf("invalid Stellar account ID format: %q", id)
+		}
+		filter.Accounts = append(filter.Accounts, id)
+	}
+	if len(filter.Accounts) > maxEventAccounts {
+		return filter, fmt.Errorf("at most %d accounts are allowed", maxEventAccounts)
+	}
+
+	types, err := events.ParseTypes(q.Get("type"))
+	if err != nil {
+		return filter, err
+	}
+	filter.Types = types
+	return filter, nil
+}
+
+// parseLastEventID reads the event ID to resume after. Without one the
+// stream only carries new events.
+func parseLastEventID(r *http.Request) (int64, bool, error) {
+	raw := r.Header.Get("Last-Event-ID")
+	if raw == "" {
+		raw = r.URL.Query().Get("last_event_id")
+	}
+	if raw == "" {
+		return 0, false, nil
+	}
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id < 0 {
+		return 0, false, fmt.Errorf("invalid last event ID %q", raw)
+	}
+	return id, true, nil
+}
+
+// writeEvent writes e in the text/event-stream format.
+func writeEvent(w http.ResponseWriter, e events.Event) error {
+	data, err := json.Marshal(EventResponse{
+		ID:               e.ID,
+		Type:             string(e.Type),
+		AccountID:        e.AccountID,
+		RelatedAccountID: e.RelatedAccountID,
+		Data:             e.Data,
+		CreatedAt:        e.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("encode event %d: %w", e.ID, err)
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/mtlprog/lore/internal/apikey"
+	"github.com/mtlprog/lore/internal/export"
+)
+
+// exportWriteTimeout replaces the server's write timeout for export downloads.
+const exportWriteTimeout = 30 * time.Minute
+
+// Export handles GET /api/v1/export.
+//
+//	@Summary		Export the dataset
+//	@Description	Streams accounts, balances, metadata, relationships, association tags, liquidity pools, LP shares and reputation scores as a ZIP archive with one file per table and a manifest.json naming the sync run and schema version. Requires an API key with the export scope.
+//	@Tags			export
+//	@Produce		application/zip
+//	@Param			format				query		string	false	"File format: csv, jsonl or parquet"	default(csv)
+//	@Param			exclude_personal	query		bool	false	"Leave out Telegram IDs and contract links from account_metadata"
+//	@Success		200					{file}		file
+//	@Failure		400					{object}	ErrorResponse
+//	@Failure		401					{object}	ErrorResponse
+//	@Failure		403					{object}	ErrorResponse
+//	@Failure		500					{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/api/v1/export [get]
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	key := apikey.FromContext(r.Context())
+	if key == nil {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="lore"`)
+		h.writeError(w, http.StatusUnauthorized, "an API key is required")
+		return
+	}
+	if !key.HasScope(apikey.ScopeExport) {
+		h.writeError(w, http.StatusForbidden, "API key lacks the export scope")
+		return
+	}
+
+	opts := export.Options{
+		Format:          export.FormatCSV,
+		ExcludePersonal: r.URL.Query().Get("exclude_personal") == "true",
+	}
+	if f := r.URL.Query().Get("format"); f != "" {
+		format, err := export.ParseFormat(f)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		opts.Format = format
+	}
+
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(exportWriteTimeout)); err != nil {
+		slog.Warn("api: failed to extend export write deadline", "error", err)
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="lore-export-%s.zip"`, opts.Format))
+
+	out := &startedWriter{w: w}
+	zw := zip.NewWriter(out)
+	manifest, err := h.exporter.Export(r.Context(), opts, zw)
+	if err == nil {
+		err = zw.Close()
+	}
+	if err != nil {
+		slog.Error("api: export failed", "key_id", key.ID, "format", opts.Format, "error", err)
+		if !out.started {
+			w.Header().Del("Content-Disposition")
+			h.writeError(w, http.StatusInternalServerError, "export failed")
+			return
+		}
+		// The archive is already partly sent; drop the connection so the
+		// client does not mistake it for a complete download.
+		panic(http.ErrAbortHandler)
+	}
+
+	slog.Info("api: export completed", "key_id", key.ID, "format", opts.Format, "sync_run_id", manifest.SyncRunID)
+}
+
+// startedWriter records whether any of the response body has been written.
+type startedWriter struct {
+	w       io.Writer
+	started bool
+}
+
+func (s *startedWriter) Write(p []byte) (int, error) {
+	s.started = true
+	return s.w.Write(p)
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mtlprog/lore/internal/apikey"
+	"github.com/mtlprog/lore/internal/export"
+	"github.com/mtlprog/lore/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExporter writes a single empty table.
+type fakeExporter struct{}
+
+func (fakeExporter) Export(_ context.Context, opts export.Options, sink export.Sink) (*export.Manifest, error) {
+	if _, err := sink.Create("accounts.csv"); err != nil {
+		return nil, err
+	}
+	return &export.Manifest{Format: opts.Format}, nil
+}
+
+// staticKeys authenticates the tokens in its map and counts no usage.
+type staticKeys map[string]*apikey.Key
+
+func (k staticKeys) Authenticate(_ context.Context, token string) (*apikey.Key, error) {
+	key, ok := k[token]
+	if !ok {
+		return nil, apikey.ErrInvalidKey
+	}
+	return key, nil
+}
+
+func (staticKeys) RecordUsage(context.Context, *apikey.Key) (int64, error) {
+	return 1, nil
+}
+
+func TestExport_Scopes(t *testing.T) {
+	h, err := New(&fakeAccounts{}, nil, nil, nil, WithExporter(fakeExporter{}))
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	keys := staticKeys{
+		"lore_export": {ID: 1, Scopes: []apikey.Scope{apikey.ScopeExport}},
+		"lore_read":   {ID: 2, Scopes: []apikey.Scope{apikey.ScopeRead}},
+	}
+	limiter, err := middleware.New(10, nil,
+		middleware.WithAPIKeys(keys),
+		middleware.WithPathScope("/api/v1/export", apikey.ScopeExport),
+	)
+	require.NoError(t, err)
+	t.Cleanup(limiter.Close)
+	handler := limiter.Middleware(mux)
+
+	get := func(authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/export", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("export-only key", func(t *testing.T) {
+		w := get("Bearer lore_export")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	})
+
+	t.Run("read-only key", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, get("Bearer lore_read").Code)
+	})
+
+	t.Run("anonymous", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, get("").Code)
+	})
+}
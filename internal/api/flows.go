@@ -0,0 +1,142 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/samber/lo"
+)
+
+// GetAccountFlows handles GET /api/v1/accounts/{id}/flows.
+//
+//	@Summary		Get account payment flows
+//	@Description	Returns the tracked accounts this account exchanged the most value with, per asset.
+//	@Description	Built from indexed payments and path payments; path payments count the delivered amount.
+//	@Tags			flows
+//	@Produce		json
+//	@Param			id		path		string	true	"Stellar account ID"
+//	@Param			asset	query		string	false	"Asset code (XLM for native), or CODE:ISSUER for one issuer"
+//	@Param			since	query		string	false	"Start date, YYYY-MM-DD or RFC 3339 (inclusive)"
+//	@Param			until	query		string	false	"End date, YYYY-MM-DD (inclusive) or RFC 3339 (exclusive)"
+//	@Param			limit	query		int		false	"Number of counterparties"	default(20)	maximum(100)
+//	@Success		200		{array}		CounterpartyFlowResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/api/v1/accounts/{id}/flows [get]
+func (h *Handler) GetAccountFlows(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	accountID, ok := h.validateAccountID(w, r)
+	if !ok {
+		return
+	}
+
+	filter, ok := h.parseFlowFilter(w, r)
+	if !ok {
+		return
+	}
+
+	exists, err := h.accounts.AccountExists(ctx, accountID)
+	if err != nil {
+		slog.Error("api: failed to check account existence", "account_id", accountID, "error", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to check account")
+		return
+	}
+	if !exists {
+		h.writeError(w, http.StatusNotFound, "account not found")
+		return
+	}
+
+	flows, err := h.accounts.GetAccountFlows(ctx, accountID, filter, parseIntParam(r, "limit", defaultLimit, maxLimit))
+	if err != nil {
+		slog.Error("api: failed to fetch account flows", "account_id", accountID, "error", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to fetch flows")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, lo.Map(flows, func(f model.CounterpartyFlow, _ int) CounterpartyFlowResponse {
+		return CounterpartyFlowResponse{
+			AccountID:   f.AccountID,
+			Name:        f.Name,
+			AssetCode:   f.AssetCode,
+			AssetIssuer: f.AssetIssuer,
+			Sent:        f.Sent,
+			Received:    f.Received,
+			Volume:      f.Volume(),
+			Payments:    f.Payments,
+		}
+	}))
+}
+
+// GetFlows handles GET /api/v1/flows.
+//
+//	@Summary		Get the internal economy
+//	@Description	Returns monthly payment volume between tracked accounts per asset, and the largest
+//	@Description	flows between pairs of tracked accounts. Each payment is counted once.
+//	@Tags			flows
+//	@Produce		json
+//	@Param			asset	query		string	false	"Asset code (XLM for native), or CODE:ISSUER for one issuer"
+//	@Param			since	query		string	false	"Start date, YYYY-MM-DD or RFC 3339 (inclusive)"
+//	@Param			until	query		string	false	"End date, YYYY-MM-DD (inclusive) or RFC 3339 (exclusive)"
+//	@Param			limit	query		int		false	"Number of flows"	default(20)	maximum(100)
+//	@Success		200		{object}	FlowsResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/api/v1/flows [get]
+func (h *Handler) GetFlows(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	filter, ok := h.parseFlowFilter(w, r)
+	if !ok {
+		return
+	}
+
+	periods, err := h.accounts.GetFlowPeriods(ctx, filter)
+	if err != nil {
+		slog.Error("api: failed to fetch flow periods", "error", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to fetch flows")
+		return
+	}
+
+	edges, err := h.accounts.GetFlowEdges(ctx, filter, parseIntParam(r, "limit", defaultLimit, maxLimit))
+	if err != nil {
+		slog.Error("api: failed to fetch flow edges", "error", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to fetch flows")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, FlowsResponse{
+		Periods: lo.Map(periods, func(p model.FlowPeriod, _ int) FlowPeriodResponse {
+			return FlowPeriodResponse{
+				Month:       p.Month.Format("2006-01"),
+				AssetCode:   p.AssetCode,
+				AssetIssuer: p.AssetIssuer,
+				Volume:      p.Volume,
+				Payments:    p.Payments,
+				Payers:      p.Payers,
+			}
+		}),
+		Edges: lo.Map(edges, func(e model.FlowEdge, _ int) FlowEdgeResponse {
+			return FlowEdgeResponse{
+				From:        e.FromID,
+				FromName:    e.FromName,
+				To:          e.ToID,
+				ToName:      e.ToName,
+				AssetCode:   e.AssetCode,
+				AssetIssuer: e.AssetIssuer,
+				Volume:      e.Volume,
+				Payments:    e.Payments,
+			}
+		}),
+	})
+}
+
+// parseFlowFilter reads the asset, since and until query parameters. It writes
+// an error response and returns false if a date is invalid.
+func (h *Handler) parseFlowFilter(w http.ResponseWriter, r *http.Request) (model.FlowFilter, bool) {
+	asset := model.ParseFlowAsset(r.URL.Query().Get("asset"))
+	filter := model.FlowFilter{AssetCode: asset.Code, AssetIssuer: asset.Issuer}
+	var ok bool
+	filter.Since, filter.Until, ok = h.parseDateRange(w, r)
+	return filter, ok
+}
@@ -0,0 +1,410 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/mtlprog/lore/internal/repository"
+	"github.com/mtlprog/lore/pkg/lorev1"
+	"github.com/samber/lo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCServer implements lorev1.LoreServiceServer. It runs the same queries as
+// the REST handlers, so both APIs return the same data.
+type GRPCServer struct {
+	lorev1.UnimplementedLoreServiceServer
+
+	h      *Handler
+	health *health.Server
+}
+
+// NewGRPCServer creates the gRPC service.
+// reputation can be nil (GetReputation then returns Unavailable). opts configure
+// the shared handler, e.g. WithRelationTypes.
+func NewGRPCServer(accounts accountQuerierBase, reputation reputationQuerierBase, opts ...Option) (*GRPCServer, error) {
+	h, err := New(accounts, reputation, nil, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GRPCServer{h: h, health: health.NewServer()}, nil
+}
+
+// Register registers the Lore service, the health service and server
+// reflection on srv.
+func (s *GRPCServer) Register(srv *grpc.Server) {
+	lorev1.RegisterLoreServiceServer(srv, s)
+	healthpb.RegisterHealthServer(srv, s.health)
+	reflection.Register(srv)
+	s.health.SetServingStatus(lorev1.LoreService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+}
+
+// Shutdown reports every service as not serving, so health-checking clients
+// move away before the server stops.
+func (s *GRPCServer) Shutdown() {
+	s.health.Shutdown()
+}
+
+// GetStats returns aggregate statistics and membership growth.
+func (s *GRPCServer) GetStats(ctx context.Context, _ *lorev1.GetStatsRequest) (*lorev1.GetStatsResponse, error) {
+	stats, err := s.h.stats(ctx)
+	if err != nil {
+		return nil, grpcError(err, "failed to fetch stats")
+	}
+	return &lorev1.GetStatsResponse{
+		TotalAccounts:  int32(stats.TotalAccounts),
+		TotalPersons:   int32(stats.TotalPersons),
+		TotalCompanies: int32(stats.TotalCompanies),
+		TotalSynthetic: int32(stats.TotalSynthetic),
+		TotalXlmValue:  stats.TotalXLMValue,
+		Growth: lo.Map(stats.Growth, func(g MembershipGrowthResponse, _ int) *lorev1.MembershipGrowth {
+			return &lorev1.MembershipGrowth{
+				Month:     g.Month,
+				AssetCode: g.AssetCode,
+				Joined:    int32(g.Joined),
+				Left:      int32(g.Left),
+				Net:       int32(g.Net),
+			}
+		}),
+	}, nil
+}
+
+// ListAccounts returns a page of accounts, optionally filtered by type.
+func (s *GRPCServer) ListAccounts(ctx context.Context, req *lorev1.ListAccountsRequest) (*lorev1.ListAccountsResponse, error) {
+	accountType, err := accountTypeFilter(req.GetType())
+	if err != nil {
+		return nil, err
+	}
+	limit, offset := pageParams(req.GetPage())
+
+	if cursor, ok := pageCursor(req.GetPage()); ok {
+		items, page, err := s.h.listAccountsPage(ctx, accountType, cursor, limit)
+		if err != nil {
+			return nil, grpcError(err, "failed to list accounts")
+		}
+		return &lorev1.ListAccountsResponse{Accounts: accountMessages(items), Page: keysetPageMessage(limit, page)}, nil
+	}
+
+	items, total, err := s.h.listAccounts(ctx, accountType, limit, offset)
+	if err != nil {
+		return nil, grpcError(err, "failed to list accounts")
+	}
+	return &lorev1.ListAccountsResponse{Accounts: accountMessages(items), Page: offsetPageMessage(limit, offset, total)}, nil
+}
+
+// GetAccount returns the full account detail.
+func (s *GRPCServer) GetAccount(ctx context.Context, req *lorev1.GetAccountRequest) (*lorev1.GetAccountResponse, error) {
+	if err := validateGRPCAccountID(req.GetId()); err != nil {
+		return nil, err
+	}
+	detail, err := s.h.accountDetail(ctx, req.GetId())
+	if err != nil {
+		return nil, grpcError(err, "failed to fetch account")
+	}
+	return &lorev1.GetAccountResponse{Account: accountDetailMessage(detail)}, nil
+}
+
+// GetRelationships returns relationships grouped by category.
+func (s *GRPCServer) GetRelationships(ctx context.Context, req *lorev1.GetRelationshipsRequest) (*lorev1.GetRelationshipsResponse, error) {
+	if err := validateGRPCAccountID(req.GetId()); err != nil {
+		return nil, err
+	}
+	categories, err := s.h.relationships(ctx, req.GetId(), relationshipFilter{
+		Type:      req.GetType(),
+		Confirmed: req.GetConfirmed(),
+		Mutual:    req.GetMutual(),
+	})
+	if err != nil {
+		return nil, grpcError(err, "failed to fetch relationships")
+	}
+	return &lorev1.GetRelationshipsResponse{Categories: categoryMessages(categories)}, nil
+}
+
+// GetReputation returns the reputation graph of an account.
+func (s *GRPCServer) GetReputation(ctx context.Context, req *lorev1.GetReputationRequest) (*lorev1.GetReputationResponse, error) {
+	if err := validateGRPCAccountID(req.GetId()); err != nil {
+		return nil, err
+	}
+	graph, err := s.h.reputationGraph(ctx, req.GetId())
+	if err != nil {
+		return nil, grpcError(err, "failed to fetch reputation data")
+	}
+	return &lorev1.GetReputationResponse{
+		TargetAccountId: graph.TargetAccountID,
+		TargetName:      graph.TargetName,
+		Score:           reputationMessage(graph.Score),
+		Level1Nodes:     reputationNodeMessages(graph.Level1Nodes),
+		Level2Nodes:     reputationNodeMessages(graph.Level2Nodes),
+	}, nil
+}
+
+// SearchAccounts searches accounts by name, account ID and tags.
+func (s *GRPCServer) SearchAccounts(ctx context.Context, req *lorev1.SearchAccountsRequest) (*lorev1.SearchAccountsResponse, error) {
+	limit, offset := pageParams(req.GetPage())
+
+	query, tags, err := searchTerms(req.GetQuery(), req.GetTags())
+	if err != nil {
+		return nil, grpcError(err, "failed to search accounts")
+	}
+	if query == "" && len(tags) == 0 {
+		return &lorev1.SearchAccountsResponse{Accounts: []*lorev1.Account{}, Page: offsetPageMessage(limit, offset, 0)}, nil
+	}
+
+	sortBy := repository.SearchSortByBalance
+	if req.GetSort() == lorev1.SearchSort_SEARCH_SORT_REPUTATION {
+		sortBy = repository.SearchSortByReputation
+	}
+
+	if cursor, ok := pageCursor(req.GetPage()); ok {
+		items, page, err := s.h.searchPage(ctx, query, tags, cursor, limit, sortBy)
+		if err != nil {
+			return nil, grpcError(err, "failed to search accounts")
+		}
+		return &lorev1.SearchAccountsResponse{Accounts: accountMessages(items), Page: keysetPageMessage(limit, page)}, nil
+	}
+
+	items, total, err := s.h.search(ctx, query, tags, limit, offset, sortBy)
+	if err != nil {
+		return nil, grpcError(err, "failed to search accounts")
+	}
+	return &lorev1.SearchAccountsResponse{Accounts: accountMessages(items), Page: offsetPageMessage(limit, offset, total)}, nil
+}
+
+// RecoverUnary is a unary server interceptor that turns a panicking handler
+// into an Internal error instead of crashing the process, which also serves
+// the web UI and REST API.
+func RecoverUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("api: grpc handler panicked", "method", info.FullMethod, "panic", r, "stack", string(debug.Stack()))
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// grpcError maps errors of the shared queries to status errors. Unexpected
+// errors are logged and reported as Internal with msg, like the REST API.
+func grpcError(err error, msg string) error {
+	switch {
+	case errors.Is(err, errAccountNotFound):
+		return status.Error(codes.NotFound, "account not found")
+	case errors.Is(err, errReputationUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, errSearchQueryTooLong):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repository.ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, "invalid cursor")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	slog.Error("api: grpc request failed", "message", msg, "error", err)
+	return status.Error(codes.Internal, msg)
+}
+
+func validateGRPCAccountID(id string) error {
+	if id == "" {
+		return status.Error(codes.InvalidArgument, "account ID is required")
+	}
+	if !isValidStellarID(id) {
+		return status.Error(codes.InvalidArgument, "invalid Stellar account ID format")
+	}
+	return nil
+}
+
+// accountTypeFilter returns the account type name used by the REST API.
+func accountTypeFilter(t lorev1.AccountType) (string, error) {
+	switch t {
+	case lorev1.AccountType_ACCOUNT_TYPE_UNSPECIFIED:
+		return "", nil
+	case lorev1.AccountType_ACCOUNT_TYPE_PERSON:
+		return "person", nil
+	case lorev1.AccountType_ACCOUNT_TYPE_CORPORATE:
+		return "corporate", nil
+	case lorev1.AccountType_ACCOUNT_TYPE_SYNTHETIC:
+		return "synthetic", nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "invalid account type: %d", t)
+	}
+}
+
+func accountTypeMessage(t string) lorev1.AccountType {
+	switch t {
+	case "person":
+		return lorev1.AccountType_ACCOUNT_TYPE_PERSON
+	case "corporate":
+		return lorev1.AccountType_ACCOUNT_TYPE_CORPORATE
+	case "synthetic":
+		return lorev1.AccountType_ACCOUNT_TYPE_SYNTHETIC
+	default:
+		return lorev1.AccountType_ACCOUNT_TYPE_UNSPECIFIED
+	}
+}
+
+// pageParams applies the REST defaults and bounds to a page request.
+func pageParams(p *lorev1.PageRequest) (limit, offset int) {
+	limit = int(p.GetLimit())
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	return min(limit, maxLimit), max(int(p.GetOffset()), 0)
+}
+
+// pageCursor returns the keyset cursor of a page request and whether keyset
+// pagination was requested. A missing page selects offset pagination.
+func pageCursor(p *lorev1.PageRequest) (string, bool) {
+	if p == nil || p.Cursor == nil {
+		return "", false
+	}
+	return *p.Cursor, true
+}
+
+func offsetPageMessage(limit, offset, total int) *lorev1.Page {
+	t := int32(total)
+	return &lorev1.Page{Limit: int32(limit), Offset: int32(offset), Total: &t}
+}
+
+func keysetPageMessage(limit int, page repository.KeysetPage) *lorev1.Page {
+	return &lorev1.Page{
+		Limit:      int32(limit),
+		Offset:     int32(page.Start),
+		NextCursor: page.Next,
+		PrevCursor: page.Prev,
+	}
+}
+
+func accountMessages(items []AccountListItem) []*lorev1.Account {
+	return lo.Map(items, func(a AccountListItem, _ int) *lorev1.Account {
+		return &lorev1.Account{
+			Id:              a.ID,
+			Name:            a.Name,
+			Type:            accountTypeMessage(a.Type),
+			MtlapBalance:    a.MTLAPBalance,
+			MtlacBalance:    a.MTLACBalance,
+			MtlaxBalance:    a.MTLAXBalance,
+			TotalXlmValue:   a.TotalXLMValue,
+			ReputationScore: a.ReputationScore,
+			ReputationGrade: a.ReputationGrade,
+			IsCouncilReady:  a.IsCouncilReady,
+			ReceivedVotes:   int32(a.ReceivedVotes),
+		}
+	})
+}
+
+func accountDetailMessage(d *AccountDetailResponse) *lorev1.AccountDetail {
+	msg := &lorev1.AccountDetail{
+		Id:            d.ID,
+		Name:          d.Name,
+		About:         d.About,
+		Websites:      d.Websites,
+		Tags:          d.Tags,
+		IsCorporate:   d.IsCorporate,
+		TotalXlmValue: d.TotalXLMValue,
+		Trustlines: lo.Map(d.Trustlines, func(t TrustlineResponse, _ int) *lorev1.Trustline {
+			return &lorev1.Trustline{AssetCode: t.AssetCode, AssetIssuer: t.AssetIssuer, Balance: t.Balance}
+		}),
+		LpShares: lo.Map(d.LPShares, func(lp LPShareResponse, _ int) *lorev1.LPShare {
+			return &lorev1.LPShare{
+				PoolId:       lp.PoolID,
+				ShareBalance: lp.ShareBalance,
+				SharePercent: lp.SharePercent,
+				ReserveA:     reserveMessage(lp.ReserveA),
+				ReserveB:     reserveMessage(lp.ReserveB),
+				XlmValue:     lp.XLMValue,
+			}
+		}),
+		Reputation: reputationMessage(d.Reputation),
+		Categories: categoryMessages(d.Categories),
+	}
+	if id := d.Identity; id != nil {
+		msg.Identity = &lorev1.Identity{
+			TelegramUserId:     id.TelegramUserID,
+			TimeTokenCode:      id.TimeTokenCode,
+			TimeTokenIssuer:    id.TimeTokenIssuer,
+			TimeTokenDesc:      id.TimeTokenDesc,
+			TimeTokenOfferIpfs: id.TimeTokenOfferIPFS,
+			TimeTokenOfferUrl:  id.TimeTokenOfferURL,
+			TelegramPartChatId: id.TelegramPartChatID,
+			ContractIpfs:       id.ContractIPFS,
+			ContractUrl:        id.ContractURL,
+			PiiStandard:        id.PIIStandard,
+		}
+	}
+	if tr := d.TrustRating; tr != nil {
+		msg.TrustRating = &lorev1.TrustRating{
+			CountA: int32(tr.CountA),
+			CountB: int32(tr.CountB),
+			CountC: int32(tr.CountC),
+			CountD: int32(tr.CountD),
+			Total:  int32(tr.Total),
+			Score:  tr.Score,
+			Grade:  tr.Grade,
+		}
+	}
+	return msg
+}
+
+func reserveMessage(r ReserveResponse) *lorev1.Reserve {
+	return &lorev1.Reserve{AssetCode: r.AssetCode, AssetIssuer: r.AssetIssuer, Amount: r.Amount}
+}
+
+func reputationMessage(r *ReputationResponse) *lorev1.ReputationScore {
+	if r == nil {
+		return nil
+	}
+	return &lorev1.ReputationScore{
+		WeightedScore: r.WeightedScore,
+		BaseScore:     r.BaseScore,
+		Grade:         r.Grade,
+		RatingCountA:  int32(r.RatingCountA),
+		RatingCountB:  int32(r.RatingCountB),
+		RatingCountC:  int32(r.RatingCountC),
+		RatingCountD:  int32(r.RatingCountD),
+		TotalRatings:  int32(r.TotalRatings),
+		TotalWeight:   r.TotalWeight,
+	}
+}
+
+func reputationNodeMessages(nodes []ReputationNodeResponse) []*lorev1.ReputationNode {
+	return lo.Map(nodes, func(n ReputationNodeResponse, _ int) *lorev1.ReputationNode {
+		return &lorev1.ReputationNode{
+			AccountId:    n.AccountID,
+			Name:         n.Name,
+			Rating:       n.Rating,
+			Weight:       n.Weight,
+			PortfolioXlm: n.PortfolioXLM,
+			Connections:  int32(n.Connections),
+			OwnScore:     n.OwnScore,
+			Distance:     int32(n.Distance),
+		}
+	})
+}
+
+func categoryMessages(categories []RelationshipCategoryResponse) []*lorev1.RelationshipCategory {
+	return lo.Map(categories, func(c RelationshipCategoryResponse, _ int) *lorev1.RelationshipCategory {
+		return &lorev1.RelationshipCategory{
+			Name:  c.Name,
+			Color: c.Color,
+			Relationships: lo.Map(c.Relationships, func(r RelationshipResponse, _ int) *lorev1.Relationship {
+				return &lorev1.Relationship{
+					Type:        r.Type,
+					TypeName:    r.TypeName,
+					TargetId:    r.TargetID,
+					TargetName:  r.TargetName,
+					Direction:   r.Direction,
+					IsMutual:    r.IsMutual,
+					IsConfirmed: r.IsConfirmed,
+				}
+			}),
+		}
+	})
+}
@@ -0,0 +1,261 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/repository"
+	"github.com/mtlprog/lore/pkg/lorev1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeAccounts serves a fixed list of accounts. Keyset cursors are row
+// indexes. Methods the tests do not reach panic through the nil interface.
+type fakeAccounts struct {
+	accountQuerierBase
+	rows []repository.AllAccountRow
+}
+
+func (f *fakeAccounts) GetStats(context.Context) (*repository.Stats, error) {
+	return &repository.Stats{TotalAccounts: len(f.rows)}, nil
+}
+
+func (f *fakeAccounts) GetMembershipGrowth(context.Context, time.Time) ([]model.MembershipGrowth, error) {
+	return nil, nil
+}
+
+func (f *fakeAccounts) GetAllAccounts(_ context.Context, limit, offset int) ([]repository.AllAccountRow, error) {
+	return f.rows[min(offset, len(f.rows)):min(offset+limit, len(f.rows))], nil
+}
+
+func (f *fakeAccounts) GetAllAccountsPage(_ context.Context, cursor string, limit int) ([]repository.AllAccountRow, repository.KeysetPage, error) {
+	start := 0
+	if cursor != "" {
+		var err error
+		if start, err = strconv.Atoi(cursor); err != nil || start < 0 || start > len(f.rows) {
+			return nil, repository.KeysetPage{}, repository.ErrInvalidCursor
+		}
+	}
+	end := min(start+limit, len(f.rows))
+	page := repository.KeysetPage{Start: start}
+	if end < len(f.rows) {
+		page.Next = strconv.Itoa(end)
+	}
+	return f.rows[start:end], page, nil
+}
+
+// newTestGRPCServer serves svc over an in-memory listener and returns a client
+// connection to it.
+func newTestGRPCServer(t *testing.T, svc *GRPCServer) *grpc.ClientConn {
+	t.Helper()
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(RecoverUnary))
+	svc.Register(srv)
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func newTestGRPCClient(t *testing.T, accounts accountQuerierBase) lorev1.LoreServiceClient {
+	t.Helper()
+
+	svc, err := NewGRPCServer(accounts, nil)
+	require.NoError(t, err)
+	return lorev1.NewLoreServiceClient(newTestGRPCServer(t, svc))
+}
+
+func testAccounts() *fakeAccounts {
+	return &fakeAccounts{rows: []repository.AllAccountRow{
+		{AccountID: "GALICE", Name: "Alice", MTLAPBalance: 5},
+		{AccountID: "GBOB", Name: "Bob", MTLAPBalance: 4},
+		{AccountID: "GCAROL", Name: "Carol", MTLACBalance: 2},
+	}}
+}
+
+func TestGRPCServer_GetStats(t *testing.T) {
+	client := newTestGRPCClient(t, testAccounts())
+
+	resp, err := client.GetStats(context.Background(), &lorev1.GetStatsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), resp.GetTotalAccounts())
+}
+
+func TestGRPCServer_ListAccountsOffset(t *testing.T) {
+	client := newTestGRPCClient(t, testAccounts())
+
+	resp, err := client.ListAccounts(context.Background(), &lorev1.ListAccountsRequest{Page: &lorev1.PageRequest{Limit: 2, Offset: 1}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GBOB", "GCAROL"}, accountIDs(resp.GetAccounts()))
+	assert.Equal(t, lorev1.AccountType_ACCOUNT_TYPE_PERSON, resp.GetAccounts()[0].GetType())
+	assert.Equal(t, int32(2), resp.GetPage().GetLimit())
+	assert.Equal(t, int32(1), resp.GetPage().GetOffset())
+	assert.Equal(t, int32(3), resp.GetPage().GetTotal())
+}
+
+func TestGRPCServer_ListAccountsWithoutPage(t *testing.T) {
+	client := newTestGRPCClient(t, testAccounts())
+
+	resp, err := client.ListAccounts(context.Background(), &lorev1.ListAccountsRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetAccounts(), 3)
+	assert.Equal(t, int32(defaultLimit), resp.GetPage().GetLimit())
+	assert.Equal(t, int32(3), resp.GetPage().GetTotal())
+}
+
+func TestGRPCServer_ListAccountsCursor(t *testing.T) {
+	client := newTestGRPCClient(t, testAccounts())
+	ctx := context.Background()
+
+	first := ""
+	resp, err := client.ListAccounts(ctx, &lorev1.ListAccountsRequest{Page: &lorev1.PageRequest{Limit: 2, Cursor: &first}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GALICE", "GBOB"}, accountIDs(resp.GetAccounts()))
+	assert.Equal(t, "2", resp.GetPage().GetNextCursor())
+	assert.Nil(t, resp.GetPage().Total, "keyset pages are not counted")
+
+	next := resp.GetPage().GetNextCursor()
+	resp, err = client.ListAccounts(ctx, &lorev1.ListAccountsRequest{Page: &lorev1.PageRequest{Limit: 2, Cursor: &next}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GCAROL"}, accountIDs(resp.GetAccounts()))
+	assert.Empty(t, resp.GetPage().GetNextCursor())
+
+	bad := "x"
+	_, err = client.ListAccounts(ctx, &lorev1.ListAccountsRequest{Page: &lorev1.PageRequest{Cursor: &bad}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPCServer_ListAccountsMatchesREST(t *testing.T) {
+	accounts := testAccounts()
+	client := newTestGRPCClient(t, accounts)
+
+	h, err := New(accounts, nil, nil, nil)
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/accounts?limit=2&offset=1", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var rest struct {
+		Data       []AccountListItem `json:"data"`
+		Pagination Pagination        `json:"pagination"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rest))
+
+	resp, err := client.ListAccounts(context.Background(), &lorev1.ListAccountsRequest{Page: &lorev1.PageRequest{Limit: 2, Offset: 1}})
+	require.NoError(t, err)
+
+	require.Len(t, resp.GetAccounts(), len(rest.Data))
+	for i, item := range rest.Data {
+		assert.Equal(t, item.ID, resp.GetAccounts()[i].GetId())
+		assert.Equal(t, item.Name, resp.GetAccounts()[i].GetName())
+	}
+	require.NotNil(t, rest.Pagination.Total)
+	assert.Equal(t, int32(*rest.Pagination.Total), resp.GetPage().GetTotal())
+}
+
+func TestGRPCServer_SearchAccountsWithoutPage(t *testing.T) {
+	client := newTestGRPCClient(t, testAccounts())
+
+	resp, err := client.SearchAccounts(context.Background(), &lorev1.SearchAccountsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.GetAccounts())
+}
+
+func TestGRPCServer_InvalidAccountID(t *testing.T) {
+	client := newTestGRPCClient(t, testAccounts())
+	ctx := context.Background()
+
+	for _, id := range []string{"", "GSHORT"} {
+		_, err := client.GetAccount(ctx, &lorev1.GetAccountRequest{Id: id})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "GetAccount(%q)", id)
+
+		_, err = client.GetRelationships(ctx, &lorev1.GetRelationshipsRequest{Id: id})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "GetRelationships(%q)", id)
+	}
+}
+
+func TestGRPCServer_RecoversPanics(t *testing.T) {
+	// GetAccount reaches AccountExists, which the fake leaves unimplemented
+	client := newTestGRPCClient(t, testAccounts())
+	ctx := context.Background()
+
+	_, err := client.GetAccount(ctx, &lorev1.GetAccountRequest{Id: "G" + strings.Repeat("A", 55)})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// The server keeps serving
+	_, err = client.ListAccounts(ctx, &lorev1.ListAccountsRequest{})
+	assert.NoError(t, err)
+}
+
+func TestGRPCServer_Health(t *testing.T) {
+	svc, err := NewGRPCServer(testAccounts(), nil)
+	require.NoError(t, err)
+	health := healthpb.NewHealthClient(newTestGRPCServer(t, svc))
+	ctx := context.Background()
+	service := lorev1.LoreService_ServiceDesc.ServiceName
+
+	resp, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	svc.Shutdown()
+	resp, err = health.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+}
+
+func TestGRPCServer_Reflection(t *testing.T) {
+	svc, err := NewGRPCServer(testAccounts(), nil)
+	require.NoError(t, err)
+	reflection := reflectionpb.NewServerReflectionClient(newTestGRPCServer(t, svc))
+
+	stream, err := reflection.ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.NoError(t, stream.CloseSend())
+
+	var services []string
+	for _, s := range resp.GetListServicesResponse().GetService() {
+		services = append(services, s.GetName())
+	}
+	assert.Contains(t, services, lorev1.LoreService_ServiceDesc.ServiceName)
+	assert.Contains(t, services, "grpc.health.v1.Health")
+}
+
+func accountIDs(accounts []*lorev1.Account) []string {
+	ids := make([]string, len(accounts))
+	for i, a := range accounts {
+		ids[i] = a.GetId()
+	}
+	return ids
+}
@@ -2,46 +2,103 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"sync"
+
+	"github.com/mtlprog/lore/internal/relation"
+	"github.com/mtlprog/lore/internal/repository"
 )
 
 // Handler holds dependencies for API handlers.
 type Handler struct {
 	accounts   accountQuerierBase
 	reputation reputationQuerierBase
-	bufferPool *sync.Pool // Pool of bytes.Buffer for JSON encoding
+	linter     linterBase
+	submitter  txSubmitterBase
+	exporter   exporterBase       // nil disables /api/v1/export
+	events     eventsBase         // nil disables /api/v1/events
+	relations  *relation.Registry // BSN relation types of relationships and decoded XDR
+	bufferPool *sync.Pool         // Pool of bytes.Buffer for JSON encoding
+}
+
+// Option configures optional API features.
+type Option func(*Handler)
+
+// WithExporter enables the dataset export endpoint.
+func WithExporter(exporter exporterBase) Option {
+	return func(h *Handler) {
+		h.exporter = exporter
+	}
+}
+
+// WithEvents enables the live event stream.
+func WithEvents(events eventsBase) Option {
+	return func(h *Handler) {
+		h.events = events
+	}
+}
+
+// WithRelationTypes sets the BSN relation types relationships are grouped by
+// and decoded transactions are described with. Without it, no relation type is known.
+func WithRelationTypes(types *relation.Registry) Option {
+	return func(h *Handler) {
+		if types != nil {
+			h.relations = types
+		}
+	}
 }
 
 // New creates a new API Handler.
-// reputation can be nil (feature is optional).
-func New(accounts accountQuerierBase, reputation reputationQuerierBase) (*Handler, error) {
+// reputation, linter and submitter can be nil (features are optional).
+func New(accounts accountQuerierBase, reputation reputationQuerierBase, linter linterBase, submitter txSubmitterBase, opts ...Option) (*Handler, error) {
 	if accounts == nil {
 		return nil, errors.New("account repository is required")
 	}
-	return &Handler{
+	h := &Handler{
 		accounts:   accounts,
 		reputation: reputation,
+		linter:     linter,
+		submitter:  submitter,
+		relations:  relation.NewRegistry(nil),
 		bufferPool: &sync.Pool{
 			New: func() interface{} {
 				return new(bytes.Buffer)
 			},
 		},
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h, nil
 }
 
 // RegisterRoutes registers all API routes on the given mux.
 func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/v1/stats", h.Stats)
 	mux.HandleFunc("GET /api/v1/accounts", h.ListAccounts)
+	mux.HandleFunc("POST /api/v1/accounts:batch", h.BatchAccounts)
 	mux.HandleFunc("GET /api/v1/accounts/{id}", h.GetAccount)
 	mux.HandleFunc("GET /api/v1/accounts/{id}/reputation", h.GetReputation)
 	mux.HandleFunc("GET /api/v1/accounts/{id}/relationships", h.GetRelationships)
+	mux.HandleFunc("GET /api/v1/accounts/{id}/issues", h.GetIssues)
+	mux.HandleFunc("GET /api/v1/accounts/{id}/operations", h.GetOperations)
+	mux.HandleFunc("GET /api/v1/accounts/{id}/flows", h.GetAccountFlows)
+	mux.HandleFunc("GET /api/v1/flows", h.GetFlows)
 	mux.HandleFunc("GET /api/v1/search", h.Search)
+	mux.HandleFunc("POST /api/v1/init/submit", h.SubmitTransaction)
+	mux.HandleFunc("POST /api/v1/xdr/decode", h.DecodeXDR)
+	if h.exporter != nil {
+		mux.HandleFunc("GET /api/v1/export", h.Export)
+	}
+	if h.events != nil {
+		mux.HandleFunc("GET /api/v1/events", h.Events)
+	}
 }
 
 func (h *Handler) writeJSON(w http.ResponseWriter, status int, data any) {
@@ -70,6 +127,27 @@ func (h *Handler) writeError(w http.ResponseWriter, status int, msg string) {
 	})
 }
 
+var (
+	// errAccountNotFound is returned for accounts Lore has not synced.
+	errAccountNotFound = errors.New("account not found")
+	// errReputationUnavailable is returned when the reputation service is disabled.
+	errReputationUnavailable = errors.New("reputation feature not available")
+	// errSearchQueryTooLong is returned for search queries over 100 characters.
+	errSearchQueryTooLong = errors.New("search query too long (max 100 characters)")
+)
+
+// requireAccount returns errAccountNotFound unless the account exists.
+func (h *Handler) requireAccount(ctx context.Context, accountID string) error {
+	exists, err := h.accounts.AccountExists(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("check account existence: %w", err)
+	}
+	if !exists {
+		return errAccountNotFound
+	}
+	return nil
+}
+
 func isValidStellarID(id string) bool {
 	return len(id) == 56 && id[0] == 'G'
 }
@@ -114,3 +192,38 @@ func parseIntParam(r *http.Request, name string, defaultVal, maxVal int) int {
 	}
 	return v
 }
+
+// offsetPagination builds pagination metadata for an offset-paginated list,
+// linking the neighbouring pages by offset.
+func offsetPagination(r *http.Request, limit, offset, total int) Pagination {
+	p := Pagination{Limit: limit, Offset: offset, Total: &total}
+	if offset+limit < total {
+		p.Next = pageLink(r, "offset", strconv.Itoa(offset+limit))
+	}
+	if offset > 0 && limit > 0 {
+		p.Prev = pageLink(r, "offset", strconv.Itoa(max(offset-limit, 0)))
+	}
+	return p
+}
+
+// cursorPagination builds pagination metadata for a keyset page.
+func cursorPagination(r *http.Request, limit int, page repository.KeysetPage) Pagination {
+	p := Pagination{Limit: limit, Offset: page.Start}
+	if page.Next != "" {
+		p.Next = pageLink(r, "cursor", page.Next)
+	}
+	if page.Prev != "" {
+		p.Prev = pageLink(r, "cursor", page.Prev)
+	}
+	return p
+}
+
+// pageLink returns the request path and query with one pagination parameter
+// set and the other one removed.
+func pageLink(r *http.Request, param, value string) string {
+	q := r.URL.Query()
+	q.Del("offset")
+	q.Del("cursor")
+	q.Set(param, value)
+	return r.URL.Path + "?" + q.Encode()
+}
@@ -2,7 +2,11 @@ package api
 
 import (
 	"context"
+	"time"
 
+	"github.com/mtlprog/lore/internal/events"
+	"github.com/mtlprog/lore/internal/export"
+	"github.com/mtlprog/lore/internal/lint"
 	"github.com/mtlprog/lore/internal/model"
 	"github.com/mtlprog/lore/internal/repository"
 )
@@ -15,6 +19,11 @@ type accountQuerierBase interface {
 	GetCorporate(ctx context.Context, limit int, offset int) ([]repository.CorporateRow, error)
 	GetSynthetic(ctx context.Context, limit int, offset int) ([]repository.SyntheticRow, error)
 	GetAllAccounts(ctx context.Context, limit int, offset int) ([]repository.AllAccountRow, error)
+	GetPersonsPage(ctx context.Context, cursor string, limit int) ([]repository.PersonRow, repository.KeysetPage, error)
+	GetCorporatePage(ctx context.Context, cursor string, limit int) ([]repository.CorporateRow, repository.KeysetPage, error)
+	GetSyntheticPage(ctx context.Context, cursor string, limit int) ([]repository.SyntheticRow, repository.KeysetPage, error)
+	GetAllAccountsPage(ctx context.Context, cursor string, limit int) ([]repository.AllAccountRow, repository.KeysetPage, error)
+	GetAccountsByIDs(ctx context.Context, accountIDs []string) ([]repository.AllAccountRow, error)
 	GetRelationships(ctx context.Context, accountID string) ([]repository.RelationshipRow, error)
 	GetTrustRatings(ctx context.Context, accountID string) (*repository.TrustRating, error)
 	GetConfirmedRelationships(ctx context.Context, accountID string) (map[string]bool, error)
@@ -22,16 +31,44 @@ type accountQuerierBase interface {
 	GetAccountNames(ctx context.Context, accountIDs []string) (map[string]string, error)
 	GetAccountBalances(ctx context.Context, accountID string) ([]repository.BalanceRow, error)
 	SearchAccounts(ctx context.Context, query string, tags []string, limit int, offset int, sortBy repository.SearchSortOrder) ([]repository.SearchAccountRow, error)
+	SearchAccountsPage(ctx context.Context, query string, tags []string, cursor string, limit int, sortBy repository.SearchSortOrder) ([]repository.SearchAccountRow, repository.KeysetPage, error)
 	CountSearchAccounts(ctx context.Context, query string, tags []string) (int, error)
 	GetLPShares(ctx context.Context, accountID string) ([]repository.LPShareRow, error)
 	CountPersons(ctx context.Context) (int, error)
 	CountCorporate(ctx context.Context) (int, error)
 	CountSynthetic(ctx context.Context) (int, error)
 	GetAccountMetadata(ctx context.Context, accountID string) (*repository.AccountMetadata, error)
+	GetMembershipGrowth(ctx context.Context, since time.Time) ([]model.MembershipGrowth, error)
+	GetAccountOperations(ctx context.Context, accountID string, filter model.OperationFilter, cursor string, limit int) (*model.OperationsPage, error)
+	GetAccountFlows(ctx context.Context, accountID string, filter model.FlowFilter, limit int) ([]model.CounterpartyFlow, error)
+	GetFlowEdges(ctx context.Context, filter model.FlowFilter, limit int) ([]model.FlowEdge, error)
+	GetFlowPeriods(ctx context.Context, filter model.FlowFilter) ([]model.FlowPeriod, error)
 }
 
 // reputationQuerierBase defines the interface for reputation data access needed by the API.
 type reputationQuerierBase interface {
 	GetScore(ctx context.Context, accountID string) (*model.ReputationScore, error)
+	GetScores(ctx context.Context, accountIDs []string) (map[string]*model.ReputationScore, error)
 	GetGraph(ctx context.Context, accountID string) (*model.ReputationGraph, error)
 }
+
+// linterBase defines the interface for relationship consistency checks needed by the API.
+type linterBase interface {
+	LintAccount(ctx context.Context, accountID string) ([]lint.Finding, bool, error)
+}
+
+// txSubmitterBase defines the interface for submitting signed init transactions needed by the API.
+type txSubmitterBase interface {
+	Submit(ctx context.Context, signedXDR, expectedXDR string) (*model.SubmitResult, error)
+}
+
+// exporterBase defines the interface for dataset exports needed by the API.
+type exporterBase interface {
+	Export(ctx context.Context, opts export.Options, sink export.Sink) (*export.Manifest, error)
+}
+
+// eventsBase defines the interface for the live event stream needed by the API.
+type eventsBase interface {
+	Subscribe(filter events.Filter) (*events.Subscription, error)
+	Replay(ctx context.Context, after int64, filter events.Filter, limit int) ([]events.Event, error)
+}
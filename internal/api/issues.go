@@ -0,0 +1,82 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/mtlprog/lore/internal/lint"
+	"github.com/samber/lo"
+)
+
+// GetIssues handles GET /api/v1/accounts/{id}/issues.
+//
+//	@Summary		Get relationship issues
+//	@Description	Returns data-quality findings for the account's relationships and ManageData (contradictions, missing confirmations, self-references, duplicates, unknown targets, malformed entries). Each issue links to the init form where it can be fixed.
+//	@Tags			relationships
+//	@Produce		json
+//	@Param			id			path		string	true	"Stellar account ID"
+//	@Param			severity	query		string	false	"Filter by severity (error, warning, info)"
+//	@Param			category	query		string	false	"Filter by category (contradiction, confirmation, self_reference, duplicate, unknown_target, malformed)"
+//	@Success		200			{object}	IssuesResponse
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		404			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Failure		503			{object}	ErrorResponse
+//	@Router			/api/v1/accounts/{id}/issues [get]
+func (h *Handler) GetIssues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	accountID, ok := h.validateAccountID(w, r)
+	if !ok {
+		return
+	}
+
+	if h.linter == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "linter feature not available")
+		return
+	}
+
+	findings, exists, err := h.linter.LintAccount(ctx, accountID)
+	if err != nil {
+		slog.Error("api: failed to lint account", "account_id", accountID, "error", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to check account")
+		return
+	}
+	if !exists {
+		h.writeError(w, http.StatusNotFound, "account not found")
+		return
+	}
+
+	filterSeverity := r.URL.Query().Get("severity")
+	filterCategory := r.URL.Query().Get("category")
+	findings = lo.Filter(findings, func(f lint.Finding, _ int) bool {
+		if filterSeverity != "" && string(f.Severity) != filterSeverity {
+			return false
+		}
+		if filterCategory != "" && string(f.Category) != filterCategory {
+			return false
+		}
+		return true
+	})
+
+	h.writeJSON(w, http.StatusOK, IssuesResponse{
+		AccountID: accountID,
+		Total:     len(findings),
+		Issues:    convertFindings(findings),
+	})
+}
+
+func convertFindings(findings []lint.Finding) []IssueResponse {
+	return lo.Map(findings, func(f lint.Finding, _ int) IssueResponse {
+		return IssueResponse{
+			Rule:         f.Rule,
+			Category:     string(f.Category),
+			Severity:     string(f.Severity),
+			Key:          f.Key,
+			RelationType: f.RelationType,
+			TargetID:     f.TargetID,
+			Message:      f.Message,
+			Suggestion:   f.Suggestion,
+			FixURL:       f.FixURL,
+		}
+	})
+}
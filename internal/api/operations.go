@@ -0,0 +1,146 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/repository"
+	"github.com/samber/lo"
+)
+
+// GetOperations handles GET /api/v1/accounts/{id}/operations.
+//
+//	@Summary		Get account operations
+//	@Description	Returns operations of a tracked account from the local index, newest first.
+//	@Description	Spam (claimable balances, XLM payments below 1) is excluded. Works while Horizon is unavailable.
+//	@Tags			accounts
+//	@Produce		json
+//	@Param			id				path		string	true	"Stellar account ID"
+//	@Param			type			query		string	false	"Operation type (e.g. payment, manage_data)"
+//	@Param			asset			query		string	false	"Asset code (XLM for native)"
+//	@Param			counterparty	query		string	false	"Other account involved"
+//	@Param			since			query		string	false	"Start date, YYYY-MM-DD or RFC 3339 (inclusive)"
+//	@Param			until			query		string	false	"End date, YYYY-MM-DD (inclusive) or RFC 3339 (exclusive)"
+//	@Param			cursor			query		string	false	"next_cursor of the previous page"
+//	@Param			limit			query		int		false	"Number of results"	default(20)	maximum(100)
+//	@Success		200				{object}	OperationsResponse
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/api/v1/accounts/{id}/operations [get]
+func (h *Handler) GetOperations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	accountID, ok := h.validateAccountID(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	filter := model.OperationFilter{
+		Type:         q.Get("type"),
+		AssetCode:    q.Get("asset"),
+		Counterparty: q.Get("counterparty"),
+	}
+	if filter.Counterparty != "" && !isValidStellarID(filter.Counterparty) {
+		h.writeError(w, http.StatusBadRequest, "invalid counterparty account ID format")
+		return
+	}
+
+	filter.Since, filter.Until, ok = h.parseDateRange(w, r)
+	if !ok {
+		return
+	}
+
+	exists, err := h.accounts.AccountExists(ctx, accountID)
+	if err != nil {
+		slog.Error("api: failed to check account existence", "account_id", accountID, "error", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to check account")
+		return
+	}
+	if !exists {
+		h.writeError(w, http.StatusNotFound, "account not found")
+		return
+	}
+
+	limit := parseIntParam(r, "limit", defaultLimit, maxLimit)
+	page, err := h.accounts.GetAccountOperations(ctx, accountID, filter, q.Get("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			h.writeError(w, http.StatusBadRequest, "invalid cursor")
+			return
+		}
+		slog.Error("api: failed to fetch operations", "account_id", accountID, "error", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to fetch operations")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, OperationsResponse{
+		Data: lo.Map(page.Operations, func(op model.Operation, _ int) OperationResponse {
+			return OperationResponse{
+				ID:              op.ID,
+				Type:            op.Type,
+				TypeDisplay:     op.TypeDisplay,
+				CreatedAt:       op.CreatedAt,
+				TransactionHash: op.TransactionHash,
+				SourceAccount:   op.SourceAccount,
+				Amount:          op.Amount,
+				AssetCode:       op.AssetCode,
+				AssetIssuer:     op.AssetIssuer,
+				From:            op.From,
+				To:              op.To,
+				DataName:        op.DataName,
+				DataValue:       op.DataValue,
+				StartingBalance: op.StartingBalance,
+				TrustLimit:      op.TrustLimit,
+				SourceAmount:    op.SourceAmount,
+				SourceAsset:     op.SourceAsset,
+				DestAmount:      op.DestAmount,
+				DestAsset:       op.DestAsset,
+				Selling:         op.Selling,
+				Buying:          op.Buying,
+				Price:           op.Price,
+				OfferID:         op.OfferID,
+			}
+		}),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	})
+}
+
+// parseDateRange reads the since and until query parameters. It writes an
+// error response and returns false if either is invalid.
+func (h *Handler) parseDateRange(w http.ResponseWriter, r *http.Request) (since, until time.Time, ok bool) {
+	var err error
+	if since, err = parseDateParam(r.URL.Query().Get("since"), false); err != nil {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid since: %v", err))
+		return since, until, false
+	}
+	if until, err = parseDateParam(r.URL.Query().Get("until"), true); err != nil {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid until: %v", err))
+		return since, until, false
+	}
+	return since, until, true
+}
+
+// parseDateParam parses a YYYY-MM-DD or RFC 3339 query value. A bare date used
+// as an upper bound covers the whole day. An empty value yields the zero time.
+func parseDateParam(s string, endOfDay bool) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, errors.New("expected YYYY-MM-DD or RFC 3339")
+	}
+	return t, nil
+}
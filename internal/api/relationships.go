@@ -1,6 +1,9 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -24,32 +27,60 @@ import (
 //	@Failure		500			{object}	ErrorResponse
 //	@Router			/api/v1/accounts/{id}/relationships [get]
 func (h *Handler) GetRelationships(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
 	accountID, ok := h.validateAccountID(w, r)
 	if !ok {
 		return
 	}
 
-	exists, err := h.accounts.AccountExists(ctx, accountID)
+	filter := relationshipFilter{
+		Type:      r.URL.Query().Get("type"),
+		Confirmed: r.URL.Query().Get("confirmed") == "true",
+		Mutual:    r.URL.Query().Get("mutual") == "true",
+	}
+
+	resp, err := h.relationships(r.Context(), accountID, filter)
 	if err != nil {
-		slog.Error("api: failed to check account existence", "account_id", accountID, "error", err)
-		h.writeError(w, http.StatusInternalServerError, "failed to check account")
+		if errors.Is(err, errAccountNotFound) {
+			h.writeError(w, http.StatusNotFound, "account not found")
+			return
+		}
+		slog.Error("api: failed to fetch relationships", "account_id", accountID, "error", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to fetch relationships")
 		return
 	}
-	if !exists {
-		h.writeError(w, http.StatusNotFound, "account not found")
-		return
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// relationshipFilter narrows the relationships of each category.
+type relationshipFilter struct {
+	Type      string // Relationship type, e.g. Spouse; empty for all
+	Confirmed bool   // Only confirmed relationships
+	Mutual    bool   // Only mutual relationships
+}
+
+func (f relationshipFilter) match(rel RelationshipResponse) bool {
+	if f.Type != "" && rel.Type != f.Type {
+		return false
+	}
+	if f.Confirmed && !rel.IsConfirmed {
+		return false
 	}
+	if f.Mutual && !rel.IsMutual {
+		return false
+	}
+	return true
+}
 
-	filterType := r.URL.Query().Get("type")
-	filterConfirmed := r.URL.Query().Get("confirmed") == "true"
-	filterMutual := r.URL.Query().Get("mutual") == "true"
+// relationships returns the account's relationships grouped by category.
+func (h *Handler) relationships(ctx context.Context, accountID string, filter relationshipFilter) ([]RelationshipCategoryResponse, error) {
+	if err := h.requireAccount(ctx, accountID); err != nil {
+		return nil, err
+	}
 
 	relationships, err := h.accounts.GetRelationships(ctx, accountID)
 	if err != nil {
-		slog.Error("api: failed to fetch relationships", "account_id", accountID, "error", err)
-		h.writeError(w, http.StatusInternalServerError, "failed to fetch relationships")
-		return
+		return nil, fmt.Errorf("get relationships: %w", err)
 	}
 
 	confirmed, err := h.accounts.GetConfirmedRelationships(ctx, accountID)
@@ -58,27 +89,16 @@ func (h *Handler) GetRelationships(w http.ResponseWriter, r *http.Request) {
 		confirmed = make(map[string]bool)
 	}
 
-	categories := bsn.GroupRelationships(accountID, relationships, confirmed)
-	resp := convertCategories(categories)
+	categories := convertCategories(bsn.GroupRelationships(h.relations, accountID, relationships, confirmed))
 
 	// Apply optional filters to each category's relationships
-	hasFilters := filterType != "" || filterConfirmed || filterMutual
-	if hasFilters {
-		for i := range resp {
-			resp[i].Relationships = lo.Filter(resp[i].Relationships, func(rel RelationshipResponse, _ int) bool {
-				if filterType != "" && rel.Type != filterType {
-					return false
-				}
-				if filterConfirmed && !rel.IsConfirmed {
-					return false
-				}
-				if filterMutual && !rel.IsMutual {
-					return false
-				}
-				return true
+	if filter != (relationshipFilter{}) {
+		for i := range categories {
+			categories[i].Relationships = lo.Filter(categories[i].Relationships, func(rel RelationshipResponse, _ int) bool {
+				return filter.match(rel)
 			})
 		}
 	}
 
-	h.writeJSON(w, http.StatusOK, resp)
+	return categories, nil
 }
@@ -1,6 +1,9 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -22,33 +25,42 @@ import (
 //	@Failure		503	{object}	ErrorResponse
 //	@Router			/api/v1/accounts/{id}/reputation [get]
 func (h *Handler) GetReputation(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
 	accountID, ok := h.validateAccountID(w, r)
 	if !ok {
 		return
 	}
 
-	exists, err := h.accounts.AccountExists(ctx, accountID)
+	resp, err := h.reputationGraph(r.Context(), accountID)
 	if err != nil {
-		slog.Error("api: failed to check account existence", "account_id", accountID, "error", err)
-		h.writeError(w, http.StatusInternalServerError, "failed to check account")
+		switch {
+		case errors.Is(err, errAccountNotFound):
+			h.writeError(w, http.StatusNotFound, "account not found")
+		case errors.Is(err, errReputationUnavailable):
+			h.writeError(w, http.StatusServiceUnavailable, "reputation feature not available")
+		default:
+			slog.Error("api: failed to fetch reputation graph", "account_id", accountID, "error", err)
+			h.writeError(w, http.StatusInternalServerError, "failed to fetch reputation data")
+		}
 		return
 	}
-	if !exists {
-		h.writeError(w, http.StatusNotFound, "account not found")
-		return
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// reputationGraph returns the account's raters and their raters. Accounts
+// without a graph get an empty one with their score, if any.
+func (h *Handler) reputationGraph(ctx context.Context, accountID string) (*ReputationGraphResponse, error) {
+	if err := h.requireAccount(ctx, accountID); err != nil {
+		return nil, err
 	}
 
 	if h.reputation == nil {
-		h.writeError(w, http.StatusServiceUnavailable, "reputation feature not available")
-		return
+		return nil, errReputationUnavailable
 	}
 
 	graph, err := h.reputation.GetGraph(ctx, accountID)
 	if err != nil {
-		slog.Error("api: failed to fetch reputation graph", "account_id", accountID, "error", err)
-		h.writeError(w, http.StatusInternalServerError, "failed to fetch reputation data")
-		return
+		return nil, fmt.Errorf("get reputation graph: %w", err)
 	}
 
 	if graph == nil {
@@ -58,18 +70,16 @@ func (h *Handler) GetReputation(w http.ResponseWriter, r *http.Request) {
 			slog.Error("api: failed to fetch reputation score", "account_id", accountID, "error", err)
 		}
 
-		resp := ReputationGraphResponse{
+		return &ReputationGraphResponse{
 			TargetAccountID: accountID,
 			TargetName:      accountID,
 			Score:           convertReputationScore(score),
 			Level1Nodes:     []ReputationNodeResponse{},
 			Level2Nodes:     []ReputationNodeResponse{},
-		}
-		h.writeJSON(w, http.StatusOK, resp)
-		return
+		}, nil
 	}
 
-	resp := ReputationGraphResponse{
+	return &ReputationGraphResponse{
 		TargetAccountID: graph.TargetAccountID,
 		TargetName:      graph.TargetName,
 		Score:           convertReputationScore(graph.Score),
@@ -79,9 +89,7 @@ func (h *Handler) GetReputation(w http.ResponseWriter, r *http.Request) {
 		Level2Nodes: lo.Map(graph.Level2Nodes, func(n model.ReputationNode, _ int) ReputationNodeResponse {
 			return convertReputationNode(n)
 		}),
-	}
-
-	h.writeJSON(w, http.StatusOK, resp)
+	}, nil
 }
 
 func convertReputationNode(n model.ReputationNode) ReputationNodeResponse {
@@ -1,6 +1,9 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
@@ -13,7 +16,8 @@ import (
 // Search handles GET /api/v1/search.
 //
 //	@Summary		Search accounts
-//	@Description	Search accounts by name or account ID, optionally filtered by tags
+//	@Description	Search accounts by name or account ID, optionally filtered by tags.
+//	@Description	Pass cursor (empty for the first page) for keyset pagination without counting total.
 //	@Tags			search
 //	@Produce		json
 //	@Param			q		query		string	false	"Search query (min 2 chars)"
@@ -21,6 +25,7 @@ import (
 //	@Param			sort	query		string	false	"Sort order"	Enums(balance, reputation)	default(balance)
 //	@Param			limit	query		int		false	"Number of results"	default(20)	maximum(100)
 //	@Param			offset	query		int		false	"Offset for pagination"	default(0)
+//	@Param			cursor	query		string	false	"Page cursor from pagination.next or pagination.prev"
 //	@Success		200		{object}	PaginatedResponse
 //	@Failure		400		{object}	ErrorResponse
 //	@Failure		500		{object}	ErrorResponse
@@ -28,68 +33,111 @@ import (
 func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	query := strings.TrimSpace(r.URL.Query().Get("q"))
 	limit := parseIntParam(r, "limit", defaultLimit, maxLimit)
 	offset := parseIntParam(r, "offset", 0, 0)
 
 	// Parse tags from comma-separated string
 	var tags []string
 	if tagsParam := r.URL.Query().Get("tags"); tagsParam != "" {
-		tags = lo.FilterMap(strings.Split(tagsParam, ","), func(t string, _ int) (string, bool) {
-			trimmed := strings.TrimSpace(t)
-			return trimmed, trimmed != ""
-		})
+		tags = strings.Split(tagsParam, ",")
 	}
 
 	// Parse sort
-	sortParam := r.URL.Query().Get("sort")
 	repoSort := repository.SearchSortByBalance
-	if sortParam == "reputation" {
+	if r.URL.Query().Get("sort") == "reputation" {
 		repoSort = repository.SearchSortByReputation
 	}
 
-	// Validate query length
-	if len(query) > 100 {
-		h.writeError(w, http.StatusBadRequest, "search query too long (max 100 characters)")
+	query, tags, err := searchTerms(r.URL.Query().Get("q"), tags)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Validate tag lengths
-	tags = lo.Filter(tags, func(t string, _ int) bool { return len(t) <= 100 })
-
-	// Discard too-short queries (still allow tag-only search)
-	if len(query) < 2 {
-		query = ""
-	}
-
 	// If no query and no tags, return empty result
 	if query == "" && len(tags) == 0 {
 		h.writeJSON(w, http.StatusOK, PaginatedResponse{
-			Data: []AccountListItem{},
-			Pagination: Pagination{
-				Limit:  limit,
-				Offset: offset,
-				Total:  0,
-			},
+			Data:       []AccountListItem{},
+			Pagination: offsetPagination(r, limit, offset, 0),
 		})
 		return
 	}
 
-	totalCount, err := h.accounts.CountSearchAccounts(ctx, query, tags)
-	if err != nil {
-		slog.Error("api: failed to count search results", "query", query, "tags", tags, "error", err)
-		h.writeError(w, http.StatusInternalServerError, "failed to search accounts")
+	if r.URL.Query().Has("cursor") {
+		items, page, err := h.searchPage(ctx, query, tags, r.URL.Query().Get("cursor"), limit, repoSort)
+		if err != nil {
+			if errors.Is(err, repository.ErrInvalidCursor) {
+				h.writeError(w, http.StatusBadRequest, "invalid cursor")
+				return
+			}
+			slog.Error("api: failed to search accounts", "query", query, "tags", tags, "error", err)
+			h.writeError(w, http.StatusInternalServerError, "failed to search accounts")
+			return
+		}
+		h.writeJSON(w, http.StatusOK, PaginatedResponse{
+			Data:       items,
+			Pagination: cursorPagination(r, limit, page),
+		})
 		return
 	}
 
-	rows, err := h.accounts.SearchAccounts(ctx, query, tags, limit, offset, repoSort)
+	items, total, err := h.search(ctx, query, tags, limit, offset, repoSort)
 	if err != nil {
 		slog.Error("api: failed to search accounts", "query", query, "tags", tags, "error", err)
 		h.writeError(w, http.StatusInternalServerError, "failed to search accounts")
 		return
 	}
 
-	items := lo.Map(rows, func(row repository.SearchAccountRow, _ int) AccountListItem {
+	h.writeJSON(w, http.StatusOK, PaginatedResponse{
+		Data:       items,
+		Pagination: offsetPagination(r, limit, offset, total),
+	})
+}
+
+// searchTerms trims and validates a search. Queries shorter than 2 characters
+// are dropped, so tag-only searches still work, and over-long tags are ignored.
+// Both results are empty when there is nothing to search for.
+func searchTerms(query string, tags []string) (string, []string, error) {
+	query = strings.TrimSpace(query)
+	if len(query) > 100 {
+		return "", nil, errSearchQueryTooLong
+	}
+	if len(query) < 2 {
+		query = ""
+	}
+
+	tags = lo.FilterMap(tags, func(t string, _ int) (string, bool) {
+		trimmed := strings.TrimSpace(t)
+		return trimmed, trimmed != "" && len(trimmed) <= 100
+	})
+	return query, tags, nil
+}
+
+// search returns an offset page of matching accounts and the number of matches.
+func (h *Handler) search(ctx context.Context, query string, tags []string, limit, offset int, sortBy repository.SearchSortOrder) ([]AccountListItem, int, error) {
+	total, err := h.accounts.CountSearchAccounts(ctx, query, tags)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count search results: %w", err)
+	}
+
+	rows, err := h.accounts.SearchAccounts(ctx, query, tags, limit, offset, sortBy)
+	if err != nil {
+		return nil, 0, fmt.Errorf("search accounts: %w", err)
+	}
+	return searchItems(rows), total, nil
+}
+
+// searchPage returns a keyset page of matching accounts.
+func (h *Handler) searchPage(ctx context.Context, query string, tags []string, cursor string, limit int, sortBy repository.SearchSortOrder) ([]AccountListItem, repository.KeysetPage, error) {
+	rows, page, err := h.accounts.SearchAccountsPage(ctx, query, tags, cursor, limit, sortBy)
+	if err != nil {
+		return nil, page, err
+	}
+	return searchItems(rows), page, nil
+}
+
+func searchItems(rows []repository.SearchAccountRow) []AccountListItem {
+	return lo.Map(rows, func(row repository.SearchAccountRow, _ int) AccountListItem {
 		grade := ""
 		if row.ReputationScore > 0 {
 			grade = reputation.ScoreToGrade(row.ReputationScore)
@@ -107,13 +155,4 @@ func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
 			ReputationGrade: grade,
 		}
 	})
-
-	h.writeJSON(w, http.StatusOK, PaginatedResponse{
-		Data: items,
-		Pagination: Pagination{
-			Limit:  limit,
-			Offset: offset,
-			Total:  totalCount,
-		},
-	})
 }
@@ -1,32 +1,68 @@
 package api
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/samber/lo"
 )
 
+// statsGrowthMonths is how many months of membership growth, including the
+// current one, are returned by the stats endpoint.
+const statsGrowthMonths = 12
+
 // Stats handles GET /api/v1/stats.
 //
 //	@Summary		Get aggregate statistics
-//	@Description	Returns aggregate statistics for accounts, persons, companies, and synthetic tokens
+//	@Description	Returns aggregate statistics for accounts, persons, companies, and synthetic tokens,
+//	@Description	plus monthly membership growth over the last year indexed from issuer operations
 //	@Tags			stats
 //	@Produce		json
 //	@Success		200	{object}	StatsResponse
 //	@Failure		500	{object}	ErrorResponse
 //	@Router			/api/v1/stats [get]
 func (h *Handler) Stats(w http.ResponseWriter, r *http.Request) {
-	stats, err := h.accounts.GetStats(r.Context())
+	resp, err := h.stats(r.Context())
 	if err != nil {
 		slog.Error("api: failed to fetch stats", "error", err)
 		h.writeError(w, http.StatusInternalServerError, "failed to fetch stats")
 		return
 	}
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// stats loads the aggregate statistics shared by the REST and gRPC APIs.
+func (h *Handler) stats(ctx context.Context) (*StatsResponse, error) {
+	stats, err := h.accounts.GetStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get stats: %w", err)
+	}
+
+	now := time.Now().UTC()
+	since := time.Date(now.Year(), now.Month()-statsGrowthMonths+1, 1, 0, 0, 0, 0, time.UTC)
+	growth, err := h.accounts.GetMembershipGrowth(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("get membership growth: %w", err)
+	}
 
-	h.writeJSON(w, http.StatusOK, StatsResponse{
+	return &StatsResponse{
 		TotalAccounts:  stats.TotalAccounts,
 		TotalPersons:   stats.TotalPersons,
 		TotalCompanies: stats.TotalCompanies,
 		TotalSynthetic: stats.TotalSynthetic,
 		TotalXLMValue:  stats.TotalXLMValue,
-	})
+		Growth: lo.Map(growth, func(g model.MembershipGrowth, _ int) MembershipGrowthResponse {
+			return MembershipGrowthResponse{
+				Month:     g.Month.Format("2006-01"),
+				AssetCode: g.AssetCode,
+				Joined:    g.Joined,
+				Left:      g.Left,
+				Net:       g.Joined - g.Left,
+			}
+		}),
+	}, nil
 }
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/mtlprog/lore/internal/service"
+)
+
+// maxSubmitBodyBytes limits the submission body; init transactions are far smaller.
+const maxSubmitBodyBytes = 64 << 10
+
+// SubmitTransaction handles POST /api/v1/init/submit.
+//
+//	@Summary		Submit a signed init transaction
+//	@Description	Validates a signed envelope produced by the init forms (ManageData operations on the source account only; when unsigned_xdr is given the envelope must be exactly that transaction), checks that Lore generated the transaction and it has not expired, checks that its sequence number and time bounds are still current, submits it to Horizon and starts refreshing the account in Lore. Outdated, expired or out-of-order transactions return 409. Rejections return Horizon result codes with a human-readable message.
+//	@Tags			init
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		SubmitRequest	true	"Signed transaction"
+//	@Success		200		{object}	SubmitResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		422		{object}	SubmitErrorResponse
+//	@Failure		502		{object}	ErrorResponse
+//	@Failure		503		{object}	ErrorResponse
+//	@Failure		504		{object}	ErrorResponse
+//	@Router			/api/v1/init/submit [post]
+func (h *Handler) SubmitTransaction(w http.ResponseWriter, r *http.Request) {
+	if h.submitter == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "transaction submission not available")
+		return
+	}
+
+	var req SubmitRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSubmitBodyBytes)).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.XDR = strings.TrimSpace(req.XDR)
+	if req.XDR == "" {
+		h.writeError(w, http.StatusBadRequest, "xdr is required")
+		return
+	}
+
+	result, err := h.submitter.Submit(r.Context(), req.XDR, strings.TrimSpace(req.UnsignedXDR))
+
+	var rejected *service.TxRejectedError
+	switch {
+	case err == nil:
+		h.writeJSON(w, http.StatusOK, SubmitResponse{
+			AccountID:  result.AccountID,
+			Hash:       result.Hash,
+			Ledger:     result.Ledger,
+			Refreshing: result.Refreshing,
+		})
+	case errors.Is(err, service.ErrInvalidEnvelope):
+		h.writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrStaleSequence), errors.Is(err, service.ErrSequenceGap), errors.Is(err, service.ErrTxExpired):
+		h.writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrSubmitTimeout):
+		h.writeError(w, http.StatusGatewayTimeout, "transaction submitted but not confirmed in time; it may still be applied")
+	case errors.As(err, &rejected):
+		h.writeJSON(w, http.StatusUnprocessableEntity, SubmitErrorResponse{
+			Error:       rejected.Message,
+			Code:        http.StatusUnprocessableEntity,
+			ResultCodes: rejected.Codes(),
+		})
+	default:
+		slog.Error("api: failed to submit transaction", "error", err)
+		h.writeError(w, http.StatusBadGateway, "failed to submit transaction")
+	}
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/service"
+)
+
+// maxDecodeBodyBytes limits the decode body; a transaction with 100 operations fits easily.
+const maxDecodeBodyBytes = 256 << 10
+
+// DecodeXDR handles POST /api/v1/xdr/decode.
+//
+//	@Summary		Decode a transaction envelope
+//	@Description	Decodes a TransactionEnvelope or FeeBumpTransactionEnvelope. Each operation gets a plain-language action; ManageData entries are read as BSN actions (relations, tags, delegation) and operations that change account control (signers, thresholds, master weight, flags, merges) carry a risk note. Involved accounts are resolved to names known to Lore.
+//	@Tags			xdr
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		DecodeRequest	true	"Transaction envelope"
+//	@Success		200		{object}	DecodeResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Router			/api/v1/xdr/decode [post]
+func (h *Handler) DecodeXDR(w http.ResponseWriter, r *http.Request) {
+	var req DecodeRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxDecodeBodyBytes)).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	tx, err := service.DecodeEnvelope(req.XDR, h.relations)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidXDR) {
+			h.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		slog.Error("api: failed to decode envelope", "error", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to decode envelope")
+		return
+	}
+
+	names := map[string]string{}
+	if ids := tx.AccountIDs(); len(ids) > 0 {
+		found, err := h.accounts.GetAccountNames(r.Context(), ids)
+		if err != nil {
+			slog.Error("api: failed to fetch account names", "lookup_count", len(ids), "error", err)
+		} else if found != nil {
+			names = found
+		}
+	}
+
+	h.writeJSON(w, http.StatusOK, decodeResponse(tx, names))
+}
+
+// decodeResponse converts a decoded transaction to its API representation.
+func decodeResponse(tx *model.DecodedTransaction, names map[string]string) DecodeResponse {
+	ops := make([]DecodedOperationResponse, 0, len(tx.Operations))
+	for _, op := range tx.Operations {
+		ops = append(ops, DecodedOperationResponse{
+			Type:          op.Type,
+			TypeDisplay:   op.TypeDisplay,
+			SourceAccount: op.SourceAccount,
+			Action:        op.Action,
+			TargetAccount: op.TargetAccount,
+			TargetValue:   op.TargetValue,
+			Risk:          op.Risk,
+			Amount:        op.Amount,
+			AssetCode:     op.AssetCode,
+			AssetIssuer:   op.AssetIssuer,
+			DataName:      op.DataName,
+			DataValue:     op.DataValue,
+		})
+	}
+
+	return DecodeResponse{
+		Hash:           tx.Hash,
+		InnerHash:      tx.InnerHash,
+		FeeBump:        tx.FeeBump,
+		FeeAccount:     tx.FeeAccount,
+		SourceAccount:  tx.SourceAccount,
+		Sequence:       tx.Sequence,
+		MaxFee:         tx.MaxFee,
+		MemoType:       tx.MemoType,
+		Memo:           tx.Memo,
+		ValidAfter:     tx.ValidAfter,
+		ValidBefore:    tx.ValidBefore,
+		SignatureCount: tx.SignatureCount,
+		RiskCount:      tx.RiskCount,
+		Operations:     ops,
+		AccountNames:   names,
+	}
+}
@@ -0,0 +1,228 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScopes(t *testing.T) {
+	scopes, err := ParseScopes(" read, export,read ")
+	require.NoError(t, err)
+	assert.Equal(t, []Scope{ScopeRead, ScopeExport}, scopes)
+
+	_, err = ParseScopes("read,admin")
+	assert.ErrorContains(t, err, `unknown scope "admin"`)
+
+	_, err = ParseScopes(" , ")
+	assert.Error(t, err)
+}
+
+func TestGenerateToken(t *testing.T) {
+	a, err := generateToken()
+	require.NoError(t, err)
+	b, err := generateToken()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(a, tokenPrefix))
+	assert.Len(t, a, len(tokenPrefix)+48)
+	assert.NotEqual(t, a, b)
+	assert.NotEqual(t, hashToken(a), hashToken(b))
+	assert.Equal(t, hashToken(a), hashToken(a))
+}
+
+func TestKeyContext(t *testing.T) {
+	assert.Nil(t, FromContext(context.Background()))
+
+	key := &Key{ID: 7}
+	assert.Same(t, key, FromContext(WithKey(context.Background(), key)))
+}
+
+// fakeStore serves keys by token and counts lookups and usage writes.
+type fakeStore struct {
+	keys        map[string]*Key
+	err         error
+	lookups     int
+	usage       map[int64]int64
+	usageErr    error
+	usageWrites int
+}
+
+func (f *fakeStore) FindByToken(_ context.Context, token string) (*Key, error) {
+	f.lookups++
+	if f.err != nil {
+		return nil, f.err
+	}
+	key, ok := f.keys[token]
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+	return key, nil
+}
+
+func (f *fakeStore) RecordUsage(_ context.Context, keyID int64, _ time.Time, requests int64) (int64, error) {
+	f.usageWrites++
+	if f.usageErr != nil {
+		return 0, f.usageErr
+	}
+	f.usage[keyID] += requests
+	return f.usage[keyID], nil
+}
+
+func TestAuthenticator(t *testing.T) {
+	const (
+		valid   = "lore_0123456789abcdef"
+		revoked = "lore_fedcba9876543210"
+		unknown = "lore_00000000000000000"
+	)
+	revokedAt := time.Now()
+	newAuth := func(t *testing.T) (*Authenticator, *fakeStore) {
+		store := &fakeStore{
+			keys: map[string]*Key{
+				valid:   {ID: 1, Scopes: []Scope{ScopeRead}},
+				revoked: {ID: 2, RevokedAt: &revokedAt},
+			},
+			usage: make(map[int64]int64),
+		}
+		auth, err := NewAuthenticator(store)
+		require.NoError(t, err)
+		t.Cleanup(auth.Close)
+		return auth, store
+	}
+
+	t.Run("nil store", func(t *testing.T) {
+		_, err := NewAuthenticator(nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("valid key is cached", func(t *testing.T) {
+		auth, store := newAuth(t)
+		for range 3 {
+			key, err := auth.Authenticate(context.Background(), valid)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), key.ID)
+		}
+		assert.Equal(t, 1, store.lookups)
+	})
+
+	t.Run("cache expires", func(t *testing.T) {
+		auth, store := newAuth(t)
+		now := time.Now()
+		auth.now = func() time.Time { return now }
+		_, err := auth.Authenticate(context.Background(), valid)
+		require.NoError(t, err)
+
+		now = now.Add(cacheTTL + time.Second)
+		_, err = auth.Authenticate(context.Background(), valid)
+		require.NoError(t, err)
+		assert.Equal(t, 2, store.lookups)
+	})
+
+	t.Run("unknown key miss is cached", func(t *testing.T) {
+		auth, store := newAuth(t)
+		for range 2 {
+			_, err := auth.Authenticate(context.Background(), unknown)
+			assert.ErrorIs(t, err, ErrInvalidKey)
+		}
+		assert.Equal(t, 1, store.lookups)
+	})
+
+	t.Run("malformed token skips the store", func(t *testing.T) {
+		auth, store := newAuth(t)
+		_, err := auth.Authenticate(context.Background(), "not-a-lore-key")
+		assert.ErrorIs(t, err, ErrInvalidKey)
+		assert.Zero(t, store.lookups)
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		auth, _ := newAuth(t)
+		_, err := auth.Authenticate(context.Background(), revoked)
+		assert.ErrorIs(t, err, ErrRevokedKey)
+	})
+
+	t.Run("store error is not cached", func(t *testing.T) {
+		auth, store := newAuth(t)
+		store.err = errors.New("database down")
+		_, err := auth.Authenticate(context.Background(), valid)
+		assert.ErrorContains(t, err, "database down")
+
+		store.err = nil
+		_, err = auth.Authenticate(context.Background(), valid)
+		assert.NoError(t, err)
+	})
+
+	t.Run("usage is batched", func(t *testing.T) {
+		auth, store := newAuth(t)
+		key := &Key{ID: 1}
+		for want := range int64(3) {
+			used, err := auth.RecordUsage(context.Background(), key)
+			require.NoError(t, err)
+			assert.Equal(t, want+1, used)
+		}
+		assert.Equal(t, 1, store.usageWrites, "only the first request is written")
+
+		auth.flush()
+		assert.Equal(t, int64(3), store.usage[1])
+		assert.Equal(t, 2, store.usageWrites)
+
+		// An idle counter is dropped without a write
+		auth.flush()
+		assert.Equal(t, 2, store.usageWrites)
+	})
+
+	t.Run("flush reads usage of other servers", func(t *testing.T) {
+		auth, store := newAuth(t)
+		key := &Key{ID: 1}
+		_, err := auth.RecordUsage(context.Background(), key)
+		require.NoError(t, err)
+		_, err = auth.RecordUsage(context.Background(), key)
+		require.NoError(t, err)
+
+		store.usage[1] += 10
+		auth.flush()
+
+		used, err := auth.RecordUsage(context.Background(), key)
+		require.NoError(t, err)
+		assert.Equal(t, int64(13), used)
+	})
+
+	t.Run("failed flush keeps counts", func(t *testing.T) {
+		auth, store := newAuth(t)
+		key := &Key{ID: 1}
+		for range 3 {
+			_, err := auth.RecordUsage(context.Background(), key)
+			require.NoError(t, err)
+		}
+
+		store.usageErr = errors.New("database down")
+		auth.flush()
+		store.usageErr = nil
+		auth.flush()
+		assert.Equal(t, int64(3), store.usage[1])
+	})
+
+	t.Run("close writes pending usage", func(t *testing.T) {
+		auth, store := newAuth(t)
+		key := &Key{ID: 1}
+		for range 2 {
+			_, err := auth.RecordUsage(context.Background(), key)
+			require.NoError(t, err)
+		}
+
+		auth.Close()
+		auth.Close()
+		assert.Equal(t, int64(2), store.usage[1])
+	})
+
+	t.Run("usage error", func(t *testing.T) {
+		auth, store := newAuth(t)
+		store.usageErr = errors.New("database down")
+		_, err := auth.RecordUsage(context.Background(), &Key{ID: 1})
+		assert.ErrorContains(t, err, "database down")
+	})
+}
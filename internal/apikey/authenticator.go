@@ -0,0 +1,229 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// cacheTTL bounds how long a revoked key keeps working on a running server.
+	cacheTTL = 1 * time.Minute
+	// maxCachedTokens caps the lookup cache; it is emptied when full so random
+	// tokens cannot grow it without bound.
+	maxCachedTokens = 10000
+	// usageFlushInterval is how often batched usage counts are written. Daily
+	// totals may lag by this much when several servers share the database.
+	usageFlushInterval = 10 * time.Second
+	// usageFlushTimeout bounds one flush of usage counts.
+	usageFlushTimeout = 10 * time.Second
+)
+
+// keyStore is the part of Repository the authenticator needs.
+type keyStore interface {
+	FindByToken(ctx context.Context, token string) (*Key, error)
+	RecordUsage(ctx context.Context, keyID int64, day time.Time, requests int64) (int64, error)
+}
+
+type cachedLookup struct {
+	key     *Key // nil for tokens that match no key
+	expires time.Time
+}
+
+// usageKey identifies the usage counter of a key on a UTC day.
+type usageKey struct {
+	keyID int64
+	day   string
+}
+
+// usageCount is a daily usage counter: the total last read from the store plus
+// requests counted since then.
+type usageCount struct {
+	day     time.Time
+	stored  int64
+	pending int64
+}
+
+// Authenticator resolves bearer tokens to keys and counts their usage. Lookups,
+// including misses, are cached briefly. Usage is counted in memory and written
+// in batches, so keyed requests usually cost no database round trip.
+//
+// IMPORTANT: Close() must be called when shutting down to stop the background
+// flush goroutine and write the remaining usage counts.
+type Authenticator struct {
+	store keyStore
+	now   func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedLookup
+
+	usageMu   sync.Mutex
+	usage     map[usageKey]*usageCount
+	flushMu   sync.Mutex    // Serializes flushes
+	flushDone chan struct{} // Shutdown signal for flush goroutine
+	closeOnce sync.Once     // Ensures Close() is called only once
+}
+
+// NewAuthenticator creates an authenticator backed by the given store.
+//
+// IMPORTANT: Close() must be called when shutting down.
+func NewAuthenticator(store keyStore) (*Authenticator, error) {
+	if store == nil {
+		return nil, errors.New("API key store is required")
+	}
+	a := &Authenticator{
+		store:     store,
+		now:       time.Now,
+		cache:     make(map[string]cachedLookup),
+		usage:     make(map[usageKey]*usageCount),
+		flushDone: make(chan struct{}),
+	}
+
+	// Start background flush goroutine
+	go a.flushLoop()
+
+	return a, nil
+}
+
+// Authenticate returns the active key for a token. It returns ErrInvalidKey
+// for unknown or malformed tokens and ErrRevokedKey for revoked keys.
+func (a *Authenticator) Authenticate(ctx context.Context, token string) (*Key, error) {
+	if !strings.HasPrefix(token, tokenPrefix) || len(token) <= displayLength {
+		return nil, ErrInvalidKey
+	}
+
+	hash := hashToken(token)
+	now := a.now()
+
+	a.mu.Lock()
+	cached, ok := a.cache[hash]
+	a.mu.Unlock()
+
+	if !ok || now.After(cached.expires) {
+		key, err := a.store.FindByToken(ctx, token)
+		if err != nil && !errors.Is(err, ErrInvalidKey) {
+			return nil, err
+		}
+		cached = cachedLookup{key: key, expires: now.Add(cacheTTL)}
+
+		a.mu.Lock()
+		if len(a.cache) >= maxCachedTokens {
+			clear(a.cache)
+		}
+		a.cache[hash] = cached
+		a.mu.Unlock()
+	}
+
+	switch {
+	case cached.key == nil:
+		return nil, ErrInvalidKey
+	case cached.key.Revoked():
+		return nil, ErrRevokedKey
+	}
+	return cached.key, nil
+}
+
+// RecordUsage counts a request for the key and returns its total for the
+// current UTC day. A key's first request of the day, or its first after being
+// idle for a flush, is written to the store to read the day's total; later ones
+// are counted in memory until the next flush.
+func (a *Authenticator) RecordUsage(ctx context.Context, key *Key) (int64, error) {
+	now := a.now()
+	uk := usageKey{keyID: key.ID, day: now.UTC().Format(time.DateOnly)}
+
+	a.usageMu.Lock()
+	if c, ok := a.usage[uk]; ok {
+		c.pending++
+		used := c.stored + c.pending
+		a.usageMu.Unlock()
+		return used, nil
+	}
+	a.usageMu.Unlock()
+
+	stored, err := a.store.RecordUsage(ctx, key.ID, now, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	a.usageMu.Lock()
+	defer a.usageMu.Unlock()
+	c, ok := a.usage[uk]
+	if !ok {
+		c = &usageCount{day: now}
+		a.usage[uk] = c
+	}
+	// A concurrent first request may have stored its count already; the larger
+	// total includes both
+	c.stored = max(c.stored, stored)
+	return c.stored + c.pending, nil
+}
+
+// flushLoop runs in the background and periodically writes usage counts.
+func (a *Authenticator) flushLoop() {
+	ticker := time.NewTicker(usageFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.flush()
+		case <-a.flushDone:
+			return
+		}
+	}
+}
+
+// flush writes pending usage counts and refreshes the stored totals, which
+// include requests counted by other servers. Counters with nothing to write
+// are dropped, so the next request of an idle key reads a fresh total. Counts
+// that fail to write are kept for the next flush.
+func (a *Authenticator) flush() {
+	a.flushMu.Lock()
+	defer a.flushMu.Unlock()
+
+	a.usageMu.Lock()
+	pending := make(map[usageKey]usageCount, len(a.usage))
+	for uk, c := range a.usage {
+		if c.pending == 0 {
+			delete(a.usage, uk)
+			continue
+		}
+		pending[uk] = *c
+		c.pending = 0
+	}
+	a.usageMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), usageFlushTimeout)
+	defer cancel()
+
+	for uk, p := range pending {
+		requests := p.pending
+		stored, err := a.store.RecordUsage(ctx, uk.keyID, p.day, requests)
+
+		a.usageMu.Lock()
+		c := a.usage[uk]
+		if err != nil {
+			c.pending += requests
+		} else {
+			c.stored = max(c.stored, stored)
+		}
+		a.usageMu.Unlock()
+
+		if err != nil {
+			slog.Error("failed to flush API key usage", "key_id", uk.keyID, "day", uk.day, "requests", requests, "error", err)
+		}
+	}
+}
+
+// Close stops the background flush goroutine and writes the remaining usage
+// counts. MUST be called when shutting down the server. Safe to call multiple
+// times.
+func (a *Authenticator) Close() {
+	a.closeOnce.Do(func() {
+		close(a.flushDone)
+		a.flush()
+	})
+}
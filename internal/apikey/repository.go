@@ -0,0 +1,172 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mtlprog/lore/internal/database"
+	"github.com/samber/lo"
+)
+
+// Repository reads and writes api_keys and api_key_usage.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new API key repository.
+func NewRepository(pool *pgxpool.Pool) (*Repository, error) {
+	if pool == nil {
+		return nil, errors.New("database pool is required")
+	}
+	return &Repository{pool: pool}, nil
+}
+
+// Create stores a new key and returns it with its token. The token is only
+// available here; afterwards the key can only be looked up by it.
+func (r *Repository) Create(ctx context.Context, name string, scopes []Scope, rateLimit, dailyQuota int) (*Key, string, error) {
+	if rateLimit < 0 || dailyQuota < 0 {
+		return nil, "", errors.New("rate limit and daily quota must not be negative")
+	}
+	token, err := generateToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	key := &Key{
+		Name:       name,
+		Prefix:     token[:displayLength],
+		Scopes:     scopes,
+		RateLimit:  rateLimit,
+		DailyQuota: dailyQuota,
+	}
+	query, args, err := database.QB.
+		Insert("api_keys").
+		Columns("name", "prefix", "key_hash", "scopes", "rate_limit", "daily_quota").
+		Values(name, key.Prefix, hashToken(token), scopeStrings(scopes), rateLimit, dailyQuota).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+	if err != nil {
+		return nil, "", fmt.Errorf("build create API key query: %w", err)
+	}
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&key.ID, &key.CreatedAt); err != nil {
+		return nil, "", fmt.Errorf("create API key: %w", err)
+	}
+	return key, token, nil
+}
+
+// Revoke marks a key as revoked. Revoking an already revoked key is a no-op.
+func (r *Repository) Revoke(ctx context.Context, id int64) error {
+	query, args, err := database.QB.
+		Update("api_keys").
+		Set("revoked_at", sq.Expr("COALESCE(revoked_at, NOW())")).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build revoke API key query: %w", err)
+	}
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("revoke API key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %d", ErrNotFound, id)
+	}
+	return nil
+}
+
+// List returns all keys, newest first, with today's usage.
+func (r *Repository) List(ctx context.Context) ([]Key, error) {
+	query, args, err := keyColumns("COALESCE(u.requests, 0)").
+		LeftJoin("api_key_usage u ON u.key_id = k.id AND u.day = (NOW() AT TIME ZONE 'UTC')::date").
+		OrderBy("k.id DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build list API keys query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []Key
+	for rows.Next() {
+		var k Key
+		var scopes []string
+		if err := rows.Scan(&k.ID, &k.Name, &k.Prefix, &scopes, &k.RateLimit, &k.DailyQuota, &k.CreatedAt, &k.RevokedAt, &k.UsedToday); err != nil {
+			return nil, fmt.Errorf("scan API key: %w", err)
+		}
+		k.Scopes = toScopes(scopes)
+		keys = append(keys, k)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// FindByToken returns the key a token belongs to, revoked or not.
+func (r *Repository) FindByToken(ctx context.Context, token string) (*Key, error) {
+	query, args, err := keyColumns().
+		Where(sq.Eq{"k.key_hash": hashToken(token)}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build find API key query: %w", err)
+	}
+
+	var k Key
+	var scopes []string
+	err = r.pool.QueryRow(ctx, query, args...).
+		Scan(&k.ID, &k.Name, &k.Prefix, &scopes, &k.RateLimit, &k.DailyQuota, &k.CreatedAt, &k.RevokedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrInvalidKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query API key: %w", err)
+	}
+	k.Scopes = toScopes(scopes)
+	return &k, nil
+}
+
+// RecordUsage adds requests to the count of a key on the given UTC day and
+// returns the day's total.
+func (r *Repository) RecordUsage(ctx context.Context, keyID int64, day time.Time, requests int64) (int64, error) {
+	query, args, err := database.QB.
+		Insert("api_key_usage").
+		Columns("key_id", "day", "requests").
+		Values(keyID, day.UTC().Format(time.DateOnly), requests).
+		Suffix("ON CONFLICT (key_id, day) DO UPDATE SET requests = api_key_usage.requests + EXCLUDED.requests RETURNING requests").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("build record usage query: %w", err)
+	}
+
+	var total int64
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("record API key usage: %w", err)
+	}
+	return total, nil
+}
+
+func keyColumns(extra ...string) sq.SelectBuilder {
+	return database.QB.
+		Select(append([]string{
+			"k.id", "k.name", "k.prefix", "k.scopes", "k.rate_limit", "k.daily_quota", "k.created_at", "k.revoked_at",
+		}, extra...)...).
+		From("api_keys k")
+}
+
+func scopeStrings(scopes []Scope) []string {
+	return lo.Map(scopes, func(s Scope, _ int) string { return string(s) })
+}
+
+func toScopes(scopes []string) []Scope {
+	return lo.Map(scopes, func(s string, _ int) Scope { return Scope(s) })
+}
@@ -5,72 +5,21 @@ import (
 	"sort"
 
 	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/relation"
 	"github.com/mtlprog/lore/internal/repository"
 	"github.com/samber/lo"
 )
 
-// CategoryDef defines a relationship category.
-type CategoryDef struct {
-	Name  string
-	Color string
-	Types []string
-}
-
-var categoryDefinitions = []CategoryDef{
-	{
-		Name:  "FAMILY",
-		Color: "#f85149",
-		Types: []string{"OneFamily", "Spouse", "Guardian", "Ward", "Sympathy", "Love", "Divorce"},
-	},
-	{
-		Name:  "WORK",
-		Color: "#58a6ff",
-		Types: []string{"Employer", "Employee", "Contractor", "Client"},
-	},
-	{
-		Name:  "NETWORK",
-		Color: "#a371f7",
-		Types: []string{"Partnership", "Collaboration", "MyPart", "PartOf", "RecommendToMTLA"},
-	},
-	{
-		Name:  "OWNERSHIP",
-		Color: "#f0b429",
-		Types: []string{"OwnershipFull", "OwnershipMajority", "OwnershipMinority", "Owner", "OwnerMajority", "OwnerMinority"},
-	},
-	{
-		Name:  "SOCIAL",
-		Color: "#00ff88",
-		Types: []string{"WelcomeGuest", "FactionMember"},
-	},
-}
-
-var complementaryPairs = map[string]string{
-	"MyPart":            "PartOf",
-	"PartOf":            "MyPart",
-	"Guardian":          "Ward",
-	"Ward":              "Guardian",
-	"OwnershipFull":     "Owner",
-	"Owner":             "OwnershipFull",
-	"OwnershipMajority": "OwnerMajority",
-	"OwnerMajority":     "OwnershipMajority",
-	"OwnershipMinority": "OwnerMinority",
-	"OwnerMinority":     "OwnershipMinority",
-	"Employer":          "Employee",
-	"Employee":          "Employer",
-}
-
-var symmetricTypes = map[string]bool{
-	"FactionMember": true,
-	"Partnership":   true,
-	"Collaboration": true,
-	"Spouse":        true,
-	"OneFamily":     true,
-}
-
-// GroupRelationships organizes relationships into display categories.
+// GroupRelationships organizes relationships into display categories of types.
 // It merges complementary pairs and deduplicates mutual symmetric relationships.
 // For symmetric types (FactionMember, Partnership, etc.), one-way relationships are hidden.
-func GroupRelationships(accountID string, rows []repository.RelationshipRow, confirmed map[string]bool) []model.RelationshipCategory {
+//
+// Complementary pairs follow paired_with in relation_type_settings, so
+// Contractor/Client is merged like Employer/Employee. The hard-coded table
+// this replaced left that pair out by mistake.
+func GroupRelationships(types *relation.Registry, accountID string, rows []repository.RelationshipRow, confirmed map[string]bool) []model.RelationshipCategory {
+	categoryDefinitions := types.Categories()
+
 	// Build maps for relationship lookup
 	outgoingMap := make(map[string]repository.RelationshipRow)
 	incomingMap := make(map[string]repository.RelationshipRow)
@@ -114,7 +63,7 @@ func GroupRelationships(accountID string, rows []repository.RelationshipRow, con
 		}
 
 		// Check if this is a complementary pair
-		if complement, ok := complementaryPairs[row.RelationType]; ok {
+		if complement, ok := types.ComplementOf(row.RelationType); ok {
 			typeA, typeB := row.RelationType, complement
 			if typeB < typeA {
 				typeA, typeB = typeB, typeA
@@ -152,6 +101,7 @@ func GroupRelationships(accountID string, rows []repository.RelationshipRow, con
 
 			rel := model.Relationship{
 				Type:        row.RelationType,
+				TypeName:    types.Label(row.RelationType),
 				TargetID:    otherID,
 				TargetName:  row.TargetName,
 				Direction:   row.Direction,
@@ -174,7 +124,7 @@ func GroupRelationships(accountID string, rows []repository.RelationshipRow, con
 		_, hasIncoming := incomingMap[mutualKey]
 		isMutual := hasOutgoing && hasIncoming
 
-		if symmetricTypes[row.RelationType] && !isMutual {
+		if types.IsSymmetric(row.RelationType) && !isMutual {
 			continue
 		}
 
@@ -197,6 +147,7 @@ func GroupRelationships(accountID string, rows []repository.RelationshipRow, con
 
 		rel := model.Relationship{
 			Type:        row.RelationType,
+			TypeName:    types.Label(row.RelationType),
 			TargetID:    otherID,
 			TargetName:  row.TargetName,
 			Direction:   row.Direction,
@@ -220,7 +171,7 @@ func GroupRelationships(accountID string, rows []repository.RelationshipRow, con
 	}
 
 	// Build final categories
-	categories := lo.Map(categoryDefinitions, func(def CategoryDef, i int) model.RelationshipCategory {
+	categories := lo.Map(categoryDefinitions, func(def relation.Category, i int) model.RelationshipCategory {
 		return model.RelationshipCategory{
 			Name:          def.Name,
 			Color:         def.Color,
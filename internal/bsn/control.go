@@ -0,0 +1,103 @@
+package bsn
+
+import (
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/repository"
+)
+
+// controlEdge converts a control row and classifies it against declared ownership.
+func controlEdge(row repository.ControlRow) model.ControlEdge {
+	edge := model.ControlEdge{
+		SignerID:    row.SignerID,
+		SignerName:  row.SignerName,
+		AccountID:   row.AccountID,
+		AccountName: row.AccountName,
+		Weight:      row.Weight,
+		Ownership:   row.Ownership,
+		Sole:        row.Weight > 0 && row.Weight >= int32(max(row.MedThreshold, 1)),
+	}
+	switch {
+	case row.Weight == 0:
+		edge.Status = model.ControlNotSigner
+	case row.Ownership == "":
+		edge.Status = model.ControlUndeclared
+	default:
+		edge.Status = model.ControlDeclared
+	}
+	return edge
+}
+
+// BuildAccountControl combines the live signers of an account with the synced
+// control rows it is part of. Live signers take precedence over synced ones so
+// the page reflects signer changes made since the last sync.
+func BuildAccountControl(account *model.AccountDetail, rows []repository.ControlRow) *model.AccountControl {
+	control := &model.AccountControl{Thresholds: account.Thresholds}
+
+	declared := make(map[string]repository.ControlRow)
+	for _, row := range rows {
+		switch {
+		case row.AccountID == account.ID && row.Ownership != "":
+			declared[row.SignerID] = row
+		case row.SignerID == account.ID:
+			control.Controls = append(control.Controls, controlEdge(row))
+		}
+	}
+
+	threshold := int32(max(account.Thresholds.Med, 1))
+	for _, signer := range account.Signers {
+		edge := model.ControlEdge{
+			SignerID:    signer.Key,
+			SignerName:  signer.Name,
+			AccountID:   account.ID,
+			AccountName: account.Name,
+			Weight:      signer.Weight,
+			Sole:        signer.Weight >= threshold,
+			Status:      model.ControlUndeclared,
+		}
+		if row, ok := declared[signer.Key]; ok {
+			edge.Ownership = row.Ownership
+			edge.Status = model.ControlDeclared
+			if edge.SignerName == "" {
+				edge.SignerName = row.SignerName
+			}
+			delete(declared, signer.Key)
+		}
+		if signer.Key == account.ID {
+			edge.Status = model.ControlSelf
+		}
+		control.Signers = append(control.Signers, edge)
+	}
+
+	// Declared owners left over are not signers of the account
+	for _, row := range rows {
+		if _, ok := declared[row.SignerID]; ok && row.AccountID == account.ID {
+			row.Weight = 0
+			control.Signers = append(control.Signers, controlEdge(row))
+		}
+	}
+
+	return control
+}
+
+// BuildControlGraph groups control rows by the controlled account. Rows must be
+// ordered by account, as returned by the repository.
+func BuildControlGraph(rows []repository.ControlRow, issuer string) []model.ControlledAccount {
+	var accounts []model.ControlledAccount
+	for _, row := range rows {
+		if len(accounts) == 0 || accounts[len(accounts)-1].AccountID != row.AccountID {
+			accounts = append(accounts, model.ControlledAccount{
+				AccountID:    row.AccountID,
+				AccountName:  row.AccountName,
+				IsIssuer:     row.AccountID == issuer,
+				MedThreshold: row.MedThreshold,
+			})
+		}
+		current := &accounts[len(accounts)-1]
+		edge := controlEdge(row)
+		current.Edges = append(current.Edges, edge)
+		if edge.Mismatch() {
+			current.Mismatches++
+		}
+	}
+	return accounts
+}
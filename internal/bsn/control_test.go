@@ -0,0 +1,74 @@
+package bsn
+
+import (
+	"testing"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildAccountControl(t *testing.T) {
+	account := &model.AccountDetail{
+		ID:   "GCOMPANY",
+		Name: "Acme",
+		Signers: []model.Signer{
+			{Key: "GCOMPANY", Weight: 1},
+			{Key: "GALICE", Weight: 2, Name: "Alice"},
+			{Key: "GBOB", Weight: 1},
+		},
+		Thresholds: model.Thresholds{Low: 1, Med: 2, High: 2},
+	}
+	rows := []repository.ControlRow{
+		{SignerID: "GALICE", SignerName: "Alice", AccountID: "GCOMPANY", AccountName: "Acme", Weight: 2, MedThreshold: 2, Ownership: "OwnershipFull"},
+		{SignerID: "GBOB", SignerName: "Bob", AccountID: "GCOMPANY", AccountName: "Acme", Weight: 1, MedThreshold: 2},
+		{SignerID: "GCAROL", SignerName: "Carol", AccountID: "GCOMPANY", AccountName: "Acme", MedThreshold: 2, Ownership: "OwnershipMinority"},
+		{SignerID: "GCOMPANY", AccountID: "GSUBSIDIARY", AccountName: "Acme Labs", Weight: 1, MedThreshold: 1, Ownership: "Owner"},
+	}
+
+	control := BuildAccountControl(account, rows)
+
+	assert.Equal(t, account.Thresholds, control.Thresholds)
+	require.Len(t, control.Signers, 4)
+
+	statuses := make(map[string]model.ControlStatus)
+	for _, e := range control.Signers {
+		statuses[e.SignerID] = e.Status
+	}
+	assert.Equal(t, map[string]model.ControlStatus{
+		"GCOMPANY": model.ControlSelf,
+		"GALICE":   model.ControlDeclared,
+		"GBOB":     model.ControlUndeclared,
+		"GCAROL":   model.ControlNotSigner,
+	}, statuses)
+
+	assert.True(t, control.Signers[1].Sole, "Alice reaches the medium threshold alone")
+	assert.False(t, control.Signers[2].Sole)
+	assert.Equal(t, "OwnershipMinority", control.Signers[3].Ownership)
+
+	require.Len(t, control.Controls, 1)
+	assert.Equal(t, "GSUBSIDIARY", control.Controls[0].AccountID)
+	assert.Equal(t, model.ControlDeclared, control.Controls[0].Status)
+	assert.Equal(t, 2, control.Mismatches())
+}
+
+func TestBuildControlGraph(t *testing.T) {
+	rows := []repository.ControlRow{
+		{SignerID: "GALICE", AccountID: "GACME", AccountName: "Acme", Weight: 1, MedThreshold: 1, Ownership: "Owner"},
+		{SignerID: "GBOB", AccountID: "GACME", AccountName: "Acme", Weight: 1, MedThreshold: 1},
+		{SignerID: "GALICE", AccountID: "GISSUER", AccountName: "MTLA", Weight: 1, MedThreshold: 3},
+	}
+
+	accounts := BuildControlGraph(rows, "GISSUER")
+
+	require.Len(t, accounts, 2)
+	assert.Equal(t, "GACME", accounts[0].AccountID)
+	assert.Len(t, accounts[0].Edges, 2)
+	assert.Equal(t, 1, accounts[0].Mismatches)
+	assert.False(t, accounts[0].IsIssuer)
+
+	assert.True(t, accounts[1].IsIssuer)
+	assert.Equal(t, uint8(3), accounts[1].MedThreshold)
+	assert.False(t, accounts[1].Edges[0].Sole)
+}
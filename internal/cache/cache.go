@@ -0,0 +1,152 @@
+// Package cache provides the in-process cache for data that only changes when
+// a sync run is published: a size-bounded LRU with coalesced loads, emptied
+// when PostgreSQL notifies that a new run completed.
+package cache
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Cache is a least-recently-used cache bounded by the approximate size of its
+// values. It is safe for concurrent use.
+type Cache struct {
+	maxBytes int64
+	group    singleflight.Group
+
+	mu         sync.Mutex
+	bytes      int64
+	ll         *list.List // Front is most recently used
+	items      map[string]*list.Element
+	generation uint64 // Incremented by Invalidate
+	hits       uint64
+	misses     uint64
+}
+
+type entry struct {
+	key   string
+	value any
+	size  int64
+}
+
+// Stats reports cache usage.
+type Stats struct {
+	Entries int
+	Bytes   int64
+	Hits    uint64
+	Misses  uint64
+}
+
+// New creates a cache holding at most maxBytes of values.
+func New(maxBytes int64) (*Cache, error) {
+	if maxBytes <= 0 {
+		return nil, errors.New("cache size must be positive")
+	}
+	return &Cache{
+		maxBytes: maxBytes,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}, nil
+}
+
+// Get returns the value stored for key.
+func (c *Cache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.ll.MoveToFront(el)
+	return el.Value.(*entry).value, true
+}
+
+// Generation returns the current generation. Pass it to Add to drop values
+// loaded before an invalidation.
+func (c *Cache) Generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// Add stores a value of the given size if the cache has not been invalidated
+// since generation was read. Values larger than the whole cache are not stored.
+func (c *Cache) Add(key string, value any, size int64, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation || size > c.maxBytes {
+		return
+	}
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+	}
+	c.items[key] = c.ll.PushFront(&entry{key: key, value: value, size: size})
+	c.bytes += size
+
+	for c.bytes > c.maxBytes {
+		c.removeElement(c.ll.Back())
+	}
+}
+
+// Do runs fn once for concurrent callers with the same key and hands all of
+// them its result.
+func (c *Cache) Do(key string, fn func() (any, error)) (any, error) {
+	v, err, _ := c.group.Do(key, fn)
+	return v, err
+}
+
+// Invalidate removes all values. Loads that started before the call do not
+// store their results.
+func (c *Cache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.ll.Init()
+	clear(c.items)
+	c.bytes = 0
+}
+
+// Stats returns current usage.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{Entries: len(c.items), Bytes: c.bytes, Hits: c.hits, Misses: c.misses}
+}
+
+func (c *Cache) removeElement(el *list.Element) {
+	e := c.ll.Remove(el).(*entry)
+	delete(c.items, e.key)
+	c.bytes -= e.size
+}
+
+// Load returns the cached value for key, or calls load once for all
+// concurrent callers and caches its result. size estimates the memory held by
+// a value. Errors are returned to every waiting caller and are not cached.
+func Load[T any](c *Cache, key string, size func(T) int64, load func() (T, error)) (T, error) {
+	if v, ok := c.Get(key); ok {
+		return v.(T), nil
+	}
+
+	v, err := c.Do(key, func() (any, error) {
+		generation := c.Generation()
+		value, err := load()
+		if err != nil {
+			return nil, err
+		}
+		c.Add(key, value, size(value), generation)
+		return value, nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v.(T), nil
+}
@@ -0,0 +1,134 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCache(t *testing.T, maxBytes int64) *Cache {
+	t.Helper()
+	c, err := New(maxBytes)
+	require.NoError(t, err)
+	return c
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(0)
+	assert.Error(t, err)
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := newTestCache(t, 30)
+	gen := c.Generation()
+	c.Add("a", 1, 10, gen)
+	c.Add("b", 2, 10, gen)
+	c.Add("c", 3, 10, gen)
+
+	_, ok := c.Get("a") // a is now more recent than b
+	require.True(t, ok)
+
+	c.Add("d", 4, 10, gen)
+	_, ok = c.Get("b")
+	assert.False(t, ok, "b should be evicted")
+	for _, key := range []string{"a", "c", "d"} {
+		_, ok := c.Get(key)
+		assert.True(t, ok, key)
+	}
+	assert.Equal(t, int64(30), c.Stats().Bytes)
+}
+
+func TestCache_Add(t *testing.T) {
+	c := newTestCache(t, 30)
+
+	t.Run("replaces existing key", func(t *testing.T) {
+		c.Add("a", 1, 10, c.Generation())
+		c.Add("a", 2, 20, c.Generation())
+		v, _ := c.Get("a")
+		assert.Equal(t, 2, v)
+		assert.Equal(t, Stats{Entries: 1, Bytes: 20, Hits: 1}, c.Stats())
+	})
+
+	t.Run("skips values larger than the cache", func(t *testing.T) {
+		c.Add("big", 1, 31, c.Generation())
+		_, ok := c.Get("big")
+		assert.False(t, ok)
+	})
+
+	t.Run("skips values loaded before invalidation", func(t *testing.T) {
+		gen := c.Generation()
+		c.Invalidate()
+		c.Add("stale", 1, 1, gen)
+		_, ok := c.Get("stale")
+		assert.False(t, ok)
+		assert.Zero(t, c.Stats().Entries)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	size := func(int) int64 { return 1 }
+
+	t.Run("caches values", func(t *testing.T) {
+		c := newTestCache(t, 100)
+		calls := 0
+		for range 3 {
+			v, err := Load(c, "k", size, func() (int, error) {
+				calls++
+				return 42, nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 42, v)
+		}
+		assert.Equal(t, 1, calls)
+
+		c.Invalidate()
+		_, err := Load(c, "k", size, func() (int, error) {
+			calls++
+			return 43, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("does not cache errors", func(t *testing.T) {
+		c := newTestCache(t, 100)
+		_, err := Load(c, "k", size, func() (int, error) { return 0, errors.New("boom") })
+		assert.ErrorContains(t, err, "boom")
+
+		v, err := Load(c, "k", size, func() (int, error) { return 7, nil })
+		require.NoError(t, err)
+		assert.Equal(t, 7, v)
+	})
+
+	t.Run("coalesces concurrent misses", func(t *testing.T) {
+		c := newTestCache(t, 100)
+		var calls atomic.Int32
+		release := make(chan struct{})
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				v, err := Load(c, "k", size, func() (int, error) {
+					calls.Add(1)
+					<-release
+					return 1, nil
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, 1, v)
+			}()
+		}
+		// Give the goroutines time to join the in-flight load
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
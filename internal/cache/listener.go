@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// reconnectDelay is how long Listen waits before reconnecting after an error.
+const reconnectDelay = 5 * time.Second
+
+// Listen holds a connection from pool that listens on channel and calls
+// onNotify for every notification. After a lost connection it reconnects and
+// calls onNotify once, since notifications sent in between are gone.
+// Listen blocks until ctx is cancelled.
+func Listen(ctx context.Context, pool *pgxpool.Pool, channel string, onNotify func()) error {
+	if pool == nil {
+		return errors.New("database pool is required")
+	}
+
+	for reconnect := false; ; reconnect = true {
+		if reconnect {
+			onNotify()
+		}
+		err := listen(ctx, pool, channel, onNotify)
+		if ctx.Err() != nil {
+			return nil
+		}
+		slog.Warn("notification listener disconnected", "channel", channel, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func listen(ctx context.Context, pool *pgxpool.Pool, channel string, onNotify func()) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	// The connection carries a LISTEN registration, so it is not returned to the pool
+	listener := conn.Hijack()
+	defer listener.Close(context.Background())
+
+	if _, err := listener.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("listen on %s: %w", channel, err)
+	}
+
+	for {
+		if _, err := listener.WaitForNotification(ctx); err != nil {
+			return fmt.Errorf("wait for notification: %w", err)
+		}
+		onNotify()
+	}
+}
@@ -27,4 +27,13 @@ const (
 
 	// DefaultRateLimit is the default requests per minute per IP address.
 	DefaultRateLimit = 100
+
+	// DefaultCouncilSize is the number of council seats whose members sign for the issuer.
+	DefaultCouncilSize = 20
+
+	// DefaultCacheSize is the default size of the in-process response cache in megabytes.
+	DefaultCacheSize = 64
+
+	// DefaultEventsMaxConnections bounds the open /api/v1/events streams per server.
+	DefaultEventsMaxConnections = 500
 )
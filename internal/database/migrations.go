@@ -3,9 +3,11 @@ package database
 import (
 	"context"
 	"embed"
+	"errors"
 	"fmt"
 	"log/slog"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
@@ -16,24 +18,75 @@ var embedMigrations embed.FS
 
 // RunMigrations applies all pending database migrations.
 func RunMigrations(ctx context.Context, pool *pgxpool.Pool) error {
-	db := stdlib.OpenDBFromPool(pool)
+	return MigrateTo(ctx, pool, goose.MaxVersion)
+}
 
-	goose.SetBaseFS(embedMigrations)
+// MigrateTo applies pending migrations up to and including version.
+func MigrateTo(ctx context.Context, pool *pgxpool.Pool, version int64) error {
+	db := stdlib.OpenDBFromPool(pool)
 
-	if err := goose.SetDialect("postgres"); err != nil {
-		return fmt.Errorf("set goose dialect: %w", err)
+	if err := setupGoose(); err != nil {
+		return err
 	}
 
-	if err := goose.UpContext(ctx, db, "migrations"); err != nil {
+	if err := goose.UpToContext(ctx, db, "migrations", version); err != nil {
 		return fmt.Errorf("run migrations: %w", err)
 	}
 
-	version, err := goose.GetDBVersion(db)
+	current, err := goose.GetDBVersion(db)
 	if err != nil {
 		return fmt.Errorf("get migration version: %w", err)
 	}
 
-	slog.Info("migrations completed", "version", version)
+	slog.Info("migrations completed", "version", current)
+
+	return nil
+}
+
+// SchemaVersion returns the last applied migration, or 0 if the database has
+// never been migrated. Unlike goose it does not create the version table.
+func SchemaVersion(ctx context.Context, pool *pgxpool.Pool) (int64, error) {
+	var exists bool
+	if err := pool.QueryRow(ctx, "SELECT to_regclass('goose_db_version') IS NOT NULL").Scan(&exists); err != nil {
+		return 0, fmt.Errorf("check migration table: %w", err)
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	var version int64
+	err := pool.QueryRow(ctx, "SELECT version_id FROM goose_db_version ORDER BY id DESC LIMIT 1").Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("query migration version: %w", err)
+	}
+	return version, nil
+}
+
+// LatestSchemaVersion returns the version of the newest embedded migration.
+func LatestSchemaVersion() (int64, error) {
+	if err := setupGoose(); err != nil {
+		return 0, err
+	}
 
+	migrations, err := goose.CollectMigrations("migrations", 0, goose.MaxVersion)
+	if err != nil {
+		return 0, fmt.Errorf("collect migrations: %w", err)
+	}
+	last, err := migrations.Last()
+	if err != nil {
+		return 0, fmt.Errorf("find latest migration: %w", err)
+	}
+	return last.Version, nil
+}
+
+func setupGoose() error {
+	goose.SetBaseFS(embedMigrations)
+
+	if err := goose.SetDialect("postgres"); err != nil {
+		return fmt.Errorf("set goose dialect: %w", err)
+	}
 	return nil
 }
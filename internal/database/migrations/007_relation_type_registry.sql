@@ -0,0 +1,62 @@
+-- +goose Up
+
+-- relation_type_settings becomes the single source of truth for BSN relation types:
+-- sync parsing, category grouping and confirmation all read it at startup.
+ALTER TABLE relation_type_settings
+    ADD COLUMN category TEXT,
+    ADD COLUMN color TEXT,
+    ADD COLUMN is_symmetric BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN display_name TEXT,
+    ADD COLUMN sort_order INTEGER NOT NULL DEFAULT 0;
+
+UPDATE relation_type_settings AS s SET
+    category = v.category,
+    color = v.color,
+    is_symmetric = v.is_symmetric,
+    display_name = v.display_name,
+    sort_order = v.sort_order
+FROM (VALUES
+    -- Ratings are not grouped into a display category
+    ('A', NULL, NULL, FALSE, 'Rating A', 1),
+    ('B', NULL, NULL, FALSE, 'Rating B', 2),
+    ('C', NULL, NULL, FALSE, 'Rating C', 3),
+    ('D', NULL, NULL, FALSE, 'Rating D', 4),
+
+    ('OneFamily', 'FAMILY', '#f85149', TRUE, 'One Family', 10),
+    ('Spouse', 'FAMILY', '#f85149', TRUE, 'Spouse', 11),
+    ('Guardian', 'FAMILY', '#f85149', FALSE, 'Guardian', 12),
+    ('Ward', 'FAMILY', '#f85149', FALSE, 'Ward', 13),
+    ('Sympathy', 'FAMILY', '#f85149', FALSE, 'Sympathy', 14),
+    ('Love', 'FAMILY', '#f85149', FALSE, 'Love', 15),
+    ('Divorce', 'FAMILY', '#f85149', FALSE, 'Divorce', 16),
+
+    ('Employer', 'WORK', '#58a6ff', FALSE, 'Employer', 20),
+    ('Employee', 'WORK', '#58a6ff', FALSE, 'Employee', 21),
+    ('Contractor', 'WORK', '#58a6ff', FALSE, 'Contractor', 22),
+    ('Client', 'WORK', '#58a6ff', FALSE, 'Client', 23),
+
+    ('Partnership', 'NETWORK', '#a371f7', TRUE, 'Partnership', 30),
+    ('Collaboration', 'NETWORK', '#a371f7', TRUE, 'Collaboration', 31),
+    ('MyPart', 'NETWORK', '#a371f7', FALSE, 'My Part', 32),
+    ('PartOf', 'NETWORK', '#a371f7', FALSE, 'Part Of', 33),
+    ('RecommendToMTLA', 'NETWORK', '#a371f7', FALSE, 'Recommend to MTLA', 34),
+
+    ('OwnershipFull', 'OWNERSHIP', '#f0b429', FALSE, 'Ownership Full', 40),
+    ('OwnershipMajority', 'OWNERSHIP', '#f0b429', FALSE, 'Ownership Majority', 41),
+    ('OwnershipMinority', 'OWNERSHIP', '#f0b429', FALSE, 'Ownership Minority', 42),
+    ('Owner', 'OWNERSHIP', '#f0b429', FALSE, 'Owner', 43),
+    ('OwnerMajority', 'OWNERSHIP', '#f0b429', FALSE, 'Owner Majority', 44),
+    ('OwnerMinority', 'OWNERSHIP', '#f0b429', FALSE, 'Owner Minority', 45),
+
+    ('WelcomeGuest', 'SOCIAL', '#00ff88', FALSE, 'Welcome Guest', 50),
+    ('FactionMember', 'SOCIAL', '#00ff88', TRUE, 'Faction Member', 51)
+) AS v(relation_type, category, color, is_symmetric, display_name, sort_order)
+WHERE s.relation_type = v.relation_type;
+
+-- +goose Down
+ALTER TABLE relation_type_settings
+    DROP COLUMN IF EXISTS sort_order,
+    DROP COLUMN IF EXISTS display_name,
+    DROP COLUMN IF EXISTS is_symmetric,
+    DROP COLUMN IF EXISTS color,
+    DROP COLUMN IF EXISTS category;
@@ -0,0 +1,17 @@
+-- +goose Up
+
+-- Multisig init transactions: partially signed envelopes are merged here until the
+-- source account's medium threshold is reached. The random id is the share link.
+CREATE TABLE signature_collections (
+    id TEXT PRIMARY KEY,
+    account_id TEXT NOT NULL,
+    tx_hash TEXT NOT NULL,
+    envelope TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_signature_collections_created ON signature_collections(created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS signature_collections;
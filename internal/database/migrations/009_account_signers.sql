@@ -0,0 +1,26 @@
+-- +goose Up
+
+-- Thresholds and signers of synced accounts. Together with the declared Owner*/Ownership*
+-- relations they show who actually controls a company account.
+ALTER TABLE accounts
+    ADD COLUMN low_threshold SMALLINT NOT NULL DEFAULT 0,
+    ADD COLUMN med_threshold SMALLINT NOT NULL DEFAULT 0,
+    ADD COLUMN high_threshold SMALLINT NOT NULL DEFAULT 0;
+
+CREATE TABLE account_signers (
+    account_id TEXT NOT NULL,
+    signer_key TEXT NOT NULL,
+    signer_type TEXT NOT NULL,
+    weight INTEGER NOT NULL,
+
+    PRIMARY KEY (account_id, signer_key)
+);
+
+CREATE INDEX idx_account_signers_key ON account_signers(signer_key);
+
+-- +goose Down
+DROP TABLE IF EXISTS account_signers;
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS low_threshold,
+    DROP COLUMN IF EXISTS med_threshold,
+    DROP COLUMN IF EXISTS high_threshold;
@@ -0,0 +1,21 @@
+-- +goose Up
+
+-- Membership history indexed from the issuer's payment, clawback and set_trust_line_flags
+-- operations. Rows are immutable, so they survive full syncs and are only appended to.
+CREATE TABLE membership_events (
+    operation_id BIGINT PRIMARY KEY,
+    account_id TEXT NOT NULL,
+    asset_code TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    amount NUMERIC(20, 7) NOT NULL DEFAULT 0,
+    balance_before NUMERIC(20, 7) NOT NULL DEFAULT 0,
+    balance_after NUMERIC(20, 7) NOT NULL DEFAULT 0,
+    tx_hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_membership_events_account ON membership_events(account_id, operation_id);
+CREATE INDEX idx_membership_events_created ON membership_events(created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS membership_events;
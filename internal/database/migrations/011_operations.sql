@@ -0,0 +1,45 @@
+-- +goose Up
+
+-- Local index of the operations of tracked accounts, so account history can be
+-- filtered and served without Horizon. An operation between two tracked accounts
+-- is stored once for each of them. Like membership_events, rows are immutable
+-- and survive full syncs.
+CREATE TABLE operations (
+    account_id TEXT NOT NULL,
+    operation_id BIGINT NOT NULL,
+    op_type TEXT NOT NULL,
+    type_display TEXT NOT NULL,
+    type_category TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    tx_hash TEXT NOT NULL,
+    source_account TEXT NOT NULL,
+    counterparty TEXT NOT NULL DEFAULT '',
+    is_spam BOOLEAN NOT NULL DEFAULT FALSE,
+    amount TEXT NOT NULL DEFAULT '',
+    asset_code TEXT NOT NULL DEFAULT '',
+    asset_issuer TEXT NOT NULL DEFAULT '',
+    from_account TEXT NOT NULL DEFAULT '',
+    to_account TEXT NOT NULL DEFAULT '',
+    data_name TEXT NOT NULL DEFAULT '',
+    data_value TEXT NOT NULL DEFAULT '',
+    starting_balance TEXT NOT NULL DEFAULT '',
+    trust_limit TEXT NOT NULL DEFAULT '',
+    source_amount TEXT NOT NULL DEFAULT '',
+    source_asset TEXT NOT NULL DEFAULT '',
+    dest_amount TEXT NOT NULL DEFAULT '',
+    dest_asset TEXT NOT NULL DEFAULT '',
+    selling TEXT NOT NULL DEFAULT '',
+    buying TEXT NOT NULL DEFAULT '',
+    price TEXT NOT NULL DEFAULT '',
+    offer_id TEXT NOT NULL DEFAULT '',
+
+    PRIMARY KEY (account_id, operation_id)
+);
+
+CREATE INDEX idx_operations_account_created ON operations(account_id, created_at);
+CREATE INDEX idx_operations_account_type ON operations(account_id, op_type);
+CREATE INDEX idx_operations_account_counterparty ON operations(account_id, counterparty);
+CREATE INDEX idx_operations_account_asset ON operations(account_id, asset_code);
+
+-- +goose Down
+DROP TABLE IF EXISTS operations;
@@ -0,0 +1,27 @@
+-- +goose Up
+
+-- Optional API keys. Only a SHA-256 hash of each token is stored; prefix keeps
+-- the first characters so keys can be told apart in listings.
+CREATE TABLE api_keys (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL DEFAULT '{read}',
+    rate_limit INTEGER NOT NULL DEFAULT 0,  -- requests per minute, 0 = server default
+    daily_quota INTEGER NOT NULL DEFAULT 0, -- requests per UTC day, 0 = unlimited
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMPTZ
+);
+
+-- Requests per key and UTC day, used for daily quotas and usage reports.
+CREATE TABLE api_key_usage (
+    key_id BIGINT NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    requests BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (key_id, day)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS api_key_usage;
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,13 @@
+-- +goose Up
+
+-- Completed sync runs. A row is written once a run has finished all required
+-- steps, so the latest row identifies the data currently being served.
+CREATE TABLE sync_runs (
+    id BIGSERIAL PRIMARY KEY,
+    full_sync BOOLEAN NOT NULL DEFAULT FALSE,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS sync_runs;
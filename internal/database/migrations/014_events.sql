@@ -0,0 +1,18 @@
+-- +goose Up
+
+-- Changes detected by sync runs, streamed to clients of /api/v1/events. The id
+-- is the SSE event ID clients resume from; rows older than the retention period
+-- are pruned by sync.
+CREATE TABLE events (
+    id BIGSERIAL PRIMARY KEY,
+    type TEXT NOT NULL,
+    account_id TEXT NOT NULL,
+    related_account_id TEXT,
+    data JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_events_created_at ON events(created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS events;
@@ -0,0 +1,16 @@
+-- +goose Up
+
+-- Hashes of the init transactions Lore generated. Submissions are only relayed
+-- to Horizon if their hash is listed here, so /init/submit cannot be used to
+-- push arbitrary transactions. Expired rows are deleted when new ones are added.
+CREATE TABLE generated_transactions (
+    hash TEXT PRIMARY KEY,
+    account_id TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_generated_transactions_expires ON generated_transactions(expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS generated_transactions;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- Single-account resyncs, e.g. after a transaction was submitted through Lore,
+-- are recorded as runs too, so cache validators change with the data. They
+-- carry the refreshed account; regular runs leave it NULL.
+ALTER TABLE sync_runs ADD COLUMN account_id TEXT;
+
+-- +goose Down
+ALTER TABLE sync_runs DROP COLUMN IF EXISTS account_id;
@@ -0,0 +1,14 @@
+-- +goose Up
+
+-- Path payments keep the issuers of the sent and delivered assets next to their
+-- codes, so flows can tell an asset from a look-alike with the same code.
+-- Operations indexed before this migration cannot be backfilled and keep empty
+-- issuers; flows leave out their issued assets.
+ALTER TABLE operations
+    ADD COLUMN source_asset_issuer TEXT NOT NULL DEFAULT '',
+    ADD COLUMN dest_asset_issuer TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE operations
+    DROP COLUMN IF EXISTS source_asset_issuer,
+    DROP COLUMN IF EXISTS dest_asset_issuer;
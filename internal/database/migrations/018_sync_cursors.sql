@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- Paging tokens that incremental Horizon indexers resume from. Unlike the newest
+-- stored row, a cursor also moves past operations that produced nothing to store.
+CREATE TABLE sync_cursors (
+    name TEXT PRIMARY KEY,
+    paging_token TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS sync_cursors;
@@ -0,0 +1,14 @@
+-- +goose Up
+
+-- Ownership is declared from either side: by the owner (Owner*) or by the owned
+-- account (Ownership*). source_is_owner marks the types declared by the owner so
+-- ownership edges can be normalized to owner -> owned.
+ALTER TABLE relation_type_settings
+    ADD COLUMN source_is_owner BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE relation_type_settings SET source_is_owner = TRUE
+WHERE relation_type IN ('Owner', 'OwnerMajority', 'OwnerMinority');
+
+-- +goose Down
+ALTER TABLE relation_type_settings
+    DROP COLUMN IF EXISTS source_is_owner;
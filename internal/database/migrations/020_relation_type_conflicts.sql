@@ -0,0 +1,20 @@
+-- +goose Up
+
+-- Relation types that contradict each other when they point at the same account,
+-- reported by the relationship linter. Each pair is listed on one of its types.
+ALTER TABLE relation_type_settings
+    ADD COLUMN conflicts_with TEXT[] NOT NULL DEFAULT '{}';
+
+UPDATE relation_type_settings AS s SET conflicts_with = v.conflicts_with
+FROM (VALUES
+    ('Spouse', ARRAY['Divorce']),
+    ('Guardian', ARRAY['Ward']),
+    ('Employer', ARRAY['Employee']),
+    ('OwnershipFull', ARRAY['OwnershipMajority', 'OwnershipMinority']),
+    ('OwnershipMajority', ARRAY['OwnershipMinority'])
+) AS v(relation_type, conflicts_with)
+WHERE s.relation_type = v.relation_type;
+
+-- +goose Down
+ALTER TABLE relation_type_settings
+    DROP COLUMN IF EXISTS conflicts_with;
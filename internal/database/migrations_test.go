@@ -0,0 +1,29 @@
+package database
+
+import (
+	"io/fs"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestSchemaVersion(t *testing.T) {
+	names, err := fs.Glob(embedMigrations, "migrations/*.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, names)
+
+	var want int64
+	for _, name := range names {
+		prefix, _, _ := strings.Cut(strings.TrimPrefix(name, "migrations/"), "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		require.NoError(t, err, name)
+		want = max(want, version)
+	}
+
+	got, err := LatestSchemaVersion()
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
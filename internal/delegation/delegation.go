@@ -0,0 +1,82 @@
+// Package delegation traces MTLA vote delegation chains
+// declared with the mtla_delegate and mtla_c_delegate ManageData keys.
+package delegation
+
+import (
+	"math"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+// Info holds the delegation state of a single account.
+type Info struct {
+	AccountID         string
+	DelegateTo        *string // mtla_delegate
+	CouncilDelegateTo *string // mtla_c_delegate when it's an account ID
+	MTLAPBalance      decimal.Decimal
+	CouncilReady      bool // mtla_c_delegate == "ready"
+}
+
+// TraceChain follows the mtla_delegate chain from startID and detects cycles.
+// Returns the visited path, or only the cycle when one is found.
+func TraceChain(startID string, accounts map[string]*Info) ([]string, bool) {
+	return trace(startID, accounts, func(acc *Info) *string {
+		return acc.DelegateTo
+	})
+}
+
+// TraceCouncilChain follows the mtla_c_delegate chain from startID and detects cycles.
+// The chain ends at the first council-ready account.
+func TraceCouncilChain(startID string, accounts map[string]*Info) ([]string, bool) {
+	return trace(startID, accounts, func(acc *Info) *string {
+		if acc.CouncilReady {
+			return nil
+		}
+		return acc.CouncilDelegateTo
+	})
+}
+
+func trace(startID string, accounts map[string]*Info, next func(*Info) *string) ([]string, bool) {
+	visited := make(map[string]bool)
+	var path []string
+
+	current := startID
+	for {
+		if visited[current] {
+			cycleStart := lo.IndexOf(path, current)
+			if cycleStart >= 0 {
+				return path[cycleStart:], true
+			}
+			return path, true
+		}
+
+		visited[current] = true
+		path = append(path, current)
+
+		acc, exists := accounts[current]
+		if !exists {
+			break
+		}
+		target := next(acc)
+		if target == nil {
+			break
+		}
+
+		current = *target
+	}
+
+	return path, false
+}
+
+// VotePower converts votes (own MTLAP plus received council votes) into a council
+// signer weight on a logarithmic scale: 1-10 votes give 1, 11-100 give 2, and so on.
+func VotePower(totalVotes float64) int {
+	if totalVotes <= 0 {
+		return 0
+	}
+	if totalVotes <= 10 {
+		return 1
+	}
+	return int(math.Ceil(math.Log10(totalVotes)))
+}
@@ -0,0 +1,60 @@
+package delegation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceCouncilChain(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name        string
+		accounts    map[string]*Info
+		expectPath  []string
+		expectCycle bool
+	}{
+		{
+			name: "stops at council-ready account",
+			accounts: map[string]*Info{
+				"A": {AccountID: "A", CouncilDelegateTo: strPtr("B")},
+				"B": {AccountID: "B", CouncilDelegateTo: strPtr("C"), CouncilReady: true},
+				"C": {AccountID: "C", CouncilDelegateTo: strPtr("A")},
+			},
+			expectPath: []string{"A", "B"},
+		},
+		{
+			name: "ends at unknown account",
+			accounts: map[string]*Info{
+				"A": {AccountID: "A", CouncilDelegateTo: strPtr("X")},
+			},
+			expectPath: []string{"A", "X"},
+		},
+		{
+			name: "cycle without ready account",
+			accounts: map[string]*Info{
+				"A": {AccountID: "A", CouncilDelegateTo: strPtr("B")},
+				"B": {AccountID: "B", CouncilDelegateTo: strPtr("C")},
+				"C": {AccountID: "C", CouncilDelegateTo: strPtr("B")},
+			},
+			expectPath:  []string{"B", "C"},
+			expectCycle: true,
+		},
+		{
+			name: "ignores mtla_delegate",
+			accounts: map[string]*Info{
+				"A": {AccountID: "A", DelegateTo: strPtr("B")},
+			},
+			expectPath: []string{"A"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, hasCycle := TraceCouncilChain("A", tt.accounts)
+			assert.Equal(t, tt.expectCycle, hasCycle)
+			assert.Equal(t, tt.expectPath, path)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+// Package dump writes and restores portable snapshots of the synced database.
+//
+// A dump is a zip archive with one PostgreSQL COPY text file per table and a
+// manifest recording the archive format, the schema (goose) version and the
+// latest sync run. Restoring migrates an empty database to the dump's schema
+// version, loads the tables and then applies the remaining migrations, so
+// dumps made by older releases can be restored by newer ones.
+package dump
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// FormatVersion is the archive layout version written to the manifest.
+// Restore rejects archives with a newer layout.
+const FormatVersion = 1
+
+// ManifestFile is the name of the manifest inside the archive.
+const ManifestFile = "manifest.json"
+
+// Tables lists the dumped tables in load order: settings first, then accounts
+// before the tables referencing them. API keys, their usage and signature
+// collections belong to a deployment and are not dumped.
+var Tables = []string{
+	"relation_type_settings",
+	"association_tag_settings",
+	"accounts",
+	"account_balances",
+	"account_metadata",
+	"account_signers",
+	"relationships",
+	"association_tags",
+	"token_prices",
+	"liquidity_pools",
+	"account_lp_shares",
+	"reputation_scores",
+	"membership_events",
+	"sync_cursors",
+	"operations",
+	"sync_runs",
+	"events",
+}
+
+// Manifest describes a dump.
+type Manifest struct {
+	FormatVersion int             `json:"format_version"`
+	SchemaVersion int64           `json:"schema_version"` // Applied goose migration
+	CreatedAt     time.Time       `json:"created_at"`
+	SyncRun       *SyncRun        `json:"sync_run,omitempty"` // Latest completed sync run
+	Tables        []TableManifest `json:"tables"`
+}
+
+// SyncRun is the latest completed sync run of the dumped database.
+type SyncRun struct {
+	ID         int64     `json:"id"`
+	FullSync   bool      `json:"full_sync"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// TableManifest describes one dumped table.
+type TableManifest struct {
+	Name    string   `json:"name"`
+	File    string   `json:"file"`
+	Columns []string `json:"columns"` // Column order of the COPY data
+	Rows    int64    `json:"rows"`
+}
+
+// Dumper writes dumps.
+type Dumper struct {
+	pool *pgxpool.Pool
+	now  func() time.Time
+}
+
+// NewDumper creates a dumper reading from pool.
+func NewDumper(pool *pgxpool.Pool) (*Dumper, error) {
+	if pool == nil {
+		return nil, errors.New("database pool is required")
+	}
+	return &Dumper{pool: pool, now: time.Now}, nil
+}
+
+// Dump writes a zip archive of all Tables to w. The tables are read from one
+// snapshot, so they match the sync run in the manifest.
+func (d *Dumper) Dump(ctx context.Context, w io.Writer) (*Manifest, error) {
+	tx, err := d.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("begin dump transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(context.Background()) }()
+
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		CreatedAt:     d.now().UTC(),
+	}
+	if err := readVersions(ctx, tx, manifest); err != nil {
+		return nil, err
+	}
+
+	archive := zip.NewWriter(w)
+	for _, table := range Tables {
+		columns, err := tableColumns(ctx, tx, table)
+		if err != nil {
+			return nil, err
+		}
+
+		file := table + ".copy"
+		fw, err := archive.Create(file)
+		if err != nil {
+			return nil, fmt.Errorf("create %s: %w", file, err)
+		}
+		tag, err := tx.Conn().PgConn().CopyTo(ctx, fw, copySQL(table, columns, "TO STDOUT"))
+		if err != nil {
+			return nil, fmt.Errorf("dump %s: %w", table, err)
+		}
+
+		manifest.Tables = append(manifest.Tables, TableManifest{
+			Name:    table,
+			File:    file,
+			Columns: columns,
+			Rows:    tag.RowsAffected(),
+		})
+	}
+
+	fw, err := archive.Create(ManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("create manifest: %w", err)
+	}
+	enc := json.NewEncoder(fw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return nil, fmt.Errorf("write manifest: %w", err)
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("finish archive: %w", err)
+	}
+	return manifest, nil
+}
+
+// readVersions fills in the schema version and the latest sync run.
+func readVersions(ctx context.Context, tx pgx.Tx, m *Manifest) error {
+	err := tx.QueryRow(ctx, "SELECT version_id FROM goose_db_version ORDER BY id DESC LIMIT 1").Scan(&m.SchemaVersion)
+	if err != nil {
+		return fmt.Errorf("query schema version: %w", err)
+	}
+
+	var run SyncRun
+	err = tx.QueryRow(ctx, "SELECT id, full_sync, started_at, finished_at FROM sync_runs WHERE account_id IS NULL ORDER BY id DESC LIMIT 1").
+		Scan(&run.ID, &run.FullSync, &run.StartedAt, &run.FinishedAt)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return nil
+	case err != nil:
+		return fmt.Errorf("query latest sync run: %w", err)
+	}
+	run.StartedAt = run.StartedAt.UTC()
+	run.FinishedAt = run.FinishedAt.UTC()
+	m.SyncRun = &run
+	return nil
+}
+
+// tableColumns returns the table's columns in definition order.
+func tableColumns(ctx context.Context, tx pgx.Tx, table string) ([]string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1
+		ORDER BY ordinal_position`, table)
+	if err != nil {
+		return nil, fmt.Errorf("query %s columns: %w", table, err)
+	}
+	columns, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("scan %s columns: %w", table, err)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s does not exist", table)
+	}
+	return columns, nil
+}
+
+// copySQL builds a COPY statement for the table's columns in text format.
+// direction is "TO STDOUT" or "FROM STDIN".
+func copySQL(table string, columns []string, direction string) string {
+	sql := "COPY " + pgx.Identifier{table}.Sanitize() + " ("
+	for i, c := range columns {
+		if i > 0 {
+			sql += ", "
+		}
+		sql += pgx.Identifier{c}.Sanitize()
+	}
+	return sql + ") " + direction
+}
@@ -0,0 +1,113 @@
+package dump
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildArchive zips files, encoding manifest as manifest.json when non-nil.
+func buildArchive(t *testing.T, manifest any, files map[string]string) *zip.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		fw, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	if manifest != nil {
+		fw, err := w.Create(ManifestFile)
+		require.NoError(t, err)
+		require.NoError(t, json.NewEncoder(fw).Encode(manifest))
+	}
+	require.NoError(t, w.Close())
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	return r
+}
+
+func validManifest() Manifest {
+	return Manifest{
+		FormatVersion: FormatVersion,
+		SchemaVersion: 13,
+		SyncRun:       &SyncRun{ID: 7, FullSync: true},
+		Tables: []TableManifest{
+			{Name: "accounts", File: "accounts.copy", Columns: []string{"account_id"}, Rows: 1},
+		},
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	files := map[string]string{"accounts.copy": "GABC\n"}
+
+	m, err := ReadManifest(buildArchive(t, validManifest(), files))
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), m.SchemaVersion)
+	assert.Equal(t, int64(7), m.SyncRun.ID)
+	require.Len(t, m.Tables, 1)
+	assert.Equal(t, []string{"account_id"}, m.Tables[0].Columns)
+
+	t.Run("no manifest", func(t *testing.T) {
+		_, err := ReadManifest(buildArchive(t, nil, files))
+		assert.ErrorContains(t, err, "not a lore dump")
+	})
+
+	t.Run("newer format", func(t *testing.T) {
+		m := validManifest()
+		m.FormatVersion = FormatVersion + 1
+		_, err := ReadManifest(buildArchive(t, m, files))
+		assert.ErrorContains(t, err, "unsupported dump format version")
+	})
+
+	t.Run("missing schema version", func(t *testing.T) {
+		m := validManifest()
+		m.SchemaVersion = 0
+		_, err := ReadManifest(buildArchive(t, m, files))
+		assert.ErrorContains(t, err, "invalid schema version")
+	})
+
+	t.Run("missing table file", func(t *testing.T) {
+		_, err := ReadManifest(buildArchive(t, validManifest(), nil))
+		assert.ErrorContains(t, err, "file accounts.copy is missing")
+	})
+
+	t.Run("unknown table", func(t *testing.T) {
+		for _, name := range []string{"api_keys", "goose_db_version", `accounts"; DROP TABLE accounts; --`} {
+			m := validManifest()
+			m.Tables = append(m.Tables, TableManifest{Name: name, File: "accounts.copy", Columns: []string{"id"}})
+			_, err := ReadManifest(buildArchive(t, m, files))
+			assert.ErrorContains(t, err, "is not part of a lore dump", name)
+		}
+	})
+
+	t.Run("duplicate table", func(t *testing.T) {
+		m := validManifest()
+		m.Tables = append(m.Tables, m.Tables[0])
+		_, err := ReadManifest(buildArchive(t, m, files))
+		assert.ErrorContains(t, err, "accounts is listed twice")
+	})
+}
+
+func TestCopySQL(t *testing.T) {
+	assert.Equal(t, `COPY "accounts" ("account_id", "name") TO STDOUT`,
+		copySQL("accounts", []string{"account_id", "name"}, "TO STDOUT"))
+	assert.Equal(t, `COPY "sync_runs" ("id") FROM STDIN`,
+		copySQL("sync_runs", []string{"id"}, "FROM STDIN"))
+}
+
+func TestTablesLoadAccountsBeforeReferences(t *testing.T) {
+	position := make(map[string]int, len(Tables))
+	for i, table := range Tables {
+		position[table] = i
+	}
+	assert.Less(t, position["accounts"], position["reputation_scores"])
+	assert.NotContains(t, Tables, "api_keys")
+	assert.NotContains(t, Tables, "signature_collections")
+}
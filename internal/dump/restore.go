@@ -0,0 +1,194 @@
+package dump
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mtlprog/lore/internal/database"
+)
+
+// ReadManifest reads and validates the manifest of an archive.
+func ReadManifest(archive *zip.Reader) (*Manifest, error) {
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	mf, ok := files[ManifestFile]
+	if !ok {
+		return nil, errors.New("not a lore dump: manifest.json is missing")
+	}
+	r, err := mf.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open manifest: %w", err)
+	}
+	defer r.Close()
+
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+
+	if m.FormatVersion < 1 || m.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("unsupported dump format version %d (this release reads up to %d)", m.FormatVersion, FormatVersion)
+	}
+	if m.SchemaVersion < 1 {
+		return nil, fmt.Errorf("invalid schema version %d", m.SchemaVersion)
+	}
+	seen := make(map[string]bool, len(m.Tables))
+	for _, t := range m.Tables {
+		// Table names are used in DELETE and COPY statements; only the
+		// tables a dump is made of may be restored.
+		if !slices.Contains(Tables, t.Name) {
+			return nil, fmt.Errorf("table %q is not part of a lore dump", t.Name)
+		}
+		if seen[t.Name] {
+			return nil, fmt.Errorf("table %s is listed twice", t.Name)
+		}
+		seen[t.Name] = true
+		if len(t.Columns) == 0 {
+			return nil, fmt.Errorf("table %s has no columns", t.Name)
+		}
+		if _, ok := files[t.File]; !ok {
+			return nil, fmt.Errorf("table %s: file %s is missing", t.Name, t.File)
+		}
+	}
+	return &m, nil
+}
+
+// Restorer loads dumps into an empty database.
+type Restorer struct {
+	pool *pgxpool.Pool
+}
+
+// NewRestorer creates a restorer writing to pool.
+func NewRestorer(pool *pgxpool.Pool) (*Restorer, error) {
+	if pool == nil {
+		return nil, errors.New("database pool is required")
+	}
+	return &Restorer{pool: pool}, nil
+}
+
+// Restore loads the archive into the database, which must never have been
+// migrated. The schema is migrated to the dump's version, the tables are
+// loaded in one transaction and the remaining migrations then convert the
+// data to the current schema.
+func (r *Restorer) Restore(ctx context.Context, archive *zip.Reader) (*Manifest, error) {
+	m, err := ReadManifest(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := database.LatestSchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	if m.SchemaVersion > latest {
+		return nil, fmt.Errorf("dump schema version %d is newer than this release (%d); upgrade lore", m.SchemaVersion, latest)
+	}
+
+	current, err := database.SchemaVersion(ctx, r.pool)
+	if err != nil {
+		return nil, err
+	}
+	if current != 0 {
+		return nil, fmt.Errorf("database is not empty (schema version %d); restore needs a fresh database", current)
+	}
+
+	if err := database.MigrateTo(ctx, r.pool, m.SchemaVersion); err != nil {
+		return nil, err
+	}
+
+	if err := r.load(ctx, archive, m); err != nil {
+		return nil, err
+	}
+
+	if m.SchemaVersion < latest {
+		slog.Info("upgrading restored data", "from", m.SchemaVersion, "to", latest)
+	}
+	if err := database.RunMigrations(ctx, r.pool); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// load copies every table of the archive in one transaction.
+func (r *Restorer) load(ctx context.Context, archive *zip.Reader, m *Manifest) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin restore transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(context.Background()) }()
+
+	for _, t := range m.Tables {
+		if err := loadTable(ctx, tx, archive, t); err != nil {
+			return fmt.Errorf("restore %s: %w", t.Name, err)
+		}
+		slog.Info("restored table", "table", t.Name, "rows", t.Rows)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit restore: %w", err)
+	}
+	return nil
+}
+
+func loadTable(ctx context.Context, tx pgx.Tx, archive *zip.Reader, t TableManifest) error {
+	// Settings tables are seeded by migrations; the dump replaces the seed rows.
+	if _, err := tx.Exec(ctx, "DELETE FROM "+pgx.Identifier{t.Name}.Sanitize()); err != nil {
+		return fmt.Errorf("clear table: %w", err)
+	}
+
+	f, err := archive.Open(t.File)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", t.File, err)
+	}
+	defer f.Close()
+
+	tag, err := tx.Conn().PgConn().CopyFrom(ctx, f, copySQL(t.Name, t.Columns, "FROM STDIN"))
+	if err != nil {
+		return fmt.Errorf("copy rows: %w", err)
+	}
+	if tag.RowsAffected() != t.Rows {
+		return fmt.Errorf("loaded %d rows, manifest lists %d", tag.RowsAffected(), t.Rows)
+	}
+
+	return resetSequences(ctx, tx, t.Name)
+}
+
+// resetSequences moves the table's serial sequences past the restored ids.
+func resetSequences(ctx context.Context, tx pgx.Tx, table string) error {
+	rows, err := tx.Query(ctx, `
+		SELECT column_name, pg_get_serial_sequence($1, column_name)
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1
+		  AND pg_get_serial_sequence($1, column_name) IS NOT NULL`, table)
+	if err != nil {
+		return fmt.Errorf("query sequences: %w", err)
+	}
+	type serial struct{ column, sequence string }
+	serials, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (serial, error) {
+		var s serial
+		err := row.Scan(&s.column, &s.sequence)
+		return s, err
+	})
+	if err != nil {
+		return fmt.Errorf("scan sequences: %w", err)
+	}
+
+	for _, s := range serials {
+		query := fmt.Sprintf("SELECT setval($1, COALESCE((SELECT MAX(%s) FROM %s), 0) + 1, false)",
+			pgx.Identifier{s.column}.Sanitize(), pgx.Identifier{table}.Sanitize())
+		if _, err := tx.Exec(ctx, query, s.sequence); err != nil {
+			return fmt.Errorf("reset sequence %s: %w", s.sequence, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Package events stores the changes detected by sync runs and fans them out to
+// live subscribers. Sync appends events and announces them on Channel; every
+// server replica's Hub loads the new rows and passes them to subscriptions
+// whose Filter matches.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Type is the kind of change an event describes.
+type Type string
+
+const (
+	TypeAccountUpdated      Type = "account.updated"       // Name, token balances, delegation or council readiness changed
+	TypeRelationshipAdded   Type = "relationship.added"    // Source declared a relation to the related account
+	TypeRelationshipRemoved Type = "relationship.removed"  // Source dropped a relation to the related account
+	TypeCouncilVotesChanged Type = "council.votes_changed" // Delegated council votes changed
+	TypeReputationChanged   Type = "reputation.changed"    // Weighted reputation score changed
+	TypeMemberJoined        Type = "member.joined"         // Account became a participant or member company
+	TypeMemberLeft          Type = "member.left"           // Account is no longer a participant or member company
+)
+
+// Types lists all event types.
+var Types = []Type{
+	TypeAccountUpdated,
+	TypeRelationshipAdded,
+	TypeRelationshipRemoved,
+	TypeCouncilVotesChanged,
+	TypeReputationChanged,
+	TypeMemberJoined,
+	TypeMemberLeft,
+}
+
+// ParseTypes parses a comma-separated list of event types.
+func ParseTypes(s string) ([]Type, error) {
+	var types []Type
+	for _, part := range strings.Split(s, ",") {
+		t := Type(strings.TrimSpace(part))
+		if t == "" {
+			continue
+		}
+		if !slices.Contains(Types, t) {
+			return nil, fmt.Errorf("unknown event type %q", t)
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+// Event is a stored change.
+type Event struct {
+	ID               int64           `json:"id"`
+	Type             Type            `json:"type"`
+	AccountID        string          `json:"account_id"`
+	RelatedAccountID string          `json:"related_account_id,omitempty"` // Relationship target
+	Data             json.RawMessage `json:"data"`
+	CreatedAt        time.Time       `json:"created_at"`
+}
+
+// New creates an unsaved event with data encoded as JSON.
+func New(t Type, accountID, relatedAccountID string, data any) (Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, fmt.Errorf("encode %s data: %w", t, err)
+	}
+	return Event{Type: t, AccountID: accountID, RelatedAccountID: relatedAccountID, Data: raw}, nil
+}
+
+// Filter selects events. Empty fields match everything.
+type Filter struct {
+	Accounts []string // Events about or related to these accounts
+	Types    []Type
+}
+
+// Match reports whether e passes the filter.
+func (f Filter) Match(e Event) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, e.Type) {
+		return false
+	}
+	if len(f.Accounts) > 0 &&
+		!slices.Contains(f.Accounts, e.AccountID) &&
+		(e.RelatedAccountID == "" || !slices.Contains(f.Accounts, e.RelatedAccountID)) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTypes(t *testing.T) {
+	types, err := ParseTypes("member.joined, reputation.changed,")
+	require.NoError(t, err)
+	assert.Equal(t, []Type{TypeMemberJoined, TypeReputationChanged}, types)
+
+	types, err = ParseTypes("")
+	require.NoError(t, err)
+	assert.Empty(t, types)
+
+	_, err = ParseTypes("member.joined,account.deleted")
+	assert.ErrorContains(t, err, `unknown event type "account.deleted"`)
+}
+
+func TestFilterMatch(t *testing.T) {
+	relation := Event{Type: TypeRelationshipAdded, AccountID: "GA", RelatedAccountID: "GB"}
+	joined := Event{Type: TypeMemberJoined, AccountID: "GC"}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		event  Event
+		want   bool
+	}{
+		{"empty filter", Filter{}, joined, true},
+		{"type matches", Filter{Types: []Type{TypeMemberJoined}}, joined, true},
+		{"type differs", Filter{Types: []Type{TypeMemberLeft}}, joined, false},
+		{"account is source", Filter{Accounts: []string{"GA"}}, relation, true},
+		{"account is target", Filter{Accounts: []string{"GB"}}, relation, true},
+		{"account differs", Filter{Accounts: []string{"GB"}}, joined, false},
+		{"both must match", Filter{Accounts: []string{"GA"}, Types: []Type{TypeMemberJoined}}, relation, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Match(tt.event))
+		})
+	}
+}
+
+func TestAfterQuery(t *testing.T) {
+	sql, args, err := afterQuery(10, Filter{}, 100).ToSql()
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT id, type, account_id, related_account_id, data, created_at FROM events WHERE id > $1 ORDER BY id LIMIT 100", sql)
+	assert.Equal(t, []any{int64(10)}, args)
+
+	sql, args, err = afterQuery(0, Filter{Accounts: []string{"GA"}, Types: []Type{TypeMemberJoined}}, 5).ToSql()
+	require.NoError(t, err)
+	assert.Contains(t, sql, "type IN ($2)")
+	assert.Contains(t, sql, "(account_id IN ($3) OR related_account_id IN ($4))")
+	assert.Equal(t, []any{int64(0), "member.joined", "GA", "GA"}, args)
+}
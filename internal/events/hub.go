@@ -0,0 +1,177 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+const (
+	// subscriberBuffer is how many events a subscriber may fall behind before
+	// it is dropped; its client reconnects and resumes with Last-Event-ID.
+	subscriberBuffer = 64
+	// pollBatch is how many events Poll loads per query.
+	pollBatch = 500
+)
+
+var (
+	// ErrTooManySubscribers is returned by Subscribe when the hub is full.
+	ErrTooManySubscribers = errors.New("too many event subscribers")
+	// ErrClosed is returned by Subscribe after Close.
+	ErrClosed = errors.New("event hub is closed")
+)
+
+// Store is the event storage the hub reads from.
+type Store interface {
+	After(ctx context.Context, after int64, filter Filter, limit int) ([]Event, error)
+	LatestID(ctx context.Context) (int64, error)
+}
+
+// Hub delivers newly stored events to a bounded number of subscribers.
+type Hub struct {
+	store          Store
+	maxSubscribers int
+
+	pollMu sync.Mutex // Serializes Poll
+	last   int64      // Newest event delivered, guarded by pollMu
+
+	mu     sync.Mutex
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+// NewHub creates a hub that allows at most maxSubscribers subscriptions.
+// Start must be called before Poll.
+func NewHub(store Store, maxSubscribers int) (*Hub, error) {
+	if store == nil {
+		return nil, errors.New("event store is required")
+	}
+	if maxSubscribers <= 0 {
+		return nil, fmt.Errorf("max subscribers must be positive, got %d", maxSubscribers)
+	}
+	return &Hub{
+		store:          store,
+		maxSubscribers: maxSubscribers,
+		subs:           make(map[*Subscription]struct{}),
+	}, nil
+}
+
+// Start makes Poll deliver only events stored from now on.
+func (h *Hub) Start(ctx context.Context) error {
+	last, err := h.store.LatestID(ctx)
+	if err != nil {
+		return err
+	}
+	h.pollMu.Lock()
+	h.last = last
+	h.pollMu.Unlock()
+	return nil
+}
+
+// Poll loads the events stored since the last poll and delivers them to the
+// subscribers whose filter matches. It is called on every notification.
+func (h *Hub) Poll(ctx context.Context) error {
+	h.pollMu.Lock()
+	defer h.pollMu.Unlock()
+
+	for {
+		batch, err := h.store.After(ctx, h.last, Filter{}, pollBatch)
+		if err != nil {
+			return err
+		}
+		for _, e := range batch {
+			h.broadcast(e)
+			h.last = e.ID
+		}
+		if len(batch) < pollBatch {
+			return nil
+		}
+	}
+}
+
+func (h *Hub) broadcast(e Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs {
+		if !sub.filter.Match(e) {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			slog.Warn("dropping lagging event subscriber", "event_id", e.ID)
+			delete(h.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Subscribe registers a subscription for events matching filter.
+func (h *Hub) Subscribe(filter Filter) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrClosed
+	}
+	if len(h.subs) >= h.maxSubscribers {
+		return nil, ErrTooManySubscribers
+	}
+	sub := &Subscription{hub: h, filter: filter, ch: make(chan Event, subscriberBuffer)}
+	h.subs[sub] = struct{}{}
+	return sub, nil
+}
+
+// Replay returns up to limit stored events after the given ID that match
+// filter, for clients resuming with Last-Event-ID.
+func (h *Hub) Replay(ctx context.Context, after int64, filter Filter, limit int) ([]Event, error) {
+	return h.store.After(ctx, after, filter, limit)
+}
+
+// Subscribers returns the number of open subscriptions.
+func (h *Hub) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs)
+}
+
+// Close ends all subscriptions and rejects new ones, so streaming handlers
+// return when the server shuts down.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sub := range h.subs {
+		delete(h.subs, sub)
+		close(sub.ch)
+	}
+}
+
+// Subscription receives the events matching its filter.
+type Subscription struct {
+	hub    *Hub
+	filter Filter
+	ch     chan Event
+}
+
+// Events returns the channel events are delivered on. It is closed when the
+// subscription falls too far behind or the hub is closed.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Close unregisters the subscription. It is safe to call more than once.
+func (s *Subscription) Close() {
+	h := s.hub
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subs[s]; ok {
+		delete(h.subs, s)
+		close(s.ch)
+	}
+}
@@ -0,0 +1,145 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore serves events from a slice.
+type memoryStore struct {
+	events []Event
+}
+
+func (m *memoryStore) After(_ context.Context, after int64, filter Filter, limit int) ([]Event, error) {
+	var out []Event
+	for _, e := range m.events {
+		if e.ID > after && filter.Match(e) && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (m *memoryStore) LatestID(_ context.Context) (int64, error) {
+	if len(m.events) == 0 {
+		return 0, nil
+	}
+	return m.events[len(m.events)-1].ID, nil
+}
+
+func (m *memoryStore) add(t Type, account string) {
+	m.events = append(m.events, Event{ID: int64(len(m.events) + 1), Type: t, AccountID: account})
+}
+
+func newTestHub(t *testing.T, store *memoryStore, max int) *Hub {
+	t.Helper()
+	h, err := NewHub(store, max)
+	require.NoError(t, err)
+	require.NoError(t, h.Start(context.Background()))
+	return h
+}
+
+func drain(sub *Subscription) []int64 {
+	var ids []int64
+	for {
+		select {
+		case e, ok := <-sub.Events():
+			if !ok {
+				return ids
+			}
+			ids = append(ids, e.ID)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestNewHub(t *testing.T) {
+	_, err := NewHub(nil, 1)
+	assert.Error(t, err)
+	_, err = NewHub(&memoryStore{}, 0)
+	assert.Error(t, err)
+}
+
+func TestHub_PollDeliversNewMatchingEvents(t *testing.T) {
+	store := &memoryStore{}
+	store.add(TypeMemberJoined, "GA") // Stored before Start, not delivered
+	h := newTestHub(t, store, 10)
+
+	all, err := h.Subscribe(Filter{})
+	require.NoError(t, err)
+	onlyGB, err := h.Subscribe(Filter{Accounts: []string{"GB"}})
+	require.NoError(t, err)
+
+	store.add(TypeMemberJoined, "GA")
+	store.add(TypeAccountUpdated, "GB")
+	require.NoError(t, h.Poll(context.Background()))
+
+	assert.Equal(t, []int64{2, 3}, drain(all))
+	assert.Equal(t, []int64{3}, drain(onlyGB))
+
+	// Nothing new: nothing delivered twice
+	require.NoError(t, h.Poll(context.Background()))
+	assert.Empty(t, drain(all))
+}
+
+func TestHub_SubscriberLimit(t *testing.T) {
+	h := newTestHub(t, &memoryStore{}, 1)
+
+	sub, err := h.Subscribe(Filter{})
+	require.NoError(t, err)
+	_, err = h.Subscribe(Filter{})
+	assert.ErrorIs(t, err, ErrTooManySubscribers)
+
+	sub.Close()
+	sub.Close()
+	_, err = h.Subscribe(Filter{})
+	assert.NoError(t, err)
+}
+
+func TestHub_DropsLaggingSubscriber(t *testing.T) {
+	store := &memoryStore{}
+	h := newTestHub(t, store, 10)
+	sub, err := h.Subscribe(Filter{})
+	require.NoError(t, err)
+
+	for range subscriberBuffer + 1 {
+		store.add(TypeAccountUpdated, "GA")
+	}
+	require.NoError(t, h.Poll(context.Background()))
+
+	assert.Len(t, drain(sub), subscriberBuffer)
+	_, open := <-sub.Events()
+	assert.False(t, open)
+	assert.Equal(t, 0, h.Subscribers())
+}
+
+func TestHub_Close(t *testing.T) {
+	h := newTestHub(t, &memoryStore{}, 10)
+	sub, err := h.Subscribe(Filter{})
+	require.NoError(t, err)
+
+	h.Close()
+	_, open := <-sub.Events()
+	assert.False(t, open)
+
+	_, err = h.Subscribe(Filter{})
+	assert.ErrorIs(t, err, ErrClosed)
+	sub.Close()
+}
+
+func TestHub_Replay(t *testing.T) {
+	store := &memoryStore{}
+	store.add(TypeMemberJoined, "GA")
+	store.add(TypeMemberJoined, "GB")
+	store.add(TypeMemberLeft, "GA")
+	h := newTestHub(t, store, 10)
+
+	evs, err := h.Replay(context.Background(), 1, Filter{Accounts: []string{"GA"}}, 10)
+	require.NoError(t, err)
+	require.Len(t, evs, 1)
+	assert.Equal(t, int64(3), evs[0].ID)
+}
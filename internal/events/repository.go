@@ -0,0 +1,168 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mtlprog/lore/internal/database"
+)
+
+// Channel is the PostgreSQL notification channel appended events are
+// announced on, with the last event ID as payload.
+const Channel = "lore_events"
+
+// Retention is how long events stay available for replay.
+const Retention = 30 * 24 * time.Hour
+
+// appendLockID is the advisory lock serializing Append, so event IDs become
+// visible in order and a Hub never skips an event committed late.
+const appendLockID = 0x6c6f7265 // "lore"
+
+// Repository reads and writes the events table.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new event repository.
+func NewRepository(pool *pgxpool.Pool) (*Repository, error) {
+	if pool == nil {
+		return nil, errors.New("database pool is required")
+	}
+	return &Repository{pool: pool}, nil
+}
+
+// Append stores events and notifies Channel once they are committed.
+func (r *Repository) Append(ctx context.Context, events []Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	builder := database.QB.
+		Insert("events").
+		Columns("type", "account_id", "related_account_id", "data")
+	for _, e := range events {
+		builder = builder.Values(e.Type, e.AccountID, nullString(e.RelatedAccountID), e.Data)
+	}
+	query, args, err := builder.Suffix("RETURNING id").ToSql()
+	if err != nil {
+		return fmt.Errorf("build append events query: %w", err)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin append events: %w", err)
+	}
+	defer func() { _ = tx.Rollback(context.Background()) }()
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", appendLockID); err != nil {
+		return fmt.Errorf("lock events: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("append events: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return fmt.Errorf("append events: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, "SELECT pg_notify($1, $2)", Channel, strconv.FormatInt(ids[len(ids)-1], 10)); err != nil {
+		return fmt.Errorf("notify events: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit events: %w", err)
+	}
+	return nil
+}
+
+// After returns up to limit events with an ID greater than after that pass
+// the filter, oldest first.
+func (r *Repository) After(ctx context.Context, after int64, filter Filter, limit int) ([]Event, error) {
+	query, args, err := afterQuery(after, filter, limit).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build events query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query events: %w", err)
+	}
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Event, error) {
+		var e Event
+		var related *string
+		if err := row.Scan(&e.ID, &e.Type, &e.AccountID, &related, &e.Data, &e.CreatedAt); err != nil {
+			return e, err
+		}
+		if related != nil {
+			e.RelatedAccountID = *related
+		}
+		return e, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan events: %w", err)
+	}
+	return events, nil
+}
+
+func afterQuery(after int64, filter Filter, limit int) sq.SelectBuilder {
+	builder := database.QB.
+		Select("id", "type", "account_id", "related_account_id", "data", "created_at").
+		From("events").
+		Where(sq.Gt{"id": after}).
+		OrderBy("id").
+		Limit(uint64(limit))
+
+	if len(filter.Types) > 0 {
+		types := make([]string, len(filter.Types))
+		for i, t := range filter.Types {
+			types[i] = string(t)
+		}
+		builder = builder.Where(sq.Eq{"type": types})
+	}
+	if len(filter.Accounts) > 0 {
+		builder = builder.Where(sq.Or{
+			sq.Eq{"account_id": filter.Accounts},
+			sq.Eq{"related_account_id": filter.Accounts},
+		})
+	}
+	return builder
+}
+
+// LatestID returns the ID of the newest event, or 0 if there is none.
+func (r *Repository) LatestID(ctx context.Context) (int64, error) {
+	var id int64
+	if err := r.pool.QueryRow(ctx, "SELECT COALESCE(MAX(id), 0) FROM events").Scan(&id); err != nil {
+		return 0, fmt.Errorf("query latest event: %w", err)
+	}
+	return id, nil
+}
+
+// Prune deletes events created before cutoff and returns how many were deleted.
+func (r *Repository) Prune(ctx context.Context, cutoff time.Time) (int64, error) {
+	query, args, err := database.QB.
+		Delete("events").
+		Where(sq.Lt{"created_at": cutoff}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("build prune events query: %w", err)
+	}
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("prune events: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,231 @@
+// Package export writes the synced dataset (accounts, balances, metadata,
+// relationships, tags, liquidity pools and reputation scores) as CSV, JSON
+// Lines or Parquet files, one per table, with a manifest describing the export.
+// Rows are streamed from a single database snapshot.
+package export
+
+import (
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mtlprog/lore/internal/database"
+	"github.com/mtlprog/lore/internal/model"
+)
+
+// Format is an export file format.
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatJSONL   Format = "jsonl"
+	FormatParquet Format = "parquet"
+)
+
+// Formats lists all supported formats.
+var Formats = []Format{FormatCSV, FormatJSONL, FormatParquet}
+
+// ParseFormat parses a format name.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatCSV, FormatJSONL, FormatParquet:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown export format %q (want csv, jsonl or parquet)", s)
+	}
+}
+
+// ManifestFile is the name of the manifest written after all tables.
+const ManifestFile = "manifest.json"
+
+// PersonalDataKeys are the ManageData keys left out of account_metadata when
+// personal data is excluded: contact handles and signed membership contracts.
+var PersonalDataKeys = []string{
+	model.DataKeyTelegramUserID,
+	model.DataKeyTelegramPartChatID,
+	model.DataKeyContractIPFS,
+}
+
+// ColumnType is the type of an exported column.
+type ColumnType string
+
+const (
+	TypeString    ColumnType = "string"
+	TypeInt       ColumnType = "int"
+	TypeDecimal   ColumnType = "decimal" // Exact decimal; a string in CSV and Parquet, a number in JSON Lines
+	TypeBool      ColumnType = "bool"
+	TypeTimestamp ColumnType = "timestamp"
+)
+
+// Column is an exported column.
+type Column struct {
+	Name string     `json:"name"`
+	Type ColumnType `json:"type"`
+}
+
+// expr selects the column so that it scans into the Go type its writers expect.
+func (c Column) expr() string {
+	switch c.Type {
+	case TypeDecimal:
+		return c.Name + "::text AS " + c.Name
+	case TypeInt:
+		return c.Name + "::bigint AS " + c.Name
+	default:
+		return c.Name
+	}
+}
+
+// Table is an exported table.
+type Table struct {
+	Name    string
+	Columns []Column
+	OrderBy []string // Primary key, for stable output
+}
+
+// Tables lists the exported tables in export order.
+var Tables = []Table{
+	{
+		Name: "accounts",
+		Columns: []Column{
+			{"account_id", TypeString},
+			{"mtlap_balance", TypeDecimal},
+			{"mtlac_balance", TypeDecimal},
+			{"mtlax_balance", TypeDecimal},
+			{"native_balance", TypeDecimal},
+			{"total_xlm_value", TypeDecimal},
+			{"delegate_to", TypeString},
+			{"council_delegate_to", TypeString},
+			{"is_council_ready", TypeBool},
+			{"received_votes", TypeInt},
+			{"low_threshold", TypeInt},
+			{"med_threshold", TypeInt},
+			{"high_threshold", TypeInt},
+			{"updated_at", TypeTimestamp},
+		},
+		OrderBy: []string{"account_id"},
+	},
+	{
+		Name: "account_balances",
+		Columns: []Column{
+			{"account_id", TypeString},
+			{"asset_code", TypeString},
+			{"asset_issuer", TypeString},
+			{"balance", TypeDecimal},
+			{"xlm_value", TypeDecimal},
+		},
+		OrderBy: []string{"account_id", "asset_code", "asset_issuer"},
+	},
+	{
+		Name: "account_metadata",
+		Columns: []Column{
+			{"account_id", TypeString},
+			{"data_key", TypeString},
+			{"data_index", TypeString},
+			{"data_value", TypeString},
+		},
+		OrderBy: []string{"account_id", "data_key", "data_index"},
+	},
+	{
+		Name: "relationships",
+		Columns: []Column{
+			{"source_account_id", TypeString},
+			{"target_account_id", TypeString},
+			{"relation_type", TypeString},
+			{"relation_index", TypeString},
+		},
+		OrderBy: []string{"source_account_id", "relation_type", "relation_index"},
+	},
+	{
+		Name: "association_tags",
+		Columns: []Column{
+			{"tag_name", TypeString},
+			{"tag_index", TypeString},
+			{"target_account_id", TypeString},
+		},
+		OrderBy: []string{"tag_name", "tag_index"},
+	},
+	{
+		Name: "liquidity_pools",
+		Columns: []Column{
+			{"pool_id", TypeString},
+			{"total_shares", TypeDecimal},
+			{"reserve_a_code", TypeString},
+			{"reserve_a_issuer", TypeString},
+			{"reserve_a_amount", TypeDecimal},
+			{"reserve_b_code", TypeString},
+			{"reserve_b_issuer", TypeString},
+			{"reserve_b_amount", TypeDecimal},
+			{"updated_at", TypeTimestamp},
+		},
+		OrderBy: []string{"pool_id"},
+	},
+	{
+		Name: "account_lp_shares",
+		Columns: []Column{
+			{"account_id", TypeString},
+			{"pool_id", TypeString},
+			{"share_balance", TypeDecimal},
+			{"xlm_value", TypeDecimal},
+		},
+		OrderBy: []string{"account_id", "pool_id"},
+	},
+	{
+		Name: "reputation_scores",
+		Columns: []Column{
+			{"account_id", TypeString},
+			{"weighted_score", TypeDecimal},
+			{"base_score", TypeDecimal},
+			{"rating_count_a", TypeInt},
+			{"rating_count_b", TypeInt},
+			{"rating_count_c", TypeInt},
+			{"rating_count_d", TypeInt},
+			{"total_ratings", TypeInt},
+			{"total_weight", TypeDecimal},
+			{"calculated_at", TypeTimestamp},
+		},
+		OrderBy: []string{"account_id"},
+	},
+}
+
+// query selects the table's rows in primary key order.
+func (t Table) query(opts Options) sq.SelectBuilder {
+	columns := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		columns[i] = c.expr()
+	}
+
+	builder := database.QB.
+		Select(columns...).
+		From(t.Name).
+		OrderBy(t.OrderBy...)
+
+	if opts.ExcludePersonal && t.Name == "account_metadata" {
+		builder = builder.Where(sq.NotEq{"data_key": PersonalDataKeys})
+	}
+	return builder
+}
+
+// Options configures an export.
+type Options struct {
+	Format          Format
+	ExcludePersonal bool // Leave out PersonalDataKeys
+}
+
+// Manifest describes a finished export.
+type Manifest struct {
+	Format          Format          `json:"format"`
+	SchemaVersion   int64           `json:"schema_version"` // Applied database migration
+	SyncRunID       int64           `json:"sync_run_id"`    // 0 if no sync run has completed
+	SyncedAt        *time.Time      `json:"synced_at,omitempty"`
+	GeneratedAt     time.Time       `json:"generated_at"`
+	ExcludePersonal bool            `json:"exclude_personal"`
+	Tables          []TableManifest `json:"tables"`
+}
+
+// TableManifest describes one exported table.
+type TableManifest struct {
+	Name    string   `json:"name"`
+	File    string   `json:"file"`
+	Rows    int64    `json:"rows"`
+	Columns []Column `json:"columns"`
+}
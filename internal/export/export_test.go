@@ -0,0 +1,139 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTable = Table{
+	Name: "test",
+	Columns: []Column{
+		{"id", TypeString},
+		{"balance", TypeDecimal},
+		{"votes", TypeInt},
+		{"ready", TypeBool},
+		{"at", TypeTimestamp},
+	},
+	OrderBy: []string{"id"},
+}
+
+var testAt = time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+
+var testRows = [][]any{
+	{"GA", "12.3400000", int64(7), true, testAt},
+	{"GB", nil, nil, nil, nil},
+}
+
+func writeRows(t *testing.T, format Format) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := newRowWriter(format, &buf, testTable)
+	require.NoError(t, err)
+	for _, row := range testRows {
+		require.NoError(t, w.Write(row))
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range Formats {
+		got, err := ParseFormat(string(f))
+		require.NoError(t, err)
+		assert.Equal(t, f, got)
+	}
+
+	_, err := ParseFormat("xlsx")
+	assert.ErrorContains(t, err, `unknown export format "xlsx"`)
+}
+
+func TestTableQuery(t *testing.T) {
+	sql, _, err := testTable.query(Options{}).ToSql()
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT id, balance::text AS balance, votes::bigint AS votes, ready, at FROM test ORDER BY id", sql)
+
+	metadata := Tables[2]
+	require.Equal(t, "account_metadata", metadata.Name)
+
+	sql, _, err = metadata.query(Options{}).ToSql()
+	require.NoError(t, err)
+	assert.NotContains(t, sql, "WHERE")
+
+	sql, args, err := metadata.query(Options{ExcludePersonal: true}).ToSql()
+	require.NoError(t, err)
+	assert.Contains(t, sql, "WHERE data_key NOT IN ($1,$2,$3)")
+	assert.ElementsMatch(t, PersonalDataKeys, args)
+}
+
+func TestCSVWriter(t *testing.T) {
+	got := string(writeRows(t, FormatCSV))
+	assert.Equal(t, "id,balance,votes,ready,at\n"+
+		"GA,12.3400000,7,true,2026-03-01T12:30:00Z\n"+
+		"GB,,,,\n", got)
+}
+
+func TestJSONLWriter(t *testing.T) {
+	lines := strings.Split(strings.TrimSuffix(string(writeRows(t, FormatJSONL)), "\n"), "\n")
+	require.Len(t, lines, 2)
+
+	assert.Equal(t, `{"id":"GA","balance":12.3400000,"votes":7,"ready":true,"at":"2026-03-01T12:30:00Z"}`, lines[0])
+	assert.Equal(t, `{"id":"GB","balance":null,"votes":null,"ready":null,"at":null}`, lines[1])
+	for _, line := range lines {
+		assert.True(t, json.Valid([]byte(line)), line)
+	}
+}
+
+func TestParquetWriter(t *testing.T) {
+	data := writeRows(t, FormatParquet)
+
+	f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), f.NumRows())
+
+	// Leaf columns are ordered by name: at, balance, id, ready, votes
+	rows := make([]parquet.Row, 2)
+	n, _ := parquet.NewReader(f).ReadRows(rows)
+	require.Equal(t, 2, n)
+
+	first := rows[0]
+	assert.Equal(t, testAt.UnixMicro(), first[0].Int64())
+	assert.Equal(t, "12.3400000", first[1].String())
+	assert.Equal(t, "GA", first[2].String())
+	assert.True(t, first[3].Boolean())
+	assert.Equal(t, int64(7), first[4].Int64())
+
+	second := rows[1]
+	assert.Equal(t, "GB", second[2].String())
+	for _, i := range []int{0, 1, 3, 4} {
+		assert.True(t, second[i].IsNull(), "column %d", i)
+	}
+}
+
+func TestDirSink(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	sink, err := NewDirSink(dir)
+	require.NoError(t, err)
+
+	for _, name := range []string{"a.csv", "b.csv"} {
+		w, err := sink.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(name))
+		require.NoError(t, err)
+	}
+	require.NoError(t, sink.Close())
+
+	for _, name := range []string{"a.csv", "b.csv"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.Equal(t, name, string(data))
+	}
+}
@@ -0,0 +1,185 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Sink receives the exported files one after another: each table file, then
+// the manifest. A file is complete when the next one is created.
+// *zip.Writer is a Sink.
+type Sink interface {
+	Create(name string) (io.Writer, error)
+}
+
+// Exporter streams the dataset from PostgreSQL.
+type Exporter struct {
+	pool *pgxpool.Pool
+	now  func() time.Time
+}
+
+// NewExporter creates an exporter reading from pool.
+func NewExporter(pool *pgxpool.Pool) (*Exporter, error) {
+	if pool == nil {
+		return nil, errors.New("database pool is required")
+	}
+	return &Exporter{pool: pool, now: time.Now}, nil
+}
+
+// Export writes every table and then the manifest to sink. All tables are read
+// from one snapshot, so the export matches the sync run in the manifest even
+// if a sync finishes meanwhile.
+func (e *Exporter) Export(ctx context.Context, opts Options, sink Sink) (*Manifest, error) {
+	if _, err := ParseFormat(string(opts.Format)); err != nil {
+		return nil, err
+	}
+
+	tx, err := e.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("begin export transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(context.Background()) }()
+
+	manifest := &Manifest{
+		Format:          opts.Format,
+		GeneratedAt:     e.now().UTC(),
+		ExcludePersonal: opts.ExcludePersonal,
+	}
+	if err := readVersions(ctx, tx, manifest); err != nil {
+		return nil, err
+	}
+
+	for _, table := range Tables {
+		file := FileName(table.Name, opts.Format)
+		w, err := sink.Create(file)
+		if err != nil {
+			return nil, fmt.Errorf("create %s: %w", file, err)
+		}
+		rows, err := exportTable(ctx, tx, table, opts, w)
+		if err != nil {
+			return nil, fmt.Errorf("export %s: %w", table.Name, err)
+		}
+		manifest.Tables = append(manifest.Tables, TableManifest{
+			Name:    table.Name,
+			File:    file,
+			Rows:    rows,
+			Columns: table.Columns,
+		})
+	}
+
+	w, err := sink.Create(ManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("create manifest: %w", err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return nil, fmt.Errorf("write manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// readVersions fills in the schema version and the latest sync run.
+func readVersions(ctx context.Context, tx pgx.Tx, m *Manifest) error {
+	err := tx.QueryRow(ctx, "SELECT version_id FROM goose_db_version ORDER BY id DESC LIMIT 1").Scan(&m.SchemaVersion)
+	if err != nil {
+		return fmt.Errorf("query schema version: %w", err)
+	}
+
+	var finishedAt time.Time
+	err = tx.QueryRow(ctx, "SELECT id, finished_at FROM sync_runs WHERE account_id IS NULL ORDER BY id DESC LIMIT 1").Scan(&m.SyncRunID, &finishedAt)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return nil
+	case err != nil:
+		return fmt.Errorf("query latest sync run: %w", err)
+	}
+	finishedAt = finishedAt.UTC()
+	m.SyncedAt = &finishedAt
+	return nil
+}
+
+// exportTable streams one table to w and returns the number of rows written.
+func exportTable(ctx context.Context, tx pgx.Tx, table Table, opts Options, w io.Writer) (int64, error) {
+	query, args, err := table.query(opts).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("build query: %w", err)
+	}
+
+	out, err := newRowWriter(opts.Format, w, table)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("query rows: %w", err)
+	}
+	defer rows.Close()
+
+	var n int64
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return n, fmt.Errorf("read row: %w", err)
+		}
+		if err := out.Write(values); err != nil {
+			return n, fmt.Errorf("write row: %w", err)
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return n, fmt.Errorf("iterate rows: %w", err)
+	}
+
+	if err := out.Close(); err != nil {
+		return n, fmt.Errorf("flush: %w", err)
+	}
+	return n, nil
+}
+
+// DirSink writes exported files into a directory.
+type DirSink struct {
+	dir  string
+	file *os.File
+}
+
+// NewDirSink creates dir if needed and returns a sink writing into it.
+func NewDirSink(dir string) (*DirSink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create output directory: %w", err)
+	}
+	return &DirSink{dir: dir}, nil
+}
+
+// Create closes the previous file and creates the named one.
+func (s *DirSink) Create(name string) (io.Writer, error) {
+	if err := s.Close(); err != nil {
+		return nil, err
+	}
+	f, err := os.Create(filepath.Join(s.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	s.file = f
+	return f, nil
+}
+
+// Close closes the last created file.
+func (s *DirSink) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
@@ -0,0 +1,192 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetRowGroupRows bounds the rows a Parquet writer buffers before it
+// flushes a row group.
+const parquetRowGroupRows = 10000
+
+// rowWriter encodes the rows of one table.
+type rowWriter interface {
+	// Write encodes one row. values holds one value per column: nil, string,
+	// int64, bool or time.Time.
+	Write(values []any) error
+	// Close flushes buffered rows. It does not close the underlying writer.
+	Close() error
+}
+
+// newRowWriter returns a writer for the table's columns in the given format.
+func newRowWriter(format Format, w io.Writer, table Table) (rowWriter, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, table.Columns)
+	case FormatJSONL:
+		return &jsonlWriter{w: bufio.NewWriter(w), columns: table.Columns}, nil
+	case FormatParquet:
+		return newParquetWriter(w, table), nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// FileName returns the file a table is exported to.
+func FileName(table string, format Format) string {
+	return table + "." + string(format)
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+func newCSVWriter(w io.Writer, columns []Column) (*csvWriter, error) {
+	cw := &csvWriter{w: csv.NewWriter(w), record: make([]string, len(columns))}
+	for i, c := range columns {
+		cw.record[i] = c.Name
+	}
+	if err := cw.w.Write(cw.record); err != nil {
+		return nil, fmt.Errorf("write CSV header: %w", err)
+	}
+	return cw, nil
+}
+
+func (cw *csvWriter) Write(values []any) error {
+	for i, v := range values {
+		cw.record[i] = formatValue(v)
+	}
+	return cw.w.Write(cw.record)
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// formatValue renders a value as CSV text. NULL becomes an empty field.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+type jsonlWriter struct {
+	w       *bufio.Writer
+	columns []Column
+}
+
+// Write writes a JSON object with keys in column order. Decimals are written
+// as JSON numbers without going through float64, so no precision is lost.
+func (jw *jsonlWriter) Write(values []any) error {
+	jw.w.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			jw.w.WriteByte(',')
+		}
+		key, _ := json.Marshal(jw.columns[i].Name)
+		jw.w.Write(key)
+		jw.w.WriteByte(':')
+
+		if s, ok := v.(string); ok && jw.columns[i].Type == TypeDecimal {
+			jw.w.WriteString(s)
+			continue
+		}
+		if t, ok := v.(time.Time); ok {
+			v = t.UTC()
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", jw.columns[i].Name, err)
+		}
+		jw.w.Write(value)
+	}
+	jw.w.WriteByte('}')
+	return jw.w.WriteByte('\n')
+}
+
+func (jw *jsonlWriter) Close() error {
+	return jw.w.Flush()
+}
+
+type parquetWriter struct {
+	w *parquet.Writer
+	// leaves maps the schema's leaf columns, which parquet orders by name, to
+	// the table's column positions.
+	leaves []int
+	row    parquet.Row
+}
+
+func newParquetWriter(w io.Writer, table Table) *parquetWriter {
+	group := parquet.Group{}
+	positions := make(map[string]int, len(table.Columns))
+	for i, c := range table.Columns {
+		group[c.Name] = parquet.Optional(parquetNode(c.Type))
+		positions[c.Name] = i
+	}
+	schema := parquet.NewSchema(table.Name, group)
+
+	pw := &parquetWriter{
+		w: parquet.NewWriter(w, schema,
+			parquet.Compression(&parquet.Snappy),
+			parquet.MaxRowsPerRowGroup(parquetRowGroupRows),
+		),
+	}
+	for _, f := range schema.Fields() {
+		pw.leaves = append(pw.leaves, positions[f.Name()])
+	}
+	return pw
+}
+
+func parquetNode(t ColumnType) parquet.Node {
+	switch t {
+	case TypeInt:
+		return parquet.Int(64)
+	case TypeBool:
+		return parquet.Leaf(parquet.BooleanType)
+	case TypeTimestamp:
+		return parquet.Timestamp(parquet.Microsecond)
+	default:
+		return parquet.String()
+	}
+}
+
+func (pw *parquetWriter) Write(values []any) error {
+	pw.row = pw.row[:0]
+	for leaf, pos := range pw.leaves {
+		v := values[pos]
+		if v == nil {
+			pw.row = append(pw.row, parquet.Value{}.Level(0, 0, leaf))
+			continue
+		}
+		if t, ok := v.(time.Time); ok {
+			v = t.UnixMicro()
+		}
+		pw.row = append(pw.row, parquet.ValueOf(v).Level(0, 1, leaf))
+	}
+	_, err := pw.w.WriteRows([]parquet.Row{pw.row})
+	return err
+}
+
+func (pw *parquetWriter) Close() error {
+	return pw.w.Close()
+}
@@ -71,13 +71,20 @@ func (h *Handler) Account(w http.ResponseWriter, r *http.Request) {
 		accountInfo = nil
 	}
 
-	// Fetch operations with cursor-based pagination
+	// Fetch operations with cursor-based pagination, from the local index first.
+	// Accounts that are not tracked, and history older than the index, come from Horizon.
 	opsCursor := r.URL.Query().Get("ops_cursor")
 	const operationsLimit = 10
-	operations, err := h.stellar.GetAccountOperations(ctx, accountID, opsCursor, operationsLimit)
+	operations, err := h.accounts.GetAccountOperations(ctx, accountID, model.OperationFilter{}, opsCursor, operationsLimit)
 	if err != nil {
-		slog.Error("failed to fetch operations", "account_id", accountID, "error", err)
-		operations = nil
+		slog.Error("failed to fetch indexed operations, falling back to Horizon", "account_id", accountID, "error", err)
+	}
+	if err != nil || len(operations.Operations) == 0 {
+		operations, err = h.stellar.GetAccountOperations(ctx, accountID, opsCursor, operationsLimit)
+		if err != nil {
+			slog.Error("failed to fetch operations", "account_id", accountID, "error", err)
+			operations = nil
+		}
 	}
 
 	// Collect account IDs from operations for name lookup
@@ -104,6 +111,22 @@ func (h *Handler) Account(w http.ResponseWriter, r *http.Request) {
 	// Convert LP rows to display model
 	account.LPShares = convertLPShares(lpRows)
 
+	// Compare signers with declared ownership
+	// Continue without the control section on error
+	controlRows, err := h.accounts.GetAccountControl(ctx, accountID)
+	if err != nil {
+		slog.Error("failed to fetch account control", "account_id", accountID, "error", err)
+	} else {
+		h.resolveSignerNames(ctx, account)
+		account.Control = bsn.BuildAccountControl(account, controlRows)
+	}
+
+	// Fetch membership timeline indexed from issuer operations
+	account.Membership, err = h.accounts.GetMembershipTimeline(ctx, accountID)
+	if err != nil {
+		slog.Error("failed to fetch membership timeline", "account_id", accountID, "error", err)
+	}
+
 	// Separate NFTs from regular tokens (NFTs have balance == "0.0000001")
 	// FilterReject returns (kept, rejected) - we keep regular tokens, reject NFTs
 	tokens, nfts := lo.FilterReject(account.Trustlines, func(t model.Trustline, _ int) bool {
@@ -124,7 +147,7 @@ func (h *Handler) Account(w http.ResponseWriter, r *http.Request) {
 	})
 
 	// Process relationships into categories
-	account.Categories = bsn.GroupRelationships(accountID, relationships, confirmed)
+	account.Categories = bsn.GroupRelationships(h.relations, accountID, relationships, confirmed)
 
 	// Set XLM valuation for corporate accounts
 	if accountInfo != nil && accountInfo.MTLACBalance > 0 {
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mtlprog/lore/internal/handler/mocks"
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountHandlerIndexedOperations(t *testing.T) {
+	stellar := mocks.NewMockStellarServicer(t)
+	accounts := mocks.NewMockAccountQuerier(t)
+	tmpl := mocks.NewMockTemplateRenderer(t)
+
+	stellar.EXPECT().GetAccountDetail(mock.Anything, "GME").Return(&model.AccountDetail{ID: "GME"}, nil)
+	accounts.EXPECT().GetRelationships(mock.Anything, "GME").Return(nil, nil)
+	accounts.EXPECT().GetTrustRatings(mock.Anything, "GME").Return(&repository.TrustRating{}, nil)
+	accounts.EXPECT().GetConfirmedRelationships(mock.Anything, "GME").Return(nil, nil)
+	accounts.EXPECT().GetAccountInfo(mock.Anything, "GME").Return(&repository.AccountInfo{}, nil)
+	accounts.EXPECT().GetLPShares(mock.Anything, "GME").Return(nil, nil)
+	accounts.EXPECT().GetAccountControl(mock.Anything, "GME").Return(nil, nil)
+	accounts.EXPECT().GetMembershipTimeline(mock.Anything, "GME").Return(nil, nil)
+	accounts.EXPECT().GetAccountOperations(mock.Anything, "GME", model.OperationFilter{}, "900", 10).Return(&model.OperationsPage{
+		Operations: []model.Operation{{ID: "800", Type: "payment", From: "GBOB", To: "GME"}},
+		NextCursor: "800",
+		HasMore:    true,
+	}, nil)
+	accounts.EXPECT().GetAccountNames(mock.Anything, mock.Anything).Return(map[string]string{"GBOB": "Bob"}, nil)
+
+	var rendered AccountData
+	tmpl.EXPECT().Render(mock.Anything, "account.html", mock.Anything).Run(func(w io.Writer, name string, data any) {
+		rendered = data.(AccountData)
+	}).Return(nil)
+
+	h, err := New(stellar, accounts, nil, tmpl)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/GME?ops_cursor=900", nil)
+	req.SetPathValue("id", "GME")
+	w := httptest.NewRecorder()
+	h.Account(w, req)
+
+	// Horizon is not asked for operations: the mock fails on unexpected calls.
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, rendered.Operations)
+	assert.Len(t, rendered.Operations.Operations, 1)
+	assert.Equal(t, "800", rendered.Operations.NextCursor)
+	assert.Equal(t, "Bob", rendered.AccountNames["GBOB"])
+}
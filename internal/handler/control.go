@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/mtlprog/lore/internal/bsn"
+	"github.com/mtlprog/lore/internal/config"
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/samber/lo"
+)
+
+// Control handles the control graph page: who can sign for which company or the
+// issuer, compared with declared ownership. ?issues=1 shows only mismatches.
+func (h *Handler) Control(w http.ResponseWriter, r *http.Request) {
+	rows, err := h.accounts.GetControlGraph(r.Context(), config.TokenIssuer)
+	if err != nil {
+		slog.Error("failed to fetch control graph", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	data := model.ControlPageData{
+		Accounts:   bsn.BuildControlGraph(rows, config.TokenIssuer),
+		OnlyIssues: r.URL.Query().Get("issues") == "1",
+	}
+	for _, account := range data.Accounts {
+		data.Mismatches += account.Mismatches
+	}
+	if data.OnlyIssues {
+		data.Accounts = lo.Filter(data.Accounts, func(a model.ControlledAccount, _ int) bool {
+			return a.Mismatches > 0
+		})
+	}
+
+	buf := h.getBuffer()
+	defer h.putBuffer(buf)
+
+	if err := h.tmpl.Render(buf, "control.html", data); err != nil {
+		slog.Error("failed to render control template", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		slog.Debug("failed to write response", "error", err)
+	}
+}
+
+// resolveSignerNames fills in the account names of the signers of account.
+func (h *Handler) resolveSignerNames(ctx context.Context, account *model.AccountDetail) {
+	ids := lo.FilterMap(account.Signers, func(s model.Signer, _ int) (string, bool) {
+		return s.Key, s.Key != account.ID
+	})
+	if len(ids) == 0 {
+		return
+	}
+
+	names, err := h.accounts.GetAccountNames(ctx, ids)
+	if err != nil {
+		slog.Error("failed to fetch signer names", "account_id", account.ID, "error", err)
+		return
+	}
+	for i := range account.Signers {
+		account.Signers[i].Name = names[account.Signers[i].Key]
+	}
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mtlprog/lore/internal/config"
+	"github.com/mtlprog/lore/internal/handler/mocks"
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControlHandler(t *testing.T) {
+	rows := []repository.ControlRow{
+		{SignerID: "GALICE", AccountID: "GACME", AccountName: "Acme", Weight: 1, MedThreshold: 1, Ownership: "Owner"},
+		{SignerID: "GBOB", AccountID: "GBETA", AccountName: "Beta", Weight: 1, MedThreshold: 1},
+		{SignerID: "GCAROL", AccountID: config.TokenIssuer, AccountName: "MTLA", Weight: 1, MedThreshold: 2},
+	}
+
+	render := func(t *testing.T, target string) (*httptest.ResponseRecorder, model.ControlPageData) {
+		t.Helper()
+		accounts := mocks.NewMockAccountQuerier(t)
+		tmpl := mocks.NewMockTemplateRenderer(t)
+		accounts.EXPECT().GetControlGraph(mock.Anything, config.TokenIssuer).Return(rows, nil)
+
+		var rendered model.ControlPageData
+		tmpl.EXPECT().Render(mock.Anything, "control.html", mock.Anything).Run(func(w io.Writer, name string, data any) {
+			rendered = data.(model.ControlPageData)
+		}).Return(nil)
+
+		h, err := New(mocks.NewMockStellarServicer(t), accounts, nil, tmpl)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		h.Control(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w, rendered
+	}
+
+	t.Run("lists all controlled accounts", func(t *testing.T) {
+		w, data := render(t, "/control")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, data.Accounts, 3)
+		assert.Equal(t, 2, data.Mismatches)
+		assert.True(t, data.Accounts[2].IsIssuer)
+	})
+
+	t.Run("filters to mismatches", func(t *testing.T) {
+		_, data := render(t, "/control?issues=1")
+
+		assert.True(t, data.OnlyIssues)
+		assert.Equal(t, 2, data.Mismatches)
+		require.Len(t, data.Accounts, 2)
+		assert.Equal(t, "GBETA", data.Accounts[0].AccountID)
+	})
+
+	t.Run("database error returns 500", func(t *testing.T) {
+		accounts := mocks.NewMockAccountQuerier(t)
+		accounts.EXPECT().GetControlGraph(mock.Anything, config.TokenIssuer).Return(nil, errors.New("database error"))
+
+		h, err := New(mocks.NewMockStellarServicer(t), accounts, nil, mocks.NewMockTemplateRenderer(t))
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		h.Control(w, httptest.NewRequest(http.MethodGet, "/control", nil))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestAccountHandlerControl(t *testing.T) {
+	stellar := mocks.NewMockStellarServicer(t)
+	accounts := mocks.NewMockAccountQuerier(t)
+	tmpl := mocks.NewMockTemplateRenderer(t)
+
+	stellar.EXPECT().GetAccountDetail(mock.Anything, "GACME").Return(&model.AccountDetail{
+		ID:         "GACME",
+		Name:       "Acme",
+		Signers:    []model.Signer{{Key: "GALICE", Weight: 1}, {Key: "GBOB", Weight: 1}},
+		Thresholds: model.Thresholds{Med: 2},
+	}, nil)
+	accounts.EXPECT().GetAccountOperations(mock.Anything, "GACME", model.OperationFilter{}, "", 10).Return(nil, errors.New("database error"))
+	stellar.EXPECT().GetAccountOperations(mock.Anything, "GACME", "", 10).Return(nil, nil)
+	accounts.EXPECT().GetRelationships(mock.Anything, "GACME").Return(nil, nil)
+	accounts.EXPECT().GetTrustRatings(mock.Anything, "GACME").Return(&repository.TrustRating{}, nil)
+	accounts.EXPECT().GetConfirmedRelationships(mock.Anything, "GACME").Return(nil, nil)
+	accounts.EXPECT().GetAccountInfo(mock.Anything, "GACME").Return(&repository.AccountInfo{}, nil)
+	accounts.EXPECT().GetLPShares(mock.Anything, "GACME").Return(nil, nil)
+	accounts.EXPECT().GetAccountControl(mock.Anything, "GACME").Return([]repository.ControlRow{
+		{SignerID: "GALICE", AccountID: "GACME", Weight: 1, MedThreshold: 2, Ownership: "OwnershipMajority"},
+	}, nil)
+	accounts.EXPECT().GetMembershipTimeline(mock.Anything, "GACME").Return(nil, errors.New("database error"))
+	accounts.EXPECT().GetAccountNames(mock.Anything, []string{"GALICE", "GBOB"}).
+		Return(map[string]string{"GALICE": "Alice", "GBOB": "Bob"}, nil)
+
+	var rendered AccountData
+	tmpl.EXPECT().Render(mock.Anything, "account.html", mock.Anything).Run(func(w io.Writer, name string, data any) {
+		rendered = data.(AccountData)
+	}).Return(nil)
+
+	h, err := New(stellar, accounts, nil, tmpl)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/GACME", nil)
+	req.SetPathValue("id", "GACME")
+	h.Account(httptest.NewRecorder(), req)
+
+	control := rendered.Account.Control
+	require.NotNil(t, control)
+	require.Len(t, control.Signers, 2)
+	assert.Equal(t, "Alice", control.Signers[0].SignerName)
+	assert.Equal(t, model.ControlDeclared, control.Signers[0].Status)
+	assert.Equal(t, "Bob", control.Signers[1].SignerName)
+	assert.Equal(t, model.ControlUndeclared, control.Signers[1].Status)
+}
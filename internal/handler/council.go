@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/mtlprog/lore/internal/config"
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/service"
+)
+
+// Council handles GET /council - compares the issuer's signers with the council
+// computed from delegated votes.
+func (h *Handler) Council(w http.ResponseWriter, r *http.Request) {
+	rec, ok := h.reconcileCouncil(r.Context(), w)
+	if !ok {
+		return
+	}
+	h.renderCouncil(w, model.CouncilPageData{Reconciliation: rec})
+}
+
+// CouncilXDR handles POST /council - generates the SetOptions transactions that
+// bring the issuer's signers in line with the council.
+func (h *Handler) CouncilXDR(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec, ok := h.reconcileCouncil(ctx, w)
+	if !ok {
+		return
+	}
+	data := model.CouncilPageData{Reconciliation: rec}
+
+	seqNum, err := h.stellar.GetAccountSequence(ctx, rec.IssuerID)
+	if err != nil {
+		slog.Error("failed to fetch account sequence", "account_id", rec.IssuerID, "error", err)
+		data.Error = "Could not connect to Stellar network. Please try again."
+		h.renderCouncil(w, data)
+		return
+	}
+
+	txs, err := h.xdrBuilder(ctx).GenerateCouncilXDR(rec, seqNum+1)
+	if err != nil {
+		data.Error = err.Error()
+		h.renderCouncil(w, data)
+		return
+	}
+	h.fillSigningLinks(rec.IssuerID, txs, "Update MTLA council signers")
+	data.Transactions = txs
+	h.renderCouncil(w, data)
+}
+
+// reconcileCouncil loads the issuer and the council candidates. It writes an
+// error response and returns false if either could not be loaded.
+func (h *Handler) reconcileCouncil(ctx context.Context, w http.ResponseWriter) (*model.CouncilReconciliation, bool) {
+	issuer, err := h.stellar.GetAccountDetail(ctx, config.TokenIssuer)
+	if err != nil {
+		slog.Error("failed to fetch issuer", "account_id", config.TokenIssuer, "error", err)
+		http.Error(w, "Failed to fetch issuer account", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	candidates, err := h.accounts.GetCouncilCandidates(ctx, h.council)
+	if err != nil {
+		slog.Error("failed to fetch council candidates", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	h.resolveSignerNames(ctx, issuer)
+	return service.ReconcileCouncil(issuer, candidates, h.council), true
+}
+
+// renderCouncil renders the council reconciliation page.
+func (h *Handler) renderCouncil(w http.ResponseWriter, data model.CouncilPageData) {
+	buf := h.getBuffer()
+	defer h.putBuffer(buf)
+
+	if err := h.tmpl.Render(buf, "council.html", data); err != nil {
+		slog.Error("failed to render council template", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		slog.Debug("failed to write response", "error", err)
+	}
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mtlprog/lore/internal/config"
+	"github.com/mtlprog/lore/internal/handler/mocks"
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCouncilHandler(t *testing.T) {
+	alice, bob := keypair.MustRandom().Address(), keypair.MustRandom().Address()
+
+	setup := func(t *testing.T) (*Handler, *mocks.MockStellarServicer, *model.CouncilPageData) {
+		t.Helper()
+		stellar := mocks.NewMockStellarServicer(t)
+		accounts := mocks.NewMockAccountQuerier(t)
+		tmpl := mocks.NewMockTemplateRenderer(t)
+
+		stellar.EXPECT().GetAccountDetail(mock.Anything, config.TokenIssuer).Return(&model.AccountDetail{
+			ID:         config.TokenIssuer,
+			Signers:    []model.Signer{{Key: bob, Type: "ed25519_public_key", Weight: 1}},
+			Thresholds: model.Thresholds{High: 1},
+		}, nil)
+		accounts.EXPECT().GetCouncilCandidates(mock.Anything, 2).
+			Return([]model.CouncilCandidate{{AccountID: alice, Name: "Alice", Votes: 50}}, nil)
+		accounts.EXPECT().GetAccountNames(mock.Anything, []string{bob}).Return(map[string]string{bob: "Bob"}, nil)
+
+		rendered := &model.CouncilPageData{}
+		tmpl.EXPECT().Render(mock.Anything, "council.html", mock.Anything).Run(func(w io.Writer, name string, data any) {
+			*rendered = data.(model.CouncilPageData)
+		}).Return(nil)
+
+		h, err := New(stellar, accounts, nil, tmpl, WithCouncilSize(2))
+		require.NoError(t, err)
+		return h, stellar, rendered
+	}
+
+	t.Run("report lists changes", func(t *testing.T) {
+		h, _, rendered := setup(t)
+
+		w := httptest.NewRecorder()
+		h.Council(w, httptest.NewRequest(http.MethodGet, "/council", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		rec := rendered.Reconciliation
+		require.NotNil(t, rec)
+		require.Len(t, rec.Changes, 2)
+		assert.Equal(t, model.CouncilAdd, rec.Changes[0].Action)
+		assert.Equal(t, int32(2), rec.Changes[0].TargetWeight)
+		assert.Equal(t, model.CouncilRemove, rec.Changes[1].Action)
+		assert.Equal(t, "Bob", rec.Changes[1].Name)
+		assert.Empty(t, rendered.Transactions)
+	})
+
+	t.Run("generates XDR", func(t *testing.T) {
+		h, stellar, rendered := setup(t)
+		stellar.EXPECT().SuggestBaseFee(mock.Anything, mock.Anything, mock.Anything).Return(int64(100))
+		stellar.EXPECT().GetAccountSequence(mock.Anything, config.TokenIssuer).Return(int64(41), nil)
+
+		w := httptest.NewRecorder()
+		h.CouncilXDR(w, httptest.NewRequest(http.MethodPost, "/council", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, rendered.Error)
+		require.Len(t, rendered.Transactions, 1)
+		assert.Equal(t, int64(42), rendered.Transactions[0].Sequence)
+		assert.Len(t, rendered.Transactions[0].Operations, 2)
+		assert.NotEmpty(t, rendered.Transactions[0].LabLink)
+	})
+
+	t.Run("sequence error is shown on the page", func(t *testing.T) {
+		h, stellar, rendered := setup(t)
+		stellar.EXPECT().GetAccountSequence(mock.Anything, config.TokenIssuer).Return(int64(0), errors.New("horizon down"))
+
+		h.CouncilXDR(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/council", nil))
+
+		assert.Contains(t, rendered.Error, "Could not connect")
+		assert.Empty(t, rendered.Transactions)
+	})
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"cmp"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/samber/lo"
+)
+
+const (
+	// economyMonths is how many months, including the current one, the economy page covers.
+	economyMonths = 12
+	// economyEdges is how many of the largest flows the economy page lists.
+	economyEdges = 50
+)
+
+// Economy handles the internal economy page: monthly payment volume between
+// tracked accounts and the largest flows. ?asset=CODE:ISSUER narrows it to one
+// asset; a bare code matches every issuer of that code.
+func (h *Handler) Economy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	now := time.Now().UTC()
+	filter := model.FlowFilter{
+		Since: time.Date(now.Year(), now.Month()-economyMonths+1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// Periods are loaded for all assets so the asset selector lists every asset
+	periods, err := h.accounts.GetFlowPeriods(ctx, filter)
+	if err != nil {
+		slog.Error("failed to fetch flow periods", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	selected := model.ParseFlowAsset(r.URL.Query().Get("asset"))
+	filter.AssetCode, filter.AssetIssuer = selected.Code, selected.Issuer
+	edges, err := h.accounts.GetFlowEdges(ctx, filter, economyEdges)
+	if err != nil {
+		slog.Error("failed to fetch flow edges", "asset", selected.Key(), "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	assets := lo.Uniq(lo.Map(periods, func(p model.FlowPeriod, _ int) model.FlowAsset { return p.Asset() }))
+	slices.SortFunc(assets, func(a, b model.FlowAsset) int {
+		return cmp.Or(cmp.Compare(a.Code, b.Code), cmp.Compare(a.Issuer, b.Issuer))
+	})
+
+	data := model.EconomyPageData{
+		Asset:   selected,
+		Assets:  assets,
+		Periods: periods,
+		Edges:   edges,
+	}
+	if filter.AssetCode != "" {
+		data.Periods = lo.Filter(periods, func(p model.FlowPeriod, _ int) bool {
+			return p.AssetCode == filter.AssetCode && (filter.AssetIssuer == "" || p.AssetIssuer == filter.AssetIssuer)
+		})
+	}
+
+	buf := h.getBuffer()
+	defer h.putBuffer(buf)
+
+	if err := h.tmpl.Render(buf, "economy.html", data); err != nil {
+		slog.Error("failed to render economy template", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		slog.Debug("failed to write response", "error", err)
+	}
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mtlprog/lore/internal/handler/mocks"
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEconomyHandler(t *testing.T) {
+	periods := []model.FlowPeriod{
+		{AssetCode: "MTL", AssetIssuer: "GISSUER", Volume: 10},
+		{AssetCode: "EURMTL", AssetIssuer: "GISSUER", Volume: 500},
+		{AssetCode: "EURMTL", AssetIssuer: "GISSUER", Volume: 700},
+		{AssetCode: "EURMTL", AssetIssuer: "GFAKE", Volume: 900},
+		{AssetCode: "XLM", Volume: 30},
+	}
+
+	t.Run("filters by asset", func(t *testing.T) {
+		accounts := mocks.NewMockAccountQuerier(t)
+		tmpl := mocks.NewMockTemplateRenderer(t)
+
+		accounts.EXPECT().GetFlowPeriods(mock.Anything, mock.MatchedBy(func(f model.FlowFilter) bool {
+			return f.AssetCode == "" && !f.Since.IsZero()
+		})).Return(periods, nil)
+		accounts.EXPECT().GetFlowEdges(mock.Anything, mock.MatchedBy(func(f model.FlowFilter) bool {
+			return f.AssetCode == "EURMTL" && f.AssetIssuer == "GISSUER"
+		}), economyEdges).Return([]model.FlowEdge{{FromID: "GALICE", ToID: "GACME", AssetCode: "EURMTL", AssetIssuer: "GISSUER"}}, nil)
+
+		var rendered model.EconomyPageData
+		tmpl.EXPECT().Render(mock.Anything, "economy.html", mock.Anything).Run(func(w io.Writer, name string, data any) {
+			rendered = data.(model.EconomyPageData)
+		}).Return(nil)
+
+		h, err := New(mocks.NewMockStellarServicer(t), accounts, nil, tmpl)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		h.Economy(w, httptest.NewRequest(http.MethodGet, "/economy?asset=EURMTL:GISSUER", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []model.FlowAsset{
+			{Code: "EURMTL", Issuer: "GFAKE"},
+			{Code: "EURMTL", Issuer: "GISSUER"},
+			{Code: "MTL", Issuer: "GISSUER"},
+			{Code: "XLM"},
+		}, rendered.Assets)
+		assert.Equal(t, model.FlowAsset{Code: "EURMTL", Issuer: "GISSUER"}, rendered.Asset)
+		assert.Len(t, rendered.Periods, 2, "the look-alike EURMTL is excluded")
+		assert.Len(t, rendered.Edges, 1)
+	})
+
+	t.Run("bare code matches every issuer", func(t *testing.T) {
+		accounts := mocks.NewMockAccountQuerier(t)
+		tmpl := mocks.NewMockTemplateRenderer(t)
+
+		accounts.EXPECT().GetFlowPeriods(mock.Anything, mock.Anything).Return(periods, nil)
+		accounts.EXPECT().GetFlowEdges(mock.Anything, mock.MatchedBy(func(f model.FlowFilter) bool {
+			return f.AssetCode == "EURMTL" && f.AssetIssuer == ""
+		}), economyEdges).Return(nil, nil)
+
+		var rendered model.EconomyPageData
+		tmpl.EXPECT().Render(mock.Anything, "economy.html", mock.Anything).Run(func(w io.Writer, name string, data any) {
+			rendered = data.(model.EconomyPageData)
+		}).Return(nil)
+
+		h, err := New(mocks.NewMockStellarServicer(t), accounts, nil, tmpl)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		h.Economy(w, httptest.NewRequest(http.MethodGet, "/economy?asset=EURMTL", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, rendered.Periods, 3)
+	})
+
+	t.Run("database error returns 500", func(t *testing.T) {
+		accounts := mocks.NewMockAccountQuerier(t)
+		accounts.EXPECT().GetFlowPeriods(mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+		h, err := New(mocks.NewMockStellarServicer(t), accounts, nil, mocks.NewMockTemplateRenderer(t))
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		h.Economy(w, httptest.NewRequest(http.MethodGet, "/economy", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -9,9 +9,14 @@ import (
 	"io"
 	"net/http"
 	"sync"
+	"time"
 
+	"github.com/mtlprog/lore/internal/config"
+	"github.com/mtlprog/lore/internal/delegation"
 	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/relation"
 	"github.com/mtlprog/lore/internal/repository"
+	"github.com/mtlprog/lore/internal/service"
 )
 
 // StellarServicer defines the interface for Stellar blockchain operations.
@@ -25,23 +30,37 @@ type StellarServicer interface {
 	FetchStellarToml(ctx context.Context, homeDomain string) (*model.StellarTomlCurrency, string, error)
 	GetRawAccountData(ctx context.Context, accountID string) (map[string]string, error)
 	GetAccountSequence(ctx context.Context, accountID string) (int64, error)
+	SuggestBaseFee(ctx context.Context, strategy service.FeeStrategy, maxBaseFee int64) int64
+	GetSigningRequirement(ctx context.Context, accountID string) (*model.SigningRequirement, error)
 }
 
 // AccountQuerier defines the interface for account data access.
 type AccountQuerier interface {
 	GetStats(ctx context.Context) (*repository.Stats, error)
 	GetPersons(ctx context.Context, limit int, offset int) ([]repository.PersonRow, error)
+	GetPersonsPage(ctx context.Context, cursor string, limit int) ([]repository.PersonRow, repository.KeysetPage, error)
 	GetCorporate(ctx context.Context, limit int, offset int) ([]repository.CorporateRow, error)
+	GetCorporatePage(ctx context.Context, cursor string, limit int) ([]repository.CorporateRow, repository.KeysetPage, error)
 	GetSynthetic(ctx context.Context, limit int, offset int) ([]repository.SyntheticRow, error)
+	GetSyntheticPage(ctx context.Context, cursor string, limit int) ([]repository.SyntheticRow, repository.KeysetPage, error)
 	GetRelationships(ctx context.Context, accountID string) ([]repository.RelationshipRow, error)
 	GetTrustRatings(ctx context.Context, accountID string) (*repository.TrustRating, error)
 	GetConfirmedRelationships(ctx context.Context, accountID string) (map[string]bool, error)
 	GetAccountInfo(ctx context.Context, accountID string) (*repository.AccountInfo, error)
 	GetAccountNames(ctx context.Context, accountIDs []string) (map[string]string, error)
+	GetDelegationInfo(ctx context.Context) (map[string]*delegation.Info, error)
 	GetAllTags(ctx context.Context) ([]repository.TagRow, error)
 	SearchAccounts(ctx context.Context, query string, tags []string, limit int, offset int, sortBy repository.SearchSortOrder) ([]repository.SearchAccountRow, error)
 	CountSearchAccounts(ctx context.Context, query string, tags []string) (int, error)
 	GetLPShares(ctx context.Context, accountID string) ([]repository.LPShareRow, error)
+	GetAccountControl(ctx context.Context, accountID string) ([]repository.ControlRow, error)
+	GetControlGraph(ctx context.Context, issuer string) ([]repository.ControlRow, error)
+	GetCouncilCandidates(ctx context.Context, limit int) ([]model.CouncilCandidate, error)
+	GetMembershipTimeline(ctx context.Context, accountID string) ([]model.MembershipEvent, error)
+	GetNewMembers(ctx context.Context, since time.Time, limit int) ([]model.MembershipEvent, error)
+	GetAccountOperations(ctx context.Context, accountID string, filter model.OperationFilter, cursor string, limit int) (*model.OperationsPage, error)
+	GetFlowEdges(ctx context.Context, filter model.FlowFilter, limit int) ([]model.FlowEdge, error)
+	GetFlowPeriods(ctx context.Context, filter model.FlowFilter) ([]model.FlowPeriod, error)
 }
 
 // ReputationQuerier defines the interface for reputation data access.
@@ -50,6 +69,20 @@ type ReputationQuerier interface {
 	GetGraph(ctx context.Context, accountID string) (*model.ReputationGraph, error)
 }
 
+// TxSubmitter defines the interface for submitting signed init transactions.
+// Only transactions passed to Remember can be submitted.
+type TxSubmitter interface {
+	Remember(ctx context.Context, accountID string, txs []model.InitTransaction) error
+	Submit(ctx context.Context, signedXDR, expectedXDR string) (*model.SubmitResult, error)
+}
+
+// CollectionStore defines the interface for signature collection storage.
+type CollectionStore interface {
+	CreateCollection(ctx context.Context, c *model.SignatureCollection) error
+	GetCollection(ctx context.Context, id string) (*model.SignatureCollection, error)
+	UpdateCollectionEnvelope(ctx context.Context, id, previous, envelope string) (bool, error)
+}
+
 // TemplateRenderer defines the interface for template rendering.
 type TemplateRenderer interface {
 	Render(w io.Writer, name string, data any) error
@@ -61,13 +94,72 @@ type Handler struct {
 	accounts   AccountQuerier
 	reputation ReputationQuerier
 	tmpl       TemplateRenderer
-	bufferPool *sync.Pool // Pool of bytes.Buffer for template rendering
+	txURIs     *service.TxURIBuilder // SEP-0007 wallet links on transaction previews
+	submitter  TxSubmitter           // Optional: submission of signed transactions
+	txSettings service.TxSettings    // Fees and time bounds of generated transactions
+	collection CollectionStore       // Optional: shareable signature collection pages
+	council    int                   // Number of council seats on the issuer
+	relations  *relation.Registry    // BSN relation types of forms and account pages
+	bufferPool *sync.Pool            // Pool of bytes.Buffer for template rendering
+}
+
+// Option configures optional Handler dependencies.
+type Option func(*Handler)
+
+// WithTxURIBuilder sets the SEP-0007 URI builder used on transaction previews.
+// Without it, previews get unsigned URIs without callback or origin domain.
+func WithTxURIBuilder(b *service.TxURIBuilder) Option {
+	return func(h *Handler) {
+		if b != nil {
+			h.txURIs = b
+		}
+	}
+}
+
+// WithTxSubmitter enables submission of signed transactions at /init/submit.
+func WithTxSubmitter(s TxSubmitter) Option {
+	return func(h *Handler) {
+		h.submitter = s
+	}
+}
+
+// WithTxSettings sets the fee strategy and validity of generated transactions.
+// Without it, service.DefaultTxSettings are used.
+func WithTxSettings(settings service.TxSettings) Option {
+	return func(h *Handler) {
+		h.txSettings = settings
+	}
+}
+
+// WithCollectionStore enables signature collection pages at /init/collect.
+func WithCollectionStore(s CollectionStore) Option {
+	return func(h *Handler) {
+		h.collection = s
+	}
+}
+
+// WithCouncilSize sets the number of council seats the issuer's signers are
+// reconciled against. Without it, config.DefaultCouncilSize is used.
+func WithCouncilSize(size int) Option {
+	return func(h *Handler) {
+		h.council = size
+	}
+}
+
+// WithRelationTypes sets the BSN relation types used by the relationship editor,
+// transaction decoding and account pages. Without it, no relation type is known.
+func WithRelationTypes(types *relation.Registry) Option {
+	return func(h *Handler) {
+		if types != nil {
+			h.relations = types
+		}
+	}
 }
 
 // New creates a new Handler with the given dependencies.
 // Returns error if any required dependency is nil.
 // reputation can be nil (feature is optional).
-func New(stellar StellarServicer, accounts AccountQuerier, reputation ReputationQuerier, tmpl TemplateRenderer) (*Handler, error) {
+func New(stellar StellarServicer, accounts AccountQuerier, reputation ReputationQuerier, tmpl TemplateRenderer, opts ...Option) (*Handler, error) {
 	if stellar == nil {
 		return nil, errors.New("stellar service is required")
 	}
@@ -77,17 +169,30 @@ func New(stellar StellarServicer, accounts AccountQuerier, reputation Reputation
 	if tmpl == nil {
 		return nil, errors.New("templates are required")
 	}
-	return &Handler{
+	txURIs, err := service.NewTxURIBuilder(service.TxURIConfig{})
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Handler{
 		stellar:    stellar,
 		accounts:   accounts,
 		reputation: reputation,
 		tmpl:       tmpl,
+		txURIs:     txURIs,
+		txSettings: service.DefaultTxSettings(),
+		council:    config.DefaultCouncilSize,
+		relations:  relation.NewRegistry(nil),
 		bufferPool: &sync.Pool{
 			New: func() interface{} {
 				return new(bytes.Buffer)
 			},
 		},
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h, nil
 }
 
 // getBuffer retrieves a buffer from the pool.
@@ -109,6 +214,10 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /transactions/{hash}", h.Transaction)
 	mux.HandleFunc("GET /search", h.Search)
 	mux.HandleFunc("GET /tokens/{issuer}/{code}", h.Token)
+	mux.HandleFunc("GET /control", h.Control)
+	mux.HandleFunc("GET /council", h.Council)
+	mux.HandleFunc("POST /council", h.CouncilXDR)
+	mux.HandleFunc("GET /economy", h.Economy)
 
 	// Init form routes
 	mux.HandleFunc("GET /init", h.InitLanding)
@@ -116,6 +225,21 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /init/participant", h.InitParticipantSubmit)
 	mux.HandleFunc("GET /init/corporate", h.InitCorporate)
 	mux.HandleFunc("POST /init/corporate", h.InitCorporateSubmit)
+	mux.HandleFunc("GET /init/delegation", h.InitDelegation)
+	mux.HandleFunc("POST /init/delegation", h.InitDelegationSubmit)
+	mux.HandleFunc("POST /init/submit", h.InitSubmit)
+	mux.HandleFunc("POST /init/collect", h.InitCollectCreate)
+	mux.HandleFunc("GET /init/collect/{id}", h.InitCollect)
+	mux.HandleFunc("POST /init/collect/{id}", h.InitCollectUpload)
+
+	// Tools
+	mux.HandleFunc("GET /tools/xdr", h.XDRTool)
+	mux.HandleFunc("POST /tools/xdr", h.XDRTool)
+
+	// Publish the SEP-0007 signing key so wallets can verify origin_domain
+	if h.txURIs.SigningKey() != "" {
+		mux.HandleFunc("GET /.well-known/stellar.toml", h.StellarToml)
+	}
 }
 
 // RegisterStaticRoutes registers routes for static files (favicon, og-image, etc.).
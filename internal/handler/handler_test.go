@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -12,8 +13,11 @@ import (
 	"github.com/mtlprog/lore/internal/config"
 	"github.com/mtlprog/lore/internal/handler/mocks"
 	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/relation/relationtest"
 	"github.com/mtlprog/lore/internal/repository"
+	"github.com/mtlprog/lore/internal/service"
 	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/keypair"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -70,16 +74,19 @@ func TestHomeHandler(t *testing.T) {
 			TotalXLMValue:  1000000.0,
 		}, nil)
 
-		accounts.EXPECT().GetPersons(mock.Anything, mock.Anything, mock.Anything).Return([]repository.PersonRow{
+		accounts.EXPECT().GetPersonsPage(mock.Anything, mock.Anything, mock.Anything).Return([]repository.PersonRow{
 			{AccountID: "GABC", Name: "Test Person", MTLAPBalance: 100.0},
-		}, nil)
+		}, repository.KeysetPage{}, nil)
 
-		accounts.EXPECT().GetCorporate(mock.Anything, mock.Anything, mock.Anything).Return([]repository.CorporateRow{
+		accounts.EXPECT().GetCorporatePage(mock.Anything, mock.Anything, mock.Anything).Return([]repository.CorporateRow{
 			{AccountID: "GDEF", Name: "Test Company", MTLACBalance: 50.0, TotalXLMValue: 5000.0},
-		}, nil)
+		}, repository.KeysetPage{}, nil)
 
-		accounts.EXPECT().GetSynthetic(mock.Anything, mock.Anything, mock.Anything).Return([]repository.SyntheticRow{
+		accounts.EXPECT().GetSyntheticPage(mock.Anything, mock.Anything, mock.Anything).Return([]repository.SyntheticRow{
 			{AccountID: "GHIJ", Name: "Test Synthetic", MTLAXBalance: 1.0, ReputationScore: 3.5, ReputationWeight: 10.0},
+		}, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetNewMembers(mock.Anything, mock.Anything, config.DefaultPageLimit).Return([]model.MembershipEvent{
+			{AccountID: "GNEW", AccountName: "Newcomer", AssetCode: "MTLAP", Type: model.MembershipReceived},
 		}, nil)
 
 		var renderedData any
@@ -104,6 +111,31 @@ func TestHomeHandler(t *testing.T) {
 		assert.Len(t, homeData.Synthetic, 1)
 		assert.Equal(t, "A", homeData.Synthetic[0].ReputationGrade)
 		assert.Equal(t, 10.0, homeData.Synthetic[0].ReputationWeight)
+		require.Len(t, homeData.NewMembers, 1)
+		assert.Equal(t, "Newcomer", homeData.NewMembers[0].AccountName)
+	})
+
+	t.Run("list cursors passed through", func(t *testing.T) {
+		accounts := mocks.NewMockAccountQuerier(t)
+		stellar := mocks.NewMockStellarServicer(t)
+		tmpl := mocks.NewMockTemplateRenderer(t)
+
+		accounts.EXPECT().GetStats(mock.Anything).Return(&repository.Stats{}, nil)
+		accounts.EXPECT().GetPersonsPage(mock.Anything, "p1", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetCorporatePage(mock.Anything, "c1", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetSyntheticPage(mock.Anything, "", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetNewMembers(mock.Anything, mock.Anything, config.DefaultPageLimit).Return(nil, nil)
+		tmpl.EXPECT().Render(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		h, err := New(stellar, accounts, nil, tmpl)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/?persons_cursor=p1&corporate_cursor=c1", nil)
+		w := httptest.NewRecorder()
+
+		h.Home(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
 	t.Run("pagination parameters parsed correctly", func(t *testing.T) {
@@ -112,10 +144,11 @@ func TestHomeHandler(t *testing.T) {
 		tmpl := mocks.NewMockTemplateRenderer(t)
 
 		accounts.EXPECT().GetStats(mock.Anything).Return(&repository.Stats{}, nil)
-		// Expect offset 20 for persons, 40 for corporate, 0 for synthetic
-		accounts.EXPECT().GetPersons(mock.Anything, mock.Anything, 20).Return(nil, nil)
-		accounts.EXPECT().GetCorporate(mock.Anything, mock.Anything, 40).Return(nil, nil)
-		accounts.EXPECT().GetSynthetic(mock.Anything, mock.Anything, 0).Return(nil, nil)
+		// Expect offset 20 for persons, 40 for corporate, the first keyset page for synthetic
+		accounts.EXPECT().GetPersons(mock.Anything, config.DefaultPageLimit+1, 20).Return(nil, nil)
+		accounts.EXPECT().GetCorporate(mock.Anything, config.DefaultPageLimit+1, 40).Return(nil, nil)
+		accounts.EXPECT().GetSyntheticPage(mock.Anything, "", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetNewMembers(mock.Anything, mock.Anything, config.DefaultPageLimit).Return(nil, nil)
 		tmpl.EXPECT().Render(mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 		h, err := New(stellar, accounts, nil, tmpl)
@@ -135,9 +168,10 @@ func TestHomeHandler(t *testing.T) {
 		tmpl := mocks.NewMockTemplateRenderer(t)
 
 		accounts.EXPECT().GetStats(mock.Anything).Return(&repository.Stats{}, nil)
-		accounts.EXPECT().GetPersons(mock.Anything, mock.Anything, 0).Return(nil, nil)
-		accounts.EXPECT().GetCorporate(mock.Anything, mock.Anything, 0).Return(nil, nil)
-		accounts.EXPECT().GetSynthetic(mock.Anything, mock.Anything, 0).Return(nil, nil)
+		accounts.EXPECT().GetPersonsPage(mock.Anything, "", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetCorporatePage(mock.Anything, "", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetSyntheticPage(mock.Anything, "", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetNewMembers(mock.Anything, mock.Anything, config.DefaultPageLimit).Return(nil, nil)
 		tmpl.EXPECT().Render(mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 		h, err := New(stellar, accounts, nil, tmpl)
@@ -148,7 +182,7 @@ func TestHomeHandler(t *testing.T) {
 
 		h.Home(w, req)
 
-		// Test passes if GetPersons was called with offset 0
+		// Test passes if the first persons page was requested
 	})
 
 	t.Run("invalid offset defaults to zero", func(t *testing.T) {
@@ -157,9 +191,10 @@ func TestHomeHandler(t *testing.T) {
 		tmpl := mocks.NewMockTemplateRenderer(t)
 
 		accounts.EXPECT().GetStats(mock.Anything).Return(&repository.Stats{}, nil)
-		accounts.EXPECT().GetPersons(mock.Anything, mock.Anything, 0).Return(nil, nil)
-		accounts.EXPECT().GetCorporate(mock.Anything, mock.Anything, 0).Return(nil, nil)
-		accounts.EXPECT().GetSynthetic(mock.Anything, mock.Anything, 0).Return(nil, nil)
+		accounts.EXPECT().GetPersonsPage(mock.Anything, "", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetCorporatePage(mock.Anything, "", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetSyntheticPage(mock.Anything, "", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetNewMembers(mock.Anything, mock.Anything, config.DefaultPageLimit).Return(nil, nil)
 		tmpl.EXPECT().Render(mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 		h, err := New(stellar, accounts, nil, tmpl)
@@ -170,7 +205,87 @@ func TestHomeHandler(t *testing.T) {
 
 		h.Home(w, req)
 
-		// Test passes if GetPersons was called with offset 0
+		// Test passes if the first persons page was requested
+	})
+
+	t.Run("cursor takes precedence over offset", func(t *testing.T) {
+		accounts := mocks.NewMockAccountQuerier(t)
+		stellar := mocks.NewMockStellarServicer(t)
+		tmpl := mocks.NewMockTemplateRenderer(t)
+
+		accounts.EXPECT().GetStats(mock.Anything).Return(&repository.Stats{}, nil)
+		accounts.EXPECT().GetPersonsPage(mock.Anything, "p1", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetCorporatePage(mock.Anything, "", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetSyntheticPage(mock.Anything, "", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetNewMembers(mock.Anything, mock.Anything, config.DefaultPageLimit).Return(nil, nil)
+		tmpl.EXPECT().Render(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		h, err := New(stellar, accounts, nil, tmpl)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/?persons_cursor=p1&persons_offset=20", nil)
+		w := httptest.NewRecorder()
+
+		h.Home(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("offset paging links", func(t *testing.T) {
+		accounts := mocks.NewMockAccountQuerier(t)
+		stellar := mocks.NewMockStellarServicer(t)
+		tmpl := mocks.NewMockTemplateRenderer(t)
+
+		// Return 21 items (more than DefaultPageLimit of 20)
+		persons := make([]repository.PersonRow, config.DefaultPageLimit+1)
+		for i := range persons {
+			persons[i] = repository.PersonRow{AccountID: "G" + string(rune('A'+i))}
+		}
+
+		accounts.EXPECT().GetStats(mock.Anything).Return(&repository.Stats{}, nil)
+		accounts.EXPECT().GetPersons(mock.Anything, config.DefaultPageLimit+1, 20).Return(persons, nil)
+		accounts.EXPECT().GetCorporatePage(mock.Anything, "c1", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetSyntheticPage(mock.Anything, "", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetNewMembers(mock.Anything, mock.Anything, config.DefaultPageLimit).Return(nil, nil)
+
+		var renderedData any
+		tmpl.EXPECT().Render(mock.Anything, "home.html", mock.Anything).Run(func(w io.Writer, name string, data any) {
+			renderedData = data
+		}).Return(nil)
+
+		h, err := New(stellar, accounts, nil, tmpl)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/?persons_offset=20&corporate_cursor=c1", nil)
+		w := httptest.NewRecorder()
+
+		h.Home(w, req)
+
+		homeData := renderedData.(HomeData)
+		assert.Len(t, homeData.Persons, config.DefaultPageLimit) // Should be truncated to DefaultPageLimit
+		assert.Equal(t, 20, homeData.PersonsPager.Start)
+		assert.Equal(t, "?corporate_cursor=c1&persons_offset=40#persons-section", homeData.PersonsPager.Next)
+		assert.Equal(t, "?corporate_cursor=c1&persons_offset=0#persons-section", homeData.PersonsPager.Prev)
+	})
+
+	t.Run("invalid cursor returns 400", func(t *testing.T) {
+		accounts := mocks.NewMockAccountQuerier(t)
+		stellar := mocks.NewMockStellarServicer(t)
+		tmpl := mocks.NewMockTemplateRenderer(t)
+
+		accounts.EXPECT().GetStats(mock.Anything).Return(&repository.Stats{}, nil)
+		accounts.EXPECT().GetPersonsPage(mock.Anything, "bogus", config.DefaultPageLimit).
+			Return(nil, repository.KeysetPage{}, fmt.Errorf("%w: bad token", repository.ErrInvalidCursor))
+
+		h, err := New(stellar, accounts, nil, tmpl)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/?persons_cursor=bogus", nil)
+		w := httptest.NewRecorder()
+
+		h.Home(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
 	t.Run("stats error returns 500", func(t *testing.T) {
@@ -198,7 +313,7 @@ func TestHomeHandler(t *testing.T) {
 		tmpl := mocks.NewMockTemplateRenderer(t)
 
 		accounts.EXPECT().GetStats(mock.Anything).Return(&repository.Stats{}, nil)
-		accounts.EXPECT().GetPersons(mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+		accounts.EXPECT().GetPersonsPage(mock.Anything, mock.Anything, mock.Anything).Return(nil, repository.KeysetPage{}, errors.New("database error"))
 
 		h, err := New(stellar, accounts, nil, tmpl)
 		require.NoError(t, err)
@@ -218,8 +333,8 @@ func TestHomeHandler(t *testing.T) {
 		tmpl := mocks.NewMockTemplateRenderer(t)
 
 		accounts.EXPECT().GetStats(mock.Anything).Return(&repository.Stats{}, nil)
-		accounts.EXPECT().GetPersons(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
-		accounts.EXPECT().GetCorporate(mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+		accounts.EXPECT().GetPersonsPage(mock.Anything, mock.Anything, mock.Anything).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetCorporatePage(mock.Anything, mock.Anything, mock.Anything).Return(nil, repository.KeysetPage{}, errors.New("database error"))
 
 		h, err := New(stellar, accounts, nil, tmpl)
 		require.NoError(t, err)
@@ -239,9 +354,9 @@ func TestHomeHandler(t *testing.T) {
 		tmpl := mocks.NewMockTemplateRenderer(t)
 
 		accounts.EXPECT().GetStats(mock.Anything).Return(&repository.Stats{}, nil)
-		accounts.EXPECT().GetPersons(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
-		accounts.EXPECT().GetCorporate(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
-		accounts.EXPECT().GetSynthetic(mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+		accounts.EXPECT().GetPersonsPage(mock.Anything, mock.Anything, mock.Anything).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetCorporatePage(mock.Anything, mock.Anything, mock.Anything).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetSyntheticPage(mock.Anything, mock.Anything, mock.Anything).Return(nil, repository.KeysetPage{}, errors.New("database error"))
 
 		h, err := New(stellar, accounts, nil, tmpl)
 		require.NoError(t, err)
@@ -261,9 +376,10 @@ func TestHomeHandler(t *testing.T) {
 		tmpl := mocks.NewMockTemplateRenderer(t)
 
 		accounts.EXPECT().GetStats(mock.Anything).Return(&repository.Stats{}, nil)
-		accounts.EXPECT().GetPersons(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
-		accounts.EXPECT().GetCorporate(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
-		accounts.EXPECT().GetSynthetic(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+		accounts.EXPECT().GetPersonsPage(mock.Anything, mock.Anything, mock.Anything).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetCorporatePage(mock.Anything, mock.Anything, mock.Anything).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetSyntheticPage(mock.Anything, mock.Anything, mock.Anything).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetNewMembers(mock.Anything, mock.Anything, config.DefaultPageLimit).Return(nil, nil)
 		tmpl.EXPECT().Render(mock.Anything, mock.Anything, mock.Anything).Return(errors.New("template error"))
 
 		h, err := New(stellar, accounts, nil, tmpl)
@@ -277,21 +393,59 @@ func TestHomeHandler(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
+	t.Run("paging links keep other list positions", func(t *testing.T) {
+		accounts := mocks.NewMockAccountQuerier(t)
+		stellar := mocks.NewMockStellarServicer(t)
+		tmpl := mocks.NewMockTemplateRenderer(t)
+
+		accounts.EXPECT().GetStats(mock.Anything).Return(&repository.Stats{}, nil)
+		accounts.EXPECT().GetPersonsPage(mock.Anything, "p1", config.DefaultPageLimit).
+			Return([]repository.PersonRow{{AccountID: "GA"}}, repository.KeysetPage{Start: 20, Next: "p2", Prev: "p0"}, nil)
+		accounts.EXPECT().GetCorporatePage(mock.Anything, "c1", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetSyntheticPage(mock.Anything, "", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetNewMembers(mock.Anything, mock.Anything, config.DefaultPageLimit).Return(nil, nil)
+
+		var renderedData any
+		tmpl.EXPECT().Render(mock.Anything, mock.Anything, mock.Anything).Run(func(w io.Writer, name string, data any) {
+			renderedData = data
+		}).Return(nil)
+
+		h, err := New(stellar, accounts, nil, tmpl)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/?persons_cursor=p1&corporate_cursor=c1", nil)
+		w := httptest.NewRecorder()
+
+		h.Home(w, req)
+
+		homeData := renderedData.(HomeData)
+		assert.Equal(t, 20, homeData.PersonsPager.Start)
+		assert.Equal(t, "?corporate_cursor=c1&persons_cursor=p2#persons-section", homeData.PersonsPager.Next)
+		assert.Equal(t, "?corporate_cursor=c1&persons_cursor=p0#persons-section", homeData.PersonsPager.Prev)
+		assert.Empty(t, homeData.CorporatePager.Next)
+		assert.Empty(t, homeData.SyntheticPager.Prev)
+	})
+
 	t.Run("has more pagination flags set correctly", func(t *testing.T) {
 		accounts := mocks.NewMockAccountQuerier(t)
 		stellar := mocks.NewMockStellarServicer(t)
 		tmpl := mocks.NewMockTemplateRenderer(t)
 
 		// Return 21 items (more than DefaultPageLimit of 20)
-		persons := make([]repository.PersonRow, 21)
+		persons := make([]repository.PersonRow, config.DefaultPageLimit+1)
 		for i := range persons {
 			persons[i] = repository.PersonRow{AccountID: "G" + string(rune('A'+i))}
 		}
+		corporate := make([]repository.CorporateRow, config.DefaultPageLimit)
+		for i := range corporate {
+			corporate[i] = repository.CorporateRow{AccountID: "G" + string(rune('A'+i))}
+		}
 
 		accounts.EXPECT().GetStats(mock.Anything).Return(&repository.Stats{}, nil)
-		accounts.EXPECT().GetPersons(mock.Anything, mock.Anything, mock.Anything).Return(persons, nil)
-		accounts.EXPECT().GetCorporate(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
-		accounts.EXPECT().GetSynthetic(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+		accounts.EXPECT().GetPersons(mock.Anything, config.DefaultPageLimit+1, 20).Return(persons, nil)
+		accounts.EXPECT().GetCorporate(mock.Anything, config.DefaultPageLimit+1, 20).Return(corporate, nil)
+		accounts.EXPECT().GetSyntheticPage(mock.Anything, "", config.DefaultPageLimit).Return(nil, repository.KeysetPage{}, nil)
+		accounts.EXPECT().GetNewMembers(mock.Anything, mock.Anything, config.DefaultPageLimit).Return(nil, nil)
 
 		var renderedData any
 		tmpl.EXPECT().Render(mock.Anything, mock.Anything, mock.Anything).Run(func(w io.Writer, name string, data any) {
@@ -301,14 +455,16 @@ func TestHomeHandler(t *testing.T) {
 		h, err := New(stellar, accounts, nil, tmpl)
 		require.NoError(t, err)
 
-		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req := httptest.NewRequest(http.MethodGet, "/?persons_offset=20&corporate_offset=20", nil)
 		w := httptest.NewRecorder()
 
 		h.Home(w, req)
 
 		homeData := renderedData.(HomeData)
-		assert.True(t, homeData.HasMorePersons)
+		assert.NotEmpty(t, homeData.PersonsPager.Next)
 		assert.Len(t, homeData.Persons, config.DefaultPageLimit) // Should be truncated to DefaultPageLimit
+		assert.Empty(t, homeData.CorporatePager.Next)
+		assert.Len(t, homeData.Corporate, config.DefaultPageLimit)
 	})
 }
 
@@ -355,8 +511,11 @@ func TestAccountHandler(t *testing.T) {
 		accounts.EXPECT().GetConfirmedRelationships(mock.Anything, "GABC123").Return(nil, nil)
 		accounts.EXPECT().GetAccountInfo(mock.Anything, "GABC123").Return(&repository.AccountInfo{}, nil)
 		accounts.EXPECT().GetLPShares(mock.Anything, "GABC123").Return(nil, nil)
+		accounts.EXPECT().GetAccountControl(mock.Anything, "GABC123").Return(nil, nil)
+		accounts.EXPECT().GetMembershipTimeline(mock.Anything, "GABC123").Return(nil, nil)
 
 		// Expect operations fetch
+		accounts.EXPECT().GetAccountOperations(mock.Anything, "GABC123", model.OperationFilter{}, "", 10).Return(&model.OperationsPage{}, nil)
 		stellar.EXPECT().GetAccountOperations(mock.Anything, "GABC123", "", 10).Return(&model.OperationsPage{
 			Operations: []model.Operation{},
 			HasMore:    false,
@@ -417,6 +576,9 @@ func TestAccountHandler(t *testing.T) {
 		accounts.EXPECT().GetConfirmedRelationships(mock.Anything, "GABC123").Return(nil, nil)
 		accounts.EXPECT().GetAccountInfo(mock.Anything, "GABC123").Return(&repository.AccountInfo{}, nil)
 		accounts.EXPECT().GetLPShares(mock.Anything, "GABC123").Return(nil, nil)
+		accounts.EXPECT().GetAccountControl(mock.Anything, "GABC123").Return(nil, nil)
+		accounts.EXPECT().GetMembershipTimeline(mock.Anything, "GABC123").Return(nil, nil)
+		accounts.EXPECT().GetAccountOperations(mock.Anything, "GABC123", model.OperationFilter{}, "", 10).Return(&model.OperationsPage{}, nil)
 		stellar.EXPECT().GetAccountOperations(mock.Anything, "GABC123", "", 10).Return(nil, nil)
 		accounts.EXPECT().GetAccountNames(mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 		tmpl.EXPECT().Render(mock.Anything, mock.Anything, mock.Anything).Return(errors.New("template error"))
@@ -500,7 +662,10 @@ func TestAccountHandler(t *testing.T) {
 				XLMValue:       2000.0,
 			},
 		}, nil)
+		accounts.EXPECT().GetAccountControl(mock.Anything, "GABC123").Return(nil, nil)
+		accounts.EXPECT().GetMembershipTimeline(mock.Anything, "GABC123").Return(nil, nil)
 
+		accounts.EXPECT().GetAccountOperations(mock.Anything, "GABC123", model.OperationFilter{}, "", 10).Return(&model.OperationsPage{}, nil)
 		stellar.EXPECT().GetAccountOperations(mock.Anything, "GABC123", "", 10).Return(&model.OperationsPage{
 			Operations: []model.Operation{},
 			HasMore:    false,
@@ -562,7 +727,10 @@ func TestAccountHandler(t *testing.T) {
 
 		// LP shares fetch fails
 		accounts.EXPECT().GetLPShares(mock.Anything, "GABC123").Return(nil, errors.New("database error"))
+		accounts.EXPECT().GetAccountControl(mock.Anything, "GABC123").Return(nil, nil)
+		accounts.EXPECT().GetMembershipTimeline(mock.Anything, "GABC123").Return(nil, nil)
 
+		accounts.EXPECT().GetAccountOperations(mock.Anything, "GABC123", model.OperationFilter{}, "", 10).Return(&model.OperationsPage{}, nil)
 		stellar.EXPECT().GetAccountOperations(mock.Anything, "GABC123", "", 10).Return(nil, nil)
 		accounts.EXPECT().GetAccountNames(mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 
@@ -679,15 +847,50 @@ func TestRegisterRoutes(t *testing.T) {
 		// Go 1.22+ returns 405 Method Not Allowed for wrong method
 		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
 	})
+
+	t.Run("stellar.toml only served with a SEP-0007 signing key", func(t *testing.T) {
+		stellar := mocks.NewMockStellarServicer(t)
+		accounts := mocks.NewMockAccountQuerier(t)
+		tmpl := mocks.NewMockTemplateRenderer(t)
+
+		kp := keypair.MustRandom()
+		txURIs, err := service.NewTxURIBuilder(service.TxURIConfig{OriginDomain: "lore.example.org", SigningSeed: kp.Seed()})
+		require.NoError(t, err)
+
+		signed, err := New(stellar, accounts, nil, tmpl, WithTxURIBuilder(txURIs))
+		require.NoError(t, err)
+		mux := http.NewServeMux()
+		signed.RegisterRoutes(mux)
+
+		req := httptest.NewRequest(http.MethodGet, "/.well-known/stellar.toml", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "URI_REQUEST_SIGNING_KEY = \""+kp.Address()+"\"\n", w.Body.String())
+		assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+
+		// Without a signing key the path falls through to the home handler
+		accounts.EXPECT().GetStats(mock.Anything).Return(nil, errors.New("expected error"))
+		unsigned, err := New(stellar, accounts, nil, tmpl)
+		require.NoError(t, err)
+		mux = http.NewServeMux()
+		unsigned.RegisterRoutes(mux)
+
+		w = httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.NotEqual(t, http.StatusOK, w.Code)
+	})
 }
 
 // groupRelationships tests
 
 func TestGroupRelationships(t *testing.T) {
 	const accountID = "GTEST1234567890123456789012345678901234567890123456"
+	types := relationtest.Registry()
 
 	t.Run("empty rows returns empty categories", func(t *testing.T) {
-		categories := bsn.GroupRelationships(accountID, nil, nil)
+		categories := bsn.GroupRelationships(types, accountID, nil, nil)
 
 		assert.Len(t, categories, 5) // All category definitions
 		for _, cat := range categories {
@@ -716,7 +919,7 @@ func TestGroupRelationships(t *testing.T) {
 			},
 		}
 
-		categories := bsn.GroupRelationships(accountID, rows, nil)
+		categories := bsn.GroupRelationships(types, accountID, rows, nil)
 
 		// Find NETWORK category
 		var networkCat *model.RelationshipCategory
@@ -734,6 +937,44 @@ func TestGroupRelationships(t *testing.T) {
 		assert.True(t, networkCat.Relationships[0].IsConfirmed)
 	})
 
+	t.Run("complementary pair Contractor/Client shows as confirmed", func(t *testing.T) {
+		rows := []repository.RelationshipRow{
+			{
+				SourceAccountID: accountID,
+				TargetAccountID: "GCLIENT1234567890123456789012345678901234567890123",
+				TargetName:      "Test Client",
+				RelationType:    "Contractor",
+				RelationIndex:   "",
+				Direction:       "outgoing",
+			},
+			{
+				SourceAccountID: "GCLIENT1234567890123456789012345678901234567890123",
+				TargetAccountID: accountID,
+				TargetName:      "Test Client",
+				RelationType:    "Client",
+				RelationIndex:   "",
+				Direction:       "incoming",
+			},
+		}
+
+		categories := bsn.GroupRelationships(types, accountID, rows, nil)
+
+		// Find WORK category
+		var workCat *model.RelationshipCategory
+		for i := range categories {
+			if categories[i].Name == "WORK" {
+				workCat = &categories[i]
+				break
+			}
+		}
+
+		require.NotNil(t, workCat)
+		// Should have 1 merged relationship (not 2 separate)
+		assert.Len(t, workCat.Relationships, 1)
+		assert.Equal(t, "Contractor", workCat.Relationships[0].Type)
+		assert.True(t, workCat.Relationships[0].IsConfirmed)
+	})
+
 	t.Run("symmetric type with one-way declaration is hidden", func(t *testing.T) {
 		rows := []repository.RelationshipRow{
 			{
@@ -746,7 +987,7 @@ func TestGroupRelationships(t *testing.T) {
 			},
 		}
 
-		categories := bsn.GroupRelationships(accountID, rows, nil)
+		categories := bsn.GroupRelationships(types, accountID, rows, nil)
 
 		// Find SOCIAL category
 		var socialCat *model.RelationshipCategory
@@ -784,7 +1025,7 @@ func TestGroupRelationships(t *testing.T) {
 			},
 		}
 
-		categories := bsn.GroupRelationships(accountID, rows, nil)
+		categories := bsn.GroupRelationships(types, accountID, rows, nil)
 
 		// Find SOCIAL category
 		var socialCat *model.RelationshipCategory
@@ -823,7 +1064,7 @@ func TestGroupRelationships(t *testing.T) {
 			},
 		}
 
-		categories := bsn.GroupRelationships(accountID, rows, nil)
+		categories := bsn.GroupRelationships(types, accountID, rows, nil)
 
 		// Find NETWORK category (Partnership is in NETWORK)
 		var networkCat *model.RelationshipCategory
@@ -852,7 +1093,7 @@ func TestGroupRelationships(t *testing.T) {
 			},
 		}
 
-		categories := bsn.GroupRelationships(accountID, rows, nil)
+		categories := bsn.GroupRelationships(types, accountID, rows, nil)
 
 		// All categories should be empty
 		for _, cat := range categories {
@@ -888,7 +1129,7 @@ func TestGroupRelationships(t *testing.T) {
 			},
 		}
 
-		categories := bsn.GroupRelationships(accountID, rows, nil)
+		categories := bsn.GroupRelationships(types, accountID, rows, nil)
 
 		// Check FAMILY category
 		var familyCat, workCat, ownershipCat *model.RelationshipCategory
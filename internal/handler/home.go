@@ -1,11 +1,17 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"log/slog"
+	"maps"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/mtlprog/lore/internal/config"
+	"github.com/mtlprog/lore/internal/model"
 	"github.com/mtlprog/lore/internal/repository"
 	"github.com/mtlprog/lore/internal/reputation"
 )
@@ -18,51 +24,32 @@ type SyntheticDisplay struct {
 	ReputationWeight float64
 }
 
+// ListPager holds the rank of the first row and the paging links of a home page list.
+type ListPager struct {
+	Start int    // Number of rows before this page
+	Next  string // URL of the following page, empty on the last page
+	Prev  string // URL of the preceding page, empty on the first page
+}
+
 // HomeData holds data for the home page template.
 type HomeData struct {
-	Stats               *repository.Stats
-	Persons             []repository.PersonRow
-	Corporate           []repository.CorporateRow
-	Synthetic           []SyntheticDisplay
-	PersonsOffset       int
-	CorporateOffset     int
-	SyntheticOffset     int
-	NextPersonsOffset   int
-	NextCorporateOffset int
-	NextSyntheticOffset int
-	HasMorePersons      bool
-	HasMoreCorporate    bool
-	HasMoreSynthetic    bool
+	Stats          *repository.Stats
+	Persons        []repository.PersonRow
+	Corporate      []repository.CorporateRow
+	Synthetic      []SyntheticDisplay
+	PersonsPager   ListPager
+	CorporatePager ListPager
+	SyntheticPager ListPager
+	NewMembers     []model.MembershipEvent // Accounts that received MTLAP or MTLAC this month
 }
 
 // Home handles the main page showing Persons and Companies.
+// Each list pages independently by keyset cursor, so rows do not shift between
+// pages while a sync rewrites balances. Links with the older <list>_offset
+// parameters keep working.
 func (h *Handler) Home(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-
-	personsOffsetParam := r.URL.Query().Get("persons_offset")
-	personsOffset, err := strconv.Atoi(personsOffsetParam)
-	if err != nil || personsOffset < 0 {
-		if personsOffsetParam != "" {
-			slog.Debug("invalid persons_offset parameter, defaulting to 0", "value", personsOffsetParam)
-		}
-		personsOffset = 0
-	}
-	corporateOffsetParam := r.URL.Query().Get("corporate_offset")
-	corporateOffset, err := strconv.Atoi(corporateOffsetParam)
-	if err != nil || corporateOffset < 0 {
-		if corporateOffsetParam != "" {
-			slog.Debug("invalid corporate_offset parameter, defaulting to 0", "value", corporateOffsetParam)
-		}
-		corporateOffset = 0
-	}
-	syntheticOffsetParam := r.URL.Query().Get("synthetic_offset")
-	syntheticOffset, err := strconv.Atoi(syntheticOffsetParam)
-	if err != nil || syntheticOffset < 0 {
-		if syntheticOffsetParam != "" {
-			slog.Debug("invalid synthetic_offset parameter, defaulting to 0", "value", syntheticOffsetParam)
-		}
-		syntheticOffset = 0
-	}
+	query := r.URL.Query()
 
 	stats, err := h.accounts.GetStats(ctx)
 	if err != nil {
@@ -71,39 +58,31 @@ func (h *Handler) Home(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	persons, err := h.accounts.GetPersons(ctx, config.DefaultPageLimit+1, personsOffset)
+	persons, personsPager, err := homeList(ctx, query, "persons", h.accounts.GetPersonsPage, h.accounts.GetPersons)
 	if err != nil {
-		slog.Error("failed to fetch persons", "offset", personsOffset, "error", err)
-		http.Error(w, "Failed to fetch persons", http.StatusInternalServerError)
+		homeListError(w, err, "persons", "Failed to fetch persons")
 		return
 	}
 
-	corporate, err := h.accounts.GetCorporate(ctx, config.DefaultPageLimit+1, corporateOffset)
+	corporate, corporatePager, err := homeList(ctx, query, "corporate", h.accounts.GetCorporatePage, h.accounts.GetCorporate)
 	if err != nil {
-		slog.Error("failed to fetch corporate", "offset", corporateOffset, "error", err)
-		http.Error(w, "Failed to fetch corporate accounts", http.StatusInternalServerError)
+		homeListError(w, err, "corporate", "Failed to fetch corporate accounts")
 		return
 	}
 
-	synthetic, err := h.accounts.GetSynthetic(ctx, config.DefaultPageLimit+1, syntheticOffset)
+	synthetic, syntheticPager, err := homeList(ctx, query, "synthetic", h.accounts.GetSyntheticPage, h.accounts.GetSynthetic)
 	if err != nil {
-		slog.Error("failed to fetch synthetic", "offset", syntheticOffset, "error", err)
-		http.Error(w, "Failed to fetch synthetic accounts", http.StatusInternalServerError)
+		homeListError(w, err, "synthetic", "Failed to fetch synthetic accounts")
 		return
 	}
 
-	hasMorePersons := len(persons) > config.DefaultPageLimit
-	hasMoreCorporate := len(corporate) > config.DefaultPageLimit
-	hasMoreSynthetic := len(synthetic) > config.DefaultPageLimit
-
-	if hasMorePersons {
-		persons = persons[:config.DefaultPageLimit]
-	}
-	if hasMoreCorporate {
-		corporate = corporate[:config.DefaultPageLimit]
-	}
-	if hasMoreSynthetic {
-		synthetic = synthetic[:config.DefaultPageLimit]
+	// New members are non-critical - render the page without them on error
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	newMembers, err := h.accounts.GetNewMembers(ctx, monthStart, config.DefaultPageLimit)
+	if err != nil {
+		slog.Error("failed to fetch new members", "error", err)
+		newMembers = nil
 	}
 
 	syntheticDisplay := make([]SyntheticDisplay, len(synthetic))
@@ -121,19 +100,14 @@ func (h *Handler) Home(w http.ResponseWriter, r *http.Request) {
 	}
 
 	data := HomeData{
-		Stats:               stats,
-		Persons:             persons,
-		Corporate:           corporate,
-		Synthetic:           syntheticDisplay,
-		PersonsOffset:       personsOffset,
-		CorporateOffset:     corporateOffset,
-		SyntheticOffset:     syntheticOffset,
-		NextPersonsOffset:   personsOffset + config.DefaultPageLimit,
-		NextCorporateOffset: corporateOffset + config.DefaultPageLimit,
-		NextSyntheticOffset: syntheticOffset + config.DefaultPageLimit,
-		HasMorePersons:      hasMorePersons,
-		HasMoreCorporate:    hasMoreCorporate,
-		HasMoreSynthetic:    hasMoreSynthetic,
+		Stats:          stats,
+		Persons:        persons,
+		Corporate:      corporate,
+		Synthetic:      syntheticDisplay,
+		PersonsPager:   personsPager,
+		CorporatePager: corporatePager,
+		SyntheticPager: syntheticPager,
+		NewMembers:     newMembers,
 	}
 
 	buf := h.getBuffer()
@@ -150,3 +124,77 @@ func (h *Handler) Home(w http.ResponseWriter, r *http.Request) {
 		slog.Debug("failed to write response", "error", err)
 	}
 }
+
+// homeListError reports a failure to load one of the home page lists. A
+// malformed cursor in the URL is the client's fault.
+func homeListError(w http.ResponseWriter, err error, list, message string) {
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		http.Error(w, "Invalid "+list+" cursor", http.StatusBadRequest)
+		return
+	}
+	slog.Error("failed to fetch "+list, "error", err)
+	http.Error(w, message, http.StatusInternalServerError)
+}
+
+// homeList loads one page of a home page list. The <list>_cursor parameter
+// selects a keyset page. Without it, a positive <list>_offset selects an offset
+// page, like the offset parameter of the REST account lists; invalid offsets
+// fall back to the first page.
+func homeList[T any](
+	ctx context.Context,
+	query url.Values,
+	list string,
+	keysetPage func(ctx context.Context, cursor string, limit int) ([]T, repository.KeysetPage, error),
+	offsetPage func(ctx context.Context, limit, offset int) ([]T, error),
+) ([]T, ListPager, error) {
+	offsetParam := query.Get(list + "_offset")
+	offset, err := strconv.Atoi(offsetParam)
+	if err != nil || offset < 0 {
+		if offsetParam != "" {
+			slog.Debug("invalid "+list+"_offset parameter, defaulting to 0", "value", offsetParam)
+		}
+		offset = 0
+	}
+
+	if query.Has(list+"_cursor") || offset == 0 {
+		rows, page, err := keysetPage(ctx, query.Get(list+"_cursor"), config.DefaultPageLimit)
+		if err != nil {
+			return nil, ListPager{}, err
+		}
+		return rows, homePager(query, list, page), nil
+	}
+
+	rows, err := offsetPage(ctx, config.DefaultPageLimit+1, offset)
+	if err != nil {
+		return nil, ListPager{}, err
+	}
+	pager := ListPager{Start: offset}
+	if len(rows) > config.DefaultPageLimit {
+		rows = rows[:config.DefaultPageLimit]
+		pager.Next = homeLink(query, list, list+"_offset", strconv.Itoa(offset+config.DefaultPageLimit))
+	}
+	pager.Prev = homeLink(query, list, list+"_offset", strconv.Itoa(max(offset-config.DefaultPageLimit, 0)))
+	return rows, pager, nil
+}
+
+// homePager builds the keyset paging links of a home page list.
+func homePager(query url.Values, list string, page repository.KeysetPage) ListPager {
+	pager := ListPager{Start: page.Start}
+	if page.Next != "" {
+		pager.Next = homeLink(query, list, list+"_cursor", page.Next)
+	}
+	if page.Prev != "" {
+		pager.Prev = homeLink(query, list, list+"_cursor", page.Prev)
+	}
+	return pager
+}
+
+// homeLink builds a paging link of a home page list. The link keeps the
+// positions of the other lists and jumps to the list's section.
+func homeLink(query url.Values, list, param, value string) string {
+	q := maps.Clone(query)
+	q.Del(list + "_cursor")
+	q.Del(list + "_offset")
+	q.Set(param, value)
+	return "?" + q.Encode() + "#" + list + "-section"
+}
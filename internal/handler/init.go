@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -74,7 +76,7 @@ func (h *Handler) InitParticipant(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse account data into form
-	form := service.ParseAccountDataToParticipant(accountID, rawAcc)
+	form := service.ParseAccountDataToParticipant(accountID, rawAcc, h.relations)
 
 	// Pre-fill with data from account detail if raw parsing missed something
 	if form.Name == "" {
@@ -123,6 +125,10 @@ func (h *Handler) InitParticipantSubmit(w http.ResponseWriter, r *http.Request)
 		}
 		h.renderParticipantForm(w, current, original, "")
 
+	case isRelationAction(action):
+		current.Relations = h.applyRelationAction(action, r.FormValue("new_relation_type"), current.Relations)
+		h.renderParticipantForm(w, current, original, "")
+
 	case action == "preview":
 		// Generate XDR preview
 		h.previewParticipant(w, r, original, current)
@@ -175,7 +181,7 @@ func (h *Handler) InitCorporate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse account data into form
-	form := service.ParseAccountDataToCorporate(accountID, rawAcc)
+	form := service.ParseAccountDataToCorporate(accountID, rawAcc, h.relations)
 
 	// Pre-fill with data from account detail if raw parsing missed something
 	if form.Name == "" {
@@ -223,6 +229,10 @@ func (h *Handler) InitCorporateSubmit(w http.ResponseWriter, r *http.Request) {
 		}
 		h.renderCorporateForm(w, current, original, "")
 
+	case isRelationAction(action):
+		current.Relations = h.applyRelationAction(action, r.FormValue("new_relation_type"), current.Relations)
+		h.renderCorporateForm(w, current, original, "")
+
 	case action == "preview":
 		// Generate XDR preview
 		h.previewCorporate(w, r, original, current)
@@ -233,14 +243,50 @@ func (h *Handler) InitCorporateSubmit(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// isRelationAction reports whether action belongs to the relationship editor.
+func isRelationAction(action string) bool {
+	return action == "add_relation" || action == "reindex_relations" || strings.HasPrefix(action, "remove_relation:")
+}
+
+// applyRelationAction applies a relationship editor action (add_relation, remove_relation:N,
+// reindex_relations) and returns the updated rows.
+func (h *Handler) applyRelationAction(action, newType string, relations []model.RelationField) []model.RelationField {
+	switch {
+	case action == "add_relation":
+		if !h.relations.IsKnown(newType) {
+			return relations
+		}
+		return append(relations, model.RelationField{
+			Type:  newType,
+			Index: service.NextRelationIndex(relations, newType),
+		})
+
+	case strings.HasPrefix(action, "remove_relation:"):
+		// Format: remove_relation:N
+		removeIdx, _ := strconv.Atoi(strings.TrimPrefix(action, "remove_relation:"))
+		if removeIdx >= 0 && removeIdx < len(relations) {
+			relations = append(relations[:removeIdx], relations[removeIdx+1:]...)
+		}
+		return relations
+
+	case action == "reindex_relations":
+		return service.ReindexRelations(relations)
+	}
+
+	return relations
+}
+
 // renderParticipantForm renders the participant form template.
 func (h *Handler) renderParticipantForm(w http.ResponseWriter, form model.ParticipantFormData, original, errorMsg string) {
+	relationTypes, relationInfo := service.RelationEditorOptions(h.relations, model.InitFormParticipant)
 	data := model.InitFormData{
 		Page:          "participant",
 		AccountID:     form.AccountID,
 		FormData:      form,
 		OriginalJSON:  original,
 		AvailableTags: model.AvailableTags,
+		RelationTypes: relationTypes,
+		RelationInfo:  relationInfo,
 		Error:         errorMsg,
 		FormAction:    "/init/participant",
 		PreviewAction: "/init/participant",
@@ -263,12 +309,15 @@ func (h *Handler) renderParticipantForm(w http.ResponseWriter, form model.Partic
 
 // renderCorporateForm renders the corporate form template.
 func (h *Handler) renderCorporateForm(w http.ResponseWriter, form model.CorporateFormData, original, errorMsg string) {
+	relationTypes, relationInfo := service.RelationEditorOptions(h.relations, model.InitFormCorporate)
 	data := model.InitFormData{
 		Page:          "corporate",
 		AccountID:     form.AccountID,
 		FormData:      form,
 		OriginalJSON:  original,
 		AvailableTags: model.AvailableTags,
+		RelationTypes: relationTypes,
+		RelationInfo:  relationInfo,
 		Error:         errorMsg,
 		FormAction:    "/init/corporate",
 		PreviewAction: "/init/corporate",
@@ -315,34 +364,16 @@ func (h *Handler) previewParticipant(w http.ResponseWriter, r *http.Request, ori
 		return
 	}
 
-	builder := service.NewInitXDRBuilder()
-	xdr, ops, err := builder.GenerateParticipantXDR(original, current, seqNum+1)
+	txs, err := h.xdrBuilder(ctx).GenerateParticipantXDR(original, current, seqNum+1)
 	if err != nil {
 		h.renderParticipantForm(w, current, originalEncoded, err.Error())
 		return
 	}
 
-	data := model.InitPreviewData{
-		Page:       "preview",
-		AccountID:  current.AccountID,
-		XDR:        xdr,
-		Operations: ops,
-		LabLink:    service.BuildLabLink(xdr),
-	}
-
-	buf := h.getBuffer()
-	defer h.putBuffer(buf)
-
-	if err := h.tmpl.Render(buf, "init.html", data); err != nil {
-		slog.Error("failed to render preview", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
-
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if _, err := buf.WriteTo(w); err != nil {
-		slog.Debug("failed to write response", "error", err)
-	}
+	h.renderPreview(ctx, w, model.InitPreviewData{
+		AccountID:    current.AccountID,
+		Transactions: txs,
+	}, "Update Montelibero participant data")
 }
 
 // previewCorporate generates and displays XDR preview for corporate form.
@@ -371,21 +402,48 @@ func (h *Handler) previewCorporate(w http.ResponseWriter, r *http.Request, origi
 		return
 	}
 
-	builder := service.NewInitXDRBuilder()
-	xdr, ops, err := builder.GenerateCorporateXDR(original, current, seqNum+1)
+	txs, err := h.xdrBuilder(ctx).GenerateCorporateXDR(original, current, seqNum+1)
 	if err != nil {
 		h.renderCorporateForm(w, current, originalEncoded, err.Error())
 		return
 	}
 
-	data := model.InitPreviewData{
-		Page:       "preview",
-		AccountID:  current.AccountID,
-		XDR:        xdr,
-		Operations: ops,
-		LabLink:    service.BuildLabLink(xdr),
+	// Multisig companies need several signatures; show who has to sign
+	h.renderPreview(ctx, w, model.InitPreviewData{
+		AccountID:    current.AccountID,
+		Transactions: txs,
+		Signing:      h.signingRequirement(ctx, current.AccountID),
+	}, "Update Montelibero corporate data")
+}
+
+// xdrBuilder returns an XDR builder using the configured fee strategy, validity
+// and relation types.
+func (h *Handler) xdrBuilder(ctx context.Context) *service.InitXDRBuilder {
+	baseFee := h.stellar.SuggestBaseFee(ctx, h.txSettings.FeeStrategy, h.txSettings.MaxBaseFee)
+	return service.NewInitXDRBuilder(
+		service.WithBaseFee(baseFee),
+		service.WithValidity(h.txSettings.Validity),
+		service.WithRelationTypes(h.relations),
+	)
+}
+
+// renderPreview fills the signing links of each transaction of a preview and renders it.
+// msg is shown by SEP-0007 wallets before signing.
+func (h *Handler) renderPreview(ctx context.Context, w http.ResponseWriter, data model.InitPreviewData, msg string) {
+	data.Page = "preview"
+	data.CanSubmit = h.submitter != nil
+	data.CanCollect = h.collection != nil
+
+	// The submitter only relays transactions it has seen generated
+	if h.submitter != nil {
+		if err := h.submitter.Remember(ctx, data.AccountID, data.Transactions); err != nil {
+			slog.Error("failed to record generated transactions", "account_id", data.AccountID, "error", err)
+			data.CanSubmit = false
+		}
 	}
 
+	h.fillSigningLinks(data.AccountID, data.Transactions, msg)
+
 	buf := h.getBuffer()
 	defer h.putBuffer(buf)
 
@@ -401,17 +459,48 @@ func (h *Handler) previewCorporate(w http.ResponseWriter, r *http.Request, origi
 	}
 }
 
+// fillSigningLinks sets the Laboratory link, wallet URI and QR code of each transaction.
+// msg is shown by SEP-0007 wallets before signing.
+func (h *Handler) fillSigningLinks(accountID string, txs []model.InitTransaction, msg string) {
+	for i := range txs {
+		tx := &txs[i]
+		tx.LabLink = service.BuildLabLink(tx.XDR)
+
+		txMsg := msg
+		if len(txs) > 1 {
+			txMsg = fmt.Sprintf("%s (%d/%d)", msg, i+1, len(txs))
+		}
+		uri, err := h.txURIs.TxURI(tx.XDR, txMsg)
+		if err != nil {
+			slog.Error("failed to build wallet URI", "account_id", accountID, "error", err)
+			continue
+		}
+		tx.WalletURI = uri
+		// Large transactions don't fit into a QR code; the wallet link still works
+		if qr, err := service.QRCodePNG(uri); err == nil {
+			tx.QRCode = qr
+		} else {
+			slog.Debug("wallet URI does not fit into a QR code", "account_id", accountID, "error", err)
+		}
+	}
+}
+
 // maxNumberedFields is the maximum number of numbered fields allowed per form.
 const maxNumberedFields = 100
 
 // parseParticipantForm extracts ParticipantFormData from HTTP form values.
 func (h *Handler) parseParticipantForm(r *http.Request) model.ParticipantFormData {
 	form := model.ParticipantFormData{
-		AccountID: r.FormValue("account_id"),
-		Name:      r.FormValue("name"),
-		About:     r.FormValue("about"),
-		Website:   r.FormValue("website"),
-		Tags:      r.Form["tags"],
+		AccountID:          r.FormValue("account_id"),
+		Name:               r.FormValue("name"),
+		About:              r.FormValue("about"),
+		Website:            r.FormValue("website"),
+		TelegramUserID:     strings.TrimSpace(r.FormValue("telegram_user_id")),
+		TimeTokenCode:      strings.TrimSpace(r.FormValue("time_token_code")),
+		TimeTokenIssuer:    strings.TrimSpace(r.FormValue("time_token_issuer")),
+		TimeTokenDesc:      r.FormValue("time_token_desc"),
+		TimeTokenOfferIPFS: strings.TrimSpace(r.FormValue("time_token_offer_ipfs")),
+		Tags:               r.Form["tags"],
 	}
 
 	// Parse PartOf fields with limit to prevent DoS
@@ -432,17 +521,22 @@ func (h *Handler) parseParticipantForm(r *http.Request) model.ParticipantFormDat
 		})
 	}
 
+	form.Relations = parseRelationRows(r)
+
 	return form
 }
 
 // parseCorporateForm extracts CorporateFormData from HTTP form values.
 func (h *Handler) parseCorporateForm(r *http.Request) model.CorporateFormData {
 	form := model.CorporateFormData{
-		AccountID: r.FormValue("account_id"),
-		Name:      r.FormValue("name"),
-		About:     r.FormValue("about"),
-		Website:   r.FormValue("website"),
-		Tags:      r.Form["tags"],
+		AccountID:          r.FormValue("account_id"),
+		Name:               r.FormValue("name"),
+		About:              r.FormValue("about"),
+		Website:            r.FormValue("website"),
+		PIIStandard:        r.FormValue("pii_standard") != "",
+		TelegramPartChatID: strings.TrimSpace(r.FormValue("telegram_part_chat_id")),
+		ContractIPFS:       strings.TrimSpace(r.FormValue("contract_ipfs")),
+		Tags:               r.Form["tags"],
 	}
 
 	// Parse MyPart fields with limit to prevent DoS
@@ -463,9 +557,33 @@ func (h *Handler) parseCorporateForm(r *http.Request) model.CorporateFormData {
 		})
 	}
 
+	form.Relations = parseRelationRows(r)
+
 	return form
 }
 
+// parseRelationRows extracts relationship editor rows (relation_type_N, relation_index_N,
+// relation_value_N) from HTTP form values.
+func parseRelationRows(r *http.Request) []model.RelationField {
+	var relations []model.RelationField
+
+	for i := 0; i < maxNumberedFields; i++ {
+		n := strconv.Itoa(i)
+		relType := r.FormValue("relation_type_" + n)
+		if relType == "" {
+			break
+		}
+
+		relations = append(relations, model.RelationField{
+			Type:  relType,
+			Index: strings.TrimSpace(r.FormValue("relation_index_" + n)),
+			Value: strings.TrimSpace(r.FormValue("relation_value_" + n)),
+		})
+	}
+
+	return relations
+}
+
 // nextNumberedIndex returns the next available index for numbered fields.
 func (h *Handler) nextNumberedIndex(fields []model.NumberedField) string {
 	maxNum := 0
@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/service"
+)
+
+// maxCollectRetries bounds how often an upload is re-merged when another upload
+// to the same collection was stored first.
+const maxCollectRetries = 3
+
+// InitCollectCreate handles POST /init/collect - starts a signature collection for
+// a generated transaction and redirects to its shareable page.
+func (h *Handler) InitCollectCreate(w http.ResponseWriter, r *http.Request) {
+	if h.collection == nil {
+		http.Error(w, "Signature collection is not available", http.StatusNotFound)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxXDRFormBytes)
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	envelope := strings.TrimSpace(r.FormValue("xdr"))
+
+	c, err := service.NewSignatureCollection(envelope)
+	if err != nil {
+		data := model.InitCollectData{Page: "collect", Error: "Could not start a signature collection."}
+		if errors.Is(err, service.ErrInvalidEnvelope) {
+			data.Error = err.Error()
+		}
+		h.renderCollect(w, data)
+		return
+	}
+
+	// Signatures of the uploaded envelope are kept if they belong to the account's signers
+	if req := h.signingRequirement(ctx, c.AccountID); req != nil {
+		if merged, _, err := service.MergeSignatures(c.Envelope, envelope, req.Signers); err == nil {
+			c.Envelope = merged
+		}
+	}
+
+	if err := h.collection.CreateCollection(ctx, c); err != nil {
+		slog.Error("failed to create signature collection", "account_id", c.AccountID, "error", err)
+		h.renderCollect(w, model.InitCollectData{Page: "collect", Error: "Could not start a signature collection."})
+		return
+	}
+
+	http.Redirect(w, r, "/init/collect/"+c.ID, http.StatusSeeOther)
+}
+
+// InitCollect handles GET /init/collect/{id} - shows a signature collection.
+func (h *Handler) InitCollect(w http.ResponseWriter, r *http.Request) {
+	c, ok := h.loadCollection(w, r)
+	if !ok {
+		return
+	}
+	h.renderCollect(w, h.collectData(r.Context(), c, h.signingRequirement(r.Context(), c.AccountID)))
+}
+
+// InitCollectUpload handles POST /init/collect/{id} - merges the signatures of an
+// uploaded envelope into the collection.
+func (h *Handler) InitCollectUpload(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxXDRFormBytes)
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	c, ok := h.loadCollection(w, r)
+	if !ok {
+		return
+	}
+	ctx := r.Context()
+
+	req := h.signingRequirement(ctx, c.AccountID)
+	if req == nil {
+		data := h.collectData(ctx, c, nil)
+		data.Error = "Could not fetch the account's signers from the Stellar network. Please try again."
+		h.renderCollect(w, data)
+		return
+	}
+
+	added, err := h.mergeUpload(ctx, c, strings.TrimSpace(r.FormValue("xdr")), req.Signers)
+	data := h.collectData(ctx, c, req)
+	switch {
+	case err == nil && added == 0:
+		data.Error = "No new signatures of the account's signers were found in the uploaded XDR."
+	case err == nil:
+		data.Message = fmt.Sprintf("Added %d signature(s).", added)
+	case errors.Is(err, service.ErrTransactionMismatch):
+		data.Error = "The uploaded XDR is a different transaction. Sign the XDR shown on this page."
+	case errors.Is(err, service.ErrInvalidEnvelope):
+		data.Error = err.Error()
+	default:
+		slog.Error("failed to merge signatures", "collection_id", c.ID, "error", err)
+		data.Error = "Could not save the signatures. Please try again."
+	}
+	h.renderCollect(w, data)
+}
+
+// mergeUpload merges the signatures of signedXDR into c and stores the result.
+// c is updated to the stored state.
+func (h *Handler) mergeUpload(ctx context.Context, c *model.SignatureCollection, signedXDR string, signers []model.Signer) (int, error) {
+	for range maxCollectRetries {
+		merged, added, err := service.MergeSignatures(c.Envelope, signedXDR, signers)
+		if err != nil || added == 0 {
+			return 0, err
+		}
+
+		stored, err := h.collection.UpdateCollectionEnvelope(ctx, c.ID, c.Envelope, merged)
+		if err != nil {
+			return 0, err
+		}
+		if stored {
+			c.Envelope = merged
+			return added, nil
+		}
+
+		// Another signer uploaded first; merge into their envelope
+		latest, err := h.collection.GetCollection(ctx, c.ID)
+		if err != nil {
+			return 0, err
+		}
+		if latest == nil {
+			return 0, errors.New("collection disappeared")
+		}
+		*c = *latest
+	}
+	return 0, errors.New("collection is being updated concurrently")
+}
+
+// loadCollection fetches the collection named in the path. It writes an error
+// response and returns false if there is none.
+func (h *Handler) loadCollection(w http.ResponseWriter, r *http.Request) (*model.SignatureCollection, bool) {
+	if h.collection == nil {
+		http.Error(w, "Signature collection is not available", http.StatusNotFound)
+		return nil, false
+	}
+
+	id := r.PathValue("id")
+	c, err := h.collection.GetCollection(r.Context(), id)
+	if err != nil {
+		slog.Error("failed to fetch signature collection", "collection_id", id, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return nil, false
+	}
+	if c == nil {
+		http.Error(w, "Signature collection not found or expired", http.StatusNotFound)
+		return nil, false
+	}
+	return c, true
+}
+
+// collectData decodes the collected transaction and marks which signers have signed.
+// req may be nil if the signers could not be fetched.
+func (h *Handler) collectData(ctx context.Context, c *model.SignatureCollection, req *model.SigningRequirement) model.InitCollectData {
+	data := model.InitCollectData{
+		Page:        "collect",
+		Collection:  c,
+		Requirement: req,
+		LabLink:     service.BuildLabLink(c.Envelope),
+		CanSubmit:   h.submitter != nil,
+	}
+
+	tx, err := service.DecodeEnvelope(c.Envelope, h.relations)
+	if err != nil {
+		slog.Error("failed to decode collected envelope", "collection_id", c.ID, "error", err)
+		data.Error = "The collected transaction could not be decoded."
+		return data
+	}
+	h.checkOutdated(ctx, tx)
+	data.Transaction = tx
+
+	if req != nil {
+		if err := service.MarkSigned(req, c.Envelope); err != nil {
+			slog.Error("failed to check collected signatures", "collection_id", c.ID, "error", err)
+		}
+	}
+
+	if ids := tx.AccountIDs(); len(ids) > 0 {
+		data.Names, err = h.accounts.GetAccountNames(ctx, ids)
+		if err != nil {
+			slog.Error("failed to fetch account names", "lookup_count", len(ids), "error", err)
+			data.Names = make(map[string]string)
+		}
+	}
+	return data
+}
+
+// signingRequirement fetches the signers of an account with their names resolved.
+// Returns nil if they could not be fetched.
+func (h *Handler) signingRequirement(ctx context.Context, accountID string) *model.SigningRequirement {
+	req, err := h.stellar.GetSigningRequirement(ctx, accountID)
+	if err != nil {
+		slog.Warn("failed to fetch account signers", "account_id", accountID, "error", err)
+		return nil
+	}
+
+	ids := make([]string, 0, len(req.Signers))
+	for _, signer := range req.Signers {
+		ids = append(ids, signer.Key)
+	}
+	if len(ids) == 0 {
+		return req
+	}
+
+	names, err := h.accounts.GetAccountNames(ctx, ids)
+	if err != nil {
+		slog.Error("failed to fetch signer names", "account_id", accountID, "error", err)
+		return req
+	}
+	for i := range req.Signers {
+		req.Signers[i].Name = names[req.Signers[i].Key]
+	}
+	return req
+}
+
+// renderCollect renders the signature collection page.
+func (h *Handler) renderCollect(w http.ResponseWriter, data model.InitCollectData) {
+	buf := h.getBuffer()
+	defer h.putBuffer(buf)
+
+	if err := h.tmpl.Render(buf, "init.html", data); err != nil {
+		slog.Error("failed to render signature collection", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		slog.Debug("failed to write response", "error", err)
+	}
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/mtlprog/lore/internal/handler/mocks"
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/service"
+	"github.com/samber/lo"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitCollect(t *testing.T) {
+	company, alice, bob := keypair.MustRandom(), keypair.MustRandom(), keypair.MustRandom()
+
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: company.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations:           []txnbuild.Operation{&txnbuild.ManageData{Name: "Name", Value: []byte("Acme")}},
+		BaseFee:              txnbuild.MinBaseFee,
+		Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	unsigned, err := tx.Base64()
+	require.NoError(t, err)
+	signedBy := func(kp *keypair.Full) string {
+		signed, err := tx.Sign(network.PublicNetworkPassphrase, kp)
+		require.NoError(t, err)
+		envelope, err := signed.Base64()
+		require.NoError(t, err)
+		return envelope
+	}
+
+	// setup returns a handler whose account needs both Alice and Bob (2 of 2)
+	setup := func(t *testing.T) (*Handler, *mocks.MockCollectionStore, *mocks.MockTemplateRenderer) {
+		t.Helper()
+		stellar := mocks.NewMockStellarServicer(t)
+		accounts := mocks.NewMockAccountQuerier(t)
+		store := mocks.NewMockCollectionStore(t)
+		tmpl := mocks.NewMockTemplateRenderer(t)
+
+		stellar.EXPECT().GetSigningRequirement(mock.Anything, company.Address()).
+			RunAndReturn(func(context.Context, string) (*model.SigningRequirement, error) {
+				return service.NewSigningRequirement(company.Address(), []model.Signer{
+					{Key: alice.Address(), Type: "ed25519_public_key", Weight: 1},
+					{Key: bob.Address(), Type: "ed25519_public_key", Weight: 1},
+				}, 2), nil
+			}).Maybe()
+		stellar.EXPECT().GetAccountSequence(mock.Anything, company.Address()).Return(int64(1), nil).Maybe()
+		accounts.EXPECT().GetAccountNames(mock.Anything, mock.Anything).
+			Return(map[string]string{alice.Address(): "Alice"}, nil).Maybe()
+
+		h, err := New(stellar, accounts, nil, tmpl, WithCollectionStore(store))
+		require.NoError(t, err)
+		return h, store, tmpl
+	}
+
+	post := func(h *Handler, path, id string, values url.Values, handle func(http.ResponseWriter, *http.Request)) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(values.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetPathValue("id", id)
+		w := httptest.NewRecorder()
+		handle(w, req)
+		return w
+	}
+
+	t.Run("create keeps signatures of signers and redirects", func(t *testing.T) {
+		h, store, _ := setup(t)
+
+		var created *model.SignatureCollection
+		store.EXPECT().CreateCollection(mock.Anything, mock.Anything).Run(func(_ context.Context, c *model.SignatureCollection) {
+			created = c
+		}).Return(nil)
+
+		w := post(h, "/init/collect", "", url.Values{"xdr": {signedBy(alice)}}, h.InitCollectCreate)
+
+		assert.Equal(t, http.StatusSeeOther, w.Code)
+		require.NotNil(t, created)
+		assert.Equal(t, "/init/collect/"+created.ID, w.Header().Get("Location"))
+		assert.Equal(t, company.Address(), created.AccountID)
+		assert.Equal(t, signedBy(alice), created.Envelope)
+	})
+
+	t.Run("upload merges signatures until the threshold is met", func(t *testing.T) {
+		h, store, tmpl := setup(t)
+		collection := &model.SignatureCollection{ID: "abc", AccountID: company.Address(), Envelope: signedBy(alice)}
+
+		store.EXPECT().GetCollection(mock.Anything, "abc").Return(collection, nil)
+		store.EXPECT().UpdateCollectionEnvelope(mock.Anything, "abc", signedBy(alice), mock.Anything).Return(true, nil)
+
+		var rendered model.InitCollectData
+		tmpl.EXPECT().Render(mock.Anything, "init.html", mock.Anything).Run(func(w io.Writer, name string, data any) {
+			rendered = data.(model.InitCollectData)
+		}).Return(nil)
+
+		w := post(h, "/init/collect/abc", "abc", url.Values{"xdr": {signedBy(bob)}}, h.InitCollectUpload)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, rendered.Error)
+		assert.Equal(t, "Added 1 signature(s).", rendered.Message)
+		require.NotNil(t, rendered.Requirement)
+		assert.True(t, rendered.Requirement.Met())
+		names := lo.Map(rendered.Requirement.Signers, func(s model.Signer, _ int) string { return s.Name })
+		assert.ElementsMatch(t, []string{"Alice", ""}, names)
+		require.NotNil(t, rendered.Transaction)
+		assert.Empty(t, rendered.Transaction.Outdated)
+	})
+
+	t.Run("upload of another transaction is refused", func(t *testing.T) {
+		h, store, tmpl := setup(t)
+		other, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+			SourceAccount:        &txnbuild.SimpleAccount{AccountID: company.Address(), Sequence: 5},
+			IncrementSequenceNum: true,
+			Operations:           []txnbuild.Operation{&txnbuild.ManageData{Name: "Name", Value: []byte("Other")}},
+			BaseFee:              txnbuild.MinBaseFee,
+			Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+		})
+		require.NoError(t, err)
+		other, err = other.Sign(network.PublicNetworkPassphrase, bob)
+		require.NoError(t, err)
+		otherXDR, err := other.Base64()
+		require.NoError(t, err)
+
+		store.EXPECT().GetCollection(mock.Anything, "abc").
+			Return(&model.SignatureCollection{ID: "abc", AccountID: company.Address(), Envelope: unsigned}, nil)
+
+		var rendered model.InitCollectData
+		tmpl.EXPECT().Render(mock.Anything, "init.html", mock.Anything).Run(func(w io.Writer, name string, data any) {
+			rendered = data.(model.InitCollectData)
+		}).Return(nil)
+
+		post(h, "/init/collect/abc", "abc", url.Values{"xdr": {otherXDR}}, h.InitCollectUpload)
+
+		assert.Contains(t, rendered.Error, "different transaction")
+		assert.False(t, rendered.Requirement.Met())
+	})
+
+	t.Run("unknown collection", func(t *testing.T) {
+		h, store, _ := setup(t)
+		store.EXPECT().GetCollection(mock.Anything, "missing").Return(nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/init/collect/missing", nil)
+		req.SetPathValue("id", "missing")
+		w := httptest.NewRecorder()
+		h.InitCollect(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/mtlprog/lore/internal/delegation"
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/service"
+)
+
+// InitDelegation handles GET /init/delegation - loads the delegation form.
+func (h *Handler) InitDelegation(w http.ResponseWriter, r *http.Request) {
+	accountID := r.URL.Query().Get("account_id")
+	ctx := r.Context()
+
+	// If no account ID provided, show empty form
+	if accountID == "" {
+		h.renderDelegationForm(ctx, w, model.DelegationFormData{}, "", "", false)
+		return
+	}
+
+	// Validate account ID
+	if err := service.ValidateAccountID(accountID); err != nil {
+		h.renderDelegationForm(ctx, w, model.DelegationFormData{}, "", "Invalid account ID format", false)
+		return
+	}
+
+	rawAcc, err := h.stellar.GetRawAccountData(ctx, accountID)
+	if err != nil {
+		if service.IsNotFound(err) {
+			form := model.DelegationFormData{AccountID: accountID}
+			original, _ := service.EncodeOriginalData(form)
+			h.renderDelegationForm(ctx, w, form, original, "", false)
+			return
+		}
+		slog.Error("failed to fetch raw account data", "account_id", accountID, "error", err)
+		h.renderDelegationForm(ctx, w, model.DelegationFormData{AccountID: accountID}, "",
+			"Could not load existing account data. Please try again or proceed with caution.", false)
+		return
+	}
+
+	form := service.ParseAccountDataToDelegation(accountID, rawAcc)
+	original, _ := service.EncodeOriginalData(form)
+	h.renderDelegationForm(ctx, w, form, original, "", false)
+}
+
+// InitDelegationSubmit handles POST /init/delegation - chain check and preview.
+func (h *Handler) InitDelegationSubmit(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	current := parseDelegationForm(r)
+	original := r.FormValue("original")
+
+	if r.FormValue("action") == "preview" {
+		h.previewDelegation(w, r, original, current)
+		return
+	}
+
+	// Re-render form with the chain for the entered values
+	h.renderDelegationForm(r.Context(), w, current, original, "", false)
+}
+
+// parseDelegationForm extracts delegation settings from the request form.
+func parseDelegationForm(r *http.Request) model.DelegationFormData {
+	form := model.DelegationFormData{
+		AccountID:  strings.TrimSpace(r.FormValue("account_id")),
+		DelegateTo: strings.TrimSpace(r.FormValue("delegate_to")),
+	}
+
+	switch r.FormValue("council") {
+	case "ready":
+		form.CouncilReady = true
+	case "delegate":
+		form.CouncilDelegateTo = strings.TrimSpace(r.FormValue("council_delegate_to"))
+	}
+
+	return form
+}
+
+// checkDelegation simulates the form values against synced accounts.
+// Returns false if delegation data could not be loaded.
+func (h *Handler) checkDelegation(ctx context.Context, form model.DelegationFormData) (service.DelegationCheck, map[string]*delegation.Info, bool) {
+	if service.ValidateAccountID(form.AccountID) != nil {
+		return service.DelegationCheck{}, nil, false
+	}
+
+	infos, err := h.accounts.GetDelegationInfo(ctx)
+	if err != nil {
+		slog.Error("failed to load delegation info", "error", err)
+		return service.DelegationCheck{}, nil, false
+	}
+
+	return service.CheckDelegation(form, infos), infos, true
+}
+
+// renderDelegationForm renders the delegation form with the current chains and warnings.
+func (h *Handler) renderDelegationForm(
+	ctx context.Context,
+	w http.ResponseWriter,
+	form model.DelegationFormData,
+	original, errorMsg string,
+	needsConfirm bool,
+) {
+	data := model.InitDelegationData{
+		Page:         "delegation",
+		AccountID:    form.AccountID,
+		FormData:     form,
+		OriginalJSON: original,
+		NeedsConfirm: needsConfirm,
+		Error:        errorMsg,
+		FormAction:   "/init/delegation",
+	}
+
+	if check, infos, ok := h.checkDelegation(ctx, form); ok {
+		names := h.chainNames(ctx, check.Chain, check.CouncilChain)
+		data.Chain = buildDelegationHops(check.Chain, infos, names)
+		data.ChainCycle = check.ChainCycle
+		data.CouncilChain = buildDelegationHops(check.CouncilChain, infos, names)
+		data.CouncilCycle = check.CouncilCycle
+		data.Warnings = check.Warnings
+	}
+
+	buf := h.getBuffer()
+	defer h.putBuffer(buf)
+
+	if err := h.tmpl.Render(buf, "init.html", data); err != nil {
+		slog.Error("failed to render delegation form", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		slog.Debug("failed to write response", "error", err)
+	}
+}
+
+// chainNames loads display names for all accounts in the given chains.
+func (h *Handler) chainNames(ctx context.Context, chains ...[]string) map[string]string {
+	var ids []string
+	for _, chain := range chains {
+		ids = append(ids, chain...)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	names, err := h.accounts.GetAccountNames(ctx, ids)
+	if err != nil {
+		slog.Warn("failed to load account names for delegation chain", "error", err)
+		return nil
+	}
+	return names
+}
+
+// buildDelegationHops converts a traced chain into display rows.
+func buildDelegationHops(chain []string, infos map[string]*delegation.Info, names map[string]string) []model.DelegationHop {
+	hops := make([]model.DelegationHop, 0, len(chain))
+	for _, id := range chain {
+		hop := model.DelegationHop{AccountID: id, Name: names[id], MTLAPBalance: "0"}
+		if info, ok := infos[id]; ok {
+			hop.Known = true
+			hop.MTLAPBalance = info.MTLAPBalance.String()
+			hop.CouncilReady = info.CouncilReady
+		}
+		hops = append(hops, hop)
+	}
+	return hops
+}
+
+// previewDelegation generates and displays XDR preview for the delegation form.
+// Warnings must be acknowledged with the confirm_warnings checkbox before XDR is generated.
+func (h *Handler) previewDelegation(w http.ResponseWriter, r *http.Request, originalEncoded string, current model.DelegationFormData) {
+	ctx := r.Context()
+
+	original, err := service.DecodeOriginalDelegation(originalEncoded)
+	if err != nil {
+		h.renderDelegationForm(ctx, w, current, originalEncoded,
+			"Session state was corrupted. Please reload the page and try again.", false)
+		return
+	}
+
+	// Validate account ID before making network call
+	if err := service.ValidateAccountID(current.AccountID); err != nil {
+		h.renderDelegationForm(ctx, w, current, originalEncoded,
+			"Please enter a valid Stellar account ID (starts with G, 56 characters).", false)
+		return
+	}
+
+	if err := service.ValidateDelegationForm(current); err != nil {
+		h.renderDelegationForm(ctx, w, current, originalEncoded, err.Error(), false)
+		return
+	}
+
+	check, _, _ := h.checkDelegation(ctx, current)
+	if len(check.Warnings) > 0 && r.FormValue("confirm_warnings") == "" {
+		h.renderDelegationForm(ctx, w, current, originalEncoded,
+			"Please review the warnings below and confirm to continue.", true)
+		return
+	}
+
+	// Fetch current sequence number from network (transaction needs current + 1)
+	seqNum, err := h.stellar.GetAccountSequence(ctx, current.AccountID)
+	if err != nil {
+		slog.Error("failed to fetch account sequence", "account_id", current.AccountID, "error", err)
+		h.renderDelegationForm(ctx, w, current, originalEncoded,
+			"Could not connect to Stellar network. Please check your account ID and try again.", false)
+		return
+	}
+
+	txs, err := h.xdrBuilder(ctx).GenerateDelegationXDR(original, current, seqNum+1)
+	if err != nil {
+		h.renderDelegationForm(ctx, w, current, originalEncoded, err.Error(), false)
+		return
+	}
+
+	h.renderPreview(ctx, w, model.InitPreviewData{
+		AccountID:    current.AccountID,
+		Transactions: txs,
+		Warnings:     check.Warnings,
+	}, "Update MTLA vote delegation")
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/service"
+)
+
+// InitSubmit handles POST /init/submit - submits a signed transaction envelope.
+// The signed envelope is read from "xdr", so the endpoint also works as a SEP-0007 callback.
+// "unsigned_xdr" (sent by the preview page) pins the submission to the generated transaction.
+func (h *Handler) InitSubmit(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	data := model.InitSubmitData{
+		Page:      "submit",
+		XDR:       strings.TrimSpace(r.FormValue("unsigned_xdr")),
+		SignedXDR: strings.TrimSpace(r.FormValue("xdr")),
+	}
+
+	switch {
+	case h.submitter == nil:
+		data.Error = "Transaction submission is not available. Please submit the signed XDR with your wallet."
+	case data.SignedXDR == "":
+		data.Error = "Please paste the signed transaction XDR."
+	default:
+		h.submitSigned(r, &data)
+	}
+
+	buf := h.getBuffer()
+	defer h.putBuffer(buf)
+
+	if err := h.tmpl.Render(buf, "init.html", data); err != nil {
+		slog.Error("failed to render submit result", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		slog.Debug("failed to write response", "error", err)
+	}
+}
+
+// submitSigned submits the signed envelope and fills the result or a human-readable error.
+func (h *Handler) submitSigned(r *http.Request, data *model.InitSubmitData) {
+	result, err := h.submitter.Submit(r.Context(), data.SignedXDR, data.XDR)
+
+	var rejected *service.TxRejectedError
+	switch {
+	case err == nil:
+		data.Result = result
+		data.AccountID = result.AccountID
+	case errors.Is(err, service.ErrInvalidEnvelope),
+		errors.Is(err, service.ErrStaleSequence),
+		errors.Is(err, service.ErrSequenceGap),
+		errors.Is(err, service.ErrTxExpired):
+		data.Error = err.Error()
+	case errors.Is(err, service.ErrSubmitTimeout):
+		data.Error = "The transaction was submitted but not confirmed in time. It may still be applied; check your account in a minute."
+	case errors.As(err, &rejected):
+		data.Error = rejected.Message
+		data.ResultCodes = rejected.Codes()
+	default:
+		slog.Error("failed to submit transaction", "error", err)
+		data.Error = "Could not connect to Stellar network. Please try again."
+	}
+}
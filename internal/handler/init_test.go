@@ -0,0 +1,315 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/mtlprog/lore/internal/delegation"
+	"github.com/mtlprog/lore/internal/handler/mocks"
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/relation/relationtest"
+	"github.com/mtlprog/lore/internal/service"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitParticipantRelationEditor(t *testing.T) {
+	const (
+		accountID = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+		targetID  = "GB7TAYRUZGE6TVT7NHP5SMIZRNQA6PLM423EYISAOAP3MKYIQMVYP2JO"
+	)
+
+	submit := func(t *testing.T, values url.Values) model.InitFormData {
+		t.Helper()
+		stellar := mocks.NewMockStellarServicer(t)
+		accounts := mocks.NewMockAccountQuerier(t)
+		tmpl := mocks.NewMockTemplateRenderer(t)
+
+		var rendered any
+		tmpl.EXPECT().Render(mock.Anything, "init.html", mock.Anything).Run(func(w io.Writer, name string, data any) {
+			rendered = data
+		}).Return(nil)
+
+		h, err := New(stellar, accounts, nil, tmpl, WithRelationTypes(relationtest.Registry()))
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/init/participant", strings.NewReader(values.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+
+		h.InitParticipantSubmit(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		data, ok := rendered.(model.InitFormData)
+		require.True(t, ok)
+		return data
+	}
+
+	relations := func(data model.InitFormData) []model.RelationField {
+		return data.FormData.(model.ParticipantFormData).Relations
+	}
+
+	t.Run("add relation uses an unnumbered key first", func(t *testing.T) {
+		data := submit(t, url.Values{
+			"account_id":        {accountID},
+			"action":            {"add_relation"},
+			"new_relation_type": {"Spouse"},
+		})
+
+		assert.Equal(t, []model.RelationField{{Type: "Spouse"}}, relations(data))
+		assert.Equal(t, "Spouse", data.RelationInfo["Spouse"].Confirmation)
+	})
+
+	t.Run("add relation numbers further keys", func(t *testing.T) {
+		data := submit(t, url.Values{
+			"account_id":        {accountID},
+			"action":            {"add_relation"},
+			"new_relation_type": {"Employer"},
+			"relation_type_0":   {"Employer"},
+			"relation_index_0":  {""},
+			"relation_value_0":  {targetID},
+		})
+
+		require.Len(t, relations(data), 2)
+		assert.Equal(t, "001", relations(data)[1].Index)
+	})
+
+	t.Run("unknown relation type is ignored", func(t *testing.T) {
+		data := submit(t, url.Values{
+			"account_id":        {accountID},
+			"action":            {"add_relation"},
+			"new_relation_type": {"Nemesis"},
+		})
+
+		assert.Empty(t, relations(data))
+	})
+
+	t.Run("remove and reindex relations", func(t *testing.T) {
+		data := submit(t, url.Values{
+			"account_id":       {accountID},
+			"action":           {"reindex_relations"},
+			"relation_type_0":  {"RecommendToMTLA"},
+			"relation_index_0": {"3"},
+			"relation_value_0": {targetID},
+			"relation_type_1":  {"RecommendToMTLA"},
+			"relation_index_1": {"7"},
+			"relation_value_1": {accountID},
+		})
+		assert.Equal(t, []string{"001", "002"}, []string{relations(data)[0].Index, relations(data)[1].Index})
+
+		data = submit(t, url.Values{
+			"account_id":       {accountID},
+			"action":           {"remove_relation:0"},
+			"relation_type_0":  {"RecommendToMTLA"},
+			"relation_index_0": {"001"},
+			"relation_value_0": {targetID},
+			"relation_type_1":  {"RecommendToMTLA"},
+			"relation_index_1": {"002"},
+			"relation_value_1": {accountID},
+		})
+		require.Len(t, relations(data), 1)
+		assert.Equal(t, "002", relations(data)[0].Index)
+	})
+
+	t.Run("editor options skip the PartOf section type", func(t *testing.T) {
+		data := submit(t, url.Values{"account_id": {accountID}})
+
+		for _, group := range data.RelationTypes {
+			for _, opt := range group.Types {
+				assert.NotEqual(t, "PartOf", opt.Name)
+			}
+		}
+		assert.NotEmpty(t, data.RelationTypes)
+	})
+}
+
+func TestInitDelegationSubmit(t *testing.T) {
+	const (
+		accountID = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+		targetID  = "GB7TAYRUZGE6TVT7NHP5SMIZRNQA6PLM423EYISAOAP3MKYIQMVYP2JO"
+	)
+
+	strPtr := func(s string) *string { return &s }
+	infos := map[string]*delegation.Info{
+		accountID: {AccountID: accountID, MTLAPBalance: decimal.NewFromInt(2)},
+		targetID:  {AccountID: targetID, MTLAPBalance: decimal.NewFromInt(1), DelegateTo: strPtr(accountID)},
+	}
+
+	submit := func(t *testing.T, values url.Values, expectSequence bool) any {
+		t.Helper()
+		stellar := mocks.NewMockStellarServicer(t)
+		accounts := mocks.NewMockAccountQuerier(t)
+		tmpl := mocks.NewMockTemplateRenderer(t)
+
+		accounts.EXPECT().GetDelegationInfo(mock.Anything).Return(infos, nil)
+		accounts.EXPECT().GetAccountNames(mock.Anything, mock.Anything).Return(map[string]string{targetID: "Bob"}, nil).Maybe()
+		if expectSequence {
+			stellar.EXPECT().GetAccountSequence(mock.Anything, accountID).Return(int64(100), nil)
+			stellar.EXPECT().SuggestBaseFee(mock.Anything, service.DefaultFeeStrategy, int64(service.DefaultMaxBaseFee)).Return(int64(300))
+		}
+
+		var rendered any
+		tmpl.EXPECT().Render(mock.Anything, "init.html", mock.Anything).Run(func(w io.Writer, name string, data any) {
+			rendered = data
+		}).Return(nil)
+
+		h, err := New(stellar, accounts, nil, tmpl)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/init/delegation", strings.NewReader(values.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+
+		h.InitDelegationSubmit(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		return rendered
+	}
+
+	t.Run("check shows the chain with the cycle", func(t *testing.T) {
+		data, ok := submit(t, url.Values{
+			"account_id":  {accountID},
+			"delegate_to": {targetID},
+			"action":      {"check"},
+		}, false).(model.InitDelegationData)
+		require.True(t, ok)
+
+		assert.True(t, data.ChainCycle)
+		require.Len(t, data.Chain, 2)
+		assert.Equal(t, "Bob", data.Chain[1].Name)
+		assert.Equal(t, "2", data.Chain[0].MTLAPBalance)
+		assert.NotEmpty(t, data.Warnings)
+		assert.False(t, data.NeedsConfirm)
+	})
+
+	t.Run("preview with warnings requires confirmation", func(t *testing.T) {
+		data, ok := submit(t, url.Values{
+			"account_id":  {accountID},
+			"delegate_to": {targetID},
+			"action":      {"preview"},
+		}, false).(model.InitDelegationData)
+		require.True(t, ok)
+
+		assert.True(t, data.NeedsConfirm)
+		assert.NotEmpty(t, data.Error)
+	})
+
+	t.Run("confirmed preview generates XDR", func(t *testing.T) {
+		data, ok := submit(t, url.Values{
+			"account_id":       {accountID},
+			"delegate_to":      {targetID},
+			"council":          {"ready"},
+			"action":           {"preview"},
+			"confirm_warnings": {"1"},
+		}, true).(model.InitPreviewData)
+		require.True(t, ok)
+
+		assert.NotEmpty(t, data.Warnings)
+		require.Len(t, data.Transactions, 1)
+		tx := data.Transactions[0]
+		assert.NotEmpty(t, tx.XDR)
+		assert.Equal(t, int64(101), tx.Sequence)
+		assert.Equal(t, "0.0000600", tx.MaxFee)
+		assert.NotEmpty(t, tx.ValidBefore)
+		assert.Len(t, tx.Operations, 2)
+		assert.True(t, strings.HasPrefix(tx.WalletURI, "web+stellar:tx?xdr="))
+		assert.Contains(t, tx.WalletURI, "msg=Update%20MTLA%20vote%20delegation")
+		assert.NotEmpty(t, tx.QRCode)
+	})
+}
+
+func TestInitSubmit(t *testing.T) {
+	const accountID = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+
+	tests := []struct {
+		name      string
+		values    url.Values
+		submitErr error
+		noSubmit  bool
+		check     func(t *testing.T, data model.InitSubmitData)
+	}{
+		{
+			name:   "success",
+			values: url.Values{"xdr": {"signed"}, "unsigned_xdr": {"unsigned"}},
+			check: func(t *testing.T, data model.InitSubmitData) {
+				require.NotNil(t, data.Result)
+				assert.Equal(t, accountID, data.AccountID)
+				assert.Empty(t, data.Error)
+			},
+		},
+		{
+			name:     "missing signed XDR",
+			values:   url.Values{"unsigned_xdr": {"unsigned"}},
+			noSubmit: true,
+			check: func(t *testing.T, data model.InitSubmitData) {
+				assert.Contains(t, data.Error, "signed transaction XDR")
+			},
+		},
+		{
+			name:      "rejected",
+			values:    url.Values{"xdr": {"signed"}},
+			submitErr: &service.TxRejectedError{TransactionCode: "tx_bad_seq", Message: "Sequence changed."},
+			check: func(t *testing.T, data model.InitSubmitData) {
+				assert.Equal(t, "Sequence changed.", data.Error)
+				assert.Equal(t, []string{"tx_bad_seq"}, data.ResultCodes)
+				assert.Nil(t, data.Result)
+			},
+		},
+		{
+			name:      "invalid envelope",
+			values:    url.Values{"xdr": {"signed"}},
+			submitErr: fmt.Errorf("%w: transaction is not signed", service.ErrInvalidEnvelope),
+			check: func(t *testing.T, data model.InitSubmitData) {
+				assert.Contains(t, data.Error, "not signed")
+			},
+		},
+		{
+			name:      "network error",
+			values:    url.Values{"xdr": {"signed"}},
+			submitErr: errors.New("connection refused"),
+			check: func(t *testing.T, data model.InitSubmitData) {
+				assert.Contains(t, data.Error, "Could not connect")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			submitter := mocks.NewMockTxSubmitter(t)
+			tmpl := mocks.NewMockTemplateRenderer(t)
+
+			if !tt.noSubmit {
+				var result *model.SubmitResult
+				if tt.submitErr == nil {
+					result = &model.SubmitResult{AccountID: accountID, Hash: "abc", Ledger: 1, Refreshing: true}
+				}
+				submitter.EXPECT().Submit(mock.Anything, "signed", tt.values.Get("unsigned_xdr")).Return(result, tt.submitErr)
+			}
+
+			var rendered model.InitSubmitData
+			tmpl.EXPECT().Render(mock.Anything, "init.html", mock.Anything).Run(func(w io.Writer, name string, data any) {
+				rendered = data.(model.InitSubmitData)
+			}).Return(nil)
+
+			h, err := New(mocks.NewMockStellarServicer(t), mocks.NewMockAccountQuerier(t), nil, tmpl, WithTxSubmitter(submitter))
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/init/submit", strings.NewReader(tt.values.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+
+			h.InitSubmit(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			tt.check(t, rendered)
+		})
+	}
+}
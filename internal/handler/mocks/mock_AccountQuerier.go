@@ -5,9 +5,15 @@ package mocks
 import (
 	context "context"
 
+	delegation "github.com/mtlprog/lore/internal/delegation"
+
 	mock "github.com/stretchr/testify/mock"
 
+	model "github.com/mtlprog/lore/internal/model"
+
 	repository "github.com/mtlprog/lore/internal/repository"
+
+	time "time"
 )
 
 // MockAccountQuerier is an autogenerated mock type for the AccountQuerier type
@@ -81,6 +87,65 @@ func (_c *MockAccountQuerier_CountSearchAccounts_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// GetAccountControl provides a mock function with given fields: ctx, accountID
+func (_m *MockAccountQuerier) GetAccountControl(ctx context.Context, accountID string) ([]repository.ControlRow, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountControl")
+	}
+
+	var r0 []repository.ControlRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]repository.ControlRow, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []repository.ControlRow); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.ControlRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountQuerier_GetAccountControl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountControl'
+type MockAccountQuerier_GetAccountControl_Call struct {
+	*mock.Call
+}
+
+// GetAccountControl is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID string
+func (_e *MockAccountQuerier_Expecter) GetAccountControl(ctx interface{}, accountID interface{}) *MockAccountQuerier_GetAccountControl_Call {
+	return &MockAccountQuerier_GetAccountControl_Call{Call: _e.mock.On("GetAccountControl", ctx, accountID)}
+}
+
+func (_c *MockAccountQuerier_GetAccountControl_Call) Run(run func(ctx context.Context, accountID string)) *MockAccountQuerier_GetAccountControl_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetAccountControl_Call) Return(_a0 []repository.ControlRow, _a1 error) *MockAccountQuerier_GetAccountControl_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetAccountControl_Call) RunAndReturn(run func(context.Context, string) ([]repository.ControlRow, error)) *MockAccountQuerier_GetAccountControl_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAccountInfo provides a mock function with given fields: ctx, accountID
 func (_m *MockAccountQuerier) GetAccountInfo(ctx context.Context, accountID string) (*repository.AccountInfo, error) {
 	ret := _m.Called(ctx, accountID)
@@ -199,6 +264,68 @@ func (_c *MockAccountQuerier_GetAccountNames_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// GetAccountOperations provides a mock function with given fields: ctx, accountID, filter, cursor, limit
+func (_m *MockAccountQuerier) GetAccountOperations(ctx context.Context, accountID string, filter model.OperationFilter, cursor string, limit int) (*model.OperationsPage, error) {
+	ret := _m.Called(ctx, accountID, filter, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountOperations")
+	}
+
+	var r0 *model.OperationsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.OperationFilter, string, int) (*model.OperationsPage, error)); ok {
+		return rf(ctx, accountID, filter, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.OperationFilter, string, int) *model.OperationsPage); ok {
+		r0 = rf(ctx, accountID, filter, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OperationsPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, model.OperationFilter, string, int) error); ok {
+		r1 = rf(ctx, accountID, filter, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountQuerier_GetAccountOperations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountOperations'
+type MockAccountQuerier_GetAccountOperations_Call struct {
+	*mock.Call
+}
+
+// GetAccountOperations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID string
+//   - filter model.OperationFilter
+//   - cursor string
+//   - limit int
+func (_e *MockAccountQuerier_Expecter) GetAccountOperations(ctx interface{}, accountID interface{}, filter interface{}, cursor interface{}, limit interface{}) *MockAccountQuerier_GetAccountOperations_Call {
+	return &MockAccountQuerier_GetAccountOperations_Call{Call: _e.mock.On("GetAccountOperations", ctx, accountID, filter, cursor, limit)}
+}
+
+func (_c *MockAccountQuerier_GetAccountOperations_Call) Run(run func(ctx context.Context, accountID string, filter model.OperationFilter, cursor string, limit int)) *MockAccountQuerier_GetAccountOperations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(model.OperationFilter), args[3].(string), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetAccountOperations_Call) Return(_a0 *model.OperationsPage, _a1 error) *MockAccountQuerier_GetAccountOperations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetAccountOperations_Call) RunAndReturn(run func(context.Context, string, model.OperationFilter, string, int) (*model.OperationsPage, error)) *MockAccountQuerier_GetAccountOperations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAllTags provides a mock function with given fields: ctx
 func (_m *MockAccountQuerier) GetAllTags(ctx context.Context) ([]repository.TagRow, error) {
 	ret := _m.Called(ctx)
@@ -316,6 +443,65 @@ func (_c *MockAccountQuerier_GetConfirmedRelationships_Call) RunAndReturn(run fu
 	return _c
 }
 
+// GetControlGraph provides a mock function with given fields: ctx, issuer
+func (_m *MockAccountQuerier) GetControlGraph(ctx context.Context, issuer string) ([]repository.ControlRow, error) {
+	ret := _m.Called(ctx, issuer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetControlGraph")
+	}
+
+	var r0 []repository.ControlRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]repository.ControlRow, error)); ok {
+		return rf(ctx, issuer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []repository.ControlRow); ok {
+		r0 = rf(ctx, issuer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.ControlRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, issuer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountQuerier_GetControlGraph_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetControlGraph'
+type MockAccountQuerier_GetControlGraph_Call struct {
+	*mock.Call
+}
+
+// GetControlGraph is a helper method to define mock.On call
+//   - ctx context.Context
+//   - issuer string
+func (_e *MockAccountQuerier_Expecter) GetControlGraph(ctx interface{}, issuer interface{}) *MockAccountQuerier_GetControlGraph_Call {
+	return &MockAccountQuerier_GetControlGraph_Call{Call: _e.mock.On("GetControlGraph", ctx, issuer)}
+}
+
+func (_c *MockAccountQuerier_GetControlGraph_Call) Run(run func(ctx context.Context, issuer string)) *MockAccountQuerier_GetControlGraph_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetControlGraph_Call) Return(_a0 []repository.ControlRow, _a1 error) *MockAccountQuerier_GetControlGraph_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetControlGraph_Call) RunAndReturn(run func(context.Context, string) ([]repository.ControlRow, error)) *MockAccountQuerier_GetControlGraph_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCorporate provides a mock function with given fields: ctx, limit, offset
 func (_m *MockAccountQuerier) GetCorporate(ctx context.Context, limit int, offset int) ([]repository.CorporateRow, error) {
 	ret := _m.Called(ctx, limit, offset)
@@ -376,6 +562,309 @@ func (_c *MockAccountQuerier_GetCorporate_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// GetCorporatePage provides a mock function with given fields: ctx, cursor, limit
+func (_m *MockAccountQuerier) GetCorporatePage(ctx context.Context, cursor string, limit int) ([]repository.CorporateRow, repository.KeysetPage, error) {
+	ret := _m.Called(ctx, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCorporatePage")
+	}
+
+	var r0 []repository.CorporateRow
+	var r1 repository.KeysetPage
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]repository.CorporateRow, repository.KeysetPage, error)); ok {
+		return rf(ctx, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []repository.CorporateRow); ok {
+		r0 = rf(ctx, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.CorporateRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) repository.KeysetPage); ok {
+		r1 = rf(ctx, cursor, limit)
+	} else {
+		r1 = ret.Get(1).(repository.KeysetPage)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int) error); ok {
+		r2 = rf(ctx, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockAccountQuerier_GetCorporatePage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCorporatePage'
+type MockAccountQuerier_GetCorporatePage_Call struct {
+	*mock.Call
+}
+
+// GetCorporatePage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cursor string
+//   - limit int
+func (_e *MockAccountQuerier_Expecter) GetCorporatePage(ctx interface{}, cursor interface{}, limit interface{}) *MockAccountQuerier_GetCorporatePage_Call {
+	return &MockAccountQuerier_GetCorporatePage_Call{Call: _e.mock.On("GetCorporatePage", ctx, cursor, limit)}
+}
+
+func (_c *MockAccountQuerier_GetCorporatePage_Call) Run(run func(ctx context.Context, cursor string, limit int)) *MockAccountQuerier_GetCorporatePage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetCorporatePage_Call) Return(_a0 []repository.CorporateRow, _a1 repository.KeysetPage, _a2 error) *MockAccountQuerier_GetCorporatePage_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetCorporatePage_Call) RunAndReturn(run func(context.Context, string, int) ([]repository.CorporateRow, repository.KeysetPage, error)) *MockAccountQuerier_GetCorporatePage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCouncilCandidates provides a mock function with given fields: ctx, limit
+func (_m *MockAccountQuerier) GetCouncilCandidates(ctx context.Context, limit int) ([]model.CouncilCandidate, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCouncilCandidates")
+	}
+
+	var r0 []model.CouncilCandidate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]model.CouncilCandidate, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.CouncilCandidate); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CouncilCandidate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountQuerier_GetCouncilCandidates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCouncilCandidates'
+type MockAccountQuerier_GetCouncilCandidates_Call struct {
+	*mock.Call
+}
+
+// GetCouncilCandidates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockAccountQuerier_Expecter) GetCouncilCandidates(ctx interface{}, limit interface{}) *MockAccountQuerier_GetCouncilCandidates_Call {
+	return &MockAccountQuerier_GetCouncilCandidates_Call{Call: _e.mock.On("GetCouncilCandidates", ctx, limit)}
+}
+
+func (_c *MockAccountQuerier_GetCouncilCandidates_Call) Run(run func(ctx context.Context, limit int)) *MockAccountQuerier_GetCouncilCandidates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetCouncilCandidates_Call) Return(_a0 []model.CouncilCandidate, _a1 error) *MockAccountQuerier_GetCouncilCandidates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetCouncilCandidates_Call) RunAndReturn(run func(context.Context, int) ([]model.CouncilCandidate, error)) *MockAccountQuerier_GetCouncilCandidates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDelegationInfo provides a mock function with given fields: ctx
+func (_m *MockAccountQuerier) GetDelegationInfo(ctx context.Context) (map[string]*delegation.Info, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelegationInfo")
+	}
+
+	var r0 map[string]*delegation.Info
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[string]*delegation.Info, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]*delegation.Info); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*delegation.Info)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountQuerier_GetDelegationInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDelegationInfo'
+type MockAccountQuerier_GetDelegationInfo_Call struct {
+	*mock.Call
+}
+
+// GetDelegationInfo is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAccountQuerier_Expecter) GetDelegationInfo(ctx interface{}) *MockAccountQuerier_GetDelegationInfo_Call {
+	return &MockAccountQuerier_GetDelegationInfo_Call{Call: _e.mock.On("GetDelegationInfo", ctx)}
+}
+
+func (_c *MockAccountQuerier_GetDelegationInfo_Call) Run(run func(ctx context.Context)) *MockAccountQuerier_GetDelegationInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetDelegationInfo_Call) Return(_a0 map[string]*delegation.Info, _a1 error) *MockAccountQuerier_GetDelegationInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetDelegationInfo_Call) RunAndReturn(run func(context.Context) (map[string]*delegation.Info, error)) *MockAccountQuerier_GetDelegationInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFlowEdges provides a mock function with given fields: ctx, filter, limit
+func (_m *MockAccountQuerier) GetFlowEdges(ctx context.Context, filter model.FlowFilter, limit int) ([]model.FlowEdge, error) {
+	ret := _m.Called(ctx, filter, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFlowEdges")
+	}
+
+	var r0 []model.FlowEdge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.FlowFilter, int) ([]model.FlowEdge, error)); ok {
+		return rf(ctx, filter, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.FlowFilter, int) []model.FlowEdge); ok {
+		r0 = rf(ctx, filter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.FlowEdge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.FlowFilter, int) error); ok {
+		r1 = rf(ctx, filter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountQuerier_GetFlowEdges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFlowEdges'
+type MockAccountQuerier_GetFlowEdges_Call struct {
+	*mock.Call
+}
+
+// GetFlowEdges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter model.FlowFilter
+//   - limit int
+func (_e *MockAccountQuerier_Expecter) GetFlowEdges(ctx interface{}, filter interface{}, limit interface{}) *MockAccountQuerier_GetFlowEdges_Call {
+	return &MockAccountQuerier_GetFlowEdges_Call{Call: _e.mock.On("GetFlowEdges", ctx, filter, limit)}
+}
+
+func (_c *MockAccountQuerier_GetFlowEdges_Call) Run(run func(ctx context.Context, filter model.FlowFilter, limit int)) *MockAccountQuerier_GetFlowEdges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.FlowFilter), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetFlowEdges_Call) Return(_a0 []model.FlowEdge, _a1 error) *MockAccountQuerier_GetFlowEdges_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetFlowEdges_Call) RunAndReturn(run func(context.Context, model.FlowFilter, int) ([]model.FlowEdge, error)) *MockAccountQuerier_GetFlowEdges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFlowPeriods provides a mock function with given fields: ctx, filter
+func (_m *MockAccountQuerier) GetFlowPeriods(ctx context.Context, filter model.FlowFilter) ([]model.FlowPeriod, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFlowPeriods")
+	}
+
+	var r0 []model.FlowPeriod
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.FlowFilter) ([]model.FlowPeriod, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.FlowFilter) []model.FlowPeriod); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.FlowPeriod)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.FlowFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountQuerier_GetFlowPeriods_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFlowPeriods'
+type MockAccountQuerier_GetFlowPeriods_Call struct {
+	*mock.Call
+}
+
+// GetFlowPeriods is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter model.FlowFilter
+func (_e *MockAccountQuerier_Expecter) GetFlowPeriods(ctx interface{}, filter interface{}) *MockAccountQuerier_GetFlowPeriods_Call {
+	return &MockAccountQuerier_GetFlowPeriods_Call{Call: _e.mock.On("GetFlowPeriods", ctx, filter)}
+}
+
+func (_c *MockAccountQuerier_GetFlowPeriods_Call) Run(run func(ctx context.Context, filter model.FlowFilter)) *MockAccountQuerier_GetFlowPeriods_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.FlowFilter))
+	})
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetFlowPeriods_Call) Return(_a0 []model.FlowPeriod, _a1 error) *MockAccountQuerier_GetFlowPeriods_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetFlowPeriods_Call) RunAndReturn(run func(context.Context, model.FlowFilter) ([]model.FlowPeriod, error)) *MockAccountQuerier_GetFlowPeriods_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLPShares provides a mock function with given fields: ctx, accountID
 func (_m *MockAccountQuerier) GetLPShares(ctx context.Context, accountID string) ([]repository.LPShareRow, error) {
 	ret := _m.Called(ctx, accountID)
@@ -435,6 +924,125 @@ func (_c *MockAccountQuerier_GetLPShares_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// GetMembershipTimeline provides a mock function with given fields: ctx, accountID
+func (_m *MockAccountQuerier) GetMembershipTimeline(ctx context.Context, accountID string) ([]model.MembershipEvent, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembershipTimeline")
+	}
+
+	var r0 []model.MembershipEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.MembershipEvent, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.MembershipEvent); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.MembershipEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountQuerier_GetMembershipTimeline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMembershipTimeline'
+type MockAccountQuerier_GetMembershipTimeline_Call struct {
+	*mock.Call
+}
+
+// GetMembershipTimeline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID string
+func (_e *MockAccountQuerier_Expecter) GetMembershipTimeline(ctx interface{}, accountID interface{}) *MockAccountQuerier_GetMembershipTimeline_Call {
+	return &MockAccountQuerier_GetMembershipTimeline_Call{Call: _e.mock.On("GetMembershipTimeline", ctx, accountID)}
+}
+
+func (_c *MockAccountQuerier_GetMembershipTimeline_Call) Run(run func(ctx context.Context, accountID string)) *MockAccountQuerier_GetMembershipTimeline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetMembershipTimeline_Call) Return(_a0 []model.MembershipEvent, _a1 error) *MockAccountQuerier_GetMembershipTimeline_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetMembershipTimeline_Call) RunAndReturn(run func(context.Context, string) ([]model.MembershipEvent, error)) *MockAccountQuerier_GetMembershipTimeline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNewMembers provides a mock function with given fields: ctx, since, limit
+func (_m *MockAccountQuerier) GetNewMembers(ctx context.Context, since time.Time, limit int) ([]model.MembershipEvent, error) {
+	ret := _m.Called(ctx, since, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNewMembers")
+	}
+
+	var r0 []model.MembershipEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]model.MembershipEvent, error)); ok {
+		return rf(ctx, since, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []model.MembershipEvent); ok {
+		r0 = rf(ctx, since, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.MembershipEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, since, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountQuerier_GetNewMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNewMembers'
+type MockAccountQuerier_GetNewMembers_Call struct {
+	*mock.Call
+}
+
+// GetNewMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - since time.Time
+//   - limit int
+func (_e *MockAccountQuerier_Expecter) GetNewMembers(ctx interface{}, since interface{}, limit interface{}) *MockAccountQuerier_GetNewMembers_Call {
+	return &MockAccountQuerier_GetNewMembers_Call{Call: _e.mock.On("GetNewMembers", ctx, since, limit)}
+}
+
+func (_c *MockAccountQuerier_GetNewMembers_Call) Run(run func(ctx context.Context, since time.Time, limit int)) *MockAccountQuerier_GetNewMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetNewMembers_Call) Return(_a0 []model.MembershipEvent, _a1 error) *MockAccountQuerier_GetNewMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetNewMembers_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]model.MembershipEvent, error)) *MockAccountQuerier_GetNewMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPersons provides a mock function with given fields: ctx, limit, offset
 func (_m *MockAccountQuerier) GetPersons(ctx context.Context, limit int, offset int) ([]repository.PersonRow, error) {
 	ret := _m.Called(ctx, limit, offset)
@@ -495,6 +1103,73 @@ func (_c *MockAccountQuerier_GetPersons_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// GetPersonsPage provides a mock function with given fields: ctx, cursor, limit
+func (_m *MockAccountQuerier) GetPersonsPage(ctx context.Context, cursor string, limit int) ([]repository.PersonRow, repository.KeysetPage, error) {
+	ret := _m.Called(ctx, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPersonsPage")
+	}
+
+	var r0 []repository.PersonRow
+	var r1 repository.KeysetPage
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]repository.PersonRow, repository.KeysetPage, error)); ok {
+		return rf(ctx, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []repository.PersonRow); ok {
+		r0 = rf(ctx, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.PersonRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) repository.KeysetPage); ok {
+		r1 = rf(ctx, cursor, limit)
+	} else {
+		r1 = ret.Get(1).(repository.KeysetPage)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int) error); ok {
+		r2 = rf(ctx, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockAccountQuerier_GetPersonsPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPersonsPage'
+type MockAccountQuerier_GetPersonsPage_Call struct {
+	*mock.Call
+}
+
+// GetPersonsPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cursor string
+//   - limit int
+func (_e *MockAccountQuerier_Expecter) GetPersonsPage(ctx interface{}, cursor interface{}, limit interface{}) *MockAccountQuerier_GetPersonsPage_Call {
+	return &MockAccountQuerier_GetPersonsPage_Call{Call: _e.mock.On("GetPersonsPage", ctx, cursor, limit)}
+}
+
+func (_c *MockAccountQuerier_GetPersonsPage_Call) Run(run func(ctx context.Context, cursor string, limit int)) *MockAccountQuerier_GetPersonsPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetPersonsPage_Call) Return(_a0 []repository.PersonRow, _a1 repository.KeysetPage, _a2 error) *MockAccountQuerier_GetPersonsPage_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetPersonsPage_Call) RunAndReturn(run func(context.Context, string, int) ([]repository.PersonRow, repository.KeysetPage, error)) *MockAccountQuerier_GetPersonsPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetRelationships provides a mock function with given fields: ctx, accountID
 func (_m *MockAccountQuerier) GetRelationships(ctx context.Context, accountID string) ([]repository.RelationshipRow, error) {
 	ret := _m.Called(ctx, accountID)
@@ -672,6 +1347,73 @@ func (_c *MockAccountQuerier_GetSynthetic_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// GetSyntheticPage provides a mock function with given fields: ctx, cursor, limit
+func (_m *MockAccountQuerier) GetSyntheticPage(ctx context.Context, cursor string, limit int) ([]repository.SyntheticRow, repository.KeysetPage, error) {
+	ret := _m.Called(ctx, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSyntheticPage")
+	}
+
+	var r0 []repository.SyntheticRow
+	var r1 repository.KeysetPage
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]repository.SyntheticRow, repository.KeysetPage, error)); ok {
+		return rf(ctx, cursor, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []repository.SyntheticRow); ok {
+		r0 = rf(ctx, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.SyntheticRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) repository.KeysetPage); ok {
+		r1 = rf(ctx, cursor, limit)
+	} else {
+		r1 = ret.Get(1).(repository.KeysetPage)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int) error); ok {
+		r2 = rf(ctx, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockAccountQuerier_GetSyntheticPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSyntheticPage'
+type MockAccountQuerier_GetSyntheticPage_Call struct {
+	*mock.Call
+}
+
+// GetSyntheticPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cursor string
+//   - limit int
+func (_e *MockAccountQuerier_Expecter) GetSyntheticPage(ctx interface{}, cursor interface{}, limit interface{}) *MockAccountQuerier_GetSyntheticPage_Call {
+	return &MockAccountQuerier_GetSyntheticPage_Call{Call: _e.mock.On("GetSyntheticPage", ctx, cursor, limit)}
+}
+
+func (_c *MockAccountQuerier_GetSyntheticPage_Call) Run(run func(ctx context.Context, cursor string, limit int)) *MockAccountQuerier_GetSyntheticPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetSyntheticPage_Call) Return(_a0 []repository.SyntheticRow, _a1 repository.KeysetPage, _a2 error) *MockAccountQuerier_GetSyntheticPage_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockAccountQuerier_GetSyntheticPage_Call) RunAndReturn(run func(context.Context, string, int) ([]repository.SyntheticRow, repository.KeysetPage, error)) *MockAccountQuerier_GetSyntheticPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTrustRatings provides a mock function with given fields: ctx, accountID
 func (_m *MockAccountQuerier) GetTrustRatings(ctx context.Context, accountID string) (*repository.TrustRating, error) {
 	ret := _m.Called(ctx, accountID)
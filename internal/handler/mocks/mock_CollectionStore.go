@@ -0,0 +1,203 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/mtlprog/lore/internal/model"
+)
+
+// MockCollectionStore is an autogenerated mock type for the CollectionStore type
+type MockCollectionStore struct {
+	mock.Mock
+}
+
+type MockCollectionStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCollectionStore) EXPECT() *MockCollectionStore_Expecter {
+	return &MockCollectionStore_Expecter{mock: &_m.Mock}
+}
+
+// CreateCollection provides a mock function with given fields: ctx, c
+func (_m *MockCollectionStore) CreateCollection(ctx context.Context, c *model.SignatureCollection) error {
+	ret := _m.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCollection")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SignatureCollection) error); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCollectionStore_CreateCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCollection'
+type MockCollectionStore_CreateCollection_Call struct {
+	*mock.Call
+}
+
+// CreateCollection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - c *model.SignatureCollection
+func (_e *MockCollectionStore_Expecter) CreateCollection(ctx interface{}, c interface{}) *MockCollectionStore_CreateCollection_Call {
+	return &MockCollectionStore_CreateCollection_Call{Call: _e.mock.On("CreateCollection", ctx, c)}
+}
+
+func (_c *MockCollectionStore_CreateCollection_Call) Run(run func(ctx context.Context, c *model.SignatureCollection)) *MockCollectionStore_CreateCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.SignatureCollection))
+	})
+	return _c
+}
+
+func (_c *MockCollectionStore_CreateCollection_Call) Return(_a0 error) *MockCollectionStore_CreateCollection_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCollectionStore_CreateCollection_Call) RunAndReturn(run func(context.Context, *model.SignatureCollection) error) *MockCollectionStore_CreateCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCollection provides a mock function with given fields: ctx, id
+func (_m *MockCollectionStore) GetCollection(ctx context.Context, id string) (*model.SignatureCollection, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCollection")
+	}
+
+	var r0 *model.SignatureCollection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.SignatureCollection, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.SignatureCollection); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SignatureCollection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCollectionStore_GetCollection_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCollection'
+type MockCollectionStore_GetCollection_Call struct {
+	*mock.Call
+}
+
+// GetCollection is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockCollectionStore_Expecter) GetCollection(ctx interface{}, id interface{}) *MockCollectionStore_GetCollection_Call {
+	return &MockCollectionStore_GetCollection_Call{Call: _e.mock.On("GetCollection", ctx, id)}
+}
+
+func (_c *MockCollectionStore_GetCollection_Call) Run(run func(ctx context.Context, id string)) *MockCollectionStore_GetCollection_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCollectionStore_GetCollection_Call) Return(_a0 *model.SignatureCollection, _a1 error) *MockCollectionStore_GetCollection_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCollectionStore_GetCollection_Call) RunAndReturn(run func(context.Context, string) (*model.SignatureCollection, error)) *MockCollectionStore_GetCollection_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCollectionEnvelope provides a mock function with given fields: ctx, id, previous, envelope
+func (_m *MockCollectionStore) UpdateCollectionEnvelope(ctx context.Context, id string, previous string, envelope string) (bool, error) {
+	ret := _m.Called(ctx, id, previous, envelope)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCollectionEnvelope")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (bool, error)); ok {
+		return rf(ctx, id, previous, envelope)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
+		r0 = rf(ctx, id, previous, envelope)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, id, previous, envelope)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCollectionStore_UpdateCollectionEnvelope_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCollectionEnvelope'
+type MockCollectionStore_UpdateCollectionEnvelope_Call struct {
+	*mock.Call
+}
+
+// UpdateCollectionEnvelope is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - previous string
+//   - envelope string
+func (_e *MockCollectionStore_Expecter) UpdateCollectionEnvelope(ctx interface{}, id interface{}, previous interface{}, envelope interface{}) *MockCollectionStore_UpdateCollectionEnvelope_Call {
+	return &MockCollectionStore_UpdateCollectionEnvelope_Call{Call: _e.mock.On("UpdateCollectionEnvelope", ctx, id, previous, envelope)}
+}
+
+func (_c *MockCollectionStore_UpdateCollectionEnvelope_Call) Run(run func(ctx context.Context, id string, previous string, envelope string)) *MockCollectionStore_UpdateCollectionEnvelope_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockCollectionStore_UpdateCollectionEnvelope_Call) Return(_a0 bool, _a1 error) *MockCollectionStore_UpdateCollectionEnvelope_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCollectionStore_UpdateCollectionEnvelope_Call) RunAndReturn(run func(context.Context, string, string, string) (bool, error)) *MockCollectionStore_UpdateCollectionEnvelope_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCollectionStore creates a new instance of MockCollectionStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCollectionStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCollectionStore {
+	mock := &MockCollectionStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
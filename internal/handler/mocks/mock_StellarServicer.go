@@ -8,6 +8,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	model "github.com/mtlprog/lore/internal/model"
+
+	service "github.com/mtlprog/lore/internal/service"
 )
 
 // MockStellarServicer is an autogenerated mock type for the StellarServicer type
@@ -385,6 +387,65 @@ func (_c *MockStellarServicer_GetRawAccountData_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// GetSigningRequirement provides a mock function with given fields: ctx, accountID
+func (_m *MockStellarServicer) GetSigningRequirement(ctx context.Context, accountID string) (*model.SigningRequirement, error) {
+	ret := _m.Called(ctx, accountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSigningRequirement")
+	}
+
+	var r0 *model.SigningRequirement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.SigningRequirement, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.SigningRequirement); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SigningRequirement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStellarServicer_GetSigningRequirement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSigningRequirement'
+type MockStellarServicer_GetSigningRequirement_Call struct {
+	*mock.Call
+}
+
+// GetSigningRequirement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID string
+func (_e *MockStellarServicer_Expecter) GetSigningRequirement(ctx interface{}, accountID interface{}) *MockStellarServicer_GetSigningRequirement_Call {
+	return &MockStellarServicer_GetSigningRequirement_Call{Call: _e.mock.On("GetSigningRequirement", ctx, accountID)}
+}
+
+func (_c *MockStellarServicer_GetSigningRequirement_Call) Run(run func(ctx context.Context, accountID string)) *MockStellarServicer_GetSigningRequirement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockStellarServicer_GetSigningRequirement_Call) Return(_a0 *model.SigningRequirement, _a1 error) *MockStellarServicer_GetSigningRequirement_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStellarServicer_GetSigningRequirement_Call) RunAndReturn(run func(context.Context, string) (*model.SigningRequirement, error)) *MockStellarServicer_GetSigningRequirement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTokenDetail provides a mock function with given fields: ctx, code, issuer
 func (_m *MockStellarServicer) GetTokenDetail(ctx context.Context, code string, issuer string) (*model.TokenDetail, error) {
 	ret := _m.Called(ctx, code, issuer)
@@ -565,6 +626,54 @@ func (_c *MockStellarServicer_GetTransactionDetail_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// SuggestBaseFee provides a mock function with given fields: ctx, strategy, maxBaseFee
+func (_m *MockStellarServicer) SuggestBaseFee(ctx context.Context, strategy service.FeeStrategy, maxBaseFee int64) int64 {
+	ret := _m.Called(ctx, strategy, maxBaseFee)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuggestBaseFee")
+	}
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, service.FeeStrategy, int64) int64); ok {
+		r0 = rf(ctx, strategy, maxBaseFee)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// MockStellarServicer_SuggestBaseFee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuggestBaseFee'
+type MockStellarServicer_SuggestBaseFee_Call struct {
+	*mock.Call
+}
+
+// SuggestBaseFee is a helper method to define mock.On call
+//   - ctx context.Context
+//   - strategy service.FeeStrategy
+//   - maxBaseFee int64
+func (_e *MockStellarServicer_Expecter) SuggestBaseFee(ctx interface{}, strategy interface{}, maxBaseFee interface{}) *MockStellarServicer_SuggestBaseFee_Call {
+	return &MockStellarServicer_SuggestBaseFee_Call{Call: _e.mock.On("SuggestBaseFee", ctx, strategy, maxBaseFee)}
+}
+
+func (_c *MockStellarServicer_SuggestBaseFee_Call) Run(run func(ctx context.Context, strategy service.FeeStrategy, maxBaseFee int64)) *MockStellarServicer_SuggestBaseFee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(service.FeeStrategy), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockStellarServicer_SuggestBaseFee_Call) Return(_a0 int64) *MockStellarServicer_SuggestBaseFee_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStellarServicer_SuggestBaseFee_Call) RunAndReturn(run func(context.Context, service.FeeStrategy, int64) int64) *MockStellarServicer_SuggestBaseFee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockStellarServicer creates a new instance of MockStellarServicer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockStellarServicer(t interface {
@@ -0,0 +1,146 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/mtlprog/lore/internal/model"
+)
+
+// MockTxSubmitter is an autogenerated mock type for the TxSubmitter type
+type MockTxSubmitter struct {
+	mock.Mock
+}
+
+type MockTxSubmitter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTxSubmitter) EXPECT() *MockTxSubmitter_Expecter {
+	return &MockTxSubmitter_Expecter{mock: &_m.Mock}
+}
+
+// Remember provides a mock function with given fields: ctx, accountID, txs
+func (_m *MockTxSubmitter) Remember(ctx context.Context, accountID string, txs []model.InitTransaction) error {
+	ret := _m.Called(ctx, accountID, txs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []model.InitTransaction) error); ok {
+		r0 = rf(ctx, accountID, txs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTxSubmitter_Remember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remember'
+type MockTxSubmitter_Remember_Call struct {
+	*mock.Call
+}
+
+// Remember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountID string
+//   - txs []model.InitTransaction
+func (_e *MockTxSubmitter_Expecter) Remember(ctx interface{}, accountID interface{}, txs interface{}) *MockTxSubmitter_Remember_Call {
+	return &MockTxSubmitter_Remember_Call{Call: _e.mock.On("Remember", ctx, accountID, txs)}
+}
+
+func (_c *MockTxSubmitter_Remember_Call) Run(run func(ctx context.Context, accountID string, txs []model.InitTransaction)) *MockTxSubmitter_Remember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]model.InitTransaction))
+	})
+	return _c
+}
+
+func (_c *MockTxSubmitter_Remember_Call) Return(_a0 error) *MockTxSubmitter_Remember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTxSubmitter_Remember_Call) RunAndReturn(run func(context.Context, string, []model.InitTransaction) error) *MockTxSubmitter_Remember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Submit provides a mock function with given fields: ctx, signedXDR, expectedXDR
+func (_m *MockTxSubmitter) Submit(ctx context.Context, signedXDR string, expectedXDR string) (*model.SubmitResult, error) {
+	ret := _m.Called(ctx, signedXDR, expectedXDR)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Submit")
+	}
+
+	var r0 *model.SubmitResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.SubmitResult, error)); ok {
+		return rf(ctx, signedXDR, expectedXDR)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.SubmitResult); ok {
+		r0 = rf(ctx, signedXDR, expectedXDR)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.SubmitResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, signedXDR, expectedXDR)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTxSubmitter_Submit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Submit'
+type MockTxSubmitter_Submit_Call struct {
+	*mock.Call
+}
+
+// Submit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - signedXDR string
+//   - expectedXDR string
+func (_e *MockTxSubmitter_Expecter) Submit(ctx interface{}, signedXDR interface{}, expectedXDR interface{}) *MockTxSubmitter_Submit_Call {
+	return &MockTxSubmitter_Submit_Call{Call: _e.mock.On("Submit", ctx, signedXDR, expectedXDR)}
+}
+
+func (_c *MockTxSubmitter_Submit_Call) Run(run func(ctx context.Context, signedXDR string, expectedXDR string)) *MockTxSubmitter_Submit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockTxSubmitter_Submit_Call) Return(_a0 *model.SubmitResult, _a1 error) *MockTxSubmitter_Submit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTxSubmitter_Submit_Call) RunAndReturn(run func(context.Context, string, string) (*model.SubmitResult, error)) *MockTxSubmitter_Submit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTxSubmitter creates a new instance of MockTxSubmitter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTxSubmitter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTxSubmitter {
+	mock := &MockTxSubmitter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// StellarToml handles GET /.well-known/stellar.toml - publishes URI_REQUEST_SIGNING_KEY
+// so wallets can verify the origin_domain of SEP-0007 URIs signed by Lore.
+func (h *Handler) StellarToml(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Access-Control-Allow-Origin", "*") // required by SEP-0001
+	if _, err := fmt.Fprintf(w, "URI_REQUEST_SIGNING_KEY = %q\n", h.txURIs.SigningKey()); err != nil {
+		slog.Debug("failed to write response", "error", err)
+	}
+}
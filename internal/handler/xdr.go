@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/service"
+)
+
+// maxXDRFormBytes limits the inspector form; a transaction with 100 operations fits easily.
+const maxXDRFormBytes = 256 << 10
+
+// XDRToolData holds data for the XDR inspector template.
+type XDRToolData struct {
+	XDR          string
+	Transaction  *model.DecodedTransaction
+	AccountNames map[string]string // Map of account ID to name for involved accounts
+	Error        string
+}
+
+// XDRTool handles GET and POST /tools/xdr - decodes a pasted transaction envelope.
+func (h *Handler) XDRTool(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxXDRFormBytes)
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	data := XDRToolData{XDR: strings.TrimSpace(r.FormValue("xdr"))}
+
+	if data.XDR != "" {
+		tx, err := service.DecodeEnvelope(data.XDR, h.relations)
+		switch {
+		case errors.Is(err, service.ErrInvalidXDR):
+			data.Error = "Could not decode the envelope. Please paste a base64 TransactionEnvelope."
+		case err != nil:
+			slog.Error("failed to decode envelope", "error", err)
+			data.Error = "Could not decode the envelope."
+		default:
+			data.Transaction = tx
+			h.checkOutdated(ctx, tx)
+			if ids := tx.AccountIDs(); len(ids) > 0 {
+				data.AccountNames, err = h.accounts.GetAccountNames(ctx, ids)
+				if err != nil {
+					slog.Error("failed to fetch account names", "lookup_count", len(ids), "error", err)
+					data.AccountNames = make(map[string]string)
+				}
+			}
+		}
+	}
+
+	buf := h.getBuffer()
+	defer h.putBuffer(buf)
+
+	if err := h.tmpl.Render(buf, "xdr.html", data); err != nil {
+		slog.Error("failed to render xdr template", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := buf.WriteTo(w); err != nil {
+		slog.Debug("failed to write response", "error", err)
+	}
+}
+
+// checkOutdated marks a decoded transaction whose sequence number was already used or
+// whose time bounds have passed. Lookup failures only skip the check.
+func (h *Handler) checkOutdated(ctx context.Context, tx *model.DecodedTransaction) {
+	accountSequence, err := h.stellar.GetAccountSequence(ctx, tx.SourceAccount)
+	if err != nil {
+		slog.Debug("failed to fetch account sequence", "account_id", tx.SourceAccount, "error", err)
+		return
+	}
+	if err := service.CheckSubmittable(tx.Sequence, tx.MaxTime, accountSequence, time.Now()); err != nil {
+		tx.Outdated = err.Error()
+	}
+}
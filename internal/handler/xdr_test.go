@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/mtlprog/lore/internal/handler/mocks"
+	"github.com/samber/lo"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXDRTool(t *testing.T) {
+	const (
+		sourceID = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+		targetID = "GB7TAYRUZGE6TVT7NHP5SMIZRNQA6PLM423EYISAOAP3MKYIQMVYP2JO"
+	)
+
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: sourceID, Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations:           []txnbuild.Operation{&txnbuild.ManageData{Name: "mtla_delegate", Value: []byte(targetID)}},
+		BaseFee:              txnbuild.MinBaseFee,
+		Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	envelope, err := tx.Base64()
+	require.NoError(t, err)
+
+	// accountSequence is the source account's current sequence; nil means the envelope is not decoded
+	render := func(t *testing.T, method string, values url.Values, accountSequence *int64) XDRToolData {
+		t.Helper()
+		stellar := mocks.NewMockStellarServicer(t)
+		accounts := mocks.NewMockAccountQuerier(t)
+		tmpl := mocks.NewMockTemplateRenderer(t)
+
+		if accountSequence != nil {
+			stellar.EXPECT().GetAccountSequence(mock.Anything, sourceID).Return(*accountSequence, nil)
+			accounts.EXPECT().GetAccountNames(mock.Anything, []string{sourceID, targetID}).
+				Return(map[string]string{targetID: "Bob"}, nil)
+		}
+
+		var rendered XDRToolData
+		tmpl.EXPECT().Render(mock.Anything, "xdr.html", mock.Anything).Run(func(w io.Writer, name string, data any) {
+			rendered = data.(XDRToolData)
+		}).Return(nil)
+
+		h, err := New(stellar, accounts, nil, tmpl)
+		require.NoError(t, err)
+
+		var req *http.Request
+		if method == http.MethodPost {
+			req = httptest.NewRequest(method, "/tools/xdr", strings.NewReader(values.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		} else {
+			req = httptest.NewRequest(method, "/tools/xdr?"+values.Encode(), nil)
+		}
+		w := httptest.NewRecorder()
+
+		h.XDRTool(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		return rendered
+	}
+
+	t.Run("empty form", func(t *testing.T) {
+		data := render(t, http.MethodGet, url.Values{}, nil)
+		assert.Nil(t, data.Transaction)
+		assert.Empty(t, data.Error)
+	})
+
+	t.Run("decodes envelope and resolves names", func(t *testing.T) {
+		data := render(t, http.MethodPost, url.Values{"xdr": {envelope}}, lo.ToPtr(int64(1)))
+		require.NotNil(t, data.Transaction)
+		require.Len(t, data.Transaction.Operations, 1)
+		assert.Equal(t, "sets vote delegate to", data.Transaction.Operations[0].Action)
+		assert.Equal(t, "Bob", data.AccountNames[targetID])
+		assert.Empty(t, data.Transaction.Outdated)
+	})
+
+	t.Run("flags envelope with a used sequence number", func(t *testing.T) {
+		data := render(t, http.MethodPost, url.Values{"xdr": {envelope}}, lo.ToPtr(int64(2)))
+		require.NotNil(t, data.Transaction)
+		assert.Contains(t, data.Transaction.Outdated, "transaction is outdated")
+	})
+
+	t.Run("invalid envelope shows error", func(t *testing.T) {
+		data := render(t, http.MethodPost, url.Values{"xdr": {"garbage"}}, nil)
+		assert.Nil(t, data.Transaction)
+		assert.Contains(t, data.Error, "Could not decode")
+		assert.Equal(t, "garbage", data.XDR)
+	})
+}
@@ -0,0 +1,249 @@
+package lint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mtlprog/lore/internal/database"
+)
+
+// Repository loads the relationships and metadata the linter works on.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new lint repository.
+func NewRepository(pool *pgxpool.Pool) (*Repository, error) {
+	if pool == nil {
+		return nil, errors.New("database pool is required")
+	}
+	return &Repository{pool: pool}, nil
+}
+
+// GetAccountData loads relationships, metadata and related account info for one account.
+// Returns nil if the account is not synced.
+func (r *Repository) GetAccountData(ctx context.Context, accountID string) (*AccountData, error) {
+	corporate, err := r.getCorporateFlags(ctx, sq.Eq{"account_id": accountID})
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := corporate[accountID]; !exists {
+		return nil, nil
+	}
+
+	outgoing, err := r.getRelations(ctx, sq.Eq{"source_account_id": accountID})
+	if err != nil {
+		return nil, fmt.Errorf("get outgoing relations: %w", err)
+	}
+	incoming, err := r.getRelations(ctx, sq.Eq{"target_account_id": accountID})
+	if err != nil {
+		return nil, fmt.Errorf("get incoming relations: %w", err)
+	}
+	metadata, err := r.getMetadata(ctx, sq.Eq{"account_id": accountID})
+	if err != nil {
+		return nil, err
+	}
+
+	related := make([]string, 0, len(outgoing)+len(incoming))
+	for _, rel := range outgoing {
+		related = append(related, rel.TargetID)
+	}
+	for _, rel := range incoming {
+		related = append(related, rel.SourceID)
+	}
+	if len(related) > 0 {
+		relatedFlags, err := r.getCorporateFlags(ctx, sq.Eq{"account_id": related})
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(corporate, relatedFlags)
+	}
+
+	return newAccountData(accountID, corporate, outgoing, incoming, metadata), nil
+}
+
+// GetAllAccountData loads the data of every synced account in account ID order.
+// Accounts, relationships and metadata are each read with a single query.
+func (r *Repository) GetAllAccountData(ctx context.Context) ([]*AccountData, error) {
+	corporate, err := r.getCorporateFlags(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	relations, err := r.getRelations(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get relations: %w", err)
+	}
+	metadata, err := r.getMetadata(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return groupAccountData(corporate, relations, metadata), nil
+}
+
+// groupAccountData splits relationships and metadata of all accounts by account.
+// Only accounts present in corporate are returned, in account ID order.
+func groupAccountData(corporate map[string]bool, relations []Relation, metadata []Metadata) []*AccountData {
+	outgoing := make(map[string][]Relation)
+	incoming := make(map[string][]Relation)
+	for _, rel := range relations {
+		outgoing[rel.SourceID] = append(outgoing[rel.SourceID], rel)
+		incoming[rel.TargetID] = append(incoming[rel.TargetID], rel)
+	}
+	byAccount := make(map[string][]Metadata)
+	for _, m := range metadata {
+		byAccount[m.AccountID] = append(byAccount[m.AccountID], m)
+	}
+
+	ids := slices.Sorted(maps.Keys(corporate))
+	result := make([]*AccountData, len(ids))
+	for i, id := range ids {
+		result[i] = newAccountData(id, corporate, outgoing[id], incoming[id], byAccount[id])
+	}
+	return result
+}
+
+// newAccountData assembles the data of one account. corporate maps synced
+// account IDs to their MTLAC flag and must contain the account itself; related
+// accounts missing from it are unknown.
+func newAccountData(accountID string, corporate map[string]bool, outgoing, incoming []Relation, metadata []Metadata) *AccountData {
+	data := &AccountData{
+		AccountID:   accountID,
+		IsCorporate: corporate[accountID],
+		Outgoing:    outgoing,
+		Incoming:    incoming,
+		Metadata:    metadata,
+		Known:       make(map[string]bool),
+		Corporate:   make(map[string]bool),
+	}
+
+	related := func(id string) {
+		if isCorporate, ok := corporate[id]; ok {
+			data.Known[id] = true
+			data.Corporate[id] = isCorporate
+		}
+	}
+	for _, rel := range outgoing {
+		related(rel.TargetID)
+	}
+	for _, rel := range incoming {
+		related(rel.SourceID)
+	}
+	return data
+}
+
+// getCorporateFlags returns a map of existing account ID -> is MTLAC holder for
+// the accounts matching where, or all accounts if where is nil. Accounts that
+// are not synced are absent from the map.
+func (r *Repository) getCorporateFlags(ctx context.Context, where sq.Sqlizer) (map[string]bool, error) {
+	builder := database.QB.
+		Select("account_id", "COALESCE(mtlac_balance, 0) > 0 AND COALESCE(mtlac_balance, 0) <= 4").
+		From("accounts")
+	if where != nil {
+		builder = builder.Where(where)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build accounts query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query accounts: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		var corporate bool
+		if err := rows.Scan(&id, &corporate); err != nil {
+			return nil, fmt.Errorf("scan account: %w", err)
+		}
+		result[id] = corporate
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate accounts: %w", err)
+	}
+
+	return result, nil
+}
+
+// getRelations returns the relationships matching where, or all of them if
+// where is nil.
+func (r *Repository) getRelations(ctx context.Context, where sq.Sqlizer) ([]Relation, error) {
+	builder := database.QB.
+		Select("source_account_id", "target_account_id", "relation_type", "relation_index").
+		From("relationships").
+		OrderBy("relation_type", "relation_index")
+	if where != nil {
+		builder = builder.Where(where)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build relations query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query relations: %w", err)
+	}
+	defer rows.Close()
+
+	var relations []Relation
+	for rows.Next() {
+		var rel Relation
+		if err := rows.Scan(&rel.SourceID, &rel.TargetID, &rel.Type, &rel.Index); err != nil {
+			return nil, fmt.Errorf("scan relation: %w", err)
+		}
+		relations = append(relations, rel)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate relations: %w", err)
+	}
+
+	return relations, nil
+}
+
+// getMetadata returns the metadata entries matching where, or all of them if
+// where is nil.
+func (r *Repository) getMetadata(ctx context.Context, where sq.Sqlizer) ([]Metadata, error) {
+	builder := database.QB.
+		Select("account_id", "data_key", "data_index", "data_value").
+		From("account_metadata").
+		OrderBy("account_id", "data_key", "data_index")
+	if where != nil {
+		builder = builder.Where(where)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build metadata query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query metadata: %w", err)
+	}
+	defer rows.Close()
+
+	var metadata []Metadata
+	for rows.Next() {
+		var m Metadata
+		if err := rows.Scan(&m.AccountID, &m.Key, &m.Index, &m.Value); err != nil {
+			return nil, fmt.Errorf("scan metadata: %w", err)
+		}
+		metadata = append(metadata, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate metadata: %w", err)
+	}
+
+	return metadata, nil
+}
@@ -0,0 +1,42 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupAccountData(t *testing.T) {
+	const unknown = "GUNKNOWN"
+	corporate := map[string]bool{accB: true, accA: false, accC: false}
+	relations := []Relation{
+		{SourceID: accA, TargetID: accB, Type: "Employer", Index: "1"},
+		{SourceID: accB, TargetID: accA, Type: "Employee", Index: "1"},
+		{SourceID: accA, TargetID: unknown, Type: "Spouse"},
+	}
+	metadata := []Metadata{
+		{AccountID: accA, Key: "Friend", Index: "1", Value: "not an account"},
+		{AccountID: accC, Key: "Name", Value: "Carol"},
+	}
+
+	accounts := groupAccountData(corporate, relations, metadata)
+	require.Len(t, accounts, 3)
+
+	a, b, c := accounts[0], accounts[1], accounts[2]
+	assert.Equal(t, []string{accA, accB, accC}, []string{a.AccountID, b.AccountID, c.AccountID})
+
+	assert.False(t, a.IsCorporate)
+	assert.Equal(t, []Relation{relations[0], relations[2]}, a.Outgoing)
+	assert.Equal(t, []Relation{relations[1]}, a.Incoming)
+	assert.Equal(t, []Metadata{metadata[0]}, a.Metadata)
+	assert.Equal(t, map[string]bool{accB: true}, a.Known, "untracked targets are unknown")
+	assert.Equal(t, map[string]bool{accB: true}, a.Corporate)
+
+	assert.True(t, b.IsCorporate)
+	assert.Equal(t, map[string]bool{accA: false}, b.Corporate)
+
+	assert.Empty(t, c.Outgoing)
+	assert.Empty(t, c.Known)
+	assert.Equal(t, []Metadata{metadata[1]}, c.Metadata)
+}
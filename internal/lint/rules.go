@@ -0,0 +1,369 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/mtlprog/lore/internal/relation"
+	"github.com/mtlprog/lore/internal/reputation"
+)
+
+// Rule inspects one account and returns the issues it finds.
+type Rule func(data *AccountData) []Finding
+
+// DefaultRules is the rule set used by Lint.
+var DefaultRules = []Rule{
+	checkSelfReferences,
+	checkConflictingRelations,
+	checkDuplicates,
+	checkCounterparts,
+	checkUnknownTargets,
+	checkMalformedRelations,
+}
+
+// Lint runs DefaultRules against the account and returns findings
+// ordered by severity, then rule, then ManageData key.
+func Lint(data *AccountData) []Finding {
+	return LintWith(data, DefaultRules)
+}
+
+// LintWith runs the given rules against the account.
+func LintWith(data *AccountData, rules []Rule) []Finding {
+	if data.Types == nil {
+		data.Types = relation.NewRegistry(nil)
+	}
+
+	var findings []Finding
+	for _, rule := range rules {
+		findings = append(findings, rule(data)...)
+	}
+
+	for i := range findings {
+		if findings[i].AccountID == "" {
+			findings[i].AccountID = data.AccountID
+		}
+	}
+
+	sort.SliceStable(findings, func(a, b int) bool {
+		fa, fb := findings[a], findings[b]
+		if fa.Severity.rank() != fb.Severity.rank() {
+			return fa.Severity.rank() < fb.Severity.rank()
+		}
+		if fa.Rule != fb.Rule {
+			return fa.Rule < fb.Rule
+		}
+		return fa.Key < fb.Key
+	})
+
+	return findings
+}
+
+// FixURL returns the init form link where accountID can edit its ManageData.
+func FixURL(accountID string, corporate bool) string {
+	if corporate {
+		return "/init/corporate?account_id=" + accountID
+	}
+	return "/init/participant?account_id=" + accountID
+}
+
+func isRating(relType string) bool {
+	return reputation.Rating(relType).IsValid()
+}
+
+// ownFixURL links to the linted account's own init form.
+func ownFixURL(data *AccountData) string {
+	return FixURL(data.AccountID, data.IsCorporate)
+}
+
+// otherFixURL links to the init form of a related account.
+func otherFixURL(data *AccountData, accountID string) string {
+	return FixURL(accountID, data.Corporate[accountID])
+}
+
+func checkSelfReferences(data *AccountData) []Finding {
+	var findings []Finding
+	for _, rel := range data.Outgoing {
+		if rel.TargetID != data.AccountID {
+			continue
+		}
+
+		if isRating(rel.Type) {
+			findings = append(findings, Finding{
+				Rule:         RuleSelfRating,
+				Category:     CategorySelfReference,
+				Severity:     SeverityError,
+				Key:          rel.Key(),
+				RelationType: rel.Type,
+				TargetID:     rel.TargetID,
+				Message:      fmt.Sprintf("%s rates the account itself; self-ratings are ignored by reputation", rel.Key()),
+				Suggestion:   fmt.Sprintf("Delete the %s entry", rel.Key()),
+				FixURL:       ownFixURL(data),
+			})
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Rule:         RuleSelfReference,
+			Category:     CategorySelfReference,
+			Severity:     SeverityError,
+			Key:          rel.Key(),
+			RelationType: rel.Type,
+			TargetID:     rel.TargetID,
+			Message:      fmt.Sprintf("%s points at the account itself", rel.Key()),
+			Suggestion:   fmt.Sprintf("Delete %s or set it to the intended account", rel.Key()),
+			FixURL:       ownFixURL(data),
+		})
+	}
+	return findings
+}
+
+func checkConflictingRelations(data *AccountData) []Finding {
+	// target -> relation type -> first declaring key
+	byTarget := make(map[string]map[string]string)
+	for _, rel := range data.Outgoing {
+		if byTarget[rel.TargetID] == nil {
+			byTarget[rel.TargetID] = make(map[string]string)
+		}
+		if _, exists := byTarget[rel.TargetID][rel.Type]; !exists {
+			byTarget[rel.TargetID][rel.Type] = rel.Key()
+		}
+	}
+
+	targets := make([]string, 0, len(byTarget))
+	for target := range byTarget {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	var findings []Finding
+	for _, target := range targets {
+		types := byTarget[target]
+
+		for _, pair := range data.Types.Conflicts() {
+			keyA, hasA := types[pair[0]]
+			keyB, hasB := types[pair[1]]
+			if !hasA || !hasB {
+				continue
+			}
+			findings = append(findings, Finding{
+				Rule:         RuleConflictingRelations,
+				Category:     CategoryContradiction,
+				Severity:     SeverityError,
+				Key:          keyA,
+				RelationType: pair[0],
+				TargetID:     target,
+				Message:      fmt.Sprintf("%s and %s point at the same account", keyA, keyB),
+				Suggestion:   fmt.Sprintf("Keep only the one that is currently true and delete the other (%s or %s)", keyA, keyB),
+				FixURL:       ownFixURL(data),
+			})
+		}
+
+		var ratings []string
+		for t := range types {
+			if isRating(t) {
+				ratings = append(ratings, t)
+			}
+		}
+		if len(ratings) > 1 {
+			sort.Strings(ratings)
+			keys := make([]string, len(ratings))
+			for i, t := range ratings {
+				keys[i] = types[t]
+			}
+			findings = append(findings, Finding{
+				Rule:         RuleConflictingRelations,
+				Category:     CategoryContradiction,
+				Severity:     SeverityWarning,
+				Key:          keys[0],
+				RelationType: ratings[0],
+				TargetID:     target,
+				Message:      fmt.Sprintf("account is rated %v at once; only the worst rating is counted", ratings),
+				Suggestion:   fmt.Sprintf("Keep a single rating and delete the others %v", keys),
+				FixURL:       ownFixURL(data),
+			})
+		}
+	}
+	return findings
+}
+
+func checkDuplicates(data *AccountData) []Finding {
+	var findings []Finding
+
+	seenIndex := make(map[string]string)  // type:numeric index -> first key
+	seenTarget := make(map[string]string) // type:target -> first key
+	for _, rel := range data.Outgoing {
+		if n, err := strconv.Atoi(rel.Index); err == nil {
+			indexKey := fmt.Sprintf("%s:%d", rel.Type, n)
+			if first, ok := seenIndex[indexKey]; ok {
+				findings = append(findings, Finding{
+					Rule:         RuleDuplicateIndex,
+					Category:     CategoryDuplicate,
+					Severity:     SeverityWarning,
+					Key:          rel.Key(),
+					RelationType: rel.Type,
+					TargetID:     rel.TargetID,
+					Message:      fmt.Sprintf("%s and %s use the same index with different padding", first, rel.Key()),
+					Suggestion:   fmt.Sprintf("Move %s to an unused index", rel.Key()),
+					FixURL:       ownFixURL(data),
+				})
+			} else {
+				seenIndex[indexKey] = rel.Key()
+			}
+		}
+
+		targetKey := rel.Type + ":" + rel.TargetID
+		if first, ok := seenTarget[targetKey]; ok {
+			findings = append(findings, Finding{
+				Rule:         RuleDuplicateTarget,
+				Category:     CategoryDuplicate,
+				Severity:     SeverityInfo,
+				Key:          rel.Key(),
+				RelationType: rel.Type,
+				TargetID:     rel.TargetID,
+				Message:      fmt.Sprintf("%s repeats %s for the same account", rel.Key(), first),
+				Suggestion:   fmt.Sprintf("Delete %s", rel.Key()),
+				FixURL:       ownFixURL(data),
+			})
+		} else {
+			seenTarget[targetKey] = rel.Key()
+		}
+	}
+	return findings
+}
+
+func checkCounterparts(data *AccountData) []Finding {
+	// source/target:type sets for quick lookups
+	incoming := make(map[string]bool)
+	for _, rel := range data.Incoming {
+		incoming[rel.SourceID+":"+rel.Type] = true
+	}
+	outgoing := make(map[string]bool)
+	for _, rel := range data.Outgoing {
+		outgoing[rel.TargetID+":"+rel.Type] = true
+	}
+
+	var findings []Finding
+	reported := make(map[string]bool)
+
+	for _, rel := range data.Outgoing {
+		if rel.TargetID == data.AccountID {
+			continue
+		}
+		dedupe := rel.TargetID + ":" + rel.Type
+		if reported[dedupe] {
+			continue
+		}
+
+		if complement, ok := data.Types.ComplementOf(rel.Type); ok && !incoming[rel.TargetID+":"+complement] {
+			reported[dedupe] = true
+			findings = append(findings, Finding{
+				Rule:         RuleMissingCounterpart,
+				Category:     CategoryConfirmation,
+				Severity:     SeverityWarning,
+				Key:          rel.Key(),
+				RelationType: rel.Type,
+				TargetID:     rel.TargetID,
+				Message:      fmt.Sprintf("%s is not confirmed: the target has no %s pointing back", rel.Key(), complement),
+				Suggestion:   fmt.Sprintf("Ask the target account to declare %s, or delete %s", complement, rel.Key()),
+				FixURL:       otherFixURL(data, rel.TargetID),
+			})
+			continue
+		}
+
+		if data.Types.IsSymmetric(rel.Type) && !incoming[rel.TargetID+":"+rel.Type] {
+			reported[dedupe] = true
+			findings = append(findings, Finding{
+				Rule:         RuleOneWaySymmetric,
+				Category:     CategoryConfirmation,
+				Severity:     SeverityWarning,
+				Key:          rel.Key(),
+				RelationType: rel.Type,
+				TargetID:     rel.TargetID,
+				Message:      fmt.Sprintf("%s is one-way and hidden from the profile until the target declares %s back", rel.Key(), rel.Type),
+				Suggestion:   fmt.Sprintf("Ask the target account to declare %s, or delete %s", rel.Type, rel.Key()),
+				FixURL:       otherFixURL(data, rel.TargetID),
+			})
+		}
+	}
+
+	for _, rel := range data.Incoming {
+		if rel.SourceID == data.AccountID {
+			continue
+		}
+		dedupe := "in:" + rel.SourceID + ":" + rel.Type
+		if reported[dedupe] {
+			continue
+		}
+
+		expected := ""
+		rule := RuleMissingCounterpart
+		if complement, ok := data.Types.ComplementOf(rel.Type); ok {
+			expected = complement
+		} else if data.Types.IsSymmetric(rel.Type) {
+			expected = rel.Type
+			rule = RuleOneWaySymmetric
+		}
+		if expected == "" || outgoing[rel.SourceID+":"+expected] {
+			continue
+		}
+
+		reported[dedupe] = true
+		findings = append(findings, Finding{
+			Rule:         rule,
+			Category:     CategoryConfirmation,
+			Severity:     SeverityInfo,
+			RelationType: expected,
+			TargetID:     rel.SourceID,
+			Message:      fmt.Sprintf("another account declared %s towards this account, but no %s points back", rel.Key(), expected),
+			Suggestion:   fmt.Sprintf("Declare %s to confirm the relation if it is true", expected),
+			FixURL:       ownFixURL(data),
+		})
+	}
+
+	return findings
+}
+
+func checkUnknownTargets(data *AccountData) []Finding {
+	var findings []Finding
+	reported := make(map[string]bool)
+	for _, rel := range data.Outgoing {
+		if rel.TargetID == data.AccountID || data.Known[rel.TargetID] || reported[rel.TargetID] {
+			continue
+		}
+		reported[rel.TargetID] = true
+		findings = append(findings, Finding{
+			Rule:         RuleUnknownTarget,
+			Category:     CategoryUnknownTarget,
+			Severity:     SeverityInfo,
+			Key:          rel.Key(),
+			RelationType: rel.Type,
+			TargetID:     rel.TargetID,
+			Message:      fmt.Sprintf("%s points at an account that is not tracked by Lore", rel.Key()),
+			Suggestion:   "Check the account ID for typos or delete the entry",
+			FixURL:       ownFixURL(data),
+		})
+	}
+	return findings
+}
+
+func checkMalformedRelations(data *AccountData) []Finding {
+	var findings []Finding
+	for _, meta := range data.Metadata {
+		if !data.Types.IsKnown(meta.Key) {
+			continue
+		}
+		key := meta.Key + meta.Index
+		findings = append(findings, Finding{
+			Rule:         RuleMalformedRelation,
+			Category:     CategoryMalformed,
+			Severity:     SeverityError,
+			Key:          key,
+			RelationType: meta.Key,
+			Message:      fmt.Sprintf("%s has value %q, which is not a Stellar account ID", key, meta.Value),
+			Suggestion:   fmt.Sprintf("Set %s to a valid account ID (G...) or delete it", key),
+			FixURL:       ownFixURL(data),
+		})
+	}
+	return findings
+}
@@ -0,0 +1,191 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/mtlprog/lore/internal/relation"
+	"github.com/mtlprog/lore/internal/relation/relationtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	accA = "GAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"
+	accB = "GBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB"
+	accC = "GCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCCC"
+)
+
+func out(target, relType, index string) Relation {
+	return Relation{SourceID: accA, TargetID: target, Type: relType, Index: index}
+}
+
+func in(source, relType, index string) Relation {
+	return Relation{SourceID: source, TargetID: accA, Type: relType, Index: index}
+}
+
+func rules(findings []Finding) []string {
+	result := make([]string, len(findings))
+	for i, f := range findings {
+		result[i] = f.Rule
+	}
+	return result
+}
+
+func TestLint(t *testing.T) {
+	known := map[string]bool{accA: true, accB: true, accC: true}
+
+	tests := []struct {
+		name     string
+		data     AccountData
+		expected []string
+	}{
+		{
+			name: "clean account",
+			data: AccountData{
+				Outgoing: []Relation{out(accB, "Spouse", ""), out(accC, "PartOf", "001")},
+				Incoming: []Relation{in(accB, "Spouse", ""), in(accC, "MyPart", "001")},
+			},
+			expected: []string{},
+		},
+		{
+			name: "spouse and divorce to the same account",
+			data: AccountData{
+				Outgoing: []Relation{out(accB, "Divorce", ""), out(accB, "Spouse", "")},
+				Incoming: []Relation{in(accB, "Spouse", "")},
+			},
+			expected: []string{RuleConflictingRelations},
+		},
+		{
+			name: "several ratings for one account",
+			data: AccountData{
+				Outgoing: []Relation{out(accB, "A", ""), out(accB, "C", "1")},
+			},
+			expected: []string{RuleConflictingRelations},
+		},
+		{
+			name: "guardian without ward",
+			data: AccountData{
+				Outgoing: []Relation{out(accB, "Guardian", "")},
+			},
+			expected: []string{RuleMissingCounterpart},
+		},
+		{
+			name: "incoming guardian without ward",
+			data: AccountData{
+				Incoming: []Relation{in(accB, "Guardian", "")},
+			},
+			expected: []string{RuleMissingCounterpart},
+		},
+		{
+			name: "one-way symmetric relation",
+			data: AccountData{
+				Outgoing: []Relation{out(accB, "Partnership", "")},
+			},
+			expected: []string{RuleOneWaySymmetric},
+		},
+		{
+			name: "self reference",
+			data: AccountData{
+				Outgoing: []Relation{out(accA, "Employer", "")},
+			},
+			expected: []string{RuleSelfReference},
+		},
+		{
+			name: "self rating",
+			data: AccountData{
+				Outgoing: []Relation{out(accA, "A", "")},
+			},
+			expected: []string{RuleSelfRating},
+		},
+		{
+			name: "duplicate index with different padding",
+			data: AccountData{
+				Outgoing: []Relation{out(accB, "RecommendToMTLA", "001"), out(accC, "RecommendToMTLA", "1")},
+			},
+			expected: []string{RuleDuplicateIndex},
+		},
+		{
+			name: "same target under several indices",
+			data: AccountData{
+				Outgoing: []Relation{out(accB, "RecommendToMTLA", "1"), out(accB, "RecommendToMTLA", "2")},
+			},
+			expected: []string{RuleDuplicateTarget},
+		},
+		{
+			name: "unknown target",
+			data: AccountData{
+				Outgoing: []Relation{out("GDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDDD", "RecommendToMTLA", "")},
+			},
+			expected: []string{RuleUnknownTarget},
+		},
+		{
+			name: "malformed relation value",
+			data: AccountData{
+				Metadata: []Metadata{{Key: "PartOf", Index: "1", Value: "not-an-account"}, {Key: "Name", Value: "Alice"}},
+			},
+			expected: []string{RuleMalformedRelation},
+		},
+		{
+			name: "errors sort before warnings",
+			data: AccountData{
+				Outgoing: []Relation{out(accB, "Partnership", ""), out(accA, "B", "")},
+			},
+			expected: []string{RuleSelfRating, RuleOneWaySymmetric},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.data
+			data.AccountID = accA
+			data.Known = known
+			data.Types = relationtest.Registry()
+
+			findings := Lint(&data)
+			assert.Equal(t, tt.expected, rules(findings))
+			for _, f := range findings {
+				assert.Equal(t, accA, f.AccountID)
+				assert.NotEmpty(t, f.Message)
+				assert.NotEmpty(t, f.FixURL)
+			}
+		})
+	}
+}
+
+func TestFixURL(t *testing.T) {
+	assert.Equal(t, "/init/participant?account_id="+accA, FixURL(accA, false))
+	assert.Equal(t, "/init/corporate?account_id="+accA, FixURL(accA, true))
+}
+
+func TestMissingCounterpartLinksToTarget(t *testing.T) {
+	data := &AccountData{
+		AccountID: accA,
+		Outgoing:  []Relation{out(accB, "MyPart", "1")},
+		Known:     map[string]bool{accB: true},
+		Corporate: map[string]bool{accB: false},
+		Types:     relationtest.Registry(),
+	}
+
+	findings := Lint(data)
+	require.Len(t, findings, 1)
+	assert.Equal(t, RuleMissingCounterpart, findings[0].Rule)
+	assert.Equal(t, accB, findings[0].TargetID)
+	assert.Equal(t, FixURL(accB, false), findings[0].FixURL)
+}
+
+func TestConflictingRelationsFollowRegistry(t *testing.T) {
+	data := &AccountData{
+		AccountID: accA,
+		Outgoing:  []Relation{out(accB, "Mentor", ""), out(accB, "Student", "")},
+		Known:     map[string]bool{accB: true},
+		Types: relation.NewRegistry([]relation.Type{
+			{Name: "Mentor", ConflictsWith: []string{"Student"}},
+			{Name: "Student"},
+		}),
+	}
+
+	findings := Lint(data)
+	require.Len(t, findings, 1)
+	assert.Equal(t, RuleConflictingRelations, findings[0].Rule)
+	assert.Equal(t, "Mentor", findings[0].RelationType)
+}
@@ -0,0 +1,71 @@
+package lint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mtlprog/lore/internal/relation"
+)
+
+// Service runs lint rules against accounts stored in the database.
+type Service struct {
+	repo  *Repository
+	types *relation.Registry
+	rules []Rule
+}
+
+// NewService creates a new lint service using DefaultRules and the given
+// relation types.
+func NewService(pool *pgxpool.Pool, types *relation.Registry) (*Service, error) {
+	if types == nil {
+		return nil, errors.New("relation types are required")
+	}
+	repo, err := NewRepository(pool)
+	if err != nil {
+		return nil, fmt.Errorf("create repository: %w", err)
+	}
+	return &Service{repo: repo, types: types, rules: DefaultRules}, nil
+}
+
+// LintAccount returns findings for a single account.
+// Returns nil findings and false if the account is not synced.
+func (s *Service) LintAccount(ctx context.Context, accountID string) ([]Finding, bool, error) {
+	data, err := s.repo.GetAccountData(ctx, accountID)
+	if err != nil {
+		return nil, false, fmt.Errorf("get account data: %w", err)
+	}
+	if data == nil {
+		return nil, false, nil
+	}
+	data.Types = s.types
+	return LintWith(data, s.rules), true, nil
+}
+
+// LintAll lints every synced account and calls fn with each account's findings.
+// Accounts without findings are skipped. Stops at the first error returned by fn.
+// All accounts are loaded up front, so the rules run without further queries.
+func (s *Service) LintAll(ctx context.Context, fn func(accountID string, findings []Finding) error) error {
+	accounts, err := s.repo.GetAllAccountData(ctx)
+	if err != nil {
+		return fmt.Errorf("get account data: %w", err)
+	}
+
+	for _, data := range accounts {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		data.Types = s.types
+		findings := LintWith(data, s.rules)
+		if len(findings) == 0 {
+			continue
+		}
+		if err := fn(data.AccountID, findings); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
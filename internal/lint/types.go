@@ -0,0 +1,100 @@
+package lint
+
+import "github.com/mtlprog/lore/internal/relation"
+
+// Severity describes how serious a finding is.
+type Severity string
+
+// Severity levels, from most to least serious.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// rank orders severities for sorting (lower is more serious).
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 0
+	case SeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Category groups findings by the kind of inconsistency.
+type Category string
+
+// Finding categories.
+const (
+	CategoryContradiction Category = "contradiction"  // Mutually exclusive relations to the same account
+	CategoryConfirmation  Category = "confirmation"   // Missing counterpart or one-way symmetric relation
+	CategorySelfReference Category = "self_reference" // Account points at itself
+	CategoryDuplicate     Category = "duplicate"      // Same relation declared more than once
+	CategoryUnknownTarget Category = "unknown_target" // Target account is not tracked by Lore
+	CategoryMalformed     Category = "malformed"      // ManageData entry that looks like a relation but is not parsable
+)
+
+// Rule identifiers reported in findings.
+const (
+	RuleConflictingRelations = "conflicting-relations"
+	RuleMissingCounterpart   = "missing-counterpart"
+	RuleOneWaySymmetric      = "one-way-symmetric"
+	RuleSelfReference        = "self-reference"
+	RuleSelfRating           = "self-rating"
+	RuleDuplicateIndex       = "duplicate-index"
+	RuleDuplicateTarget      = "duplicate-target"
+	RuleUnknownTarget        = "unknown-target"
+	RuleMalformedRelation    = "malformed-relation"
+)
+
+// Relation is a single relationship edge as stored in the relationships table.
+type Relation struct {
+	SourceID string
+	TargetID string
+	Type     string
+	Index    string
+}
+
+// Key returns the ManageData key that declares the relation (e.g. "PartOf001").
+func (r Relation) Key() string {
+	return r.Type + r.Index
+}
+
+// Metadata is a single non-relationship ManageData entry.
+type Metadata struct {
+	AccountID string // Account that declares the entry
+	Key       string
+	Index     string
+	Value     string
+}
+
+// AccountData is everything the rules need to lint one account.
+type AccountData struct {
+	AccountID   string
+	IsCorporate bool
+	Outgoing    []Relation      // Relations declared by the account
+	Incoming    []Relation      // Relations declared by others about the account
+	Metadata    []Metadata      // Remaining ManageData entries
+	Known       map[string]bool // Related accounts that exist in Lore
+	Corporate   map[string]bool // Related accounts that are MTLAC holders
+
+	// Types is the relation type registry; without it no relation type is known.
+	Types *relation.Registry
+}
+
+// Finding is a single data-quality issue with a suggested fix.
+type Finding struct {
+	Rule         string   `json:"rule"`
+	Category     Category `json:"category"`
+	Severity     Severity `json:"severity"`
+	AccountID    string   `json:"account_id"`    // Account the finding is reported for
+	Key          string   `json:"key,omitempty"` // ManageData key involved, if any
+	RelationType string   `json:"relation_type,omitempty"`
+	TargetID     string   `json:"target_id,omitempty"`
+	Message      string   `json:"message"`
+	Suggestion   string   `json:"suggestion"`
+	FixURL       string   `json:"fix_url"` // Init form link for the account that should apply the fix
+}
@@ -12,9 +12,14 @@ import (
 // - robots.txt: 1 day
 // - Swagger docs: 1 hour
 // - HTML pages: 5 minutes with revalidation
-// - Init forms: 1 hour
+// - Init forms: 1 hour, unless they load account state (private, revalidated)
+// - Signature collections: no caching (they change with every upload)
+// - Dataset exports: no caching (authenticated downloads)
+// - Event stream: no caching (live Server-Sent Events)
 // - API endpoints: 1 minute with revalidation
 // - POST/PUT/DELETE: no caching
+//
+// Revalidation relies on the ETag and Last-Modified headers set by ETags.
 func CacheControl(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Skip cache headers for non-GET requests
@@ -47,6 +52,21 @@ func CacheControl(next http.Handler) http.Handler {
 			return
 		}
 
+		// Signature collections - private share links that change with every upload
+		if strings.HasPrefix(path, "/init/collect/") {
+			w.Header().Set("Cache-Control", "no-store")
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Init forms prefilled from an account, and the delegation form with its
+		// chain check, reflect the current ledger state
+		if strings.HasPrefix(path, "/init/") && (r.URL.RawQuery != "" || path == "/init/delegation") {
+			w.Header().Set("Cache-Control", "private, no-cache")
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		// Init forms (GET only) - cache for 1 hour (static content)
 		if strings.HasPrefix(path, "/init/") {
 			w.Header().Set("Cache-Control", "public, max-age=3600")
@@ -54,6 +74,13 @@ func CacheControl(next http.Handler) http.Handler {
 			return
 		}
 
+		// Dataset exports and the event stream - never stored by caches
+		if path == "/api/v1/export" || path == "/api/v1/events" {
+			w.Header().Set("Cache-Control", "no-store")
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		// API endpoints - cache for 1 minute with revalidation
 		if strings.HasPrefix(path, "/api/") {
 			w.Header().Set("Cache-Control", "public, max-age=60, must-revalidate")
@@ -62,7 +89,7 @@ func CacheControl(next http.Handler) http.Handler {
 		}
 
 		// HTML pages - cache for 5 minutes with revalidation
-		// Includes: /, /accounts/{id}, /transactions/{hash}, /search, /tokens/{issuer}/{code}
+		// Includes: /, /accounts/{id}, /transactions/{hash}, /search, /tokens/{issuer}/{code}, /control, /economy
 		w.Header().Set("Cache-Control", "public, max-age=300, must-revalidate")
 		next.ServeHTTP(w, r)
 	})
@@ -80,6 +80,38 @@ func TestCacheControl(t *testing.T) {
 			path:           "/init/corporate",
 			expectedHeader: "public, max-age=3600",
 		},
+		{
+			name:           "init participant form for an account",
+			method:         "GET",
+			path:           "/init/participant?account_id=GABC",
+			expectedHeader: "private, no-cache",
+		},
+		{
+			name:           "init delegation form",
+			method:         "GET",
+			path:           "/init/delegation",
+			expectedHeader: "private, no-cache",
+		},
+		{
+			name:           "init signature collection",
+			method:         "GET",
+			path:           "/init/collect/0123abcd",
+			expectedHeader: "no-store",
+		},
+
+		// Dataset exports and the event stream - no caching
+		{
+			name:           "export",
+			method:         "GET",
+			path:           "/api/v1/export",
+			expectedHeader: "no-store",
+		},
+		{
+			name:           "event stream",
+			method:         "GET",
+			path:           "/api/v1/events",
+			expectedHeader: "no-store",
+		},
 
 		// API endpoints - 1 minute
 		{
@@ -0,0 +1,267 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mtlprog/lore/internal/repository"
+)
+
+// syncRunTTL is how long the latest sync run is reused before it is looked up
+// again, i.e. how late clients may see a new run's data.
+const syncRunTTL = 30 * time.Second
+
+// SyncRunSource returns the latest completed sync run.
+type SyncRunSource interface {
+	GetLatestSyncRun(ctx context.Context) (*repository.SyncRun, error)
+}
+
+// validator is the kind of ETag served for a path.
+type validator int
+
+const (
+	validateNone    validator = iota // No ETag (static files, private links, exports)
+	validateSync                     // Derived from the latest sync run and the request URI
+	validateContent                  // Hash of the rendered response
+)
+
+// ETags is a middleware that adds ETag and Last-Modified headers to GET
+// responses and answers matching conditional requests with 304 Not Modified.
+//
+// Pages and API endpoints served from the database only change when a sync
+// run completes, so their ETags are derived from the latest run without
+// rendering the response. Pages that query Horizon live get an ETag hashed
+// from the rendered body.
+type ETags struct {
+	runs SyncRunSource
+	now  func() time.Time
+
+	mu      sync.Mutex
+	run     *repository.SyncRun
+	checked time.Time
+}
+
+// NewETags creates an ETag middleware backed by the given sync run source.
+func NewETags(runs SyncRunSource) (*ETags, error) {
+	if runs == nil {
+		return nil, errors.New("sync run source is required")
+	}
+	return &ETags{runs: runs, now: time.Now}, nil
+}
+
+// Middleware returns the HTTP middleware handler.
+func (e *ETags) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		switch validatorFor(r.URL.Path) {
+		case validateSync:
+			if run := e.latestRun(r.Context()); run != nil {
+				serveSynced(w, r, next, run)
+				return
+			}
+			// Nothing synced yet: fall back to hashing the response
+			serveHashed(w, r, next)
+		case validateContent:
+			serveHashed(w, r, next)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// Invalidate makes the next request look up the latest sync run again. Call
+// it when a new run is published.
+func (e *ETags) Invalidate() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.checked = time.Time{}
+}
+
+// latestRun returns the latest sync run, looking it up at most once per
+// syncRunTTL. Lookup errors are logged and the previous run is kept.
+func (e *ETags) latestRun(ctx context.Context) *repository.SyncRun {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.now()
+	if !e.checked.IsZero() && now.Sub(e.checked) < syncRunTTL {
+		return e.run
+	}
+
+	run, err := e.runs.GetLatestSyncRun(ctx)
+	if err != nil {
+		slog.Warn("failed to get latest sync run", "error", err)
+		return e.run
+	}
+	e.run = run
+	e.checked = now
+	return run
+}
+
+// validatorFor classifies a request path.
+// Database-backed: /, /search, /control, /economy, /accounts/{id}/reputation, /api/v1/*
+// Horizon-backed: /accounts/{id}, /transactions/{hash}, /tokens/{issuer}/{code}, /council, forms
+func validatorFor(path string) validator {
+	switch {
+	case isStaticImage(path), path == "/robots.txt",
+		strings.HasPrefix(path, "/swagger/"),
+		strings.HasPrefix(path, "/init/collect/"),
+		path == "/api/v1/export", path == "/api/v1/events":
+		return validateNone
+	case strings.HasPrefix(path, "/api/"),
+		path == "/", path == "/search", path == "/control", path == "/economy",
+		strings.HasPrefix(path, "/accounts/") && strings.HasSuffix(path, "/reputation"):
+		return validateSync
+	default:
+		return validateContent
+	}
+}
+
+// serveSynced answers from the sync run alone when the client's copy is
+// current, and otherwise lets next render the response with validators set.
+func serveSynced(w http.ResponseWriter, r *http.Request, next http.Handler, run *repository.SyncRun) {
+	version, changedAt := run.Version()
+	etag := syncETag(version, r.URL.RequestURI())
+	modified := changedAt.UTC().Truncate(time.Second)
+
+	if notModified(r, etag, modified) {
+		setValidators(w.Header(), etag, modified)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	next.ServeHTTP(&validatorWriter{ResponseWriter: w, etag: etag, modified: modified}, r)
+}
+
+// serveHashed renders the response into a buffer and derives its ETag from the body.
+func serveHashed(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	buf := &bufferedWriter{ResponseWriter: w, status: http.StatusOK}
+	next.ServeHTTP(buf, r)
+
+	if buf.status == http.StatusOK {
+		etag := contentETag(buf.body.Bytes())
+		w.Header().Set("ETag", etag)
+		if notModified(r, etag, time.Time{}) {
+			w.Header().Del("Content-Length")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	w.WriteHeader(buf.status)
+	_, _ = w.Write(buf.body.Bytes())
+}
+
+// syncETag is a weak ETag for a resource as of a sync run. It is weak because
+// equal runs render semantically equal, not byte-identical, responses.
+func syncETag(runID int64, resource string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(resource))
+	return fmt.Sprintf(`W/"%d-%x"`, runID, h.Sum64())
+}
+
+// contentETag is a strong ETag for a response body.
+func contentETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// notModified evaluates If-None-Match, or If-Modified-Since when no
+// If-None-Match is sent (RFC 9110, section 13.2.2).
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etagMatches(inm, etag)
+	}
+	if modified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
+
+// etagMatches reports whether an If-None-Match list contains etag, using weak
+// comparison.
+func etagMatches(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func setValidators(h http.Header, etag string, modified time.Time) {
+	h.Set("ETag", etag)
+	h.Set("Last-Modified", modified.Format(http.TimeFormat))
+}
+
+// validatorWriter sets ETag and Last-Modified on successful responses only, so
+// errors rendered by the handler are never revalidated.
+type validatorWriter struct {
+	http.ResponseWriter
+	etag        string
+	modified    time.Time
+	wroteHeader bool
+}
+
+func (w *validatorWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if status == http.StatusOK {
+			setValidators(w.Header(), w.etag, w.modified)
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *validatorWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *validatorWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// bufferedWriter holds back the status and body so they can be hashed. Headers
+// go straight to the underlying writer.
+type bufferedWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.status = status
+	}
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.body.Write(b)
+}
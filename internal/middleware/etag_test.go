@@ -0,0 +1,289 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mtlprog/lore/internal/repository"
+)
+
+// fakeRuns returns a fixed sync run and counts lookups.
+type fakeRuns struct {
+	run     *repository.SyncRun
+	err     error
+	lookups int
+}
+
+func (f *fakeRuns) GetLatestSyncRun(_ context.Context) (*repository.SyncRun, error) {
+	f.lookups++
+	return f.run, f.err
+}
+
+func newTestETags(t *testing.T, runs *fakeRuns) *ETags {
+	t.Helper()
+	e, err := NewETags(runs)
+	if err != nil {
+		t.Fatalf("NewETags() error = %v", err)
+	}
+	return e
+}
+
+// countingHandler writes body with status and counts how often it ran.
+func countingHandler(status int, body string, calls *int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		*calls++
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+func TestNewETags_NilSource(t *testing.T) {
+	if _, err := NewETags(nil); err == nil {
+		t.Error("NewETags(nil) expected error")
+	}
+}
+
+func TestValidatorFor(t *testing.T) {
+	tests := []struct {
+		path string
+		want validator
+	}{
+		{"/", validateSync},
+		{"/search", validateSync},
+		{"/control", validateSync},
+		{"/economy", validateSync},
+		{"/accounts/GABC/reputation", validateSync},
+		{"/api/v1/accounts", validateSync},
+		{"/accounts/GABC", validateContent},
+		{"/transactions/abc", validateContent},
+		{"/tokens/GABC/MTL", validateContent},
+		{"/council", validateContent},
+		{"/init/participant", validateContent},
+		{"/favicon.svg", validateNone},
+		{"/robots.txt", validateNone},
+		{"/swagger/index.html", validateNone},
+		{"/init/collect/abc", validateNone},
+		{"/api/v1/export", validateNone},
+		{"/api/v1/events", validateNone},
+	}
+
+	for _, tt := range tests {
+		if got := validatorFor(tt.path); got != tt.want {
+			t.Errorf("validatorFor(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestETags_Synced(t *testing.T) {
+	finished := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	runs := &fakeRuns{run: &repository.SyncRun{ID: 42, FinishedAt: finished}}
+	e := newTestETags(t, runs)
+	calls := 0
+	handler := e.Middleware(countingHandler(http.StatusOK, `{"ok":true}`, &calls))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/GABC", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	etag := rec.Header().Get("ETag")
+	if etag != syncETag(42, "/api/v1/accounts/GABC") {
+		t.Fatalf("ETag = %q, want sync ETag", etag)
+	}
+	if got := rec.Header().Get("Last-Modified"); got != finished.Format(http.TimeFormat) {
+		t.Errorf("Last-Modified = %q", got)
+	}
+
+	t.Run("resource is part of the ETag", func(t *testing.T) {
+		if other := syncETag(42, "/api/v1/accounts/GXYZ"); other == etag {
+			t.Error("different resources share an ETag")
+		}
+		if other := syncETag(43, "/api/v1/accounts/GABC"); other == etag {
+			t.Error("different runs share an ETag")
+		}
+	})
+
+	t.Run("If-None-Match skips the handler", func(t *testing.T) {
+		calls = 0
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/GABC", nil)
+		req.Header.Set("If-None-Match", `"other", `+etag)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotModified {
+			t.Errorf("status = %d, want 304", rec.Code)
+		}
+		if calls != 0 {
+			t.Errorf("handler ran %d times, want 0", calls)
+		}
+		if rec.Body.Len() != 0 {
+			t.Errorf("304 has body %q", rec.Body.String())
+		}
+	})
+
+	t.Run("If-Modified-Since", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/GABC", nil)
+		req.Header.Set("If-Modified-Since", finished.Format(http.TimeFormat))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotModified {
+			t.Errorf("status = %d, want 304", rec.Code)
+		}
+
+		req.Header.Set("If-Modified-Since", finished.Add(-time.Hour).Format(http.TimeFormat))
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("status = %d, want 200", rec.Code)
+		}
+	})
+
+	t.Run("stale ETag renders", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/GABC", nil)
+		req.Header.Set("If-None-Match", syncETag(41, "/api/v1/accounts/GABC"))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("status = %d, want 200", rec.Code)
+		}
+	})
+
+	t.Run("run is looked up once per TTL", func(t *testing.T) {
+		if runs.lookups != 1 {
+			t.Errorf("lookups = %d, want 1", runs.lookups)
+		}
+	})
+}
+
+func TestETags_AccountResync(t *testing.T) {
+	finished := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	resynced := finished.Add(time.Hour)
+	runs := &fakeRuns{run: &repository.SyncRun{ID: 42, FinishedAt: finished, LastResyncID: 45, LastResyncAt: resynced}}
+	e := newTestETags(t, runs)
+	calls := 0
+	handler := e.Middleware(countingHandler(http.StatusOK, `{"ok":true}`, &calls))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/GABC", nil)
+	req.Header.Set("If-None-Match", syncETag(42, "/api/v1/accounts/GABC"))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200 after a resync", rec.Code)
+	}
+	if etag := rec.Header().Get("ETag"); etag != syncETag(45, "/api/v1/accounts/GABC") {
+		t.Errorf("ETag = %q, want the resync's", etag)
+	}
+	if got := rec.Header().Get("Last-Modified"); got != resynced.Format(http.TimeFormat) {
+		t.Errorf("Last-Modified = %q", got)
+	}
+}
+
+func TestETags_SyncedErrorHasNoValidators(t *testing.T) {
+	runs := &fakeRuns{run: &repository.SyncRun{ID: 1, FinishedAt: time.Now()}}
+	calls := 0
+	handler := newTestETags(t, runs).Middleware(countingHandler(http.StatusNotFound, "not found", &calls))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/accounts/GABC", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+	if rec.Header().Get("ETag") != "" || rec.Header().Get("Last-Modified") != "" {
+		t.Error("error response carries validators")
+	}
+}
+
+func TestETags_Content(t *testing.T) {
+	e := newTestETags(t, &fakeRuns{})
+	calls := 0
+	handler := e.Middleware(countingHandler(http.StatusOK, "<html>tx</html>", &calls))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/transactions/abc", nil))
+
+	etag := rec.Header().Get("ETag")
+	if etag != contentETag([]byte("<html>tx</html>")) {
+		t.Fatalf("ETag = %q, want content hash", etag)
+	}
+	if rec.Body.String() != "<html>tx</html>" {
+		t.Errorf("body = %q", rec.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/abc", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("status = %d, want 304", rec.Code)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("304 has body %q", rec.Body.String())
+	}
+}
+
+func TestETags_FallsBackToContentWithoutRun(t *testing.T) {
+	for name, runs := range map[string]*fakeRuns{
+		"no run yet":   {},
+		"lookup error": {err: errors.New("database down")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			handler := newTestETags(t, runs).Middleware(countingHandler(http.StatusOK, "home", &calls))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if got := rec.Header().Get("ETag"); got != contentETag([]byte("home")) {
+				t.Errorf("ETag = %q, want content hash", got)
+			}
+		})
+	}
+}
+
+func TestETags_Skipped(t *testing.T) {
+	runs := &fakeRuns{run: &repository.SyncRun{ID: 1, FinishedAt: time.Now()}}
+	calls := 0
+	handler := newTestETags(t, runs).Middleware(countingHandler(http.StatusOK, "ok", &calls))
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/api/v1/init/submit", nil),
+		httptest.NewRequest(http.MethodGet, "/init/collect/abc", nil),
+		httptest.NewRequest(http.MethodGet, "/favicon.svg", nil),
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if got := rec.Header().Get("ETag"); got != "" {
+			t.Errorf("%s %s: ETag = %q, want none", req.Method, req.URL.Path, got)
+		}
+	}
+	if runs.lookups != 0 {
+		t.Errorf("lookups = %d, want 0", runs.lookups)
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	tests := []struct {
+		header string
+		etag   string
+		want   bool
+	}{
+		{`"a"`, `"a"`, true},
+		{`W/"a"`, `"a"`, true},
+		{`"a"`, `W/"a"`, true},
+		{`"b", "a"`, `"a"`, true},
+		{`*`, `"a"`, true},
+		{`"b"`, `"a"`, false},
+	}
+
+	for _, tt := range tests {
+		if got := etagMatches(tt.header, tt.etag); got != tt.want {
+			t.Errorf("etagMatches(%q, %q) = %v, want %v", tt.header, tt.etag, got, tt.want)
+		}
+	}
+}
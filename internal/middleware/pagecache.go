@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/mtlprog/lore/internal/cache"
+)
+
+// cachedPaths are the hot routes whose rendered responses are cached. They
+// are served from the database only and look the same to every client.
+var cachedPaths = map[string]bool{
+	"/":                true,
+	"/control":         true,
+	"/economy":         true,
+	"/api/v1/stats":    true,
+	"/api/v1/accounts": true,
+	"/api/v1/flows":    true,
+}
+
+// PageCache is a middleware that caches successful GET responses of hot
+// routes, keyed by path and query. Concurrent misses for the same URL render
+// the page once.
+type PageCache struct {
+	cache *cache.Cache
+}
+
+// NewPageCache creates a page cache middleware storing responses in c.
+func NewPageCache(c *cache.Cache) (*PageCache, error) {
+	if c == nil {
+		return nil, errors.New("cache is required")
+	}
+	return &PageCache{cache: c}, nil
+}
+
+// Middleware returns the HTTP middleware handler.
+func (p *PageCache) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if (r.Method != http.MethodGet && r.Method != http.MethodHead) || !cachedPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := "page:" + r.URL.RequestURI()
+		if v, ok := p.cache.Get(key); ok {
+			v.(*recordedResponse).replay(w)
+			return
+		}
+
+		v, _ := p.cache.Do(key, func() (any, error) {
+			generation := p.cache.Generation()
+			rec := &recordedResponse{header: make(http.Header), status: http.StatusOK}
+			// Requests waiting on this render must not fail if its client goes away
+			next.ServeHTTP(rec, r.WithContext(context.WithoutCancel(r.Context())))
+
+			if rec.status == http.StatusOK {
+				p.cache.Add(key, rec, rec.size(), generation)
+			}
+			return rec, nil
+		})
+		v.(*recordedResponse).replay(w)
+	})
+}
+
+// recordedResponse captures a response so it can be replayed to other clients.
+// Only the headers set by the wrapped handler are recorded.
+type recordedResponse struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rec *recordedResponse) Header() http.Header {
+	return rec.header
+}
+
+func (rec *recordedResponse) WriteHeader(status int) {
+	if !rec.wroteHeader {
+		rec.wroteHeader = true
+		rec.status = status
+	}
+}
+
+func (rec *recordedResponse) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	return rec.body.Write(b)
+}
+
+// size approximates the memory held by the response.
+func (rec *recordedResponse) size() int64 {
+	n := int64(rec.body.Cap())
+	for k, values := range rec.header {
+		n += int64(len(k))
+		for _, v := range values {
+			n += int64(len(v))
+		}
+	}
+	return n
+}
+
+// replay writes the recorded response to w. Recorded responses are shared and
+// must not be modified.
+func (rec *recordedResponse) replay(w http.ResponseWriter) {
+	for k, values := range rec.header {
+		w.Header()[k] = append([]string(nil), values...)
+	}
+	w.WriteHeader(rec.status)
+	_, _ = w.Write(rec.body.Bytes())
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mtlprog/lore/internal/cache"
+	"github.com/mtlprog/lore/internal/repository"
+)
+
+func newTestPageCache(t *testing.T) (*PageCache, *cache.Cache) {
+	t.Helper()
+	c, err := cache.New(1 << 20)
+	if err != nil {
+		t.Fatalf("cache.New() error = %v", err)
+	}
+	p, err := NewPageCache(c)
+	if err != nil {
+		t.Fatalf("NewPageCache() error = %v", err)
+	}
+	return p, c
+}
+
+func TestNewPageCache_NilCache(t *testing.T) {
+	if _, err := NewPageCache(nil); err == nil {
+		t.Error("NewPageCache(nil) expected error")
+	}
+}
+
+func TestPageCache(t *testing.T) {
+	p, c := newTestPageCache(t)
+	calls := 0
+	handler := p.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(r.URL.RawQuery))
+	}))
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	first := get("/api/v1/stats?a=1")
+	second := get("/api/v1/stats?a=1")
+	if calls != 1 {
+		t.Errorf("handler ran %d times, want 1", calls)
+	}
+	if second.Body.String() != "a=1" || second.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("cached response = %q %v, want %q %v", second.Body.String(), second.Header(), first.Body.String(), first.Header())
+	}
+
+	get("/api/v1/stats?a=2")
+	if calls != 2 {
+		t.Errorf("query is not part of the key: handler ran %d times, want 2", calls)
+	}
+
+	c.Invalidate()
+	get("/api/v1/stats?a=1")
+	if calls != 3 {
+		t.Errorf("handler ran %d times after invalidation, want 3", calls)
+	}
+}
+
+func TestPageCache_SkipsErrorsAndOtherRoutes(t *testing.T) {
+	p, _ := newTestPageCache(t)
+	calls := 0
+	status := http.StatusInternalServerError
+	handler := p.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(status)
+	}))
+
+	for range 2 {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		if rec.Code != status {
+			t.Errorf("status = %d, want %d", rec.Code, status)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("error response was cached: handler ran %d times, want 2", calls)
+	}
+
+	status = http.StatusOK
+	calls = 0
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/accounts/GABC", nil),
+		httptest.NewRequest(http.MethodGet, "/accounts/GABC", nil),
+		httptest.NewRequest(http.MethodPost, "/api/v1/xdr/decode", nil),
+		httptest.NewRequest(http.MethodPost, "/api/v1/xdr/decode", nil),
+	} {
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if calls != 4 {
+		t.Errorf("uncached route was cached: handler ran %d times, want 4", calls)
+	}
+}
+
+func TestPageCache_WithETags(t *testing.T) {
+	p, _ := newTestPageCache(t)
+	run := &repository.SyncRun{ID: 5, FinishedAt: time.Now()}
+	runs := &fakeRuns{run: run}
+	calls := 0
+	handler := newTestETags(t, runs).Middleware(p.Middleware(countingHandler(http.StatusOK, "home", &calls)))
+
+	for range 2 {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		if rec.Header().Get("ETag") != syncETag(run.ID, "/") {
+			t.Errorf("ETag = %q, want sync ETag", rec.Header().Get("ETag"))
+		}
+		if rec.Body.String() != "home" {
+			t.Errorf("body = %q", rec.Body.String())
+		}
+	}
+	if calls != 1 {
+		t.Errorf("handler ran %d times, want 1", calls)
+	}
+}
@@ -1,12 +1,17 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/mtlprog/lore/internal/apikey"
 	"github.com/samber/lo"
 )
 
@@ -15,15 +20,47 @@ const (
 	cleanupInterval = 1 * time.Minute
 )
 
-// RateLimiter wraps an http.Handler with rate limiting per IP address.
+// KeyAuthenticator resolves API keys sent in the Authorization header and
+// counts their usage.
+type KeyAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (*apikey.Key, error)
+	RecordUsage(ctx context.Context, key *apikey.Key) (int64, error)
+}
+
+// RateLimiter wraps an http.Handler with rate limiting per IP address, or per
+// API key for requests that carry one.
 type RateLimiter struct {
-	limit       int                    // Maximum requests per window
-	window      time.Duration          // Time window for rate limiting
-	requests    map[string][]time.Time // IP -> request timestamps
-	mu          sync.RWMutex           // Thread-safe access
-	cleanupDone chan struct{}          // Shutdown signal for cleanup goroutine
-	closeOnce   sync.Once              // Ensures Close() is called only once
-	staticPaths map[string]bool        // Paths that bypass rate limiting
+	limit       int                     // Maximum requests per window
+	window      time.Duration           // Time window for rate limiting
+	requests    map[string][]time.Time  // IP or "key:<id>" -> request timestamps
+	keys        KeyAuthenticator        // nil when API keys are disabled
+	pathScopes  map[string]apikey.Scope // Paths whose keys need a scope other than read
+	mu          sync.RWMutex            // Thread-safe access
+	cleanupDone chan struct{}           // Shutdown signal for cleanup goroutine
+	closeOnce   sync.Once               // Ensures Close() is called only once
+	staticPaths map[string]bool         // Paths that bypass rate limiting
+}
+
+// Option configures a RateLimiter.
+type Option func(*RateLimiter)
+
+// WithAPIKeys enables API keys. Requests with a valid key are limited by the
+// key's own rate and daily quota instead of by IP.
+func WithAPIKeys(keys KeyAuthenticator) Option {
+	return func(rl *RateLimiter) {
+		rl.keys = keys
+	}
+}
+
+// WithPathScope makes keyed requests to path need scope instead of the read
+// scope, e.g. the export scope for /api/v1/export.
+func WithPathScope(path string, scope apikey.Scope) Option {
+	return func(rl *RateLimiter) {
+		if rl.pathScopes == nil {
+			rl.pathScopes = make(map[string]apikey.Scope)
+		}
+		rl.pathScopes[path] = scope
+	}
 }
 
 // New creates a new rate limiter with the given limit and static paths to bypass.
@@ -31,7 +68,7 @@ type RateLimiter struct {
 //
 // IMPORTANT: Close() must be called when shutting down to stop the background
 // cleanup goroutine and prevent goroutine leaks.
-func New(limit int, staticPaths []string) (*RateLimiter, error) {
+func New(limit int, staticPaths []string, opts ...Option) (*RateLimiter, error) {
 	if limit <= 0 {
 		return nil, fmt.Errorf("rate limit must be positive, got %d", limit)
 	}
@@ -49,6 +86,9 @@ func New(limit int, staticPaths []string) (*RateLimiter, error) {
 		cleanupDone: make(chan struct{}),
 		staticPaths: staticMap,
 	}
+	for _, opt := range opts {
+		opt(rl)
+	}
 
 	// Start background cleanup goroutine
 	go rl.cleanupLoop()
@@ -57,6 +97,7 @@ func New(limit int, staticPaths []string) (*RateLimiter, error) {
 		"limit", limit,
 		"window", windowDuration.String(),
 		"static_paths", len(staticPaths),
+		"api_keys", rl.keys != nil,
 	)
 
 	return rl, nil
@@ -71,6 +112,11 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 			return
 		}
 
+		if token, ok := bearerToken(r); ok && rl.keys != nil {
+			rl.serveWithKey(w, r, next, token)
+			return
+		}
+
 		// Extract client IP
 		ip := ExtractIP(r)
 		if ip == "" {
@@ -83,21 +129,12 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 		// Check rate limit
 		allowed, oldestRequest := rl.allow(ip)
 		if !allowed {
-			// Calculate Retry-After in seconds
-			now := time.Now()
-			retryAfter := int(rl.window.Seconds() - now.Sub(oldestRequest).Seconds())
-			if retryAfter < 1 {
-				retryAfter = 1
-			}
-
 			slog.Debug("rate limit exceeded",
 				"ip", ip,
 				"path", r.URL.Path,
 				"limit", rl.limit,
 			)
-
-			w.Header().Set("Retry-After", fmt.Sprintf("%d", retryAfter))
-			http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
+			rl.reject(w, oldestRequest)
 			return
 		}
 
@@ -106,31 +143,114 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 	})
 }
 
+// serveWithKey authenticates an API key and applies its rate limit and daily
+// quota. The key is passed on in the request context.
+func (rl *RateLimiter) serveWithKey(w http.ResponseWriter, r *http.Request, next http.Handler, token string) {
+	ctx := r.Context()
+
+	key, err := rl.keys.Authenticate(ctx, token)
+	if err != nil {
+		if !errors.Is(err, apikey.ErrInvalidKey) && !errors.Is(err, apikey.ErrRevokedKey) {
+			slog.Error("failed to authenticate API key", "path", r.URL.Path, "error", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		// Failed attempts count against the client IP, so guessing keys is
+		// throttled like anonymous traffic
+		if allowed, oldestRequest := rl.allow(ExtractIP(r)); !allowed {
+			rl.reject(w, oldestRequest)
+			return
+		}
+		w.Header().Set("WWW-Authenticate", `Bearer realm="lore"`)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	scope := apikey.ScopeRead
+	if s, ok := rl.pathScopes[r.URL.Path]; ok {
+		scope = s
+	}
+	if !key.HasScope(scope) {
+		http.Error(w, fmt.Sprintf("API key lacks the %s scope", scope), http.StatusForbidden)
+		return
+	}
+
+	limit := key.RateLimit
+	if limit <= 0 {
+		limit = rl.limit
+	}
+	if allowed, oldestRequest := rl.allowN("key:"+strconv.FormatInt(key.ID, 10), limit); !allowed {
+		slog.Debug("API key rate limit exceeded", "key_id", key.ID, "path", r.URL.Path, "limit", limit)
+		rl.reject(w, oldestRequest)
+		return
+	}
+
+	// Usage accounting is best effort: a database hiccup must not lock out keyed clients
+	used, err := rl.keys.RecordUsage(ctx, key)
+	if err != nil {
+		slog.Error("failed to record API key usage", "key_id", key.ID, "error", err)
+	} else if key.DailyQuota > 0 && used > int64(key.DailyQuota) {
+		now := time.Now().UTC()
+		midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+		w.Header().Set("Retry-After", strconv.Itoa(int(midnight.Sub(now).Seconds())+1))
+		http.Error(w, "Daily quota exceeded", http.StatusTooManyRequests)
+		return
+	}
+
+	next.ServeHTTP(w, r.WithContext(apikey.WithKey(ctx, key)))
+}
+
+// reject responds 429 with a Retry-After matching when the oldest request in
+// the window expires.
+func (rl *RateLimiter) reject(w http.ResponseWriter, oldestRequest time.Time) {
+	retryAfter := int(rl.window.Seconds() - time.Since(oldestRequest).Seconds())
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	w.Header().Set("Retry-After", fmt.Sprintf("%d", retryAfter))
+	http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
 // allow checks if a request from the given IP is allowed.
 // Returns (allowed, oldestRequestTime).
 // If not allowed, oldestRequestTime is the timestamp of the oldest request in the window.
 func (rl *RateLimiter) allow(ip string) (bool, time.Time) {
+	return rl.allowN(ip, rl.limit)
+}
+
+// allowN checks a request against the sliding window of a bucket with the given limit.
+func (rl *RateLimiter) allowN(bucket string, limit int) (bool, time.Time) {
 	now := time.Now()
 	cutoff := now.Add(-rl.window)
 
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	// Get existing requests for this IP
-	timestamps := rl.requests[ip]
+	// Get existing requests for this bucket
+	timestamps := rl.requests[bucket]
 
 	// Filter to only keep requests within the window (sliding window)
 	validTimestamps := filterValidTimestamps(timestamps, cutoff)
 
 	// Check if limit exceeded
-	if len(validTimestamps) >= rl.limit {
+	if len(validTimestamps) >= limit {
 		// Return oldest timestamp for Retry-After calculation
 		return false, validTimestamps[0]
 	}
 
 	// Add current request timestamp
 	validTimestamps = append(validTimestamps, now)
-	rl.requests[ip] = validTimestamps
+	rl.requests[bucket] = validTimestamps
 
 	return true, time.Time{}
 }
@@ -1,11 +1,15 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/mtlprog/lore/internal/apikey"
 )
 
 func TestNew(t *testing.T) {
@@ -503,3 +507,203 @@ func TestRateLimiter_MultipleClose(t *testing.T) {
 
 	// Test passes if no panic occurs
 }
+
+// fakeKeys authenticates the tokens in keys and counts usage per key.
+type fakeKeys struct {
+	keys  map[string]*apikey.Key
+	used  map[int64]int64
+	err   error
+	calls int
+}
+
+func (f *fakeKeys) Authenticate(_ context.Context, token string) (*apikey.Key, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	key, ok := f.keys[token]
+	if !ok {
+		return nil, apikey.ErrInvalidKey
+	}
+	return key, nil
+}
+
+func (f *fakeKeys) RecordUsage(_ context.Context, key *apikey.Key) (int64, error) {
+	f.used[key.ID]++
+	return f.used[key.ID], nil
+}
+
+func TestRateLimiter_APIKeys(t *testing.T) {
+	newKeys := func() *fakeKeys {
+		return &fakeKeys{
+			keys: map[string]*apikey.Key{
+				"lore_fast":   {ID: 1, Scopes: []apikey.Scope{apikey.ScopeRead}, RateLimit: 5},
+				"lore_quota":  {ID: 2, Scopes: []apikey.Scope{apikey.ScopeRead}, DailyQuota: 2},
+				"lore_export": {ID: 3, Scopes: []apikey.Scope{apikey.ScopeExport}},
+			},
+			used: map[int64]int64{},
+		}
+	}
+
+	serve := func(rl *RateLimiter, authorization string) *httptest.ResponseRecorder {
+		var gotKey *apikey.Key
+		handler := rl.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotKey = apikey.FromContext(r.Context())
+			if authorization != "" && gotKey == nil {
+				t.Error("keyed request reached the handler without its key")
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		req := httptest.NewRequest("GET", "/api/v1/stats", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("key limit replaces the IP limit", func(t *testing.T) {
+		rl, err := New(2, nil, WithAPIKeys(newKeys()))
+		if err != nil {
+			t.Fatalf("failed to create rate limiter: %v", err)
+		}
+		defer rl.Close()
+
+		for i := 0; i < 5; i++ {
+			if w := serve(rl, "Bearer lore_fast"); w.Code != http.StatusOK {
+				t.Fatalf("request %d: expected 200, got %d", i+1, w.Code)
+			}
+		}
+		if w := serve(rl, "Bearer lore_fast"); w.Code != http.StatusTooManyRequests {
+			t.Errorf("expected 429 over the key limit, got %d", w.Code)
+		}
+		// The IP bucket is untouched by keyed traffic
+		if w := serve(rl, ""); w.Code != http.StatusOK {
+			t.Errorf("expected anonymous request to pass, got %d", w.Code)
+		}
+	})
+
+	t.Run("daily quota", func(t *testing.T) {
+		rl, err := New(100, nil, WithAPIKeys(newKeys()))
+		if err != nil {
+			t.Fatalf("failed to create rate limiter: %v", err)
+		}
+		defer rl.Close()
+
+		serve(rl, "Bearer lore_quota")
+		serve(rl, "Bearer lore_quota")
+		w := serve(rl, "Bearer lore_quota")
+		if w.Code != http.StatusTooManyRequests {
+			t.Fatalf("expected 429 over the daily quota, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "Daily quota exceeded") {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+		if w.Header().Get("Retry-After") == "" {
+			t.Error("expected Retry-After header")
+		}
+	})
+
+	t.Run("invalid key is rejected and counted against the IP", func(t *testing.T) {
+		rl, err := New(2, nil, WithAPIKeys(newKeys()))
+		if err != nil {
+			t.Fatalf("failed to create rate limiter: %v", err)
+		}
+		defer rl.Close()
+
+		for i := 0; i < 2; i++ {
+			w := serve(rl, "Bearer lore_wrong")
+			if w.Code != http.StatusUnauthorized {
+				t.Fatalf("expected 401, got %d", w.Code)
+			}
+			if w.Header().Get("WWW-Authenticate") == "" {
+				t.Error("expected WWW-Authenticate header")
+			}
+		}
+		if w := serve(rl, "Bearer lore_wrong"); w.Code != http.StatusTooManyRequests {
+			t.Errorf("expected 429 after repeated failures, got %d", w.Code)
+		}
+	})
+
+	t.Run("key without read scope", func(t *testing.T) {
+		rl, err := New(2, nil, WithAPIKeys(newKeys()))
+		if err != nil {
+			t.Fatalf("failed to create rate limiter: %v", err)
+		}
+		defer rl.Close()
+
+		if w := serve(rl, "Bearer lore_export"); w.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("path scope replaces the read scope", func(t *testing.T) {
+		rl, err := New(10, nil, WithAPIKeys(newKeys()), WithPathScope("/api/v1/export", apikey.ScopeExport))
+		if err != nil {
+			t.Fatalf("failed to create rate limiter: %v", err)
+		}
+		defer rl.Close()
+
+		handler := rl.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		get := func(path, token string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("GET", path, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			return w
+		}
+
+		if w := get("/api/v1/export", "lore_export"); w.Code != http.StatusOK {
+			t.Errorf("expected export-only key to reach the export, got %d", w.Code)
+		}
+		if w := get("/api/v1/stats", "lore_export"); w.Code != http.StatusForbidden {
+			t.Errorf("expected export-only key to be rejected elsewhere, got %d", w.Code)
+		}
+		w := get("/api/v1/export", "lore_fast")
+		if w.Code != http.StatusForbidden {
+			t.Errorf("expected read-only key to be rejected on the export, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "lacks the export scope") {
+			t.Errorf("unexpected body %q", w.Body.String())
+		}
+	})
+
+	t.Run("lookup failure", func(t *testing.T) {
+		keys := newKeys()
+		keys.err = errors.New("database down")
+		rl, err := New(2, nil, WithAPIKeys(keys))
+		if err != nil {
+			t.Fatalf("failed to create rate limiter: %v", err)
+		}
+		defer rl.Close()
+
+		if w := serve(rl, "Bearer lore_fast"); w.Code != http.StatusInternalServerError {
+			t.Errorf("expected 500, got %d", w.Code)
+		}
+	})
+
+	t.Run("non-bearer authorization is anonymous", func(t *testing.T) {
+		keys := newKeys()
+		rl, err := New(1, nil, WithAPIKeys(keys))
+		if err != nil {
+			t.Fatalf("failed to create rate limiter: %v", err)
+		}
+		defer rl.Close()
+
+		handler := rl.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK || keys.calls != 0 {
+			t.Errorf("expected anonymous pass-through, got %d with %d lookups", w.Code, keys.calls)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package model
+
+// Thresholds holds the low, medium and high thresholds of an account.
+type Thresholds struct {
+	Low  uint8
+	Med  uint8
+	High uint8
+}
+
+// ControlStatus compares the signing power over an account with the declared
+// Owner*/Ownership* relations.
+type ControlStatus string
+
+const (
+	ControlSelf       ControlStatus = "self"       // Master key of the account itself
+	ControlDeclared   ControlStatus = "declared"   // Signer that is also a declared owner
+	ControlUndeclared ControlStatus = "undeclared" // Signer without a declared ownership relation
+	ControlNotSigner  ControlStatus = "not_signer" // Declared owner that cannot sign
+)
+
+// ControlEdge links a signer or declared owner to the account it controls.
+type ControlEdge struct {
+	SignerID    string
+	SignerName  string
+	AccountID   string
+	AccountName string
+	Weight      int32  // Signer weight, 0 for declared owners that are not signers
+	Ownership   string // Declared relation type (e.g. "OwnershipFull"), empty if undeclared
+	Sole        bool   // Weight alone reaches the account's medium threshold
+	Status      ControlStatus
+}
+
+// Mismatch reports whether signing power and declared ownership disagree.
+func (e ControlEdge) Mismatch() bool {
+	return e.Status == ControlUndeclared || e.Status == ControlNotSigner
+}
+
+// AccountControl describes who controls an account and what it controls.
+type AccountControl struct {
+	Thresholds Thresholds
+	Signers    []ControlEdge // Signers of the account and declared owners that are not signers
+	Controls   []ControlEdge // Accounts this account can sign for or is declared to own
+}
+
+// Mismatches returns the number of edges where signing and ownership disagree.
+func (c *AccountControl) Mismatches() int {
+	count := 0
+	for _, edges := range [][]ControlEdge{c.Signers, c.Controls} {
+		for _, e := range edges {
+			if e.Mismatch() {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// ControlledAccount is a company or the issuer with everyone who controls it.
+type ControlledAccount struct {
+	AccountID    string
+	AccountName  string
+	IsIssuer     bool
+	MedThreshold uint8
+	Edges        []ControlEdge
+	Mismatches   int
+}
+
+// ControlPageData holds data for the control graph page.
+type ControlPageData struct {
+	Accounts   []ControlledAccount
+	Mismatches int  // Total mismatches over all accounts
+	OnlyIssues bool // Only accounts with mismatches are shown
+}
@@ -0,0 +1,65 @@
+package model
+
+// CouncilCandidate is a council-ready account with the votes it holds.
+type CouncilCandidate struct {
+	AccountID string
+	Name      string
+	Votes     float64 // Own MTLAP plus received council votes
+}
+
+// CouncilAction is the change a signer of the issuer needs to match the council.
+type CouncilAction string
+
+const (
+	CouncilAdd    CouncilAction = "add"    // Elected, not a signer yet
+	CouncilUpdate CouncilAction = "update" // Elected signer with a different weight
+	CouncilRemove CouncilAction = "remove" // Signer that is not elected
+	CouncilKeep   CouncilAction = "keep"   // Elected signer with the right weight
+)
+
+// CouncilChange compares one council member or issuer signer with the computed council.
+type CouncilChange struct {
+	AccountID     string
+	Name          string
+	Rank          int     // Position in the computed council, 0 if not elected
+	Votes         float64 // 0 for signers that are not council candidates
+	CurrentWeight int32   // 0 if not a signer
+	TargetWeight  int32   // 0 if the signer is removed
+	Action        CouncilAction
+}
+
+// CouncilReconciliation compares the issuer's signers with the council computed
+// from delegated votes.
+type CouncilReconciliation struct {
+	IssuerID      string
+	Size          int             // Number of council seats
+	Thresholds    Thresholds      // Current thresholds of the issuer
+	Changes       []CouncilChange // Elected members by rank, then signers to remove
+	Other         []Signer        // Master key and non-account signers, left unchanged
+	CurrentWeight int32           // Total weight of all current signers
+	TargetWeight  int32           // Total weight after the changes
+}
+
+// Pending returns the number of signers that need to change.
+func (r *CouncilReconciliation) Pending() int {
+	count := 0
+	for _, c := range r.Changes {
+		if c.Action != CouncilKeep {
+			count++
+		}
+	}
+	return count
+}
+
+// LocksOut reports whether the changed signers could no longer reach the high
+// threshold, which would make the issuer unable to change its signers again.
+func (r *CouncilReconciliation) LocksOut() bool {
+	return r.TargetWeight < int32(r.Thresholds.High)
+}
+
+// CouncilPageData holds data for the council reconciliation page.
+type CouncilPageData struct {
+	Reconciliation *CouncilReconciliation
+	Transactions   []InitTransaction // Generated SetOptions transactions, if requested
+	Error          string
+}
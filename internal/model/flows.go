@@ -0,0 +1,88 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// FlowFilter narrows payment flows between tracked accounts. Zero values match everything.
+type FlowFilter struct {
+	AssetCode   string    // Asset code, "XLM" for native
+	AssetIssuer string    // Asset issuer; empty matches every issuer of AssetCode
+	Since       time.Time // Inclusive lower bound on the ledger close time
+	Until       time.Time // Exclusive upper bound on the ledger close time
+}
+
+// FlowAsset identifies an asset flows are grouped by. Assets with the same code
+// from different issuers are different assets.
+type FlowAsset struct {
+	Code   string // "XLM" for native
+	Issuer string // Empty for native
+}
+
+// ParseFlowAsset parses "CODE:ISSUER" or a bare code, which leaves the issuer empty.
+func ParseFlowAsset(s string) FlowAsset {
+	code, issuer, _ := strings.Cut(s, ":")
+	return FlowAsset{Code: code, Issuer: issuer}
+}
+
+// Key returns the asset as "CODE:ISSUER", or the bare code for native, the
+// form ParseFlowAsset reads.
+func (a FlowAsset) Key() string {
+	if a.Issuer == "" {
+		return a.Code
+	}
+	return a.Code + ":" + a.Issuer
+}
+
+// CounterpartyFlow aggregates the payments between an account and one other
+// tracked account in one asset.
+type CounterpartyFlow struct {
+	AccountID   string // The counterparty
+	Name        string
+	AssetCode   string
+	AssetIssuer string
+	Sent        float64 // Paid to the counterparty
+	Received    float64 // Received from the counterparty
+	Payments    int
+}
+
+// Volume returns the total amount moved in both directions.
+func (f CounterpartyFlow) Volume() float64 {
+	return f.Sent + f.Received
+}
+
+// FlowEdge aggregates the payments from one tracked account to another in one asset.
+type FlowEdge struct {
+	FromID      string
+	FromName    string
+	ToID        string
+	ToName      string
+	AssetCode   string
+	AssetIssuer string
+	Volume      float64
+	Payments    int
+}
+
+// FlowPeriod is the internal payment volume of one asset in one month.
+type FlowPeriod struct {
+	Month       time.Time // First day of the month, UTC
+	AssetCode   string
+	AssetIssuer string
+	Volume      float64
+	Payments    int
+	Payers      int // Distinct paying accounts
+}
+
+// Asset returns the asset the period is counted in.
+func (p FlowPeriod) Asset() FlowAsset {
+	return FlowAsset{Code: p.AssetCode, Issuer: p.AssetIssuer}
+}
+
+// EconomyPageData holds data for the internal economy page.
+type EconomyPageData struct {
+	Asset   FlowAsset // Selected asset, zero for all
+	Assets  []FlowAsset
+	Periods []FlowPeriod
+	Edges   []FlowEdge
+}
@@ -32,24 +32,107 @@ type NumberedField struct {
 	Value string `json:"v"` // Account ID or URL
 }
 
+// RelationField is a relation entry edited in the generic relationship editor.
+// The ManageData key is Type + Index; Index is empty for unnumbered keys such as "Spouse".
+type RelationField struct {
+	Type  string `json:"t"` // Relation type, e.g. "Employer"
+	Index string `json:"i"` // "", "1", "001" - preserved as string
+	Value string `json:"v"` // Target account ID
+}
+
+// RelationTypeOption describes a relation type selectable in the relationship editor.
+type RelationTypeOption struct {
+	Name         string
+	Label        string
+	Confirmation string // Counterpart type the target must declare (empty if none required)
+}
+
+// RelationTypeGroup is a category of relation types in the relationship editor.
+type RelationTypeGroup struct {
+	Name  string
+	Types []RelationTypeOption
+}
+
+// ManageData keys of the identity profile fields described in INITFORM.md.
+const (
+	DataKeyTelegramUserID     = "TelegramUserID"
+	DataKeyTimeTokenCode      = "TimeTokenCode"
+	DataKeyTimeTokenIssuer    = "TimeTokenIssuer"
+	DataKeyTimeTokenDesc      = "TimeTokenDesc"
+	DataKeyTimeTokenOfferIPFS = "TimeTokenOfferIPFS"
+	DataKeyTelegramPartChatID = "TelegramPartChatID"
+	DataKeyContractIPFS       = "ContractIPFS"
+	DataKeyPIIStandard        = "MTLA: PII Standard" // presence flag, value is ignored
+)
+
+// ManageData keys and values of MTLA vote delegation.
+const (
+	DataKeyDelegate        = "mtla_delegate"   // account ID the general vote is delegated to
+	DataKeyCouncilDelegate = "mtla_c_delegate" // account ID the council vote is delegated to, or CouncilReadyValue
+	CouncilReadyValue      = "ready"           // mtla_c_delegate value marking a council candidate
+)
+
 // ParticipantFormData holds all fields for participant init form.
 type ParticipantFormData struct {
-	AccountID string
-	Name      string
-	About     string
-	Website   string
-	PartOf    []NumberedField // PartOf001, PartOf002, etc.
-	Tags      []string        // TagBelgrade, TagDeveloper, etc.
+	AccountID          string
+	Name               string
+	About              string
+	Website            string
+	PartOf             []NumberedField // PartOf001, PartOf002, etc.
+	TelegramUserID     string
+	TimeTokenCode      string
+	TimeTokenIssuer    string // Issuer account ID
+	TimeTokenDesc      string
+	TimeTokenOfferIPFS string          // IPFS hash of the detailed offer
+	Relations          []RelationField // All other relation types (Employer001, Spouse, A, etc.)
+	Tags               []string        // TagBelgrade, TagDeveloper, etc.
 }
 
 // CorporateFormData holds all fields for corporate init form.
 type CorporateFormData struct {
-	AccountID string
-	Name      string
-	About     string
-	Website   string
-	MyPart    []NumberedField // MyPart001, MyPart002, etc.
-	Tags      []string        // TagBelgrade, TagInvestor, etc.
+	AccountID          string
+	Name               string
+	About              string
+	Website            string
+	PIIStandard        bool            // MTLA: PII Standard certification
+	MyPart             []NumberedField // MyPart001, MyPart002, etc.
+	TelegramPartChatID string
+	ContractIPFS       string          // IPFS hash of the company contract
+	Relations          []RelationField // All other relation types (Employee001, Partnership, etc.)
+	Tags               []string        // TagBelgrade, TagInvestor, etc.
+}
+
+// DelegationFormData holds the MTLA delegation settings of an account.
+type DelegationFormData struct {
+	AccountID         string
+	DelegateTo        string // mtla_delegate target (empty to remove)
+	CouncilReady      bool   // mtla_c_delegate = "ready"
+	CouncilDelegateTo string // mtla_c_delegate target, used when CouncilReady is false
+}
+
+// DelegationHop is one account in a delegation chain.
+type DelegationHop struct {
+	AccountID    string
+	Name         string
+	MTLAPBalance string
+	CouncilReady bool
+	Known        bool // account is present in the synced database
+}
+
+// InitDelegationData holds data for rendering the delegation form.
+type InitDelegationData struct {
+	Page         string             // "delegation"
+	AccountID    string             // User's Stellar account ID
+	FormData     DelegationFormData // Current form values
+	OriginalJSON string             // Base64-encoded JSON of original data
+	Chain        []DelegationHop    // mtla_delegate chain with the form values applied
+	ChainCycle   bool
+	CouncilChain []DelegationHop // mtla_c_delegate chain with the form values applied
+	CouncilCycle bool
+	Warnings     []string // Problems with the proposed delegation
+	NeedsConfirm bool     // Preview is blocked until the warnings are acknowledged
+	Error        string   // Error message to display
+	FormAction   string   // Form action URL
 }
 
 // InitLandingData holds data for the init landing page.
@@ -60,29 +143,65 @@ type InitLandingData struct {
 
 // InitFormData holds data for rendering init forms.
 type InitFormData struct {
-	Page          string      // "participant" or "corporate"
-	AccountID     string      // User's Stellar account ID
-	FormData      interface{} // ParticipantFormData or CorporateFormData
-	OriginalJSON  string      // Base64-encoded JSON of original data
-	AvailableTags []string    // List of available tags
-	Error         string      // Error message to display
-	FormAction    string      // Form action URL
-	PreviewAction string      // Preview action URL
+	Page          string                        // "participant" or "corporate"
+	AccountID     string                        // User's Stellar account ID
+	FormData      interface{}                   // ParticipantFormData or CorporateFormData
+	OriginalJSON  string                        // Base64-encoded JSON of original data
+	AvailableTags []string                      // List of available tags
+	RelationTypes []RelationTypeGroup           // Relation types offered by the relationship editor
+	RelationInfo  map[string]RelationTypeOption // Relation type details by name, for editor rows
+	Error         string                        // Error message to display
+	FormAction    string                        // Form action URL
+	PreviewAction string                        // Preview action URL
 }
 
 // InitPreviewData holds data for the XDR preview page.
 type InitPreviewData struct {
-	Page       string          // "preview"
-	AccountID  string          // User's Stellar account ID
-	XDR        string          // Base64-encoded unsigned XDR
-	Operations []InitOpSummary // Human-readable operation list
-	LabLink    string          // Stellar Laboratory link
-	Error      string          // Error message
+	Page         string              // "preview"
+	AccountID    string              // User's Stellar account ID
+	Transactions []InitTransaction   // Transactions to sign and submit in order
+	CanSubmit    bool                // Signed XDR can be submitted through Lore
+	CanCollect   bool                // Signatures can be collected on a shareable page
+	Signing      *SigningRequirement // Signers of the account (corporate forms only)
+	Warnings     []string            // Acknowledged warnings about the transaction
+	Error        string              // Error message
+}
+
+// InitTransaction is one unsigned transaction of a preview.
+// Changes above the 100-operation limit are split into consecutive transactions.
+type InitTransaction struct {
+	XDR         string          // Base64-encoded unsigned XDR
+	Sequence    int64           // Sequence number the transaction consumes
+	Operations  []InitOpSummary // Human-readable operation list
+	MaxFee      string          // Maximum fee in XLM
+	ValidBefore string          // Formatted upper time bound (UTC), empty if unbounded
+	LabLink     string          // Stellar Laboratory link
+	WalletURI   string          // SEP-0007 web+stellar:tx URI
+	QRCode      string          // Base64-encoded PNG QR code of WalletURI (empty if it does not fit)
+}
+
+// SubmitResult describes a signed transaction accepted by the network.
+type SubmitResult struct {
+	AccountID  string // Transaction source account
+	Hash       string // Transaction hash
+	Ledger     int32  // Ledger the transaction was included in
+	Refreshing bool   // Account refresh in the database was started after submission
+}
+
+// InitSubmitData holds data for rendering the signed transaction submission page.
+type InitSubmitData struct {
+	Page        string        // "submit"
+	AccountID   string        // Source account of the transaction
+	XDR         string        // Unsigned XDR generated by the form (optional)
+	SignedXDR   string        // Signed envelope pasted by the user
+	Result      *SubmitResult // Set when the transaction was accepted
+	ResultCodes []string      // Horizon result codes when the transaction was rejected
+	Error       string        // Error message to display
 }
 
-// InitOpSummary describes a ManageData operation for display.
+// InitOpSummary describes an operation for display.
 type InitOpSummary struct {
-	Action string // "Set" or "Delete"
-	Key    string // ManageData key
-	Value  string // ManageData value (empty for delete)
+	Action string // "Set" or "Delete", or "Set signer" or "Remove signer" for SetOptions
+	Key    string // ManageData key, or the signer's account ID
+	Value  string // ManageData value or signer weight (empty for delete)
 }
@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// MembershipEventType classifies an issuer operation on a membership token.
+type MembershipEventType string
+
+const (
+	MembershipReceived     MembershipEventType = "received"     // First tokens sent to an account with no balance
+	MembershipLevelChange  MembershipEventType = "level_change" // Further tokens sent by the issuer
+	MembershipReturned     MembershipEventType = "returned"     // Tokens paid back to the issuer
+	MembershipClawback     MembershipEventType = "clawback"     // Tokens clawed back by the issuer
+	MembershipAuthorized   MembershipEventType = "authorized"   // Trustline authorized by the issuer
+	MembershipDeauthorized MembershipEventType = "deauthorized" // Trustline authorization revoked
+)
+
+// MembershipEvent is one entry of an account's membership timeline.
+type MembershipEvent struct {
+	OperationID   int64
+	AccountID     string
+	AccountName   string
+	AssetCode     string // MTLAP, MTLAC or MTLAX
+	Type          MembershipEventType
+	Amount        float64
+	BalanceBefore float64
+	BalanceAfter  float64
+	TxHash        string
+	CreatedAt     time.Time
+}
+
+// Left reports whether the event took the account's balance down to zero.
+func (e MembershipEvent) Left() bool {
+	return e.BalanceBefore > 0 && e.BalanceAfter == 0
+}
+
+// MembershipGrowth counts accounts that joined or left in one month.
+type MembershipGrowth struct {
+	Month     time.Time // First day of the month, UTC
+	AssetCode string
+	Joined    int
+	Left      int
+}
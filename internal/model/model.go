@@ -1,6 +1,9 @@
 package model
 
-import "strings"
+import (
+	"strings"
+	"time"
+)
 
 // AccountSummary represents a token holder in the list view.
 type AccountSummary struct {
@@ -23,6 +26,30 @@ type AccountDetail struct {
 	TrustRating   *TrustRating // nil if no ratings
 	TotalXLMValue float64      // Portfolio value in XLM (for corporate accounts)
 	IsCorporate   bool         // true if account holds MTLAC
+	Identity      Identity
+	Signers       []Signer          // Signers with non-zero weight, from Horizon
+	Thresholds    Thresholds        // Low, medium and high thresholds, from Horizon
+	Control       *AccountControl   // nil if control data could not be loaded
+	Membership    []MembershipEvent // Issuer operations on MTLAP/MTLAC/MTLAX, oldest first
+}
+
+// Identity holds the optional identity profile fields of an account (see INITFORM.md).
+type Identity struct {
+	TelegramUserID     string
+	TimeTokenCode      string
+	TimeTokenIssuer    string
+	TimeTokenDesc      string
+	TimeTokenOfferIPFS string
+	TimeTokenOfferURL  string // Gateway URL of TimeTokenOfferIPFS
+	TelegramPartChatID string
+	ContractIPFS       string
+	ContractURL        string // Gateway URL of ContractIPFS
+	PIIStandard        bool
+}
+
+// IsEmpty returns true if no identity field is set.
+func (i Identity) IsEmpty() bool {
+	return i == Identity{}
 }
 
 // LPShareDisplay represents a liquidity pool share for display.
@@ -65,6 +92,7 @@ type AccountsPage struct {
 // Relationship represents a relationship for display.
 type Relationship struct {
 	Type        string // e.g., "Spouse", "Employer"
+	TypeName    string // Display name from relation_type_settings
 	TargetID    string // Full account ID
 	TargetName  string // Name or truncated ID
 	Direction   string // "outgoing" (→) or "incoming" (←)
@@ -146,10 +174,12 @@ type Operation struct {
 	StartingBalance string
 	TrustLimit      string
 	// Path payment fields
-	SourceAmount string
-	SourceAsset  string
-	DestAmount   string
-	DestAsset    string
+	SourceAmount      string
+	SourceAsset       string
+	SourceAssetIssuer string
+	DestAmount        string
+	DestAsset         string
+	DestAssetIssuer   string
 	// DEX offer fields
 	Selling string
 	Buying  string
@@ -164,6 +194,15 @@ type OperationsPage struct {
 	HasMore    bool
 }
 
+// OperationFilter narrows indexed operations. Zero values match everything.
+type OperationFilter struct {
+	Type         string    // Horizon operation type, e.g. "payment"
+	AssetCode    string    // Asset code of the operation, "XLM" for native
+	Counterparty string    // Other account involved
+	Since        time.Time // Inclusive lower bound on the ledger close time
+	Until        time.Time // Exclusive upper bound on the ledger close time
+}
+
 // Transaction for detail page.
 type Transaction struct {
 	Hash           string
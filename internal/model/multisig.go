@@ -0,0 +1,73 @@
+package model
+
+import "time"
+
+// Signer is a key that can sign transactions of an account.
+type Signer struct {
+	Key    string // Account ID for ed25519 keys, otherwise the encoded hash or pre-auth key
+	Type   string // Horizon signer type, e.g. "ed25519_public_key"
+	Weight int32
+	Name   string // Name of the signer's account, empty if unknown
+	Signed bool   // A valid signature of this signer is present (collection page only)
+}
+
+// SigningRequirement describes which signatures a transaction needs to reach the
+// medium threshold of its source account. Init forms only use ManageData, a medium
+// threshold operation.
+type SigningRequirement struct {
+	AccountID     string
+	Threshold     int32    // Medium threshold; at least 1 because every transaction needs a signature
+	Signers       []Signer // Signers with non-zero weight, heaviest first
+	TotalWeight   int32    // Sum of all signer weights
+	MinSignatures int      // Fewest signatures that reach the threshold (0 = unreachable)
+	Required      []string // Keys without which the threshold cannot be reached
+	SignedWeight  int32    // Weight of valid signatures (collection page only)
+}
+
+// MultiSig reports whether more than one key can sign for the account.
+func (r *SigningRequirement) MultiSig() bool {
+	return len(r.Signers) > 1
+}
+
+// Reachable reports whether the signers together can reach the threshold.
+func (r *SigningRequirement) Reachable() bool {
+	return r.TotalWeight >= r.Threshold
+}
+
+// Met reports whether the collected signatures reach the threshold.
+func (r *SigningRequirement) Met() bool {
+	return r.SignedWeight >= r.Threshold
+}
+
+// IsRequired reports whether the signer with the given key must sign.
+func (r *SigningRequirement) IsRequired(key string) bool {
+	for _, k := range r.Required {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// SignatureCollection is a transaction whose signatures are collected on a shareable page.
+type SignatureCollection struct {
+	ID        string // Random identifier used in the page URL
+	AccountID string // Transaction source account
+	TxHash    string // Hash of the transaction on the public network
+	Envelope  string // Envelope with all signatures merged so far
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// InitCollectData holds data for rendering a signature collection page.
+type InitCollectData struct {
+	Page        string               // "collect"
+	Collection  *SignatureCollection // Nil if the collection could not be created
+	Transaction *DecodedTransaction  // Collected transaction with its operations
+	Requirement *SigningRequirement  // Nil if the account's signers could not be fetched
+	Names       map[string]string    // Map of account ID to name for involved accounts
+	LabLink     string               // Stellar Laboratory link of the collected envelope
+	CanSubmit   bool                 // Signed XDR can be submitted through Lore
+	Message     string               // Result of the last upload
+	Error       string               // Error message to display
+}
@@ -0,0 +1,59 @@
+package model
+
+import "sort"
+
+// DecodedTransaction is a transaction envelope decoded by the XDR inspector.
+type DecodedTransaction struct {
+	Hash           string // Envelope hash on the public network
+	InnerHash      string // Hash of the inner transaction (fee bump only)
+	FeeBump        bool
+	FeeAccount     string // Account paying for the fee bump (fee bump only)
+	SourceAccount  string
+	Sequence       int64
+	MaxFee         string // Maximum fee in XLM
+	MemoType       string // "none", "text", "id", "hash" or "return"
+	Memo           string
+	ValidAfter     string // Formatted lower time bound, empty if unbounded
+	ValidBefore    string // Formatted upper time bound, empty if unbounded
+	MaxTime        int64  // Upper time bound as a Unix timestamp, 0 if unbounded
+	Outdated       string // Why the transaction can no longer be applied as is; empty if current
+	SignatureCount int
+	Operations     []DecodedOperation
+	RiskCount      int // Number of operations with a Risk
+}
+
+// DecodedOperation is an operation of a decoded envelope with its plain-language meaning.
+type DecodedOperation struct {
+	Operation
+	Action        string // What the operation does, e.g. "adds Employer relation to"
+	TargetAccount string // Account the action refers to, resolved to a name for display
+	TargetValue   string // Non-account value the action refers to
+	Risk          string // Why the operation deserves attention; empty for routine operations
+}
+
+// AccountIDs returns the sorted unique account IDs involved in the transaction.
+func (t *DecodedTransaction) AccountIDs() []string {
+	set := make(map[string]struct{})
+	add := func(id string) {
+		if len(id) == 56 && id[0] == 'G' {
+			set[id] = struct{}{}
+		}
+	}
+
+	add(t.SourceAccount)
+	add(t.FeeAccount)
+	for _, op := range t.Operations {
+		add(op.SourceAccount)
+		add(op.From)
+		add(op.To)
+		add(op.AssetIssuer)
+		add(op.TargetAccount)
+	}
+
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,153 @@
+package relation
+
+import (
+	"slices"
+	"sort"
+)
+
+// Type describes a BSN relation type as configured in relation_type_settings.
+type Type struct {
+	Name                 string // ManageData key prefix, e.g. "PartOf"
+	PairedWith           string // Counterpart type that confirms this one (empty if none)
+	RequiresConfirmation bool
+	Symmetric            bool     // Both sides must declare the same type
+	Category             string   // Display category, e.g. "NETWORK" (empty for hidden types such as ratings)
+	SourceIsOwner        bool     // Declared by the owner of the target (OWNERSHIP types such as "Owner")
+	ConflictsWith        []string // Types that cannot point at the same account together with this one
+	Color                string   // CSS color of the category
+	DisplayName          string
+	Description          string
+	SortOrder            int
+}
+
+// Label returns the display name, falling back to the type name.
+func (t Type) Label() string {
+	if t.DisplayName != "" {
+		return t.DisplayName
+	}
+	return t.Name
+}
+
+// Category groups relation types for display.
+type Category struct {
+	Name  string
+	Color string
+	Types []string
+}
+
+// Registry is an immutable lookup of relation types.
+type Registry struct {
+	types      map[string]Type
+	ordered    []Type
+	categories []Category
+	prefixes   []string
+	conflicts  [][2]string
+}
+
+// NewRegistry builds a registry from the given types.
+// Types are ordered by SortOrder, then name; categories follow the order of their first type.
+func NewRegistry(types []Type) *Registry {
+	ordered := make([]Type, len(types))
+	copy(ordered, types)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].SortOrder != ordered[j].SortOrder {
+			return ordered[i].SortOrder < ordered[j].SortOrder
+		}
+		return ordered[i].Name < ordered[j].Name
+	})
+
+	r := &Registry{
+		types:   make(map[string]Type, len(ordered)),
+		ordered: ordered,
+	}
+
+	catIndex := make(map[string]int)
+	for _, t := range ordered {
+		r.types[t.Name] = t
+		r.prefixes = append(r.prefixes, t.Name)
+
+		if t.Category == "" {
+			continue
+		}
+		idx, ok := catIndex[t.Category]
+		if !ok {
+			idx = len(r.categories)
+			catIndex[t.Category] = idx
+			r.categories = append(r.categories, Category{Name: t.Category, Color: t.Color})
+		}
+		r.categories[idx].Types = append(r.categories[idx].Types, t.Name)
+	}
+
+	// Each conflict is listed once, in the order of the type that declares it
+	for _, t := range ordered {
+		for _, other := range t.ConflictsWith {
+			pair := [2]string{t.Name, other}
+			if other == t.Name || slices.Contains(r.conflicts, [2]string{other, t.Name}) || slices.Contains(r.conflicts, pair) {
+				continue
+			}
+			r.conflicts = append(r.conflicts, pair)
+		}
+	}
+
+	// Longest prefix first so "OwnerMajority" is matched before "Owner"
+	sort.SliceStable(r.prefixes, func(i, j int) bool {
+		return len(r.prefixes[i]) > len(r.prefixes[j])
+	})
+
+	return r
+}
+
+// Get returns the type with the given name.
+func (r *Registry) Get(name string) (Type, bool) {
+	t, ok := r.types[name]
+	return t, ok
+}
+
+// IsKnown reports whether name is a registered relation type.
+func (r *Registry) IsKnown(name string) bool {
+	_, ok := r.types[name]
+	return ok
+}
+
+// Types returns all types in display order.
+func (r *Registry) Types() []Type {
+	return r.ordered
+}
+
+// Categories returns display categories in order, each with its types.
+func (r *Registry) Categories() []Category {
+	return r.categories
+}
+
+// Prefixes returns type names ordered longest first, for matching ManageData keys.
+func (r *Registry) Prefixes() []string {
+	return r.prefixes
+}
+
+// Conflicts returns the pairs of types that cannot both point at the same account.
+func (r *Registry) Conflicts() [][2]string {
+	return r.conflicts
+}
+
+// ComplementOf returns the counterpart type that confirms name (e.g. Ward for Guardian).
+// Symmetric types are not complementary: they are confirmed by the same type.
+func (r *Registry) ComplementOf(name string) (string, bool) {
+	t, ok := r.types[name]
+	if !ok || t.PairedWith == "" || t.PairedWith == name {
+		return "", false
+	}
+	return t.PairedWith, true
+}
+
+// IsSymmetric reports whether name must be declared by both sides with the same type.
+func (r *Registry) IsSymmetric(name string) bool {
+	return r.types[name].Symmetric
+}
+
+// Label returns the display name of a type, or the name itself if unknown.
+func (r *Registry) Label(name string) string {
+	if t, ok := r.types[name]; ok {
+		return t.Label()
+	}
+	return name
+}
@@ -0,0 +1,60 @@
+package relation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRegistry(t *testing.T) {
+	r := NewRegistry([]Type{
+		{Name: "Owner", PairedWith: "OwnershipFull", Category: "OWNERSHIP", Color: "#f0b429", SortOrder: 43},
+		{Name: "OwnershipFull", PairedWith: "Owner", Category: "OWNERSHIP", Color: "#f0b429", SortOrder: 40, ConflictsWith: []string{"Owner"}},
+		{Name: "Spouse", PairedWith: "Spouse", Symmetric: true, Category: "FAMILY", Color: "#f85149", DisplayName: "Spouse", SortOrder: 11,
+			ConflictsWith: []string{"A", "Spouse"}},
+		{Name: "A", SortOrder: 1},
+	})
+
+	t.Run("categories follow sort order and skip hidden types", func(t *testing.T) {
+		cats := r.Categories()
+		require.Len(t, cats, 2)
+		assert.Equal(t, "FAMILY", cats[0].Name)
+		assert.Equal(t, "#f85149", cats[0].Color)
+		assert.Equal(t, []string{"Spouse"}, cats[0].Types)
+		assert.Equal(t, "OWNERSHIP", cats[1].Name)
+		assert.Equal(t, []string{"OwnershipFull", "Owner"}, cats[1].Types)
+	})
+
+	t.Run("prefixes are ordered longest first", func(t *testing.T) {
+		assert.Equal(t, []string{"OwnershipFull", "Spouse", "Owner", "A"}, r.Prefixes())
+	})
+
+	t.Run("complement excludes symmetric pairs", func(t *testing.T) {
+		complement, ok := r.ComplementOf("Owner")
+		assert.True(t, ok)
+		assert.Equal(t, "OwnershipFull", complement)
+
+		_, ok = r.ComplementOf("Spouse")
+		assert.False(t, ok)
+		assert.True(t, r.IsSymmetric("Spouse"))
+
+		_, ok = r.ComplementOf("Unknown")
+		assert.False(t, ok)
+	})
+
+	t.Run("labels fall back to the type name", func(t *testing.T) {
+		assert.Equal(t, "Spouse", r.Label("Spouse"))
+		assert.Equal(t, "Owner", r.Label("Owner"))
+		assert.Equal(t, "Unknown", r.Label("Unknown"))
+	})
+
+	t.Run("conflicts are listed once per pair", func(t *testing.T) {
+		assert.Equal(t, [][2]string{{"Spouse", "A"}, {"OwnershipFull", "Owner"}}, r.Conflicts())
+	})
+
+	t.Run("known types", func(t *testing.T) {
+		assert.True(t, r.IsKnown("A"))
+		assert.False(t, r.IsKnown("Z"))
+	})
+}
@@ -0,0 +1,47 @@
+// Package relationtest provides the standard BSN relation types for tests
+// that cannot read relation_type_settings from the database.
+package relationtest
+
+import "github.com/mtlprog/lore/internal/relation"
+
+// Types mirrors the rows seeded by migrations 001, 007, 019 and 020.
+var Types = []relation.Type{
+	{Name: "A", SortOrder: 1},
+	{Name: "B", SortOrder: 2},
+	{Name: "C", SortOrder: 3},
+	{Name: "D", SortOrder: 4},
+
+	{Name: "OneFamily", PairedWith: "OneFamily", RequiresConfirmation: true, Symmetric: true, Category: "FAMILY", Color: "#f85149", SortOrder: 10},
+	{Name: "Spouse", PairedWith: "Spouse", RequiresConfirmation: true, Symmetric: true, Category: "FAMILY", Color: "#f85149", SortOrder: 11, ConflictsWith: []string{"Divorce"}},
+	{Name: "Guardian", PairedWith: "Ward", RequiresConfirmation: true, Category: "FAMILY", Color: "#f85149", SortOrder: 12, ConflictsWith: []string{"Ward"}},
+	{Name: "Ward", PairedWith: "Guardian", RequiresConfirmation: true, Category: "FAMILY", Color: "#f85149", SortOrder: 13},
+	{Name: "Sympathy", Category: "FAMILY", Color: "#f85149", SortOrder: 14},
+	{Name: "Love", Category: "FAMILY", Color: "#f85149", SortOrder: 15},
+	{Name: "Divorce", Category: "FAMILY", Color: "#f85149", SortOrder: 16},
+
+	{Name: "Employer", PairedWith: "Employee", RequiresConfirmation: true, Category: "WORK", Color: "#58a6ff", SortOrder: 20, ConflictsWith: []string{"Employee"}},
+	{Name: "Employee", PairedWith: "Employer", RequiresConfirmation: true, Category: "WORK", Color: "#58a6ff", SortOrder: 21},
+	{Name: "Contractor", PairedWith: "Client", RequiresConfirmation: true, Category: "WORK", Color: "#58a6ff", SortOrder: 22},
+	{Name: "Client", PairedWith: "Contractor", RequiresConfirmation: true, Category: "WORK", Color: "#58a6ff", SortOrder: 23},
+
+	{Name: "Partnership", PairedWith: "Partnership", RequiresConfirmation: true, Symmetric: true, Category: "NETWORK", Color: "#a371f7", SortOrder: 30},
+	{Name: "Collaboration", PairedWith: "Collaboration", RequiresConfirmation: true, Symmetric: true, Category: "NETWORK", Color: "#a371f7", SortOrder: 31},
+	{Name: "MyPart", PairedWith: "PartOf", RequiresConfirmation: true, Category: "NETWORK", Color: "#a371f7", DisplayName: "My Part", SortOrder: 32},
+	{Name: "PartOf", PairedWith: "MyPart", RequiresConfirmation: true, Category: "NETWORK", Color: "#a371f7", DisplayName: "Part Of", SortOrder: 33},
+	{Name: "RecommendToMTLA", Category: "NETWORK", Color: "#a371f7", SortOrder: 34},
+
+	{Name: "OwnershipFull", PairedWith: "Owner", RequiresConfirmation: true, Category: "OWNERSHIP", Color: "#f0b429", SortOrder: 40, ConflictsWith: []string{"OwnershipMajority", "OwnershipMinority"}},
+	{Name: "OwnershipMajority", PairedWith: "OwnerMajority", RequiresConfirmation: true, Category: "OWNERSHIP", Color: "#f0b429", SortOrder: 41, ConflictsWith: []string{"OwnershipMinority"}},
+	{Name: "OwnershipMinority", PairedWith: "OwnerMinority", RequiresConfirmation: true, Category: "OWNERSHIP", Color: "#f0b429", SortOrder: 42},
+	{Name: "Owner", SourceIsOwner: true, PairedWith: "OwnershipFull", RequiresConfirmation: true, Category: "OWNERSHIP", Color: "#f0b429", SortOrder: 43},
+	{Name: "OwnerMajority", SourceIsOwner: true, PairedWith: "OwnershipMajority", RequiresConfirmation: true, Category: "OWNERSHIP", Color: "#f0b429", SortOrder: 44},
+	{Name: "OwnerMinority", SourceIsOwner: true, PairedWith: "OwnershipMinority", RequiresConfirmation: true, Category: "OWNERSHIP", Color: "#f0b429", SortOrder: 45},
+
+	{Name: "WelcomeGuest", Category: "SOCIAL", Color: "#00ff88", SortOrder: 50},
+	{Name: "FactionMember", Symmetric: true, Category: "SOCIAL", Color: "#00ff88", SortOrder: 51},
+}
+
+// Registry returns a registry built from Types.
+func Registry() *relation.Registry {
+	return relation.NewRegistry(Types)
+}
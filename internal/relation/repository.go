@@ -0,0 +1,125 @@
+package relation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mtlprog/lore/internal/database"
+)
+
+// Repository reads and writes relation_type_settings.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRepository creates a new relation type repository.
+func NewRepository(pool *pgxpool.Pool) (*Repository, error) {
+	if pool == nil {
+		return nil, errors.New("database pool is required")
+	}
+	return &Repository{pool: pool}, nil
+}
+
+// Load reads all relation types and builds a registry.
+func (r *Repository) Load(ctx context.Context) (*Registry, error) {
+	types, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewRegistry(types), nil
+}
+
+// List returns all relation types ordered by sort order.
+func (r *Repository) List(ctx context.Context) ([]Type, error) {
+	query, args, err := database.QB.
+		Select(
+			"relation_type",
+			"COALESCE(paired_with, '')",
+			"COALESCE(requires_confirmation, FALSE)",
+			"is_symmetric",
+			"COALESCE(category, '')",
+			"source_is_owner",
+			"conflicts_with",
+			"COALESCE(color, '')",
+			"COALESCE(display_name, '')",
+			"COALESCE(description, '')",
+			"sort_order",
+		).
+		From("relation_type_settings").
+		OrderBy("sort_order", "relation_type").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build relation types query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query relation types: %w", err)
+	}
+	defer rows.Close()
+
+	var types []Type
+	for rows.Next() {
+		var t Type
+		if err := rows.Scan(
+			&t.Name, &t.PairedWith, &t.RequiresConfirmation, &t.Symmetric,
+			&t.Category, &t.SourceIsOwner, &t.ConflictsWith, &t.Color, &t.DisplayName, &t.Description, &t.SortOrder,
+		); err != nil {
+			return nil, fmt.Errorf("scan relation type: %w", err)
+		}
+		types = append(types, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate relation types: %w", err)
+	}
+
+	return types, nil
+}
+
+// Upsert inserts a relation type or replaces all of its settings.
+func (r *Repository) Upsert(ctx context.Context, t Type) error {
+	conflicts := t.ConflictsWith
+	if conflicts == nil {
+		conflicts = []string{}
+	}
+	query, args, err := database.QB.
+		Insert("relation_type_settings").
+		Columns(
+			"relation_type", "paired_with", "requires_confirmation", "is_symmetric",
+			"category", "source_is_owner", "conflicts_with", "color", "display_name", "description", "sort_order",
+		).
+		Values(
+			t.Name, nullIfEmpty(t.PairedWith), t.RequiresConfirmation, t.Symmetric,
+			nullIfEmpty(t.Category), t.SourceIsOwner, conflicts, nullIfEmpty(t.Color), nullIfEmpty(t.DisplayName), nullIfEmpty(t.Description), t.SortOrder,
+		).
+		Suffix(`ON CONFLICT (relation_type) DO UPDATE SET
+			paired_with = EXCLUDED.paired_with,
+			requires_confirmation = EXCLUDED.requires_confirmation,
+			is_symmetric = EXCLUDED.is_symmetric,
+			category = EXCLUDED.category,
+			source_is_owner = EXCLUDED.source_is_owner,
+			conflicts_with = EXCLUDED.conflicts_with,
+			color = EXCLUDED.color,
+			display_name = EXCLUDED.display_name,
+			description = EXCLUDED.description,
+			sort_order = EXCLUDED.sort_order`).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build upsert relation type query: %w", err)
+	}
+
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("upsert relation type: %w", err)
+	}
+	return nil
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/mtlprog/lore/internal/database"
+	"github.com/mtlprog/lore/internal/delegation"
+	"github.com/mtlprog/lore/internal/model"
 	"github.com/samber/lo"
 )
 
@@ -93,9 +95,9 @@ func (r *AccountRepository) GetStats(ctx context.Context) (*Stats, error) {
 	return &stats, nil
 }
 
-// GetPersons returns MTLAP holders with their names.
-func (r *AccountRepository) GetPersons(ctx context.Context, limit int, offset int) ([]PersonRow, error) {
-	query, args, err := database.QB.
+// personsQuery selects MTLAP holders with their names.
+func personsQuery() sq.SelectBuilder {
+	return database.QB.
 		Select(
 			"a.account_id",
 			"COALESCE(m.data_value, CONCAT(LEFT(a.account_id, 6), '...', RIGHT(a.account_id, 6))) AS name",
@@ -105,7 +107,12 @@ func (r *AccountRepository) GetPersons(ctx context.Context, limit int, offset in
 		).
 		From("accounts a").
 		LeftJoin("account_metadata m ON a.account_id = m.account_id AND m.data_key = 'Name' AND m.data_index = ''").
-		Where("a.mtlap_balance > 0 AND a.mtlap_balance <= 5").
+		Where("a.mtlap_balance > 0 AND a.mtlap_balance <= 5")
+}
+
+// GetPersons returns MTLAP holders with their names.
+func (r *AccountRepository) GetPersons(ctx context.Context, limit int, offset int) ([]PersonRow, error) {
+	query, args, err := personsQuery().
 		OrderBy("a.mtlap_balance DESC").
 		Limit(uint64(limit)).
 		Offset(uint64(offset)).
@@ -113,7 +120,10 @@ func (r *AccountRepository) GetPersons(ctx context.Context, limit int, offset in
 	if err != nil {
 		return nil, fmt.Errorf("build persons query: %w", err)
 	}
+	return r.queryPersons(ctx, query, args)
+}
 
+func (r *AccountRepository) queryPersons(ctx context.Context, query string, args []any) ([]PersonRow, error) {
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query persons: %w", err)
@@ -135,9 +145,9 @@ func (r *AccountRepository) GetPersons(ctx context.Context, limit int, offset in
 	return persons, nil
 }
 
-// GetCorporate returns MTLAC holders with their names and portfolio values.
-func (r *AccountRepository) GetCorporate(ctx context.Context, limit int, offset int) ([]CorporateRow, error) {
-	query, args, err := database.QB.
+// corporateQuery selects MTLAC holders with their names and portfolio values.
+func corporateQuery() sq.SelectBuilder {
+	return database.QB.
 		Select(
 			"a.account_id",
 			"COALESCE(m.data_value, CONCAT(LEFT(a.account_id, 6), '...', RIGHT(a.account_id, 6))) AS name",
@@ -146,7 +156,12 @@ func (r *AccountRepository) GetCorporate(ctx context.Context, limit int, offset
 		).
 		From("accounts a").
 		LeftJoin("account_metadata m ON a.account_id = m.account_id AND m.data_key = 'Name' AND m.data_index = ''").
-		Where("a.mtlac_balance > 0 AND a.mtlac_balance <= 4").
+		Where("a.mtlac_balance > 0 AND a.mtlac_balance <= 4")
+}
+
+// GetCorporate returns MTLAC holders with their names and portfolio values.
+func (r *AccountRepository) GetCorporate(ctx context.Context, limit int, offset int) ([]CorporateRow, error) {
+	query, args, err := corporateQuery().
 		OrderBy("a.mtlac_balance DESC", "a.total_xlm_value DESC").
 		Limit(uint64(limit)).
 		Offset(uint64(offset)).
@@ -154,7 +169,10 @@ func (r *AccountRepository) GetCorporate(ctx context.Context, limit int, offset
 	if err != nil {
 		return nil, fmt.Errorf("build corporate query: %w", err)
 	}
+	return r.queryCorporate(ctx, query, args)
+}
 
+func (r *AccountRepository) queryCorporate(ctx context.Context, query string, args []any) ([]CorporateRow, error) {
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query corporate: %w", err)
@@ -176,9 +194,9 @@ func (r *AccountRepository) GetCorporate(ctx context.Context, limit int, offset
 	return corporate, nil
 }
 
-// GetSynthetic returns MTLAX trustline holders sorted by reputation score.
-func (r *AccountRepository) GetSynthetic(ctx context.Context, limit int, offset int) ([]SyntheticRow, error) {
-	query, args, err := database.QB.
+// syntheticQuery selects MTLAX trustline holders with their reputation.
+func syntheticQuery() sq.SelectBuilder {
+	return database.QB.
 		Select(
 			"a.account_id",
 			"COALESCE(m.data_value, CONCAT(LEFT(a.account_id, 6), '...', RIGHT(a.account_id, 6))) AS name",
@@ -189,7 +207,12 @@ func (r *AccountRepository) GetSynthetic(ctx context.Context, limit int, offset
 		From("accounts a").
 		LeftJoin("account_metadata m ON a.account_id = m.account_id AND m.data_key = 'Name' AND m.data_index = ''").
 		LeftJoin("reputation_scores rs ON a.account_id = rs.account_id").
-		Where("a.mtlax_balance IS NOT NULL").
+		Where("a.mtlax_balance IS NOT NULL")
+}
+
+// GetSynthetic returns MTLAX trustline holders sorted by reputation score.
+func (r *AccountRepository) GetSynthetic(ctx context.Context, limit int, offset int) ([]SyntheticRow, error) {
+	query, args, err := syntheticQuery().
 		OrderBy("COALESCE(rs.weighted_score, 0) DESC", "COALESCE(rs.total_weight, 0) DESC").
 		Limit(uint64(limit)).
 		Offset(uint64(offset)).
@@ -197,7 +220,10 @@ func (r *AccountRepository) GetSynthetic(ctx context.Context, limit int, offset
 	if err != nil {
 		return nil, fmt.Errorf("build synthetic query: %w", err)
 	}
+	return r.querySynthetic(ctx, query, args)
+}
 
+func (r *AccountRepository) querySynthetic(ctx context.Context, query string, args []any) ([]SyntheticRow, error) {
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query synthetic: %w", err)
@@ -458,6 +484,38 @@ func (r *AccountRepository) GetAccountInfo(ctx context.Context, accountID string
 	return &info, nil
 }
 
+// GetDelegationInfo returns the delegation state of all accounts keyed by account ID.
+func (r *AccountRepository) GetDelegationInfo(ctx context.Context) (map[string]*delegation.Info, error) {
+	query, args, err := database.QB.
+		Select("account_id", "delegate_to", "council_delegate_to", "COALESCE(mtlap_balance, 0)", "COALESCE(is_council_ready, FALSE)").
+		From("accounts").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build delegation info query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query delegation info: %w", err)
+	}
+	defer rows.Close()
+
+	infos := make(map[string]*delegation.Info)
+	for rows.Next() {
+		var info delegation.Info
+		if err := rows.Scan(&info.AccountID, &info.DelegateTo, &info.CouncilDelegateTo, &info.MTLAPBalance, &info.CouncilReady); err != nil {
+			return nil, fmt.Errorf("scan delegation info: %w", err)
+		}
+		infos[info.AccountID] = &info
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate delegation info: %w", err)
+	}
+
+	return infos, nil
+}
+
 // GetAccountNames returns a map of account IDs to names for the given IDs.
 // Accounts not found in the database will not be included in the result.
 func (r *AccountRepository) GetAccountNames(ctx context.Context, accountIDs []string) (map[string]string, error) {
@@ -565,17 +623,9 @@ func escapeLikePattern(s string) string {
 	return s
 }
 
-// SearchAccounts searches accounts by name or account ID with case-insensitive substring matching.
-// If tags are provided, accounts must have ALL specified tags (AND logic).
-// Tags should be provided without the "Tag" prefix (e.g., "Belgrade", not "TagBelgrade").
-// sortBy specifies the sorting order: "balance" (default) or "reputation".
-func (r *AccountRepository) SearchAccounts(ctx context.Context, query string, tags []string, limit int, offset int, sortBy SearchSortOrder) ([]SearchAccountRow, error) {
-	// If both query and tags are empty, return nothing
-	if query == "" && len(tags) == 0 {
-		return nil, nil
-	}
-
-	// Base query builder
+// searchQuery selects accounts matching a name or account ID substring and
+// carrying all given tags. Tags are given without the "Tag" prefix.
+func searchQuery(query string, tags []string) sq.SelectBuilder {
 	qb := database.QB.
 		Select(
 			"a.account_id",
@@ -614,6 +664,20 @@ func (r *AccountRepository) SearchAccounts(ctx context.Context, query string, ta
 			GroupBy("a.account_id", "m.data_value", "rs.weighted_score", "rs.total_weight").
 			Having(fmt.Sprintf("COUNT(DISTINCT tags.data_key) = %d", len(tagKeys)))
 	}
+	return qb
+}
+
+// SearchAccounts searches accounts by name or account ID with case-insensitive substring matching.
+// If tags are provided, accounts must have ALL specified tags (AND logic).
+// Tags should be provided without the "Tag" prefix (e.g., "Belgrade", not "TagBelgrade").
+// sortBy specifies the sorting order: "balance" (default) or "reputation".
+func (r *AccountRepository) SearchAccounts(ctx context.Context, query string, tags []string, limit int, offset int, sortBy SearchSortOrder) ([]SearchAccountRow, error) {
+	// If both query and tags are empty, return nothing
+	if query == "" && len(tags) == 0 {
+		return nil, nil
+	}
+
+	qb := searchQuery(query, tags)
 
 	// Apply sorting
 	switch sortBy {
@@ -637,13 +701,17 @@ func (r *AccountRepository) SearchAccounts(ctx context.Context, query string, ta
 		qb = qb.OrderBy("GREATEST(a.mtlap_balance, a.mtlac_balance, COALESCE(a.mtlax_balance, 0)) DESC", "a.total_xlm_value DESC")
 	}
 
-	qb = qb.Limit(uint64(limit)).Offset(uint64(offset))
-
-	sql, args, err := qb.ToSql()
+	sql, args, err := qb.
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("build search query: %w", err)
 	}
+	return r.querySearchAccounts(ctx, sql, args)
+}
 
+func (r *AccountRepository) querySearchAccounts(ctx context.Context, sql string, args []any) ([]SearchAccountRow, error) {
 	rows, err := r.pool.Query(ctx, sql, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query search accounts: %w", err)
@@ -865,9 +933,9 @@ type AllAccountRow struct {
 	ReceivedVotes   int
 }
 
-// GetAllAccounts returns a paginated list of all accounts ordered by total XLM value.
-func (r *AccountRepository) GetAllAccounts(ctx context.Context, limit, offset int) ([]AllAccountRow, error) {
-	query, args, err := database.QB.
+// allAccountsQuery selects every account with its balances and reputation.
+func allAccountsQuery() sq.SelectBuilder {
+	return database.QB.
 		Select(
 			"a.account_id",
 			"COALESCE(am.data_value, '')",
@@ -881,7 +949,12 @@ func (r *AccountRepository) GetAllAccounts(ctx context.Context, limit, offset in
 		).
 		From("accounts a").
 		LeftJoin("account_metadata am ON a.account_id = am.account_id AND am.data_key = 'Name' AND am.data_index = ''").
-		LeftJoin("reputation_scores rc ON a.account_id = rc.account_id").
+		LeftJoin("reputation_scores rc ON a.account_id = rc.account_id")
+}
+
+// GetAllAccounts returns a paginated list of all accounts ordered by total XLM value.
+func (r *AccountRepository) GetAllAccounts(ctx context.Context, limit, offset int) ([]AllAccountRow, error) {
+	query, args, err := allAccountsQuery().
 		OrderBy("a.total_xlm_value DESC").
 		Limit(uint64(limit)).
 		Offset(uint64(offset)).
@@ -889,7 +962,26 @@ func (r *AccountRepository) GetAllAccounts(ctx context.Context, limit, offset in
 	if err != nil {
 		return nil, fmt.Errorf("build all accounts query: %w", err)
 	}
+	return r.queryAllAccounts(ctx, query, args)
+}
+
+// GetAccountsByIDs returns the accounts among accountIDs that are tracked, in
+// no particular order.
+func (r *AccountRepository) GetAccountsByIDs(ctx context.Context, accountIDs []string) ([]AllAccountRow, error) {
+	if len(accountIDs) == 0 {
+		return nil, nil
+	}
 
+	query, args, err := allAccountsQuery().
+		Where(sq.Eq{"a.account_id": accountIDs}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build accounts by IDs query: %w", err)
+	}
+	return r.queryAllAccounts(ctx, query, args)
+}
+
+func (r *AccountRepository) queryAllAccounts(ctx context.Context, query string, args []any) ([]AllAccountRow, error) {
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query all accounts: %w", err)
@@ -923,9 +1015,19 @@ type AccountMetadata struct {
 	About    string
 	Websites []string
 	Tags     []string
+
+	// Identity profile fields (see INITFORM.md)
+	TelegramUserID     string
+	TimeTokenCode      string
+	TimeTokenIssuer    string
+	TimeTokenDesc      string
+	TimeTokenOfferIPFS string
+	TelegramPartChatID string
+	ContractIPFS       string
+	PIIStandard        bool
 }
 
-// GetAccountMetadata returns metadata (name, about, websites, tags) for an account.
+// GetAccountMetadata returns metadata (name, about, websites, tags, identity fields) for an account.
 func (r *AccountRepository) GetAccountMetadata(ctx context.Context, accountID string) (*AccountMetadata, error) {
 	query, args, err := database.QB.
 		Select("data_key", "data_index", "data_value").
@@ -954,6 +1056,22 @@ func (r *AccountRepository) GetAccountMetadata(ctx context.Context, accountID st
 			meta.Name = value
 		case key == "About" && index == "":
 			meta.About = value
+		case key == model.DataKeyTelegramUserID && index == "":
+			meta.TelegramUserID = value
+		case key == model.DataKeyTimeTokenCode && index == "":
+			meta.TimeTokenCode = value
+		case key == model.DataKeyTimeTokenIssuer && index == "":
+			meta.TimeTokenIssuer = value
+		case key == model.DataKeyTimeTokenDesc && index == "":
+			meta.TimeTokenDesc = value
+		case key == model.DataKeyTimeTokenOfferIPFS && index == "":
+			meta.TimeTokenOfferIPFS = value
+		case key == model.DataKeyTelegramPartChatID && index == "":
+			meta.TelegramPartChatID = value
+		case key == model.DataKeyContractIPFS && index == "":
+			meta.ContractIPFS = value
+		case key == model.DataKeyPIIStandard:
+			meta.PIIStandard = true
 		case strings.HasPrefix(key, "Website"):
 			meta.Websites = append(meta.Websites, value)
 		case strings.HasPrefix(key, "Tag"):
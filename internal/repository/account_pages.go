@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Keyset orderings of the account lists. They follow the order of the offset
+// methods, with keys compared as double precision and the account ID as tiebreaker.
+var (
+	personsKeyset     = keyset{"a.mtlap_balance"}
+	corporateKeyset   = keyset{"a.mtlac_balance", "COALESCE(a.total_xlm_value, 0)"}
+	syntheticKeyset   = keyset{"COALESCE(rs.weighted_score, 0)", "COALESCE(rs.total_weight, 0)"}
+	allAccountsKeyset = keyset{"COALESCE(a.total_xlm_value, 0)"}
+)
+
+// searchMembershipLevel ranks search results by their highest membership token balance.
+const searchMembershipLevel = "GREATEST(a.mtlap_balance, a.mtlac_balance, COALESCE(a.mtlax_balance, 0))"
+
+// reputationGradeFloors are the lower score bounds of the grade buckets search
+// results are grouped in when sorted by reputation, best grade first. Scores
+// above zero but below the last floor form one more bucket, unrated accounts
+// the final one.
+var reputationGradeFloors = []float64{3.5, 3.0, 2.5, 2.0, 1.5, 1.0}
+
+// reputationBucketSQL is the grade bucket of a search result, negated so that
+// better grades sort first in the descending keyset.
+var reputationBucketSQL = func() string {
+	var b strings.Builder
+	b.WriteString("-(CASE")
+	for i, floor := range reputationGradeFloors {
+		fmt.Fprintf(&b, " WHEN COALESCE(rs.weighted_score, 0) >= %g THEN %d", floor, i+1)
+	}
+	fmt.Fprintf(&b, " WHEN COALESCE(rs.weighted_score, 0) > 0 THEN %d ELSE %d END)",
+		len(reputationGradeFloors)+1, len(reputationGradeFloors)+2)
+	return b.String()
+}()
+
+// reputationBucket mirrors reputationBucketSQL for a scanned score.
+func reputationBucket(score float64) float64 {
+	for i, floor := range reputationGradeFloors {
+		if score >= floor {
+			return -float64(i + 1)
+		}
+	}
+	if score > 0 {
+		return -float64(len(reputationGradeFloors) + 1)
+	}
+	return -float64(len(reputationGradeFloors) + 2)
+}
+
+// searchKeyset returns the ordering of search results for a sort order.
+func searchKeyset(sortBy SearchSortOrder) keyset {
+	if sortBy == SearchSortByReputation {
+		// Membership level first, then grade bucket, then weight of the raters
+		return keyset{searchMembershipLevel, reputationBucketSQL, "COALESCE(rs.total_weight, 0)"}
+	}
+	return keyset{searchMembershipLevel, "COALESCE(a.total_xlm_value, 0)"}
+}
+
+// searchPosition returns the sort key values of a search result.
+func searchPosition(sortBy SearchSortOrder) func(SearchAccountRow) ([]float64, string) {
+	return func(row SearchAccountRow) ([]float64, string) {
+		level := max(row.MTLAPBalance, row.MTLACBalance, row.MTLAXBalance)
+		if sortBy == SearchSortByReputation {
+			return []float64{level, reputationBucket(row.ReputationScore), row.ReputationWeight}, row.AccountID
+		}
+		return []float64{level, row.TotalXLMValue}, row.AccountID
+	}
+}
+
+// GetPersonsPage returns a page of MTLAP holders after (or, for a backward
+// cursor, before) the position encoded in cursor. An empty cursor starts at the top.
+func (r *AccountRepository) GetPersonsPage(ctx context.Context, cursor string, limit int) ([]PersonRow, KeysetPage, error) {
+	c, err := DecodePageCursor(cursor)
+	if err != nil {
+		return nil, KeysetPage{}, err
+	}
+	qb, err := personsKeyset.page(personsQuery(), c, limit)
+	if err != nil {
+		return nil, KeysetPage{}, err
+	}
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, KeysetPage{}, fmt.Errorf("build persons page query: %w", err)
+	}
+
+	rows, err := r.queryPersons(ctx, query, args)
+	if err != nil {
+		return nil, KeysetPage{}, err
+	}
+	persons, page := keysetPage(rows, c, limit, func(p PersonRow) ([]float64, string) {
+		return []float64{p.MTLAPBalance}, p.AccountID
+	})
+	return persons, page, nil
+}
+
+// GetCorporatePage returns a page of MTLAC holders relative to cursor.
+func (r *AccountRepository) GetCorporatePage(ctx context.Context, cursor string, limit int) ([]CorporateRow, KeysetPage, error) {
+	c, err := DecodePageCursor(cursor)
+	if err != nil {
+		return nil, KeysetPage{}, err
+	}
+	qb, err := corporateKeyset.page(corporateQuery(), c, limit)
+	if err != nil {
+		return nil, KeysetPage{}, err
+	}
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, KeysetPage{}, fmt.Errorf("build corporate page query: %w", err)
+	}
+
+	rows, err := r.queryCorporate(ctx, query, args)
+	if err != nil {
+		return nil, KeysetPage{}, err
+	}
+	corporate, page := keysetPage(rows, c, limit, func(c CorporateRow) ([]float64, string) {
+		return []float64{c.MTLACBalance, c.TotalXLMValue}, c.AccountID
+	})
+	return corporate, page, nil
+}
+
+// GetSyntheticPage returns a page of MTLAX trustline holders relative to cursor.
+func (r *AccountRepository) GetSyntheticPage(ctx context.Context, cursor string, limit int) ([]SyntheticRow, KeysetPage, error) {
+	c, err := DecodePageCursor(cursor)
+	if err != nil {
+		return nil, KeysetPage{}, err
+	}
+	qb, err := syntheticKeyset.page(syntheticQuery(), c, limit)
+	if err != nil {
+		return nil, KeysetPage{}, err
+	}
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, KeysetPage{}, fmt.Errorf("build synthetic page query: %w", err)
+	}
+
+	rows, err := r.querySynthetic(ctx, query, args)
+	if err != nil {
+		return nil, KeysetPage{}, err
+	}
+	synthetic, page := keysetPage(rows, c, limit, func(s SyntheticRow) ([]float64, string) {
+		return []float64{s.ReputationScore, s.ReputationWeight}, s.AccountID
+	})
+	return synthetic, page, nil
+}
+
+// GetAllAccountsPage returns a page of all accounts relative to cursor.
+func (r *AccountRepository) GetAllAccountsPage(ctx context.Context, cursor string, limit int) ([]AllAccountRow, KeysetPage, error) {
+	c, err := DecodePageCursor(cursor)
+	if err != nil {
+		return nil, KeysetPage{}, err
+	}
+	qb, err := allAccountsKeyset.page(allAccountsQuery(), c, limit)
+	if err != nil {
+		return nil, KeysetPage{}, err
+	}
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, KeysetPage{}, fmt.Errorf("build all accounts page query: %w", err)
+	}
+
+	rows, err := r.queryAllAccounts(ctx, query, args)
+	if err != nil {
+		return nil, KeysetPage{}, err
+	}
+	accounts, page := keysetPage(rows, c, limit, func(a AllAccountRow) ([]float64, string) {
+		return []float64{a.TotalXLMValue}, a.AccountID
+	})
+	return accounts, page, nil
+}
+
+// SearchAccountsPage is SearchAccounts with keyset pagination relative to cursor.
+func (r *AccountRepository) SearchAccountsPage(ctx context.Context, query string, tags []string, cursor string, limit int, sortBy SearchSortOrder) ([]SearchAccountRow, KeysetPage, error) {
+	c, err := DecodePageCursor(cursor)
+	if err != nil {
+		return nil, KeysetPage{}, err
+	}
+	if query == "" && len(tags) == 0 {
+		return nil, KeysetPage{}, nil
+	}
+	qb, err := searchKeyset(sortBy).page(searchQuery(query, tags), c, limit)
+	if err != nil {
+		return nil, KeysetPage{}, err
+	}
+	sql, args, err := qb.ToSql()
+	if err != nil {
+		return nil, KeysetPage{}, fmt.Errorf("build search page query: %w", err)
+	}
+
+	rows, err := r.querySearchAccounts(ctx, sql, args)
+	if err != nil {
+		return nil, KeysetPage{}, err
+	}
+	accounts, page := keysetPage(rows, c, limit, searchPosition(sortBy))
+	return accounts, page, nil
+}
@@ -14,3 +14,12 @@ func TestNewAccountRepository(t *testing.T) {
 		assert.Contains(t, err.Error(), "database pool is required")
 	})
 }
+
+func TestNewCollectionRepository(t *testing.T) {
+	t.Run("nil pool returns error", func(t *testing.T) {
+		repo, err := NewCollectionRepository(nil)
+		assert.Nil(t, repo)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "database pool is required")
+	})
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mtlprog/lore/internal/cache"
+	"github.com/mtlprog/lore/internal/model"
+)
+
+// estimatedRowBytes approximates the memory held by one cached row, including
+// its strings. Only used to bound the cache, so it errs on the large side.
+const estimatedRowBytes = 512
+
+// CachedAccountRepository serves the aggregate reads behind the home page,
+// stats, control and economy views from a cache that is emptied when a sync
+// run is published. Other methods go straight to the database.
+type CachedAccountRepository struct {
+	*AccountRepository
+	cache *cache.Cache
+}
+
+// NewCachedAccountRepository wraps repo with the given cache.
+func NewCachedAccountRepository(repo *AccountRepository, c *cache.Cache) (*CachedAccountRepository, error) {
+	if repo == nil {
+		return nil, errors.New("account repository is required")
+	}
+	if c == nil {
+		return nil, errors.New("cache is required")
+	}
+	return &CachedAccountRepository{AccountRepository: repo, cache: c}, nil
+}
+
+// rowsPage is a cached keyset page.
+type rowsPage[T any] struct {
+	rows []T
+	page KeysetPage
+}
+
+// cachedRows loads a slice through the cache. The load runs detached from the
+// caller's cancellation since other requests may be waiting for it.
+func cachedRows[T any](ctx context.Context, c *cache.Cache, key string, load func(context.Context) ([]T, error)) ([]T, error) {
+	return cache.Load(c, key, func(rows []T) int64 {
+		return int64(len(rows)+1) * estimatedRowBytes
+	}, func() ([]T, error) {
+		return load(context.WithoutCancel(ctx))
+	})
+}
+
+func cachedPage[T any](ctx context.Context, c *cache.Cache, key string, load func(context.Context) ([]T, KeysetPage, error)) ([]T, KeysetPage, error) {
+	p, err := cache.Load(c, key, func(p rowsPage[T]) int64 {
+		return int64(len(p.rows)+1) * estimatedRowBytes
+	}, func() (rowsPage[T], error) {
+		rows, page, err := load(context.WithoutCancel(ctx))
+		return rowsPage[T]{rows: rows, page: page}, err
+	})
+	return p.rows, p.page, err
+}
+
+func cachedCount(ctx context.Context, c *cache.Cache, key string, load func(context.Context) (int, error)) (int, error) {
+	return cache.Load(c, key, func(int) int64 { return estimatedRowBytes }, func() (int, error) {
+		return load(context.WithoutCancel(ctx))
+	})
+}
+
+// GetStats returns cached aggregate statistics.
+func (r *CachedAccountRepository) GetStats(ctx context.Context) (*Stats, error) {
+	return cache.Load(r.cache, "stats", func(*Stats) int64 { return estimatedRowBytes }, func() (*Stats, error) {
+		return r.AccountRepository.GetStats(context.WithoutCancel(ctx))
+	})
+}
+
+// GetPersonsPage returns a cached page of persons.
+func (r *CachedAccountRepository) GetPersonsPage(ctx context.Context, cursor string, limit int) ([]PersonRow, KeysetPage, error) {
+	return cachedPage(ctx, r.cache, fmt.Sprintf("persons:%s:%d", cursor, limit), func(ctx context.Context) ([]PersonRow, KeysetPage, error) {
+		return r.AccountRepository.GetPersonsPage(ctx, cursor, limit)
+	})
+}
+
+// GetCorporatePage returns a cached page of corporate accounts.
+func (r *CachedAccountRepository) GetCorporatePage(ctx context.Context, cursor string, limit int) ([]CorporateRow, KeysetPage, error) {
+	return cachedPage(ctx, r.cache, fmt.Sprintf("corporate:%s:%d", cursor, limit), func(ctx context.Context) ([]CorporateRow, KeysetPage, error) {
+		return r.AccountRepository.GetCorporatePage(ctx, cursor, limit)
+	})
+}
+
+// GetSyntheticPage returns a cached page of synthetic accounts.
+func (r *CachedAccountRepository) GetSyntheticPage(ctx context.Context, cursor string, limit int) ([]SyntheticRow, KeysetPage, error) {
+	return cachedPage(ctx, r.cache, fmt.Sprintf("synthetic:%s:%d", cursor, limit), func(ctx context.Context) ([]SyntheticRow, KeysetPage, error) {
+		return r.AccountRepository.GetSyntheticPage(ctx, cursor, limit)
+	})
+}
+
+// GetAllAccountsPage returns a cached page of all accounts.
+func (r *CachedAccountRepository) GetAllAccountsPage(ctx context.Context, cursor string, limit int) ([]AllAccountRow, KeysetPage, error) {
+	return cachedPage(ctx, r.cache, fmt.Sprintf("all:%s:%d", cursor, limit), func(ctx context.Context) ([]AllAccountRow, KeysetPage, error) {
+		return r.AccountRepository.GetAllAccountsPage(ctx, cursor, limit)
+	})
+}
+
+// CountPersons returns the cached number of persons.
+func (r *CachedAccountRepository) CountPersons(ctx context.Context) (int, error) {
+	return cachedCount(ctx, r.cache, "count:persons", r.AccountRepository.CountPersons)
+}
+
+// CountCorporate returns the cached number of corporate accounts.
+func (r *CachedAccountRepository) CountCorporate(ctx context.Context) (int, error) {
+	return cachedCount(ctx, r.cache, "count:corporate", r.AccountRepository.CountCorporate)
+}
+
+// CountSynthetic returns the cached number of synthetic accounts.
+func (r *CachedAccountRepository) CountSynthetic(ctx context.Context) (int, error) {
+	return cachedCount(ctx, r.cache, "count:synthetic", r.AccountRepository.CountSynthetic)
+}
+
+// GetNewMembers returns cached membership grants since a time.
+func (r *CachedAccountRepository) GetNewMembers(ctx context.Context, since time.Time, limit int) ([]model.MembershipEvent, error) {
+	key := fmt.Sprintf("new-members:%d:%d", since.Unix(), limit)
+	return cachedRows(ctx, r.cache, key, func(ctx context.Context) ([]model.MembershipEvent, error) {
+		return r.AccountRepository.GetNewMembers(ctx, since, limit)
+	})
+}
+
+// GetMembershipGrowth returns cached membership growth since a time.
+func (r *CachedAccountRepository) GetMembershipGrowth(ctx context.Context, since time.Time) ([]model.MembershipGrowth, error) {
+	key := fmt.Sprintf("membership-growth:%d", since.Unix())
+	return cachedRows(ctx, r.cache, key, func(ctx context.Context) ([]model.MembershipGrowth, error) {
+		return r.AccountRepository.GetMembershipGrowth(ctx, since)
+	})
+}
+
+// GetControlGraph returns the cached control graph rooted at an issuer.
+func (r *CachedAccountRepository) GetControlGraph(ctx context.Context, issuer string) ([]ControlRow, error) {
+	return cachedRows(ctx, r.cache, "control:"+issuer, func(ctx context.Context) ([]ControlRow, error) {
+		return r.AccountRepository.GetControlGraph(ctx, issuer)
+	})
+}
+
+// GetFlowEdges returns cached payment flows between tracked accounts.
+func (r *CachedAccountRepository) GetFlowEdges(ctx context.Context, filter model.FlowFilter, limit int) ([]model.FlowEdge, error) {
+	key := fmt.Sprintf("flow-edges:%s:%d", flowFilterKey(filter), limit)
+	return cachedRows(ctx, r.cache, key, func(ctx context.Context) ([]model.FlowEdge, error) {
+		return r.AccountRepository.GetFlowEdges(ctx, filter, limit)
+	})
+}
+
+// GetFlowPeriods returns cached monthly payment flow totals.
+func (r *CachedAccountRepository) GetFlowPeriods(ctx context.Context, filter model.FlowFilter) ([]model.FlowPeriod, error) {
+	key := "flow-periods:" + flowFilterKey(filter)
+	return cachedRows(ctx, r.cache, key, func(ctx context.Context) ([]model.FlowPeriod, error) {
+		return r.AccountRepository.GetFlowPeriods(ctx, filter)
+	})
+}
+
+func flowFilterKey(filter model.FlowFilter) string {
+	return fmt.Sprintf("%s:%s:%d:%d", filter.AssetCode, filter.AssetIssuer, filter.Since.Unix(), filter.Until.Unix())
+}
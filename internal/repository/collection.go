@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mtlprog/lore/internal/database"
+	"github.com/mtlprog/lore/internal/model"
+)
+
+// collectionRetention is how long a signature collection is kept after it was
+// started. Generated transactions expire after a day by default, and ones
+// without an upper time bound can be submitted for a week.
+const collectionRetention = 7 * 24 * time.Hour
+
+// CollectionRepository stores signature collections of multisig init transactions.
+type CollectionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCollectionRepository creates a new signature collection repository.
+// Returns error if pool is nil.
+func NewCollectionRepository(pool *pgxpool.Pool) (*CollectionRepository, error) {
+	if pool == nil {
+		return nil, errors.New("database pool is required")
+	}
+	return &CollectionRepository{pool: pool}, nil
+}
+
+// CreateCollection stores a new signature collection. Expired collections are
+// deleted on the way.
+func (r *CollectionRepository) CreateCollection(ctx context.Context, c *model.SignatureCollection) error {
+	deleteQuery, deleteArgs, err := database.QB.
+		Delete("signature_collections").
+		Where(sq.Lt{"created_at": collectionCutoff()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build delete expired collections query: %w", err)
+	}
+	if _, err := r.pool.Exec(ctx, deleteQuery, deleteArgs...); err != nil {
+		return fmt.Errorf("delete expired collections: %w", err)
+	}
+
+	query, args, err := database.QB.
+		Insert("signature_collections").
+		Columns("id", "account_id", "tx_hash", "envelope").
+		Values(c.ID, c.AccountID, c.TxHash, c.Envelope).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build create collection query: %w", err)
+	}
+
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("insert collection: %w", err)
+	}
+	return nil
+}
+
+// GetCollection returns a signature collection by ID, or nil if it does not
+// exist or has expired.
+func (r *CollectionRepository) GetCollection(ctx context.Context, id string) (*model.SignatureCollection, error) {
+	query, args, err := database.QB.
+		Select("id", "account_id", "tx_hash", "envelope", "created_at", "updated_at").
+		From("signature_collections").
+		Where("id = ?", id).
+		Where(sq.GtOrEq{"created_at": collectionCutoff()}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build get collection query: %w", err)
+	}
+
+	var c model.SignatureCollection
+	err = r.pool.QueryRow(ctx, query, args...).
+		Scan(&c.ID, &c.AccountID, &c.TxHash, &c.Envelope, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("query collection: %w", err)
+	}
+
+	return &c, nil
+}
+
+// UpdateCollectionEnvelope replaces the envelope of a collection if it still equals
+// previous. It returns false when another upload changed the envelope in the meantime.
+func (r *CollectionRepository) UpdateCollectionEnvelope(ctx context.Context, id, previous, envelope string) (bool, error) {
+	query, args, err := database.QB.
+		Update("signature_collections").
+		Set("envelope", envelope).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where("id = ? AND envelope = ?", id, previous).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("build update collection query: %w", err)
+	}
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("update collection: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// collectionCutoff returns the creation time before which collections have expired.
+func collectionCutoff() time.Time {
+	return time.Now().Add(-collectionRetention)
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// ControlRow links a signer of an account, a declared owner of it, or both.
+type ControlRow struct {
+	SignerID     string
+	SignerName   string
+	AccountID    string
+	AccountName  string
+	Weight       int32 // 0 if the declared owner is not a signer
+	MedThreshold uint8 // Medium threshold of AccountID
+	Ownership    string
+}
+
+// controlQuery joins synced signers with declared ownership. Ownership is declared
+// either by the owner or by the owned account, as configured by source_is_owner of
+// the OWNERSHIP relation types, so both directions are normalized to owner -> owned.
+// The master key of an account is not an edge. Only accounts whose signers are
+// synced are included, otherwise a declared owner would wrongly show up as not
+// being a signer.
+const controlQuery = `
+	WITH declared AS (
+		SELECT owner_id, owned_id, MIN(relation_type) AS relation_type
+		FROM (
+			SELECT
+				CASE WHEN t.source_is_owner THEN r.source_account_id ELSE r.target_account_id END AS owner_id,
+				CASE WHEN t.source_is_owner THEN r.target_account_id ELSE r.source_account_id END AS owned_id,
+				r.relation_type
+			FROM relationships r
+			JOIN relation_type_settings t ON t.relation_type = r.relation_type
+			WHERE t.category = 'OWNERSHIP'
+		) o
+		WHERE owner_id <> owned_id
+		GROUP BY owner_id, owned_id
+	),
+	signers AS (
+		SELECT signer_key, account_id, weight
+		FROM account_signers
+		WHERE signer_type = 'ed25519_public_key' AND signer_key <> account_id
+	),
+	edges AS (
+		SELECT
+			COALESCE(s.signer_key, d.owner_id) AS signer_id,
+			COALESCE(s.account_id, d.owned_id) AS account_id,
+			COALESCE(s.weight, 0) AS weight,
+			COALESCE(d.relation_type, '') AS ownership
+		FROM signers s
+		FULL OUTER JOIN declared d ON d.owner_id = s.signer_key AND d.owned_id = s.account_id
+	)
+	SELECT
+		e.signer_id,
+		COALESCE(sa.name, ''),
+		e.account_id,
+		COALESCE(a.name, ''),
+		e.weight,
+		a.med_threshold,
+		e.ownership
+	FROM edges e
+	JOIN accounts a ON a.account_id = e.account_id
+	LEFT JOIN accounts sa ON sa.account_id = e.signer_id
+	WHERE (%s)
+		AND EXISTS (SELECT 1 FROM account_signers x WHERE x.account_id = e.account_id)
+	ORDER BY COALESCE(a.name, ''), e.account_id, e.weight DESC, e.signer_id
+`
+
+// GetAccountControl returns the control edges an account is part of, as the
+// controlled account or as the signer.
+func (r *AccountRepository) GetAccountControl(ctx context.Context, accountID string) ([]ControlRow, error) {
+	return r.queryControl(ctx, "e.account_id = $1 OR e.signer_id = $1", accountID)
+}
+
+// GetControlGraph returns the control edges of all corporate accounts and the issuer.
+func (r *AccountRepository) GetControlGraph(ctx context.Context, issuer string) ([]ControlRow, error) {
+	return r.queryControl(ctx, "a.mtlac_balance > 0 OR e.account_id = $1", issuer)
+}
+
+func (r *AccountRepository) queryControl(ctx context.Context, where string, arg string) ([]ControlRow, error) {
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(controlQuery, where), arg)
+	if err != nil {
+		return nil, fmt.Errorf("query control: %w", err)
+	}
+	defer rows.Close()
+
+	var result []ControlRow
+	for rows.Next() {
+		var row ControlRow
+		if err := rows.Scan(&row.SignerID, &row.SignerName, &row.AccountID, &row.AccountName,
+			&row.Weight, &row.MedThreshold, &row.Ownership); err != nil {
+			return nil, fmt.Errorf("scan control row: %w", err)
+		}
+		result = append(result, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate control rows: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mtlprog/lore/internal/database"
+	"github.com/mtlprog/lore/internal/model"
+)
+
+// GetCouncilCandidates returns the council-ready accounts with the most votes
+// (own MTLAP plus received council votes), highest first.
+func (r *AccountRepository) GetCouncilCandidates(ctx context.Context, limit int) ([]model.CouncilCandidate, error) {
+	query, args, err := database.QB.
+		Select(
+			"account_id",
+			"name",
+			"COALESCE(mtlap_balance, 0) + COALESCE(received_votes, 0) AS votes",
+		).
+		From("accounts").
+		Where("is_council_ready = TRUE").
+		OrderBy("votes DESC", "account_id").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build council candidates query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query council candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []model.CouncilCandidate
+	for rows.Next() {
+		var c model.CouncilCandidate
+		if err := rows.Scan(&c.AccountID, &c.Name, &c.Votes); err != nil {
+			return nil, fmt.Errorf("scan council candidate: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate council candidates: %w", err)
+	}
+	return candidates, nil
+}
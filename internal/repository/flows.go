@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mtlprog/lore/internal/database"
+	"github.com/mtlprog/lore/internal/model"
+)
+
+// flowOperationTypes are the indexed operation types that move value between accounts.
+var flowOperationTypes = []string{"payment", "path_payment_strict_receive", "path_payment_strict_send"}
+
+// flowPayments selects non-spam payments from the operations index. For path
+// payments the delivered asset and amount are counted, exposed as f.asset_code,
+// f.asset_issuer and f.amount. Issued assets without a known issuer, i.e. path
+// payments indexed before issuers were stored, are left out so a look-alike
+// asset cannot be counted as the real one.
+func flowPayments(filter model.FlowFilter, columns ...string) sq.SelectBuilder {
+	builder := database.QB.
+		Select(columns...).
+		From("operations o").
+		JoinClause(`CROSS JOIN LATERAL (SELECT
+			CASE WHEN o.op_type = 'payment' THEN o.asset_code ELSE o.dest_asset END AS asset_code,
+			CASE WHEN o.op_type = 'payment' THEN o.asset_issuer ELSE o.dest_asset_issuer END AS asset_issuer,
+			NULLIF(CASE WHEN o.op_type = 'payment' THEN o.amount ELSE o.dest_amount END, '')::NUMERIC AS amount
+		) f`).
+		Where(sq.Eq{"o.op_type": flowOperationTypes, "o.is_spam": false}).
+		Where(sq.Or{sq.Eq{"f.asset_code": "XLM"}, sq.NotEq{"f.asset_issuer": ""}})
+
+	if filter.AssetCode != "" {
+		builder = builder.Where(sq.Eq{"f.asset_code": filter.AssetCode})
+	}
+	if filter.AssetIssuer != "" {
+		builder = builder.Where(sq.Eq{"f.asset_issuer": filter.AssetIssuer})
+	}
+	if !filter.Since.IsZero() {
+		builder = builder.Where(sq.GtOrEq{"o.created_at": filter.Since})
+	}
+	if !filter.Until.IsZero() {
+		builder = builder.Where(sq.Lt{"o.created_at": filter.Until})
+	}
+	return builder
+}
+
+// internalPayments restricts flowPayments to payments between two different
+// tracked accounts, counted once from the payer's side.
+func internalPayments(filter model.FlowFilter, columns ...string) sq.SelectBuilder {
+	return flowPayments(filter, columns...).
+		Join("accounts t ON t.account_id = o.to_account").
+		Where("o.account_id = o.from_account").
+		Where("o.from_account <> o.to_account")
+}
+
+// GetAccountFlows returns the tracked accounts an account exchanged the most
+// value with, per asset, largest total volume first.
+func (r *AccountRepository) GetAccountFlows(ctx context.Context, accountID string, filter model.FlowFilter, limit int) ([]model.CounterpartyFlow, error) {
+	query, args, err := flowPayments(filter,
+		"o.counterparty",
+		"COALESCE(c.name, '')",
+		"f.asset_code",
+		"f.asset_issuer",
+		"COALESCE(SUM(f.amount) FILTER (WHERE o.from_account = o.account_id), 0) AS sent",
+		"COALESCE(SUM(f.amount) FILTER (WHERE o.to_account = o.account_id), 0) AS received",
+		"COUNT(*) AS payments",
+	).
+		Join("accounts c ON c.account_id = o.counterparty").
+		Where(sq.Eq{"o.account_id": accountID}).
+		GroupBy("o.counterparty", "c.name", "f.asset_code", "f.asset_issuer").
+		OrderBy("SUM(f.amount) DESC", "o.counterparty", "f.asset_code", "f.asset_issuer").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build account flows query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query account flows: %w", err)
+	}
+	defer rows.Close()
+
+	var flows []model.CounterpartyFlow
+	for rows.Next() {
+		var f model.CounterpartyFlow
+		if err := rows.Scan(&f.AccountID, &f.Name, &f.AssetCode, &f.AssetIssuer, &f.Sent, &f.Received, &f.Payments); err != nil {
+			return nil, fmt.Errorf("scan account flow: %w", err)
+		}
+		flows = append(flows, f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate account flows: %w", err)
+	}
+	return flows, nil
+}
+
+// GetFlowEdges returns the largest payment flows between tracked accounts,
+// aggregated per payer, payee and asset.
+func (r *AccountRepository) GetFlowEdges(ctx context.Context, filter model.FlowFilter, limit int) ([]model.FlowEdge, error) {
+	query, args, err := internalPayments(filter,
+		"o.from_account",
+		"COALESCE(a.name, '')",
+		"o.to_account",
+		"COALESCE(t.name, '')",
+		"f.asset_code",
+		"f.asset_issuer",
+		"SUM(f.amount) AS volume",
+		"COUNT(*) AS payments",
+	).
+		Join("accounts a ON a.account_id = o.from_account").
+		GroupBy("o.from_account", "a.name", "o.to_account", "t.name", "f.asset_code", "f.asset_issuer").
+		OrderBy("volume DESC", "o.from_account", "o.to_account").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build flow edges query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query flow edges: %w", err)
+	}
+	defer rows.Close()
+
+	var edges []model.FlowEdge
+	for rows.Next() {
+		var e model.FlowEdge
+		if err := rows.Scan(&e.FromID, &e.FromName, &e.ToID, &e.ToName, &e.AssetCode, &e.AssetIssuer, &e.Volume, &e.Payments); err != nil {
+			return nil, fmt.Errorf("scan flow edge: %w", err)
+		}
+		edges = append(edges, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate flow edges: %w", err)
+	}
+	return edges, nil
+}
+
+// GetFlowPeriods returns the monthly payment volume between tracked accounts
+// per asset, oldest month first.
+func (r *AccountRepository) GetFlowPeriods(ctx context.Context, filter model.FlowFilter) ([]model.FlowPeriod, error) {
+	query, args, err := internalPayments(filter,
+		"date_trunc('month', o.created_at AT TIME ZONE 'UTC') AS month",
+		"f.asset_code",
+		"f.asset_issuer",
+		"SUM(f.amount) AS volume",
+		"COUNT(*) AS payments",
+		"COUNT(DISTINCT o.from_account) AS payers",
+	).
+		GroupBy("month", "f.asset_code", "f.asset_issuer").
+		OrderBy("month", "f.asset_code", "f.asset_issuer").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build flow periods query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query flow periods: %w", err)
+	}
+	defer rows.Close()
+
+	var periods []model.FlowPeriod
+	for rows.Next() {
+		var p model.FlowPeriod
+		if err := rows.Scan(&p.Month, &p.AssetCode, &p.AssetIssuer, &p.Volume, &p.Payments, &p.Payers); err != nil {
+			return nil, fmt.Errorf("scan flow period: %w", err)
+		}
+		periods = append(periods, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate flow periods: %w", err)
+	}
+	return periods, nil
+}
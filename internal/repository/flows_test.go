@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlowPaymentsQuery(t *testing.T) {
+	t.Run("issued assets need a known issuer", func(t *testing.T) {
+		sql, _, err := flowPayments(model.FlowFilter{}, "f.asset_code").ToSql()
+		require.NoError(t, err)
+		assert.Contains(t, sql, "o.dest_asset_issuer END AS asset_issuer")
+		assert.Contains(t, sql, "(f.asset_code = $5 OR f.asset_issuer <> $6)")
+	})
+
+	t.Run("foreign issuer of the same code is excluded", func(t *testing.T) {
+		sql, args, err := flowPayments(model.FlowFilter{AssetCode: "EURMTL", AssetIssuer: "GISSUER"}, "f.asset_code").ToSql()
+		require.NoError(t, err)
+		assert.Contains(t, sql, "f.asset_code = $7 AND f.asset_issuer = $8")
+		assert.Equal(t, []any{"EURMTL", "GISSUER"}, args[6:])
+	})
+
+	t.Run("bare code matches every issuer", func(t *testing.T) {
+		sql, _, err := flowPayments(model.FlowFilter{AssetCode: "EURMTL"}, "f.asset_code").ToSql()
+		require.NoError(t, err)
+		assert.NotContains(t, sql, "f.asset_issuer =")
+	})
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mtlprog/lore/internal/database"
+)
+
+// GeneratedTxRepository stores the hashes of generated init transactions, so
+// only those can be submitted through Lore.
+type GeneratedTxRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewGeneratedTxRepository creates a new generated transaction repository.
+// Returns error if pool is nil.
+func NewGeneratedTxRepository(pool *pgxpool.Pool) (*GeneratedTxRepository, error) {
+	if pool == nil {
+		return nil, errors.New("database pool is required")
+	}
+	return &GeneratedTxRepository{pool: pool}, nil
+}
+
+// RecordGenerated stores transaction hashes of accountID that can be submitted
+// until expiresAt. Expired hashes are deleted on the way.
+func (r *GeneratedTxRepository) RecordGenerated(ctx context.Context, accountID string, hashes []string, expiresAt time.Time) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	deleteQuery, deleteArgs, err := database.QB.
+		Delete("generated_transactions").
+		Where("expires_at < NOW()").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build delete expired query: %w", err)
+	}
+	if _, err := r.pool.Exec(ctx, deleteQuery, deleteArgs...); err != nil {
+		return fmt.Errorf("delete expired transactions: %w", err)
+	}
+
+	insert := database.QB.
+		Insert("generated_transactions").
+		Columns("hash", "account_id", "expires_at")
+	for _, hash := range hashes {
+		insert = insert.Values(hash, accountID, expiresAt)
+	}
+	query, args, err := insert.
+		Suffix("ON CONFLICT (hash) DO UPDATE SET expires_at = GREATEST(generated_transactions.expires_at, EXCLUDED.expires_at)").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build record generated query: %w", err)
+	}
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("insert generated transactions: %w", err)
+	}
+	return nil
+}
+
+// IsGenerated reports whether a transaction with this hash was generated and
+// has not expired.
+func (r *GeneratedTxRepository) IsGenerated(ctx context.Context, hash string) (bool, error) {
+	query, args, err := database.QB.
+		Select("1").
+		From("generated_transactions").
+		Where("hash = ? AND expires_at >= NOW()", hash).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("build is generated query: %w", err)
+	}
+
+	var dummy int
+	err = r.pool.QueryRow(ctx, query, args...).Scan(&dummy)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("query generated transaction: %w", err)
+	}
+	return true, nil
+}
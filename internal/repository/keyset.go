@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/samber/lo"
+)
+
+// PageCursor is a position in a keyset-paginated account list: the sort key
+// values and account ID of the row at the page boundary.
+type PageCursor struct {
+	Keys      []float64 `json:"k"`
+	AccountID string    `json:"id"`
+	Pos       int       `json:"p"`           // index of the boundary row when the cursor was issued
+	Backward  bool      `json:"b,omitempty"` // read the rows before the boundary instead of after it
+}
+
+// Encode returns the opaque token form of the cursor.
+func (c PageCursor) Encode() string {
+	data, _ := json.Marshal(c) //nolint:errcheck // floats, strings and ints always marshal
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageCursor parses a token produced by PageCursor.Encode. An empty token
+// yields the zero cursor, which starts at the top of the list.
+func DecodePageCursor(token string) (PageCursor, error) {
+	var c PageCursor
+	if token == "" {
+		return c, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if c.AccountID == "" || len(c.Keys) == 0 || c.Pos < 0 {
+		return c, fmt.Errorf("%w: incomplete position", ErrInvalidCursor)
+	}
+	return c, nil
+}
+
+// KeysetPage describes where a page sits in its list.
+type KeysetPage struct {
+	Start int    // index of the first row in the list
+	Next  string // cursor of the following page, empty on the last page
+	Prev  string // cursor of the preceding page, empty on the first page
+}
+
+// keyset is a descending ordering used for keyset pagination, with the account
+// ID as the final tiebreaker. Keys are compared as double precision, the type
+// rows are scanned into, so a cursor built from a scanned row lands exactly on it.
+type keyset []string
+
+func (k keyset) columns() []string {
+	return append(lo.Map(k, func(expr string, _ int) string { return "(" + expr + ")::float8" }), "a.account_id")
+}
+
+// order appends the ordering to the query, reversed when reading backwards.
+func (k keyset) order(qb sq.SelectBuilder, backward bool) sq.SelectBuilder {
+	dir := " DESC"
+	if backward {
+		dir = " ASC"
+	}
+	return qb.OrderBy(lo.Map(k.columns(), func(col string, _ int) string { return col + dir })...)
+}
+
+// page restricts the query to the rows past the cursor, in reading order, and
+// fetches one extra row to tell whether more follow.
+func (k keyset) page(qb sq.SelectBuilder, c PageCursor, limit int) (sq.SelectBuilder, error) {
+	if c.AccountID != "" {
+		if len(c.Keys) != len(k) {
+			return qb, fmt.Errorf("%w: cursor belongs to another list", ErrInvalidCursor)
+		}
+		op := "<"
+		if c.Backward {
+			op = ">"
+		}
+		args := make([]any, 0, len(c.Keys)+1)
+		for _, key := range c.Keys {
+			args = append(args, key)
+		}
+		args = append(args, c.AccountID)
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+		qb = qb.Where(fmt.Sprintf("(%s) %s (%s)", strings.Join(k.columns(), ", "), op, placeholders), args...)
+	}
+	return k.order(qb, c.Backward).Limit(uint64(limit) + 1), nil
+}
+
+// keysetPage trims rows fetched by keyset.page to the page size, restores list
+// order and builds the cursors of the neighbouring pages. position returns the
+// sort key values and account ID of a row.
+func keysetPage[T any](rows []T, c PageCursor, limit int, position func(T) ([]float64, string)) ([]T, KeysetPage) {
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+	if c.Backward {
+		slices.Reverse(rows)
+	}
+
+	var page KeysetPage
+	if len(rows) == 0 {
+		return rows, page
+	}
+
+	cursorAt := func(row T, pos int, backward bool) string {
+		keys, id := position(row)
+		return PageCursor{Keys: keys, AccountID: id, Pos: pos, Backward: backward}.Encode()
+	}
+
+	switch {
+	case c.AccountID == "":
+		page.Start = 0
+	case c.Backward && !hasMore:
+		page.Start = 0 // reached the top of the list
+	case c.Backward:
+		page.Start = max(c.Pos-len(rows), 0)
+	default:
+		page.Start = c.Pos + 1
+	}
+
+	last := page.Start + len(rows) - 1
+	if hasMore || c.Backward {
+		page.Next = cursorAt(rows[len(rows)-1], last, false)
+	}
+	if (c.Backward && hasMore) || (!c.Backward && c.AccountID != "") {
+		page.Prev = cursorAt(rows[0], page.Start, true)
+	}
+	return rows, page
+}
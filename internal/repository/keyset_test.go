@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/mtlprog/lore/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageCursorRoundTrip(t *testing.T) {
+	c := PageCursor{Keys: []float64{4.1234567, 123456.789}, AccountID: "GABC", Pos: 41, Backward: true}
+
+	decoded, err := DecodePageCursor(c.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, c, decoded)
+}
+
+func TestDecodePageCursor(t *testing.T) {
+	t.Run("empty token starts at the top", func(t *testing.T) {
+		c, err := DecodePageCursor("")
+		require.NoError(t, err)
+		assert.Equal(t, PageCursor{}, c)
+	})
+
+	for name, token := range map[string]string{
+		"not base64":        "!!!",
+		"not json":          "bm90IGpzb24",
+		"missing account":   PageCursor{Keys: []float64{1}}.Encode(),
+		"missing keys":      PageCursor{AccountID: "GABC"}.Encode(),
+		"negative position": PageCursor{Keys: []float64{1}, AccountID: "GABC", Pos: -1}.Encode(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := DecodePageCursor(token)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
+
+func TestKeysetPageQuery(t *testing.T) {
+	k := keyset{"a.mtlac_balance", "COALESCE(a.total_xlm_value, 0)"}
+	base := database.QB.Select("a.account_id").From("accounts a")
+
+	t.Run("first page", func(t *testing.T) {
+		qb, err := k.page(base, PageCursor{}, 20)
+		require.NoError(t, err)
+		sql, args, err := qb.ToSql()
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT a.account_id FROM accounts a ORDER BY (a.mtlac_balance)::float8 DESC, (COALESCE(a.total_xlm_value, 0))::float8 DESC, a.account_id DESC LIMIT 21", sql)
+		assert.Empty(t, args)
+	})
+
+	t.Run("forward", func(t *testing.T) {
+		qb, err := k.page(base, PageCursor{Keys: []float64{2, 100}, AccountID: "GABC"}, 20)
+		require.NoError(t, err)
+		sql, args, err := qb.ToSql()
+		require.NoError(t, err)
+		assert.Contains(t, sql, "WHERE ((a.mtlac_balance)::float8, (COALESCE(a.total_xlm_value, 0))::float8, a.account_id) < ($1, $2, $3)")
+		assert.Equal(t, []any{2.0, 100.0, "GABC"}, args)
+	})
+
+	t.Run("backward", func(t *testing.T) {
+		qb, err := k.page(base, PageCursor{Keys: []float64{2, 100}, AccountID: "GABC", Backward: true}, 20)
+		require.NoError(t, err)
+		sql, _, err := qb.ToSql()
+		require.NoError(t, err)
+		assert.Contains(t, sql, ") > ($1, $2, $3)")
+		assert.Contains(t, sql, "a.account_id ASC LIMIT 21")
+	})
+
+	t.Run("cursor of another list", func(t *testing.T) {
+		_, err := k.page(base, PageCursor{Keys: []float64{2}, AccountID: "GABC"}, 20)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
+
+func TestKeysetPage(t *testing.T) {
+	position := func(p PersonRow) ([]float64, string) { return []float64{p.MTLAPBalance}, p.AccountID }
+	rows := func(ids ...string) []PersonRow {
+		out := make([]PersonRow, len(ids))
+		for i, id := range ids {
+			out[i] = PersonRow{AccountID: id, MTLAPBalance: float64(len(ids) - i)}
+		}
+		return out
+	}
+	decode := func(t *testing.T, token string) PageCursor {
+		t.Helper()
+		c, err := DecodePageCursor(token)
+		require.NoError(t, err)
+		return c
+	}
+
+	t.Run("first page with more", func(t *testing.T) {
+		got, page := keysetPage(rows("A", "B", "C"), PageCursor{}, 2, position)
+		assert.Len(t, got, 2)
+		assert.Equal(t, 0, page.Start)
+		assert.Empty(t, page.Prev)
+
+		next := decode(t, page.Next)
+		assert.Equal(t, "B", next.AccountID)
+		assert.Equal(t, 1, next.Pos)
+		assert.False(t, next.Backward)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		got, page := keysetPage(rows("C"), PageCursor{Keys: []float64{2}, AccountID: "B", Pos: 1}, 2, position)
+		assert.Len(t, got, 1)
+		assert.Equal(t, 2, page.Start)
+		assert.Empty(t, page.Next)
+
+		prev := decode(t, page.Prev)
+		assert.Equal(t, "C", prev.AccountID)
+		assert.Equal(t, 2, prev.Pos)
+		assert.True(t, prev.Backward)
+	})
+
+	t.Run("backward restores list order", func(t *testing.T) {
+		// Rows come in ascending order when reading backwards.
+		fetched := []PersonRow{{AccountID: "D", MTLAPBalance: 2}, {AccountID: "C", MTLAPBalance: 3}, {AccountID: "B", MTLAPBalance: 4}}
+		got, page := keysetPage(fetched, PageCursor{Keys: []float64{1}, AccountID: "E", Pos: 4, Backward: true}, 2, position)
+		require.Len(t, got, 2)
+		assert.Equal(t, "C", got[0].AccountID)
+		assert.Equal(t, "D", got[1].AccountID)
+		assert.Equal(t, 2, page.Start)
+		assert.Equal(t, "D", decode(t, page.Next).AccountID)
+		assert.Equal(t, "C", decode(t, page.Prev).AccountID)
+	})
+
+	t.Run("backward to the top", func(t *testing.T) {
+		fetched := []PersonRow{{AccountID: "B", MTLAPBalance: 4}, {AccountID: "A", MTLAPBalance: 5}}
+		_, page := keysetPage(fetched, PageCursor{Keys: []float64{3}, AccountID: "C", Pos: 7, Backward: true}, 2, position)
+		assert.Equal(t, 0, page.Start)
+		assert.Empty(t, page.Prev)
+		assert.NotEmpty(t, page.Next)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		got, page := keysetPage[PersonRow](nil, PageCursor{}, 2, position)
+		assert.Empty(t, got)
+		assert.Equal(t, KeysetPage{}, page)
+	})
+}
+
+func TestReputationBucket(t *testing.T) {
+	assert.Equal(t, -1.0, reputationBucket(3.9))
+	assert.Equal(t, -2.0, reputationBucket(3.0))
+	assert.Equal(t, -6.0, reputationBucket(1.2))
+	assert.Equal(t, -7.0, reputationBucket(0.5))
+	assert.Equal(t, -8.0, reputationBucket(0))
+	assert.Contains(t, reputationBucketSQL, "WHEN COALESCE(rs.weighted_score, 0) >= 3.5 THEN 1")
+	assert.Contains(t, reputationBucketSQL, "THEN 7 ELSE 8 END)")
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/mtlprog/lore/internal/config"
+	"github.com/mtlprog/lore/internal/database"
+	"github.com/mtlprog/lore/internal/model"
+)
+
+// membershipEventColumns are the columns scanned by scanMembershipEvents.
+var membershipEventColumns = []string{
+	"e.operation_id",
+	"e.account_id",
+	"COALESCE(a.name, '')",
+	"e.asset_code",
+	"e.event_type",
+	"e.amount",
+	"e.balance_before",
+	"e.balance_after",
+	"e.tx_hash",
+	"e.created_at",
+}
+
+// GetMembershipTimeline returns the membership events of an account, oldest first.
+func (r *AccountRepository) GetMembershipTimeline(ctx context.Context, accountID string) ([]model.MembershipEvent, error) {
+	query, args, err := database.QB.
+		Select(membershipEventColumns...).
+		From("membership_events e").
+		LeftJoin("accounts a ON a.account_id = e.account_id").
+		Where(sq.Eq{"e.account_id": accountID}).
+		OrderBy("e.operation_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build membership timeline query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query membership timeline: %w", err)
+	}
+	return scanMembershipEvents(rows)
+}
+
+// GetNewMembers returns accounts that received MTLAP or MTLAC since the given
+// time, newest first.
+func (r *AccountRepository) GetNewMembers(ctx context.Context, since time.Time, limit int) ([]model.MembershipEvent, error) {
+	query, args, err := database.QB.
+		Select(membershipEventColumns...).
+		From("membership_events e").
+		LeftJoin("accounts a ON a.account_id = e.account_id").
+		Where(sq.Eq{
+			"e.event_type": string(model.MembershipReceived),
+			"e.asset_code": []string{config.TokenMTLAP, config.TokenMTLAC},
+		}).
+		Where(sq.GtOrEq{"e.created_at": since}).
+		OrderBy("e.operation_id DESC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build new members query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query new members: %w", err)
+	}
+	return scanMembershipEvents(rows)
+}
+
+// GetMembershipGrowth returns per month and asset how many accounts joined
+// (received their first tokens) and left (balance dropped to zero) since the
+// given time, oldest month first.
+func (r *AccountRepository) GetMembershipGrowth(ctx context.Context, since time.Time) ([]model.MembershipGrowth, error) {
+	query, args, err := database.QB.
+		Select(
+			"date_trunc('month', created_at AT TIME ZONE 'UTC') AS month",
+			"asset_code",
+			"COUNT(*) FILTER (WHERE event_type = 'received') AS joined",
+			"COUNT(*) FILTER (WHERE balance_before > 0 AND balance_after = 0) AS left_count",
+		).
+		From("membership_events").
+		Where(sq.GtOrEq{"created_at": since}).
+		GroupBy("month", "asset_code").
+		OrderBy("month", "asset_code").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build membership growth query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query membership growth: %w", err)
+	}
+	defer rows.Close()
+
+	var growth []model.MembershipGrowth
+	for rows.Next() {
+		var g model.MembershipGrowth
+		if err := rows.Scan(&g.Month, &g.AssetCode, &g.Joined, &g.Left); err != nil {
+			return nil, fmt.Errorf("scan membership growth: %w", err)
+		}
+		growth = append(growth, g)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate membership growth: %w", err)
+	}
+	return growth, nil
+}
+
+// scanMembershipEvents reads rows selected with membershipEventColumns and closes them.
+func scanMembershipEvents(rows pgx.Rows) ([]model.MembershipEvent, error) {
+	defer rows.Close()
+
+	var events []model.MembershipEvent
+	for rows.Next() {
+		var e model.MembershipEvent
+		if err := rows.Scan(
+			&e.OperationID, &e.AccountID, &e.AccountName, &e.AssetCode, &e.Type,
+			&e.Amount, &e.BalanceBefore, &e.BalanceAfter, &e.TxHash, &e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan membership event: %w", err)
+		}
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate membership events: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mtlprog/lore/internal/database"
+	"github.com/mtlprog/lore/internal/model"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// GetAccountOperations returns indexed operations of an account, newest first,
+// hiding spam. cursor is the ID of the last operation of the previous page.
+func (r *AccountRepository) GetAccountOperations(ctx context.Context, accountID string, filter model.OperationFilter, cursor string, limit int) (*model.OperationsPage, error) {
+	builder := database.QB.
+		Select(
+			"operation_id", "op_type", "type_display", "type_category", "created_at",
+			"tx_hash", "source_account", "amount", "asset_code", "asset_issuer",
+			"from_account", "to_account", "data_name", "data_value", "starting_balance",
+			"trust_limit", "source_amount", "source_asset", "source_asset_issuer",
+			"dest_amount", "dest_asset", "dest_asset_issuer",
+			"selling", "buying", "price", "offer_id",
+		).
+		From("operations").
+		Where(sq.Eq{"account_id": accountID, "is_spam": false})
+
+	if cursor != "" {
+		before, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+		}
+		builder = builder.Where(sq.Lt{"operation_id": before})
+	}
+	if filter.Type != "" {
+		builder = builder.Where(sq.Eq{"op_type": filter.Type})
+	}
+	if filter.AssetCode != "" {
+		builder = builder.Where(sq.Eq{"asset_code": filter.AssetCode})
+	}
+	if filter.Counterparty != "" {
+		builder = builder.Where(sq.Eq{"counterparty": filter.Counterparty})
+	}
+	if !filter.Since.IsZero() {
+		builder = builder.Where(sq.GtOrEq{"created_at": filter.Since})
+	}
+	if !filter.Until.IsZero() {
+		builder = builder.Where(sq.Lt{"created_at": filter.Until})
+	}
+
+	query, args, err := builder.
+		OrderBy("operation_id DESC").
+		Limit(uint64(limit) + 1).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build operations query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query operations: %w", err)
+	}
+	defer rows.Close()
+
+	var ops []model.Operation
+	for rows.Next() {
+		var (
+			op        model.Operation
+			id        int64
+			createdAt time.Time
+		)
+		if err := rows.Scan(
+			&id, &op.Type, &op.TypeDisplay, &op.TypeCategory, &createdAt,
+			&op.TransactionHash, &op.SourceAccount, &op.Amount, &op.AssetCode, &op.AssetIssuer,
+			&op.From, &op.To, &op.DataName, &op.DataValue, &op.StartingBalance,
+			&op.TrustLimit, &op.SourceAmount, &op.SourceAsset, &op.SourceAssetIssuer,
+			&op.DestAmount, &op.DestAsset, &op.DestAssetIssuer,
+			&op.Selling, &op.Buying, &op.Price, &op.OfferID,
+		); err != nil {
+			return nil, fmt.Errorf("scan operation: %w", err)
+		}
+		op.ID = strconv.FormatInt(id, 10)
+		op.CreatedAt = createdAt.UTC().Format("2006-01-02 15:04:05")
+		ops = append(ops, op)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate operations: %w", err)
+	}
+
+	page := &model.OperationsPage{Operations: ops}
+	if len(ops) > limit {
+		page.Operations = ops[:limit]
+		page.HasMore = true
+	}
+	if len(page.Operations) > 0 {
+		page.NextCursor = page.Operations[len(page.Operations)-1].ID
+	}
+	return page, nil
+}
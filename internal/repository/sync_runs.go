@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mtlprog/lore/internal/database"
+)
+
+// SyncRun is a completed sync run.
+type SyncRun struct {
+	ID         int64
+	FullSync   bool
+	StartedAt  time.Time
+	FinishedAt time.Time
+
+	// LastResyncID and LastResyncAt identify the latest single-account resync
+	// recorded after the run; zero if there was none.
+	LastResyncID int64
+	LastResyncAt time.Time
+}
+
+// Version returns the ID and time of the latest change to the served data:
+// the run itself or a later single-account resync.
+func (r *SyncRun) Version() (int64, time.Time) {
+	if r.LastResyncID > r.ID {
+		return r.LastResyncID, r.LastResyncAt
+	}
+	return r.ID, r.FinishedAt
+}
+
+// GetLatestSyncRun returns the most recently completed sync run, or nil if no
+// run has completed yet. Single-account resyncs are not runs of the dataset;
+// the latest one after the run is reported in LastResyncID.
+func (r *AccountRepository) GetLatestSyncRun(ctx context.Context) (*SyncRun, error) {
+	query, args, err := database.QB.
+		Select("r.id", "r.full_sync", "r.started_at", "r.finished_at", "COALESCE(a.id, 0)", "a.finished_at").
+		From("sync_runs r").
+		LeftJoin(`LATERAL (
+			SELECT id, finished_at FROM sync_runs
+			WHERE account_id IS NOT NULL AND id > r.id
+			ORDER BY id DESC
+			LIMIT 1
+		) a ON TRUE`).
+		Where("r.account_id IS NULL").
+		OrderBy("r.id DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build latest sync run query: %w", err)
+	}
+
+	var run SyncRun
+	var resyncAt *time.Time
+	err = r.pool.QueryRow(ctx, query, args...).
+		Scan(&run.ID, &run.FullSync, &run.StartedAt, &run.FinishedAt, &run.LastResyncID, &resyncAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("query latest sync run: %w", err)
+	}
+	if resyncAt != nil {
+		run.LastResyncAt = *resyncAt
+	}
+
+	return &run, nil
+}
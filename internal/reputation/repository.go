@@ -231,23 +231,20 @@ func (r *Repository) UpsertScores(ctx context.Context, scores map[string]*Score)
 	return nil
 }
 
-// GetScore returns the reputation score for an account.
-func (r *Repository) GetScore(ctx context.Context, accountID string) (*Score, error) {
-	query, args, err := database.QB.
+// scoresQuery selects stored reputation scores.
+func scoresQuery() sq.SelectBuilder {
+	return database.QB.
 		Select(
 			"account_id", "weighted_score", "base_score",
 			"rating_count_a", "rating_count_b", "rating_count_c", "rating_count_d",
 			"total_ratings", "total_weight", "calculated_at",
 		).
-		From("reputation_scores").
-		Where(sq.Eq{"account_id": accountID}).
-		ToSql()
-	if err != nil {
-		return nil, fmt.Errorf("build score query: %w", err)
-	}
+		From("reputation_scores")
+}
 
+func scanScore(row pgx.Row) (*Score, error) {
 	var score Score
-	err = r.pool.QueryRow(ctx, query, args...).Scan(
+	err := row.Scan(
 		&score.AccountID,
 		&score.WeightedScore,
 		&score.BaseScore,
@@ -259,6 +256,22 @@ func (r *Repository) GetScore(ctx context.Context, accountID string) (*Score, er
 		&score.TotalWeight,
 		&score.CalculatedAt,
 	)
+	if err != nil {
+		return nil, err
+	}
+	return &score, nil
+}
+
+// GetScore returns the reputation score for an account.
+func (r *Repository) GetScore(ctx context.Context, accountID string) (*Score, error) {
+	query, args, err := scoresQuery().
+		Where(sq.Eq{"account_id": accountID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build score query: %w", err)
+	}
+
+	score, err := scanScore(r.pool.QueryRow(ctx, query, args...))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -266,7 +279,42 @@ func (r *Repository) GetScore(ctx context.Context, accountID string) (*Score, er
 		return nil, fmt.Errorf("query score: %w", err)
 	}
 
-	return &score, nil
+	return score, nil
+}
+
+// GetScores returns the reputation scores of several accounts keyed by account
+// ID. Accounts without a stored score are absent from the map.
+func (r *Repository) GetScores(ctx context.Context, accountIDs []string) (map[string]*Score, error) {
+	scores := make(map[string]*Score)
+	if len(accountIDs) == 0 {
+		return scores, nil
+	}
+
+	query, args, err := scoresQuery().
+		Where(sq.Eq{"account_id": accountIDs}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build scores query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query scores: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		score, err := scanScore(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan score: %w", err)
+		}
+		scores[score.AccountID] = score
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate scores: %w", err)
+	}
+	return scores, nil
 }
 
 // GetDirectRaters returns accounts that gave A/B/C/D ratings to the target account.
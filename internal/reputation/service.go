@@ -44,6 +44,27 @@ func (s *Service) GetScore(ctx context.Context, accountID string) (*model.Reputa
 		return nil, nil
 	}
 
+	return toModelScore(score), nil
+}
+
+// GetScores returns the reputation scores of several accounts keyed by account
+// ID. Accounts without ratings are absent from the map.
+func (s *Service) GetScores(ctx context.Context, accountIDs []string) (map[string]*model.ReputationScore, error) {
+	scores, err := s.repo.GetScores(ctx, accountIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get scores: %w", err)
+	}
+
+	result := make(map[string]*model.ReputationScore, len(scores))
+	for id, score := range scores {
+		if score.TotalRatings > 0 {
+			result[id] = toModelScore(score)
+		}
+	}
+	return result, nil
+}
+
+func toModelScore(score *Score) *model.ReputationScore {
 	return &model.ReputationScore{
 		WeightedScore: score.WeightedScore,
 		BaseScore:     score.BaseScore,
@@ -54,7 +75,7 @@ func (s *Service) GetScore(ctx context.Context, accountID string) (*model.Reputa
 		RatingCountD:  score.RatingCountD,
 		TotalRatings:  score.TotalRatings,
 		TotalWeight:   score.TotalWeight,
-	}, nil
+	}
 }
 
 // GetGraph returns the reputation graph for an account.
@@ -78,17 +99,7 @@ func (s *Service) GetGraph(ctx context.Context, accountID string) (*model.Reputa
 
 	// Convert score
 	if graph.Score != nil && graph.Score.TotalRatings > 0 {
-		result.Score = &model.ReputationScore{
-			WeightedScore: graph.Score.WeightedScore,
-			BaseScore:     graph.Score.BaseScore,
-			Grade:         graph.Score.Grade(),
-			RatingCountA:  graph.Score.RatingCountA,
-			RatingCountB:  graph.Score.RatingCountB,
-			RatingCountC:  graph.Score.RatingCountC,
-			RatingCountD:  graph.Score.RatingCountD,
-			TotalRatings:  graph.Score.TotalRatings,
-			TotalWeight:   graph.Score.TotalWeight,
-		}
+		result.Score = toModelScore(graph.Score)
 	}
 
 	// Convert level 1 nodes
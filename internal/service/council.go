@@ -0,0 +1,115 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mtlprog/lore/internal/delegation"
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/stellar/go/txnbuild"
+)
+
+// ReconcileCouncil compares the signers of the issuer with the top size candidates.
+// Candidates must be ordered by votes, highest first. Each elected member should
+// sign with the weight delegation.VotePower gives its votes; every other account
+// signer should be removed. The master key and non-account signers are left alone.
+func ReconcileCouncil(issuer *model.AccountDetail, candidates []model.CouncilCandidate, size int) *model.CouncilReconciliation {
+	rec := &model.CouncilReconciliation{
+		IssuerID:   issuer.ID,
+		Size:       size,
+		Thresholds: issuer.Thresholds,
+	}
+
+	current := make(map[string]model.Signer)
+	for _, signer := range issuer.Signers {
+		rec.CurrentWeight += signer.Weight
+		if signer.Key == issuer.ID || signer.Type != signerTypeEd25519 {
+			rec.Other = append(rec.Other, signer)
+			rec.TargetWeight += signer.Weight
+			continue
+		}
+		current[signer.Key] = signer
+	}
+
+	for i, candidate := range candidates[:min(size, len(candidates))] {
+		change := model.CouncilChange{
+			AccountID:    candidate.AccountID,
+			Name:         candidate.Name,
+			Rank:         i + 1,
+			Votes:        candidate.Votes,
+			TargetWeight: int32(delegation.VotePower(candidate.Votes)),
+		}
+		signer, ok := current[candidate.AccountID]
+		delete(current, candidate.AccountID)
+		switch {
+		case !ok:
+			change.Action = model.CouncilAdd
+		case signer.Weight != change.TargetWeight:
+			change.CurrentWeight = signer.Weight
+			change.Action = model.CouncilUpdate
+		default:
+			change.CurrentWeight = signer.Weight
+			change.Action = model.CouncilKeep
+		}
+		rec.TargetWeight += change.TargetWeight
+		rec.Changes = append(rec.Changes, change)
+	}
+
+	removed := make([]model.CouncilChange, 0, len(current))
+	for _, signer := range current {
+		removed = append(removed, model.CouncilChange{
+			AccountID:     signer.Key,
+			Name:          signer.Name,
+			CurrentWeight: signer.Weight,
+			Action:        model.CouncilRemove,
+		})
+	}
+	sort.Slice(removed, func(i, j int) bool {
+		if removed[i].CurrentWeight != removed[j].CurrentWeight {
+			return removed[i].CurrentWeight > removed[j].CurrentWeight
+		}
+		return removed[i].AccountID < removed[j].AccountID
+	})
+	rec.Changes = append(rec.Changes, removed...)
+
+	return rec
+}
+
+// GenerateCouncilXDR builds SetOptions transactions on the issuer that apply the
+// pending changes of rec. Signers gaining weight come first so that, if the changes
+// are split into several transactions, the earlier ones never lower the total weight.
+func (b *InitXDRBuilder) GenerateCouncilXDR(rec *model.CouncilReconciliation, sequenceNum int64) ([]model.InitTransaction, error) {
+	if rec.Pending() == 0 {
+		return nil, fmt.Errorf("the issuer's signers already match the council")
+	}
+	if rec.LocksOut() {
+		return nil, fmt.Errorf("the new signers would have a total weight of %d, below the high threshold %d",
+			rec.TargetWeight, rec.Thresholds.High)
+	}
+
+	changes := make([]model.CouncilChange, 0, len(rec.Changes))
+	for _, c := range rec.Changes {
+		if c.Action != model.CouncilKeep {
+			changes = append(changes, c)
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].TargetWeight-changes[i].CurrentWeight > changes[j].TargetWeight-changes[j].CurrentWeight
+	})
+
+	var operations []txnbuild.Operation
+	var summaries []model.InitOpSummary
+	for _, c := range changes {
+		weight := txnbuild.Threshold(c.TargetWeight)
+		operations = append(operations, &txnbuild.SetOptions{
+			Signer: &txnbuild.Signer{Address: c.AccountID, Weight: weight},
+		})
+		summary := model.InitOpSummary{Action: "Set signer", Key: c.AccountID, Value: fmt.Sprintf("weight %d", c.TargetWeight)}
+		if c.Action == model.CouncilRemove {
+			summary = model.InitOpSummary{Action: "Remove signer", Key: c.AccountID}
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return b.buildTransactions(rec.IssuerID, sequenceNum, operations, summaries)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileCouncil(t *testing.T) {
+	issuer := keypair.MustRandom().Address()
+	alice, bob, carol, dave := keypair.MustRandom().Address(), keypair.MustRandom().Address(),
+		keypair.MustRandom().Address(), keypair.MustRandom().Address()
+
+	account := &model.AccountDetail{
+		ID: issuer,
+		Signers: []model.Signer{
+			{Key: alice, Type: signerTypeEd25519, Weight: 3},
+			{Key: bob, Type: signerTypeEd25519, Weight: 1},
+			{Key: dave, Type: signerTypeEd25519, Weight: 2, Name: "Dave"},
+			{Key: "XHASH", Type: "sha256_hash", Weight: 1},
+		},
+		Thresholds: model.Thresholds{Low: 1, Med: 3, High: 5},
+	}
+	candidates := []model.CouncilCandidate{
+		{AccountID: alice, Name: "Alice", Votes: 500},         // weight 3, unchanged
+		{AccountID: bob, Name: "Bob", Votes: 50},              // weight 1 -> 2
+		{AccountID: carol, Name: "Carol", Votes: 5},           // new, weight 1
+		{AccountID: keypair.MustRandom().Address(), Votes: 1}, // beyond the council size
+	}
+
+	rec := ReconcileCouncil(account, candidates, 3)
+
+	actions := make(map[string]model.CouncilAction)
+	for _, c := range rec.Changes {
+		actions[c.AccountID] = c.Action
+	}
+	assert.Equal(t, map[string]model.CouncilAction{
+		alice: model.CouncilKeep,
+		bob:   model.CouncilUpdate,
+		carol: model.CouncilAdd,
+		dave:  model.CouncilRemove,
+	}, actions)
+	assert.Equal(t, "Dave", rec.Changes[3].Name)
+	assert.Equal(t, 3, rec.Pending())
+	require.Len(t, rec.Other, 1)
+	assert.Equal(t, int32(7), rec.CurrentWeight)
+	assert.Equal(t, int32(7), rec.TargetWeight, "3 + 2 + 1 for the council plus the unchanged hash signer")
+	assert.False(t, rec.LocksOut())
+
+	builder := NewInitXDRBuilder()
+	txs, err := builder.GenerateCouncilXDR(rec, 100)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, []model.InitOpSummary{
+		{Action: "Set signer", Key: bob, Value: "weight 2"},
+		{Action: "Set signer", Key: carol, Value: "weight 1"},
+		{Action: "Remove signer", Key: dave},
+	}, txs[0].Operations, "signers gaining weight come first")
+
+	parsed, err := txnbuild.TransactionFromXDR(txs[0].XDR)
+	require.NoError(t, err)
+	tx, ok := parsed.Transaction()
+	require.True(t, ok)
+	assert.Equal(t, issuer, tx.SourceAccount().AccountID)
+	assert.Equal(t, int64(100), tx.SequenceNumber())
+	removal, ok := tx.Operations()[2].(*txnbuild.SetOptions)
+	require.True(t, ok)
+	assert.Equal(t, txnbuild.Threshold(0), removal.Signer.Weight)
+
+	t.Run("refuses to lock out the issuer", func(t *testing.T) {
+		locked := ReconcileCouncil(account, candidates[2:3], 1)
+		assert.True(t, locked.LocksOut())
+
+		_, err := builder.GenerateCouncilXDR(locked, 100)
+		assert.ErrorContains(t, err, "below the high threshold")
+	})
+
+	t.Run("nothing to change", func(t *testing.T) {
+		inSync := ReconcileCouncil(&model.AccountDetail{
+			ID:      issuer,
+			Signers: []model.Signer{{Key: alice, Type: signerTypeEd25519, Weight: 3}},
+		}, candidates[:1], 1)
+		assert.Zero(t, inSync.Pending())
+
+		_, err := builder.GenerateCouncilXDR(inSync, 100)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,377 @@
+package service
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/relation"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/txnbuild"
+)
+
+// ErrInvalidXDR is returned when an envelope cannot be decoded.
+var ErrInvalidXDR = errors.New("invalid transaction XDR")
+
+// DecodeEnvelope decodes a base64 TransactionEnvelope or FeeBumpTransactionEnvelope.
+// Operations use the same vocabulary as the transaction page; ManageData entries are
+// interpreted as BSN actions of the given relation types and operations that change
+// account control are flagged.
+func DecodeEnvelope(envelope string, types *relation.Registry) (*model.DecodedTransaction, error) {
+	envelope = strings.Join(strings.Fields(envelope), "")
+	if envelope == "" {
+		return nil, fmt.Errorf("%w: envelope is empty", ErrInvalidXDR)
+	}
+
+	generic, err := txnbuild.TransactionFromXDR(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidXDR, err)
+	}
+
+	result := &model.DecodedTransaction{}
+	tx, ok := generic.Transaction()
+	if ok {
+		result.Hash, err = tx.HashHex(network.PublicNetworkPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidXDR, err)
+		}
+		result.MaxFee = formatStroops(tx.MaxFee())
+		result.SignatureCount = len(tx.Signatures())
+	} else {
+		feeBump, _ := generic.FeeBump()
+		result.FeeBump = true
+		result.FeeAccount = feeBump.FeeAccount()
+		result.MaxFee = formatStroops(feeBump.MaxFee())
+		result.SignatureCount = len(feeBump.Signatures())
+		result.Hash, err = feeBump.HashHex(network.PublicNetworkPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidXDR, err)
+		}
+
+		tx = feeBump.InnerTransaction()
+		result.InnerHash, err = tx.HashHex(network.PublicNetworkPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidXDR, err)
+		}
+		result.SignatureCount += len(tx.Signatures())
+	}
+
+	result.SourceAccount = tx.SourceAccount().AccountID
+	result.Sequence = tx.SequenceNumber()
+	result.MemoType, result.Memo = memoDisplay(tx.Memo())
+
+	bounds := tx.Timebounds()
+	if bounds.MinTime > 0 {
+		result.ValidAfter = formatUnixTime(bounds.MinTime)
+	}
+	result.MaxTime = bounds.MaxTime
+	if bounds.MaxTime > 0 {
+		result.ValidBefore = formatUnixTime(bounds.MaxTime)
+	}
+
+	for _, op := range tx.Operations() {
+		decoded, err := decodeOperation(op, result.SourceAccount, types)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidXDR, err)
+		}
+		if decoded.Risk != "" {
+			result.RiskCount++
+		}
+		result.Operations = append(result.Operations, decoded)
+	}
+
+	return result, nil
+}
+
+// decodeOperation converts a txnbuild operation into a decoded operation.
+// Type-specific fields follow ConvertOperation so both pages render the same way.
+func decodeOperation(op txnbuild.Operation, txSource string, types *relation.Registry) (model.DecodedOperation, error) {
+	xdrOp, err := op.BuildXDR()
+	if err != nil {
+		return model.DecodedOperation{}, err
+	}
+	opType := operations.TypeNames[xdrOp.Body.Type]
+
+	source := op.GetSourceAccount()
+	effectiveSource := source
+	if effectiveSource == "" {
+		effectiveSource = txSource
+	}
+
+	result := model.DecodedOperation{
+		Operation: model.Operation{
+			Type:          opType,
+			TypeDisplay:   operationTypeDisplay(opType),
+			TypeCategory:  operationTypeCategory(opType),
+			SourceAccount: effectiveSource,
+		},
+	}
+	var risks []string
+	if source != "" && source != txSource {
+		risks = append(risks, "acts on behalf of another account")
+	}
+
+	switch typed := op.(type) {
+	case *txnbuild.Payment:
+		result.From = effectiveSource
+		result.To = typed.Destination
+		result.Amount = typed.Amount
+		result.AssetCode, result.AssetIssuer = assetDisplay(typed.Asset)
+		result.Action = fmt.Sprintf("sends %s %s to", typed.Amount, result.AssetCode)
+		result.TargetAccount = typed.Destination
+
+	case *txnbuild.CreateAccount:
+		result.From = effectiveSource
+		result.To = typed.Destination
+		result.StartingBalance = typed.Amount
+		result.Action = fmt.Sprintf("creates account with %s XLM:", typed.Amount)
+		result.TargetAccount = typed.Destination
+
+	case *txnbuild.ChangeTrust:
+		result.AssetCode, result.AssetIssuer = assetDisplay(typed.Line)
+		result.TrustLimit = typed.Limit
+		if typed.Limit == "0" || typed.Limit == "0.0000000" {
+			result.Action = "removes trustline " + result.AssetCode
+		} else {
+			result.Action = "trusts " + result.AssetCode
+		}
+
+	case *txnbuild.ManageData:
+		result.DataName = typed.Name
+		if typed.Value != nil {
+			result.DataValue = strings.TrimSpace(string(typed.Value))
+		}
+		result.Action, result.TargetAccount, result.TargetValue = describeDataEntry(types, typed.Name, typed.Value)
+
+	case *txnbuild.PathPaymentStrictSend:
+		result.From = effectiveSource
+		result.To = typed.Destination
+		result.SourceAmount = typed.SendAmount
+		result.SourceAsset, _ = assetDisplay(typed.SendAsset)
+		result.DestAmount = typed.DestMin
+		result.DestAsset, _ = assetDisplay(typed.DestAsset)
+		result.Action = fmt.Sprintf("sends %s %s as at least %s %s to",
+			typed.SendAmount, result.SourceAsset, typed.DestMin, result.DestAsset)
+		result.TargetAccount = typed.Destination
+
+	case *txnbuild.PathPayment:
+		result.From = effectiveSource
+		result.To = typed.Destination
+		result.SourceAmount = typed.SendMax
+		result.SourceAsset, _ = assetDisplay(typed.SendAsset)
+		result.DestAmount = typed.DestAmount
+		result.DestAsset, _ = assetDisplay(typed.DestAsset)
+		result.Action = fmt.Sprintf("sends %s %s (paying at most %s %s) to",
+			typed.DestAmount, result.DestAsset, typed.SendMax, result.SourceAsset)
+		result.TargetAccount = typed.Destination
+
+	case *txnbuild.ManageSellOffer:
+		result.Selling, _ = assetDisplay(typed.Selling)
+		result.Buying, _ = assetDisplay(typed.Buying)
+		result.Amount = typed.Amount
+		result.Price = typed.Price.String()
+		result.OfferID = strconv.FormatInt(typed.OfferID, 10)
+		result.Action = offerAction(typed.OfferID, typed.Amount, fmt.Sprintf("sells %s %s for %s", typed.Amount, result.Selling, result.Buying))
+
+	case *txnbuild.ManageBuyOffer:
+		result.Selling, _ = assetDisplay(typed.Selling)
+		result.Buying, _ = assetDisplay(typed.Buying)
+		result.Amount = typed.Amount
+		result.Price = typed.Price.String()
+		result.OfferID = strconv.FormatInt(typed.OfferID, 10)
+		result.Action = offerAction(typed.OfferID, typed.Amount, fmt.Sprintf("buys %s %s for %s", typed.Amount, result.Buying, result.Selling))
+
+	case *txnbuild.CreatePassiveSellOffer:
+		result.Selling, _ = assetDisplay(typed.Selling)
+		result.Buying, _ = assetDisplay(typed.Buying)
+		result.Amount = typed.Amount
+		result.Price = typed.Price.String()
+		result.Action = fmt.Sprintf("offers %s %s for %s", typed.Amount, result.Selling, result.Buying)
+
+	case *txnbuild.AccountMerge:
+		result.From = effectiveSource
+		result.To = typed.Destination
+		result.Action = "deletes the account and sends all XLM to"
+		result.TargetAccount = typed.Destination
+		risks = append(risks, "deletes the source account")
+
+	case *txnbuild.SetOptions:
+		var optionRisks []string
+		result.Action, result.TargetAccount, result.TargetValue, optionRisks = describeSetOptions(typed)
+		risks = append(risks, optionRisks...)
+
+	case *txnbuild.BumpSequence:
+		result.Action = fmt.Sprintf("bumps sequence number to %d", typed.BumpTo)
+	}
+
+	if result.Action == "" {
+		result.Action = strings.ToLower(result.TypeDisplay)
+	}
+	result.Risk = strings.Join(risks, "; ")
+
+	return result, nil
+}
+
+// describeDataEntry interprets a ManageData entry as a BSN action.
+// A nil value deletes the entry.
+func describeDataEntry(types *relation.Registry, name string, value []byte) (action, targetAccount, targetValue string) {
+	text := strings.TrimSpace(string(value))
+	deleted := value == nil
+
+	switch {
+	case name == model.DataKeyDelegate:
+		if deleted {
+			return "removes vote delegate", "", ""
+		}
+		return "sets vote delegate to", text, ""
+
+	case name == model.DataKeyCouncilDelegate:
+		if deleted {
+			return "removes council delegate", "", ""
+		}
+		if strings.EqualFold(text, model.CouncilReadyValue) {
+			return "declares readiness to join the Council", "", ""
+		}
+		return "sets council delegate to", text, ""
+
+	case strings.HasPrefix(name, "Tag") && len(name) > 3:
+		if deleted {
+			return "removes tag", "", name[3:]
+		}
+		return "adds tag", "", name[3:]
+	}
+
+	if relType, _, ok := splitRelationKey(types, name); ok {
+		if deleted {
+			return fmt.Sprintf("removes %s relation (%s)", relType, name), "", ""
+		}
+		return fmt.Sprintf("adds %s relation to", relType), text, ""
+	}
+
+	if deleted {
+		return "deletes " + name, "", ""
+	}
+	if isAccountAddress(text) {
+		return "sets " + name + " to", text, ""
+	}
+	return "sets " + name + " to", "", text
+}
+
+// describeSetOptions summarizes a SetOptions operation. Changes to signers, weights,
+// thresholds and authorization flags are reported as risks.
+func describeSetOptions(op *txnbuild.SetOptions) (action, targetAccount, targetValue string, risks []string) {
+	var parts []string
+
+	if op.HomeDomain != nil {
+		parts = append(parts, fmt.Sprintf("sets home domain to %q", *op.HomeDomain))
+	}
+	if op.InflationDestination != nil {
+		parts = append(parts, "sets inflation destination")
+	}
+	if len(op.SetFlags) > 0 || len(op.ClearFlags) > 0 {
+		parts = append(parts, "changes authorization flags")
+		risks = append(risks, "changes account authorization flags")
+	}
+	if op.LowThreshold != nil || op.MediumThreshold != nil || op.HighThreshold != nil {
+		parts = append(parts, "sets thresholds "+thresholdsDisplay(op))
+		risks = append(risks, "changes signing thresholds")
+	}
+	if op.MasterWeight != nil {
+		if *op.MasterWeight == 0 {
+			parts = append(parts, "disables the master key")
+			risks = append(risks, "disables the master key")
+		} else {
+			parts = append(parts, fmt.Sprintf("sets master key weight to %d", *op.MasterWeight))
+			risks = append(risks, "changes the master key weight")
+		}
+	}
+	// The signer goes last so its address follows the action text
+	if op.Signer != nil {
+		if op.Signer.Weight == 0 {
+			parts = append(parts, "removes signer")
+		} else {
+			parts = append(parts, fmt.Sprintf("sets signer with weight %d", op.Signer.Weight))
+		}
+		risks = append(risks, "changes who can sign for the account")
+		if isAccountAddress(op.Signer.Address) {
+			targetAccount = op.Signer.Address
+		} else {
+			targetValue = op.Signer.Address
+		}
+	}
+
+	if len(parts) == 0 {
+		return "changes no options", "", "", nil
+	}
+	return strings.Join(parts, ", "), targetAccount, targetValue, risks
+}
+
+// thresholdsDisplay formats low/medium/high thresholds, using "-" for unchanged ones.
+func thresholdsDisplay(op *txnbuild.SetOptions) string {
+	values := make([]string, 0, 3)
+	for _, t := range []*txnbuild.Threshold{op.LowThreshold, op.MediumThreshold, op.HighThreshold} {
+		if t == nil {
+			values = append(values, "-")
+			continue
+		}
+		values = append(values, strconv.Itoa(int(*t)))
+	}
+	return strings.Join(values, "/")
+}
+
+// offerAction describes a DEX offer; offer ID 0 creates an offer and amount 0 deletes one.
+func offerAction(offerID int64, amount, create string) string {
+	switch {
+	case offerID != 0 && (amount == "0" || amount == "0.0000000"):
+		return fmt.Sprintf("deletes offer %d", offerID)
+	case offerID != 0:
+		return fmt.Sprintf("updates offer %d: %s", offerID, create)
+	default:
+		return create
+	}
+}
+
+// assetDisplay returns the display code and issuer of an asset (XLM for native).
+func assetDisplay(asset txnbuild.BasicAsset) (code, issuer string) {
+	if asset == nil {
+		return "", ""
+	}
+	if asset.IsNative() {
+		return "XLM", ""
+	}
+	if asset.GetCode() == "" {
+		return "LP share", ""
+	}
+	return asset.GetCode(), asset.GetIssuer()
+}
+
+// memoDisplay returns the memo type and its printable value.
+func memoDisplay(memo txnbuild.Memo) (memoType, value string) {
+	switch m := memo.(type) {
+	case txnbuild.MemoText:
+		return "text", string(m)
+	case txnbuild.MemoID:
+		return "id", strconv.FormatUint(uint64(m), 10)
+	case txnbuild.MemoHash:
+		return "hash", hex.EncodeToString(m[:])
+	case txnbuild.MemoReturn:
+		return "return", hex.EncodeToString(m[:])
+	default:
+		return "none", ""
+	}
+}
+
+// formatUnixTime formats a Unix timestamp like other timestamps on the site.
+func formatUnixTime(ts int64) string {
+	return time.Unix(ts, 0).UTC().Format("2006-01-02 15:04:05")
+}
+
+// isAccountAddress reports whether s looks like a Stellar account ID.
+func isAccountAddress(s string) bool {
+	return len(s) == 56 && s[0] == 'G'
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/mtlprog/lore/internal/relation/relationtest"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildTestEnvelope(t *testing.T, source string, ops ...txnbuild.Operation) *txnbuild.Transaction {
+	t.Helper()
+
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source, Sequence: 99},
+		IncrementSequenceNum: true,
+		Operations:           ops,
+		BaseFee:              txnbuild.MinBaseFee,
+		Memo:                 txnbuild.MemoText("council vote"),
+		Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewTimebounds(0, 1700000000)},
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestDecodeEnvelope(t *testing.T) {
+	tx := buildTestEnvelope(t, testAccountID1,
+		&txnbuild.ManageData{Name: "Employer", Value: []byte(testAccountID2)},
+		&txnbuild.ManageData{Name: "PartOf002"},
+		&txnbuild.ManageData{Name: "mtla_c_delegate", Value: []byte("ready")},
+		&txnbuild.ManageData{Name: "mtla_delegate", Value: []byte(testAccountID3)},
+		&txnbuild.ManageData{Name: "TagBelgrade", Value: []byte(testAccountID1)},
+		&txnbuild.ManageData{Name: "Name", Value: []byte("Alice")},
+		&txnbuild.Payment{Destination: testAccountID2, Amount: "10", Asset: txnbuild.NativeAsset{}},
+		&txnbuild.SetOptions{Signer: &txnbuild.Signer{Address: testAccountID3, Weight: 1}},
+		&txnbuild.SetOptions{HomeDomain: txnbuild.NewHomeDomain("example.com")},
+		&txnbuild.ManageData{Name: "Website", Value: []byte("x"), SourceAccount: testAccountID2},
+	)
+	envelope, err := tx.Base64()
+	require.NoError(t, err)
+	hash, err := tx.HashHex(network.PublicNetworkPassphrase)
+	require.NoError(t, err)
+
+	decoded, err := DecodeEnvelope(envelope, relationtest.Registry())
+	require.NoError(t, err)
+
+	assert.Equal(t, hash, decoded.Hash)
+	assert.False(t, decoded.FeeBump)
+	assert.Equal(t, testAccountID1, decoded.SourceAccount)
+	assert.Equal(t, int64(100), decoded.Sequence)
+	assert.Equal(t, "text", decoded.MemoType)
+	assert.Equal(t, "council vote", decoded.Memo)
+	assert.Empty(t, decoded.ValidAfter)
+	assert.Equal(t, "2023-11-14 22:13:20", decoded.ValidBefore)
+	assert.Equal(t, 0, decoded.SignatureCount)
+	assert.Equal(t, 2, decoded.RiskCount)
+
+	type summary struct{ Type, Action, TargetAccount, TargetValue, Risk string }
+	got := make([]summary, 0, len(decoded.Operations))
+	for _, op := range decoded.Operations {
+		got = append(got, summary{op.Type, op.Action, op.TargetAccount, op.TargetValue, op.Risk})
+	}
+	assert.Equal(t, []summary{
+		{"manage_data", "adds Employer relation to", testAccountID2, "", ""},
+		{"manage_data", "removes PartOf relation (PartOf002)", "", "", ""},
+		{"manage_data", "declares readiness to join the Council", "", "", ""},
+		{"manage_data", "sets vote delegate to", testAccountID3, "", ""},
+		{"manage_data", "adds tag", "", "Belgrade", ""},
+		{"manage_data", "sets Name to", "", "Alice", ""},
+		{"payment", "sends 10.0000000 XLM to", testAccountID2, "", ""},
+		{"set_options", "sets signer with weight 1", testAccountID3, "", "changes who can sign for the account"},
+		{"set_options", `sets home domain to "example.com"`, "", "", ""},
+		{"manage_data", "sets Website to", "", "x", "acts on behalf of another account"},
+	}, got)
+
+	assert.Equal(t, "Manage Data", decoded.Operations[0].TypeDisplay)
+	assert.Equal(t, "data", decoded.Operations[0].TypeCategory)
+	assert.Equal(t, testAccountID2, decoded.Operations[9].SourceAccount)
+	assert.Equal(t, []string{testAccountID1, testAccountID2, testAccountID3}, decoded.AccountIDs())
+}
+
+func TestDecodeEnvelope_FeeBump(t *testing.T) {
+	feePayer := keypair.MustRandom()
+	inner := buildTestEnvelope(t, testAccountID1,
+		&txnbuild.SetOptions{MasterWeight: txnbuild.NewThreshold(0), LowThreshold: txnbuild.NewThreshold(2)},
+	)
+	feeBump, err := txnbuild.NewFeeBumpTransaction(txnbuild.FeeBumpTransactionParams{
+		Inner:      inner,
+		FeeAccount: feePayer.Address(),
+		BaseFee:    txnbuild.MinBaseFee * 2,
+	})
+	require.NoError(t, err)
+	feeBump, err = feeBump.Sign(network.PublicNetworkPassphrase, feePayer)
+	require.NoError(t, err)
+	envelope, err := feeBump.Base64()
+	require.NoError(t, err)
+
+	decoded, err := DecodeEnvelope(envelope, relationtest.Registry())
+	require.NoError(t, err)
+
+	assert.True(t, decoded.FeeBump)
+	assert.Equal(t, feePayer.Address(), decoded.FeeAccount)
+	assert.NotEmpty(t, decoded.InnerHash)
+	assert.NotEqual(t, decoded.Hash, decoded.InnerHash)
+	assert.Equal(t, 1, decoded.SignatureCount)
+	require.Len(t, decoded.Operations, 1)
+	assert.Equal(t, "sets thresholds 2/-/-, disables the master key", decoded.Operations[0].Action)
+	assert.Equal(t, "changes signing thresholds; disables the master key", decoded.Operations[0].Risk)
+}
+
+func TestDecodeEnvelope_Invalid(t *testing.T) {
+	for _, envelope := range []string{"", "   ", "not-base64!", "AAAA"} {
+		_, err := DecodeEnvelope(envelope, relationtest.Registry())
+		assert.ErrorIs(t, err, ErrInvalidXDR, "envelope %q", envelope)
+	}
+}
+
+func TestDecodeEnvelope_AccountMerge(t *testing.T) {
+	tx := buildTestEnvelope(t, testAccountID1, &txnbuild.AccountMerge{Destination: testAccountID2})
+	envelope, err := tx.Base64()
+	require.NoError(t, err)
+
+	// Line breaks from copy-pasting are ignored
+	decoded, err := DecodeEnvelope(envelope[:20]+"\n"+envelope[20:], relationtest.Registry())
+	require.NoError(t, err)
+
+	require.Len(t, decoded.Operations, 1)
+	op := decoded.Operations[0]
+	assert.Equal(t, "account_merge", op.Type)
+	assert.Equal(t, testAccountID2, op.TargetAccount)
+	assert.Equal(t, "deletes the source account", op.Risk)
+	assert.Equal(t, 1, decoded.RiskCount)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/txnbuild"
+)
+
+// FeeStrategy selects the base fee of generated transactions from Horizon fee_stats.
+type FeeStrategy string
+
+const (
+	FeeStrategyMin FeeStrategy = "min" // Network minimum (100 stroops), no Horizon request
+	FeeStrategyP50 FeeStrategy = "p50" // Median fee bid of recent ledgers
+	FeeStrategyP70 FeeStrategy = "p70"
+	FeeStrategyP90 FeeStrategy = "p90"
+	FeeStrategyP99 FeeStrategy = "p99" // Gets in even during heavy surges
+)
+
+const (
+	// DefaultFeeStrategy is used when no fee strategy is configured.
+	DefaultFeeStrategy = FeeStrategyP70
+
+	// DefaultMaxBaseFee caps the base fee per operation (0.001 XLM).
+	DefaultMaxBaseFee = 10000
+
+	// DefaultTxValidity is how long generated transactions can be submitted.
+	DefaultTxValidity = 24 * time.Hour
+
+	// feeStatsTTL is how long fee_stats are reused; they change with every ledger.
+	feeStatsTTL = 10 * time.Second
+)
+
+// ParseFeeStrategy parses a fee strategy name such as "min" or "p90".
+func ParseFeeStrategy(s string) (FeeStrategy, error) {
+	switch strategy := FeeStrategy(strings.ToLower(strings.TrimSpace(s))); strategy {
+	case FeeStrategyMin, FeeStrategyP50, FeeStrategyP70, FeeStrategyP90, FeeStrategyP99:
+		return strategy, nil
+	case "":
+		return DefaultFeeStrategy, nil
+	default:
+		return "", fmt.Errorf("unknown fee strategy %q (use min, p50, p70, p90 or p99)", s)
+	}
+}
+
+// TxSettings configures fees and time bounds of generated transactions.
+type TxSettings struct {
+	FeeStrategy FeeStrategy   // How the base fee is chosen
+	MaxBaseFee  int64         // Upper bound of the base fee in stroops (0 = no bound)
+	Validity    time.Duration // How long transactions stay valid (0 = no upper time bound)
+}
+
+// DefaultTxSettings returns the settings used when none are configured.
+func DefaultTxSettings() TxSettings {
+	return TxSettings{
+		FeeStrategy: DefaultFeeStrategy,
+		MaxBaseFee:  DefaultMaxBaseFee,
+		Validity:    DefaultTxValidity,
+	}
+}
+
+// SuggestBaseFee returns the base fee per operation in stroops for the given strategy,
+// capped by maxBaseFee. If fee_stats are unavailable, the network minimum is used.
+func (s *StellarService) SuggestBaseFee(ctx context.Context, strategy FeeStrategy, maxBaseFee int64) int64 {
+	if strategy == FeeStrategyMin {
+		return txnbuild.MinBaseFee
+	}
+
+	stats, err := s.feeStats()
+	if err != nil {
+		slog.Warn("failed to fetch fee stats, using minimum base fee", "error", err)
+		return txnbuild.MinBaseFee
+	}
+	return pickBaseFee(stats, strategy, maxBaseFee)
+}
+
+// feeStats returns Horizon fee_stats, cached for a few ledgers.
+func (s *StellarService) feeStats() (horizon.FeeStats, error) {
+	if cached, ok := s.feeCache.Get("fee_stats"); ok {
+		if stats, ok := cached.(horizon.FeeStats); ok {
+			return stats, nil
+		}
+	}
+
+	stats, err := s.client.FeeStats()
+	if err != nil {
+		return horizon.FeeStats{}, err
+	}
+	s.feeCache.Set("fee_stats", stats)
+	return stats, nil
+}
+
+// pickBaseFee selects a fee bid percentile. The result is never below the last
+// ledger's base fee or the network minimum, and never above maxBaseFee (when set).
+func pickBaseFee(stats horizon.FeeStats, strategy FeeStrategy, maxBaseFee int64) int64 {
+	var fee int64
+	switch strategy {
+	case FeeStrategyP50:
+		fee = stats.MaxFee.P50
+	case FeeStrategyP70:
+		fee = stats.MaxFee.P70
+	case FeeStrategyP90:
+		fee = stats.MaxFee.P90
+	case FeeStrategyP99:
+		fee = stats.MaxFee.P99
+	}
+
+	fee = max(fee, stats.LastLedgerBaseFee, txnbuild.MinBaseFee)
+	if maxBaseFee > 0 {
+		fee = min(fee, max(maxBaseFee, txnbuild.MinBaseFee))
+	}
+	return fee
+}
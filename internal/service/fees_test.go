@@ -0,0 +1,67 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFeeStrategy(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    FeeStrategy
+		wantErr bool
+	}{
+		{"", DefaultFeeStrategy, false},
+		{"min", FeeStrategyMin, false},
+		{" P90 ", FeeStrategyP90, false},
+		{"p99", FeeStrategyP99, false},
+		{"p95", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseFeeStrategy(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPickBaseFee(t *testing.T) {
+	stats := horizon.FeeStats{LastLedgerBaseFee: 100}
+	stats.MaxFee.P50 = 150
+	stats.MaxFee.P70 = 400
+	stats.MaxFee.P90 = 2000
+	stats.MaxFee.P99 = 50000
+
+	tests := []struct {
+		name     string
+		stats    horizon.FeeStats
+		strategy FeeStrategy
+		max      int64
+		want     int64
+	}{
+		{"p50", stats, FeeStrategyP50, 10000, 150},
+		{"p70", stats, FeeStrategyP70, 10000, 400},
+		{"p90", stats, FeeStrategyP90, 10000, 2000},
+		{"capped", stats, FeeStrategyP99, 10000, 10000},
+		{"no cap", stats, FeeStrategyP99, 0, 50000},
+		{"cap below minimum", stats, FeeStrategyP90, 10, txnbuild.MinBaseFee},
+		{"empty stats", horizon.FeeStats{}, FeeStrategyP70, 10000, txnbuild.MinBaseFee},
+		{"surge raises base fee", horizon.FeeStats{LastLedgerBaseFee: 700}, FeeStrategyP50, 10000, 700},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pickBaseFee(tt.stats, tt.strategy, tt.max))
+		})
+	}
+}
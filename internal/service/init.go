@@ -5,133 +5,225 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"regexp"
 	"sort"
+	"time"
 
 	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/relation"
 	"github.com/samber/lo"
 	"github.com/stellar/go/keypair"
 	"github.com/stellar/go/network"
 	"github.com/stellar/go/txnbuild"
 )
 
+// maxOpsPerTransaction is the protocol limit of operations in one transaction.
+const maxOpsPerTransaction = 100
+
 // InitXDRBuilder generates Stellar XDR transactions for init forms.
-type InitXDRBuilder struct{}
+type InitXDRBuilder struct {
+	baseFee   int64              // Base fee per operation in stroops
+	validity  time.Duration      // Upper time bound relative to now (0 = unbounded)
+	now       func() time.Time   // Clock for time bounds
+	relations *relation.Registry // Relation types the relationship editor may write
+}
+
+// InitXDRBuilderOption configures an InitXDRBuilder.
+type InitXDRBuilderOption func(*InitXDRBuilder)
+
+// WithBaseFee sets the base fee per operation in stroops (default: network minimum).
+func WithBaseFee(stroops int64) InitXDRBuilderOption {
+	return func(b *InitXDRBuilder) {
+		if stroops > txnbuild.MinBaseFee {
+			b.baseFee = stroops
+		}
+	}
+}
+
+// WithValidity sets how long generated transactions can be submitted (default: DefaultTxValidity).
+// Zero leaves the transaction without an upper time bound.
+func WithValidity(d time.Duration) InitXDRBuilderOption {
+	return func(b *InitXDRBuilder) {
+		b.validity = max(d, 0)
+	}
+}
+
+// WithRelationTypes sets the relation types the relationship editor may write
+// (default: none).
+func WithRelationTypes(types *relation.Registry) InitXDRBuilderOption {
+	return func(b *InitXDRBuilder) {
+		if types != nil {
+			b.relations = types
+		}
+	}
+}
 
 // NewInitXDRBuilder creates a new XDR builder for the Stellar public network.
-func NewInitXDRBuilder() *InitXDRBuilder {
-	return &InitXDRBuilder{}
+func NewInitXDRBuilder(opts ...InitXDRBuilderOption) *InitXDRBuilder {
+	b := &InitXDRBuilder{
+		baseFee:   txnbuild.MinBaseFee,
+		validity:  DefaultTxValidity,
+		now:       time.Now,
+		relations: relation.NewRegistry(nil),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
 }
 
 // GenerateParticipantXDR compares original vs current form data and generates XDR.
+// Changes above the operation limit are split into transactions with consecutive sequence numbers.
 func (b *InitXDRBuilder) GenerateParticipantXDR(
 	original, current model.ParticipantFormData,
 	sequenceNum int64,
-) (xdr string, ops []model.InitOpSummary, err error) {
+) ([]model.InitTransaction, error) {
 	if current.AccountID == "" {
-		return "", nil, fmt.Errorf("account ID required")
+		return nil, fmt.Errorf("account ID required")
 	}
 
 	if _, err := keypair.ParseAddress(current.AccountID); err != nil {
-		return "", nil, fmt.Errorf("invalid account ID: %w", err)
+		return nil, fmt.Errorf("invalid account ID: %w", err)
 	}
 
-	var operations []txnbuild.Operation
-
-	// Simple fields
-	if current.Name != original.Name {
-		op, summary := b.manageDataOp("Name", current.Name)
-		operations = append(operations, op)
-		ops = append(ops, summary)
+	if err := ValidateParticipantForm(original, current); err != nil {
+		return nil, err
 	}
-
-	if current.About != original.About {
-		op, summary := b.manageDataOp("About", current.About)
-		operations = append(operations, op)
-		ops = append(ops, summary)
+	if err := ValidateRelations(current.Relations, b.relations, model.InitFormParticipant); err != nil {
+		return nil, err
 	}
 
-	if current.Website != original.Website {
-		op, summary := b.manageDataOp("Website", current.Website)
-		operations = append(operations, op)
-		ops = append(ops, summary)
-	}
+	// Simple fields
+	operations, ops := b.diffSimpleFields(participantFields(original, current))
 
 	// PartOf relations - handle additions, deletions, and changes
 	partOfOps, partOfSummaries := b.diffNumberedFields("PartOf", original.PartOf, current.PartOf)
 	operations = append(operations, partOfOps...)
 	ops = append(ops, partOfSummaries...)
 
+	// Other relations from the relationship editor
+	relationOps, relationSummaries := b.diffRelations(original.Relations, current.Relations)
+	operations = append(operations, relationOps...)
+	ops = append(ops, relationSummaries...)
+
 	// Tags - handle additions and deletions
 	tagOps, tagSummaries := b.diffTags(current.AccountID, original.Tags, current.Tags)
 	operations = append(operations, tagOps...)
 	ops = append(ops, tagSummaries...)
 
 	if len(operations) == 0 {
-		return "", nil, fmt.Errorf("no changes to submit")
+		return nil, fmt.Errorf("no changes to submit")
 	}
 
-	xdr, err = b.buildXDR(current.AccountID, sequenceNum, operations)
-	if err != nil {
-		return "", nil, err
-	}
-
-	return xdr, ops, nil
+	return b.buildTransactions(current.AccountID, sequenceNum, operations, ops)
 }
 
 // GenerateCorporateXDR compares original vs current form data and generates XDR.
+// Changes above the operation limit are split into transactions with consecutive sequence numbers.
 func (b *InitXDRBuilder) GenerateCorporateXDR(
 	original, current model.CorporateFormData,
 	sequenceNum int64,
-) (xdr string, ops []model.InitOpSummary, err error) {
+) ([]model.InitTransaction, error) {
 	if current.AccountID == "" {
-		return "", nil, fmt.Errorf("account ID required")
+		return nil, fmt.Errorf("account ID required")
 	}
 
 	if _, err := keypair.ParseAddress(current.AccountID); err != nil {
-		return "", nil, fmt.Errorf("invalid account ID: %w", err)
+		return nil, fmt.Errorf("invalid account ID: %w", err)
 	}
 
-	var operations []txnbuild.Operation
-
-	// Simple fields
-	if current.Name != original.Name {
-		op, summary := b.manageDataOp("Name", current.Name)
-		operations = append(operations, op)
-		ops = append(ops, summary)
+	if err := ValidateCorporateForm(original, current); err != nil {
+		return nil, err
 	}
-
-	if current.About != original.About {
-		op, summary := b.manageDataOp("About", current.About)
-		operations = append(operations, op)
-		ops = append(ops, summary)
+	if err := ValidateRelations(current.Relations, b.relations, model.InitFormCorporate); err != nil {
+		return nil, err
 	}
 
-	if current.Website != original.Website {
-		op, summary := b.manageDataOp("Website", current.Website)
-		operations = append(operations, op)
-		ops = append(ops, summary)
-	}
+	// Simple fields
+	operations, ops := b.diffSimpleFields(corporateFields(original, current))
 
 	// MyPart relations - handle additions, deletions, and changes
 	myPartOps, myPartSummaries := b.diffNumberedFields("MyPart", original.MyPart, current.MyPart)
 	operations = append(operations, myPartOps...)
 	ops = append(ops, myPartSummaries...)
 
+	// Other relations from the relationship editor
+	relationOps, relationSummaries := b.diffRelations(original.Relations, current.Relations)
+	operations = append(operations, relationOps...)
+	ops = append(ops, relationSummaries...)
+
 	// Tags - handle additions and deletions
 	tagOps, tagSummaries := b.diffTags(current.AccountID, original.Tags, current.Tags)
 	operations = append(operations, tagOps...)
 	ops = append(ops, tagSummaries...)
 
 	if len(operations) == 0 {
-		return "", nil, fmt.Errorf("no changes to submit")
+		return nil, fmt.Errorf("no changes to submit")
 	}
 
-	xdr, err = b.buildXDR(current.AccountID, sequenceNum, operations)
-	if err != nil {
-		return "", nil, err
+	return b.buildTransactions(current.AccountID, sequenceNum, operations, ops)
+}
+
+// dataField is a single-valued ManageData key with its original and current values.
+type dataField struct {
+	key      string
+	original string
+	current  string
+}
+
+// participantFields lists the single-valued keys of a participant form.
+func participantFields(original, current model.ParticipantFormData) []dataField {
+	return []dataField{
+		{"Name", original.Name, current.Name},
+		{"About", original.About, current.About},
+		{"Website", original.Website, current.Website},
+		{model.DataKeyTelegramUserID, original.TelegramUserID, current.TelegramUserID},
+		{model.DataKeyTimeTokenCode, original.TimeTokenCode, current.TimeTokenCode},
+		{model.DataKeyTimeTokenIssuer, original.TimeTokenIssuer, current.TimeTokenIssuer},
+		{model.DataKeyTimeTokenDesc, original.TimeTokenDesc, current.TimeTokenDesc},
+		{model.DataKeyTimeTokenOfferIPFS, original.TimeTokenOfferIPFS, current.TimeTokenOfferIPFS},
+	}
+}
+
+// corporateFields lists the single-valued keys of a corporate form.
+func corporateFields(original, current model.CorporateFormData) []dataField {
+	return []dataField{
+		{"Name", original.Name, current.Name},
+		{"About", original.About, current.About},
+		{"Website", original.Website, current.Website},
+		{model.DataKeyPIIStandard, presenceValue(original.PIIStandard), presenceValue(current.PIIStandard)},
+		{model.DataKeyTelegramPartChatID, original.TelegramPartChatID, current.TelegramPartChatID},
+		{model.DataKeyContractIPFS, original.ContractIPFS, current.ContractIPFS},
+	}
+}
+
+// diffSimpleFields generates Set/Delete operations for changed single-valued fields.
+func (b *InitXDRBuilder) diffSimpleFields(fields []dataField) ([]txnbuild.Operation, []model.InitOpSummary) {
+	var ops []txnbuild.Operation
+	var summaries []model.InitOpSummary
+
+	for _, f := range fields {
+		if f.current == f.original {
+			continue
+		}
+		op, summary := b.manageDataOp(f.key, f.current)
+		ops = append(ops, op)
+		summaries = append(summaries, summary)
 	}
 
-	return xdr, ops, nil
+	return ops, summaries
+}
+
+// presenceFlagValue is written for flag keys such as "MTLA: PII Standard",
+// where only the presence of the key matters.
+const presenceFlagValue = "1"
+
+// presenceValue converts a flag to its ManageData value (empty deletes the key).
+func presenceValue(set bool) string {
+	if set {
+		return presenceFlagValue
+	}
+	return ""
 }
 
 // manageDataOp creates a ManageData operation with proper encoding.
@@ -165,9 +257,6 @@ func (b *InitXDRBuilder) diffNumberedFields(
 	prefix string,
 	original, current []model.NumberedField,
 ) ([]txnbuild.Operation, []model.InitOpSummary) {
-	var ops []txnbuild.Operation
-	var summaries []model.InitOpSummary
-
 	// Collect original keys
 	originalKeys := make(map[string]string)
 	for _, f := range original {
@@ -206,9 +295,19 @@ func (b *InitXDRBuilder) diffNumberedFields(
 		usedIndices[index] = true
 	}
 
-	// Delete keys that are no longer present or have changed
-	for key, oldVal := range originalKeys {
-		if newVal, exists := currentKeys[key]; !exists || newVal != oldVal {
+	return b.diffDataKeys(originalKeys, currentKeys)
+}
+
+// diffDataKeys compares original vs current ManageData key/value maps and generates
+// Delete operations for removed keys and Set operations for new or changed ones, sorted by key.
+// A changed key is only set: emitting a Delete as well could reorder after the Set and drop the value.
+func (b *InitXDRBuilder) diffDataKeys(originalKeys, currentKeys map[string]string) ([]txnbuild.Operation, []model.InitOpSummary) {
+	var ops []txnbuild.Operation
+	var summaries []model.InitOpSummary
+
+	// Delete keys that are no longer present
+	for key := range originalKeys {
+		if _, exists := currentKeys[key]; !exists {
 			op, summary := b.manageDataOp(key, "")
 			ops = append(ops, op)
 			summaries = append(summaries, summary)
@@ -285,8 +384,50 @@ func (b *InitXDRBuilder) diffTags(
 	return ops, summaries
 }
 
+// buildTransactions creates unsigned transaction envelopes, starting at sequenceNum and
+// splitting operations into chunks of maxOpsPerTransaction.
+func (b *InitXDRBuilder) buildTransactions(
+	accountID string,
+	sequenceNum int64,
+	operations []txnbuild.Operation,
+	summaries []model.InitOpSummary,
+) ([]model.InitTransaction, error) {
+	timeBounds := txnbuild.NewInfiniteTimeout()
+	validBefore := ""
+	if b.validity > 0 {
+		deadline := b.now().Add(b.validity).UTC()
+		timeBounds = txnbuild.NewTimebounds(0, deadline.Unix())
+		validBefore = deadline.Format("2006-01-02 15:04:05")
+	}
+
+	var txs []model.InitTransaction
+	for start := 0; start < len(operations); start += maxOpsPerTransaction {
+		end := min(start+maxOpsPerTransaction, len(operations))
+		seq := sequenceNum + int64(len(txs))
+
+		xdr, err := b.buildXDR(accountID, seq, operations[start:end], timeBounds)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, model.InitTransaction{
+			XDR:         xdr,
+			Sequence:    seq,
+			Operations:  summaries[start:end],
+			MaxFee:      formatStroops(b.baseFee * int64(end-start)),
+			ValidBefore: validBefore,
+		})
+	}
+
+	return txs, nil
+}
+
 // buildXDR creates an unsigned transaction envelope XDR.
-func (b *InitXDRBuilder) buildXDR(accountID string, sequenceNum int64, operations []txnbuild.Operation) (string, error) {
+func (b *InitXDRBuilder) buildXDR(
+	accountID string,
+	sequenceNum int64,
+	operations []txnbuild.Operation,
+	timeBounds txnbuild.TimeBounds,
+) (string, error) {
 	tx, err := txnbuild.NewTransaction(
 		txnbuild.TransactionParams{
 			SourceAccount: &txnbuild.SimpleAccount{
@@ -294,8 +435,8 @@ func (b *InitXDRBuilder) buildXDR(accountID string, sequenceNum int64, operation
 				Sequence:  sequenceNum,
 			},
 			Operations:           operations,
-			BaseFee:              txnbuild.MinBaseFee,
-			Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+			BaseFee:              b.baseFee,
+			Preconditions:        txnbuild.Preconditions{TimeBounds: timeBounds},
 			IncrementSequenceNum: false,
 		},
 	)
@@ -320,27 +461,40 @@ func BuildLabLink(xdr string) string {
 }
 
 // ParseAccountDataToParticipant converts Horizon account data to ParticipantFormData.
-func ParseAccountDataToParticipant(accountID string, data map[string]string) model.ParticipantFormData {
+// Relations are recognized by the given relation types.
+func ParseAccountDataToParticipant(accountID string, data map[string]string, types *relation.Registry) model.ParticipantFormData {
 	form := model.ParticipantFormData{
-		AccountID: accountID,
-		Name:      decodeBase64(data["Name"]),
-		About:     decodeBase64(data["About"]),
-		Website:   decodeBase64(data["Website"]),
-		PartOf:    parseNumberedFields(data, "PartOf"),
-		Tags:      parseTagFields(data),
+		AccountID:          accountID,
+		Name:               decodeBase64(data["Name"]),
+		About:              decodeBase64(data["About"]),
+		Website:            decodeBase64(data["Website"]),
+		PartOf:             parseNumberedFields(data, "PartOf"),
+		TelegramUserID:     decodeBase64(data[model.DataKeyTelegramUserID]),
+		TimeTokenCode:      decodeBase64(data[model.DataKeyTimeTokenCode]),
+		TimeTokenIssuer:    decodeBase64(data[model.DataKeyTimeTokenIssuer]),
+		TimeTokenDesc:      decodeBase64(data[model.DataKeyTimeTokenDesc]),
+		TimeTokenOfferIPFS: decodeBase64(data[model.DataKeyTimeTokenOfferIPFS]),
+		Relations:          parseRelationFields(data, types, model.InitFormParticipant),
+		Tags:               parseTagFields(data),
 	}
 	return form
 }
 
 // ParseAccountDataToCorporate converts Horizon account data to CorporateFormData.
-func ParseAccountDataToCorporate(accountID string, data map[string]string) model.CorporateFormData {
+// Relations are recognized by the given relation types.
+func ParseAccountDataToCorporate(accountID string, data map[string]string, types *relation.Registry) model.CorporateFormData {
+	_, piiStandard := data[model.DataKeyPIIStandard]
 	form := model.CorporateFormData{
-		AccountID: accountID,
-		Name:      decodeBase64(data["Name"]),
-		About:     decodeBase64(data["About"]),
-		Website:   decodeBase64(data["Website"]),
-		MyPart:    parseNumberedFields(data, "MyPart"),
-		Tags:      parseTagFields(data),
+		AccountID:          accountID,
+		Name:               decodeBase64(data["Name"]),
+		About:              decodeBase64(data["About"]),
+		Website:            decodeBase64(data["Website"]),
+		PIIStandard:        piiStandard,
+		MyPart:             parseNumberedFields(data, "MyPart"),
+		TelegramPartChatID: decodeBase64(data[model.DataKeyTelegramPartChatID]),
+		ContractIPFS:       decodeBase64(data[model.DataKeyContractIPFS]),
+		Relations:          parseRelationFields(data, types, model.InitFormCorporate),
+		Tags:               parseTagFields(data),
 	}
 	return form
 }
@@ -416,24 +570,100 @@ func ValidateAccountID(accountID string) error {
 	return nil
 }
 
+const (
+	// maxDataKeyBytes is the Stellar limit for a ManageData name.
+	maxDataKeyBytes = 64
+	// maxDataValueBytes is the Stellar limit for a ManageData value.
+	maxDataValueBytes = 64
+)
+
+var (
+	telegramUserIDPattern = regexp.MustCompile(`^[0-9]+$`)
+	telegramChatIDPattern = regexp.MustCompile(`^-?[0-9]+$`)
+	assetCodePattern      = regexp.MustCompile(`^[a-zA-Z0-9]{1,12}$`)
+)
+
+// dataFormats describes the keys whose values must have a fixed format.
+var dataFormats = map[string]struct {
+	valid func(string) bool
+	want  string
+}{
+	model.DataKeyTelegramUserID:     {telegramUserIDPattern.MatchString, "a numeric Telegram user ID"},
+	model.DataKeyTelegramPartChatID: {telegramChatIDPattern.MatchString, "a numeric Telegram chat ID"},
+	model.DataKeyTimeTokenCode:      {assetCodePattern.MatchString, "an asset code of 1-12 letters and digits"},
+	model.DataKeyTimeTokenIssuer:    {isAccountID, "a valid Stellar account ID"},
+	model.DataKeyTimeTokenOfferIPFS: {isIPFSRef, "an IPFS hash"},
+	model.DataKeyContractIPFS:       {isIPFSRef, "an IPFS hash"},
+}
+
+func isAccountID(s string) bool {
+	_, err := keypair.ParseAddress(s)
+	return err == nil
+}
+
+func isIPFSRef(s string) bool {
+	return IPFSURL(s) != ""
+}
+
+// ValidateParticipantForm checks the participant fields that differ from
+// original against ManageData limits and formats. Unchanged values are not
+// checked, so a legacy value on the account does not block other edits.
+func ValidateParticipantForm(original, current model.ParticipantFormData) error {
+	return validateChangedFields(participantFields(original, current))
+}
+
+// ValidateCorporateForm checks the corporate fields that differ from original
+// against ManageData limits and formats, like ValidateParticipantForm.
+func ValidateCorporateForm(original, current model.CorporateFormData) error {
+	return validateChangedFields(corporateFields(original, current))
+}
+
+// validateChangedFields checks that every changed value fits into a ManageData
+// entry and has the format of its key. Length is counted in bytes, so non-ASCII
+// text allows fewer characters. Deleted values are always valid.
+func validateChangedFields(fields []dataField) error {
+	for _, f := range fields {
+		if f.current == f.original || f.current == "" {
+			continue
+		}
+		if n := len(f.current); n > maxDataValueBytes {
+			return fmt.Errorf("%s is too long: %d bytes (max %d)", f.key, n, maxDataValueBytes)
+		}
+		if format, ok := dataFormats[f.key]; ok && !format.valid(f.current) {
+			return fmt.Errorf("%s must be %s", f.key, format.want)
+		}
+	}
+	return nil
+}
+
 // encodedParticipant is the JSON structure for encoding ParticipantFormData.
 type encodedParticipant struct {
-	AccountID string                `json:"a"`
-	Name      string                `json:"n"`
-	About     string                `json:"ab"`
-	Website   string                `json:"w"`
-	PartOf    []model.NumberedField `json:"p"`
-	Tags      []string              `json:"t"`
+	AccountID          string                `json:"a"`
+	Name               string                `json:"n"`
+	About              string                `json:"ab"`
+	Website            string                `json:"w"`
+	PartOf             []model.NumberedField `json:"p"`
+	TelegramUserID     string                `json:"tg,omitempty"`
+	TimeTokenCode      string                `json:"ttc,omitempty"`
+	TimeTokenIssuer    string                `json:"tti,omitempty"`
+	TimeTokenDesc      string                `json:"ttd,omitempty"`
+	TimeTokenOfferIPFS string                `json:"tto,omitempty"`
+	Relations          []model.RelationField `json:"r,omitempty"`
+	Tags               []string              `json:"t"`
 }
 
 // encodedCorporate is the JSON structure for encoding CorporateFormData.
 type encodedCorporate struct {
-	AccountID string                `json:"a"`
-	Name      string                `json:"n"`
-	About     string                `json:"ab"`
-	Website   string                `json:"w"`
-	MyPart    []model.NumberedField `json:"m"`
-	Tags      []string              `json:"t"`
+	AccountID          string                `json:"a"`
+	Name               string                `json:"n"`
+	About              string                `json:"ab"`
+	Website            string                `json:"w"`
+	PIIStandard        bool                  `json:"pii,omitempty"`
+	MyPart             []model.NumberedField `json:"m"`
+	TelegramPartChatID string                `json:"tgc,omitempty"`
+	ContractIPFS       string                `json:"ci,omitempty"`
+	Relations          []model.RelationField `json:"r,omitempty"`
+	Tags               []string              `json:"t"`
 }
 
 // EncodeOriginalData serializes form data to base64-encoded JSON for hidden field.
@@ -444,24 +674,36 @@ func EncodeOriginalData(data interface{}) (string, error) {
 	switch v := data.(type) {
 	case model.ParticipantFormData:
 		enc := encodedParticipant{
-			AccountID: v.AccountID,
-			Name:      v.Name,
-			About:     v.About,
-			Website:   v.Website,
-			PartOf:    v.PartOf,
-			Tags:      v.Tags,
+			AccountID:          v.AccountID,
+			Name:               v.Name,
+			About:              v.About,
+			Website:            v.Website,
+			PartOf:             v.PartOf,
+			TelegramUserID:     v.TelegramUserID,
+			TimeTokenCode:      v.TimeTokenCode,
+			TimeTokenIssuer:    v.TimeTokenIssuer,
+			TimeTokenDesc:      v.TimeTokenDesc,
+			TimeTokenOfferIPFS: v.TimeTokenOfferIPFS,
+			Relations:          v.Relations,
+			Tags:               v.Tags,
 		}
 		jsonBytes, err = json.Marshal(enc)
 	case model.CorporateFormData:
 		enc := encodedCorporate{
-			AccountID: v.AccountID,
-			Name:      v.Name,
-			About:     v.About,
-			Website:   v.Website,
-			MyPart:    v.MyPart,
-			Tags:      v.Tags,
+			AccountID:          v.AccountID,
+			Name:               v.Name,
+			About:              v.About,
+			Website:            v.Website,
+			PIIStandard:        v.PIIStandard,
+			MyPart:             v.MyPart,
+			TelegramPartChatID: v.TelegramPartChatID,
+			ContractIPFS:       v.ContractIPFS,
+			Relations:          v.Relations,
+			Tags:               v.Tags,
 		}
 		jsonBytes, err = json.Marshal(enc)
+	case model.DelegationFormData:
+		jsonBytes, err = json.Marshal(encodedDelegation(v))
 	default:
 		return "", fmt.Errorf("unsupported form data type")
 	}
@@ -494,6 +736,12 @@ func DecodeOriginalParticipant(encoded string) (model.ParticipantFormData, error
 	form.About = enc.About
 	form.Website = enc.Website
 	form.PartOf = enc.PartOf
+	form.TelegramUserID = enc.TelegramUserID
+	form.TimeTokenCode = enc.TimeTokenCode
+	form.TimeTokenIssuer = enc.TimeTokenIssuer
+	form.TimeTokenDesc = enc.TimeTokenDesc
+	form.TimeTokenOfferIPFS = enc.TimeTokenOfferIPFS
+	form.Relations = enc.Relations
 	form.Tags = enc.Tags
 
 	return form, nil
@@ -520,7 +768,11 @@ func DecodeOriginalCorporate(encoded string) (model.CorporateFormData, error) {
 	form.Name = enc.Name
 	form.About = enc.About
 	form.Website = enc.Website
+	form.PIIStandard = enc.PIIStandard
 	form.MyPart = enc.MyPart
+	form.TelegramPartChatID = enc.TelegramPartChatID
+	form.ContractIPFS = enc.ContractIPFS
+	form.Relations = enc.Relations
 	form.Tags = enc.Tags
 
 	return form, nil
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mtlprog/lore/internal/delegation"
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/stellar/go/keypair"
+)
+
+// ParseAccountDataToDelegation extracts mtla_delegate and mtla_c_delegate from base64-encoded account data.
+func ParseAccountDataToDelegation(accountID string, data map[string]string) model.DelegationFormData {
+	form := model.DelegationFormData{
+		AccountID:  accountID,
+		DelegateTo: strings.TrimSpace(decodeBase64(data[model.DataKeyDelegate])),
+	}
+
+	council := strings.TrimSpace(decodeBase64(data[model.DataKeyCouncilDelegate]))
+	if strings.EqualFold(council, model.CouncilReadyValue) {
+		form.CouncilReady = true
+	} else {
+		form.CouncilDelegateTo = council
+	}
+
+	return form
+}
+
+// councilDelegateValue returns the mtla_c_delegate value for the form.
+func councilDelegateValue(form model.DelegationFormData) string {
+	if form.CouncilReady {
+		return model.CouncilReadyValue
+	}
+	return form.CouncilDelegateTo
+}
+
+// ValidateDelegationForm checks delegation targets before generating XDR.
+func ValidateDelegationForm(form model.DelegationFormData) error {
+	if form.DelegateTo != "" {
+		if _, err := keypair.ParseAddress(form.DelegateTo); err != nil {
+			return fmt.Errorf("delegate must be a valid Stellar account ID")
+		}
+		if form.DelegateTo == form.AccountID {
+			return fmt.Errorf("an account cannot delegate to itself")
+		}
+	}
+
+	if form.CouncilReady && form.CouncilDelegateTo != "" {
+		return fmt.Errorf("choose either council candidacy or a council delegate, not both")
+	}
+	if form.CouncilDelegateTo != "" {
+		if _, err := keypair.ParseAddress(form.CouncilDelegateTo); err != nil {
+			return fmt.Errorf("council delegate must be a valid Stellar account ID")
+		}
+		if form.CouncilDelegateTo == form.AccountID {
+			return fmt.Errorf("an account cannot delegate its council vote to itself")
+		}
+	}
+
+	return nil
+}
+
+// GenerateDelegationXDR compares original vs current delegation settings and generates XDR.
+func (b *InitXDRBuilder) GenerateDelegationXDR(
+	original, current model.DelegationFormData,
+	sequenceNum int64,
+) ([]model.InitTransaction, error) {
+	if current.AccountID == "" {
+		return nil, fmt.Errorf("account ID required")
+	}
+
+	if _, err := keypair.ParseAddress(current.AccountID); err != nil {
+		return nil, fmt.Errorf("invalid account ID: %w", err)
+	}
+
+	if err := ValidateDelegationForm(current); err != nil {
+		return nil, err
+	}
+
+	operations, ops := b.diffSimpleFields([]dataField{
+		{model.DataKeyDelegate, original.DelegateTo, current.DelegateTo},
+		{model.DataKeyCouncilDelegate, councilDelegateValue(original), councilDelegateValue(current)},
+	})
+
+	if len(operations) == 0 {
+		return nil, fmt.Errorf("no changes to submit")
+	}
+
+	return b.buildTransactions(current.AccountID, sequenceNum, operations, ops)
+}
+
+// DelegationCheck is the outcome of simulating a delegation change against synced accounts.
+type DelegationCheck struct {
+	Chain        []string // mtla_delegate path starting at the account (the cycle only, if one is found)
+	ChainCycle   bool
+	CouncilChain []string // mtla_c_delegate path starting at the account
+	CouncilCycle bool
+	Warnings     []string
+}
+
+// CheckDelegation applies the form values to a copy of accounts and traces the resulting chains
+// the same way sync does, warning about delegations that would be ignored or break vote counting.
+func CheckDelegation(form model.DelegationFormData, accounts map[string]*delegation.Info) DelegationCheck {
+	simulated := make(map[string]*delegation.Info, len(accounts)+1)
+	for id, info := range accounts {
+		simulated[id] = info
+	}
+
+	self := &delegation.Info{AccountID: form.AccountID, CouncilReady: form.CouncilReady}
+	if existing, ok := accounts[form.AccountID]; ok {
+		self.MTLAPBalance = existing.MTLAPBalance
+	}
+	if form.DelegateTo != "" {
+		self.DelegateTo = &form.DelegateTo
+	}
+	if !form.CouncilReady && form.CouncilDelegateTo != "" {
+		self.CouncilDelegateTo = &form.CouncilDelegateTo
+	}
+	simulated[form.AccountID] = self
+
+	var check DelegationCheck
+
+	if self.MTLAPBalance.IsZero() && (self.DelegateTo != nil || self.CouncilDelegateTo != nil) {
+		check.Warnings = append(check.Warnings, "This account holds no MTLAP, so its delegation carries no votes.")
+	}
+
+	if self.DelegateTo != nil {
+		check.Chain, check.ChainCycle = delegation.TraceChain(form.AccountID, simulated)
+		if target, ok := accounts[form.DelegateTo]; !ok || target.MTLAPBalance.IsZero() {
+			check.Warnings = append(check.Warnings, fmt.Sprintf(
+				"Delegate %s holds no MTLAP: the delegation will be ignored.", shortAccountID(form.DelegateTo)))
+		}
+		if check.ChainCycle {
+			check.Warnings = append(check.Warnings, fmt.Sprintf(
+				"mtla_delegate would create a cycle: %s.", formatChain(check.Chain)))
+		}
+	}
+
+	if self.CouncilDelegateTo != nil {
+		check.CouncilChain, check.CouncilCycle = delegation.TraceCouncilChain(form.AccountID, simulated)
+		if check.CouncilCycle {
+			check.Warnings = append(check.Warnings, fmt.Sprintf(
+				"mtla_c_delegate would create a cycle: %s.", formatChain(check.CouncilChain)))
+		} else {
+			last := simulated[check.CouncilChain[len(check.CouncilChain)-1]]
+			if last == nil || !last.CouncilReady {
+				check.Warnings = append(check.Warnings,
+					"The council delegation chain does not reach a council-ready account, so the vote will not be counted.")
+			}
+		}
+	}
+
+	return check
+}
+
+// formatChain joins account IDs of a delegation path in shortened form, closing the loop for cycles.
+func formatChain(chain []string) string {
+	parts := make([]string, 0, len(chain)+1)
+	for _, id := range chain {
+		parts = append(parts, shortAccountID(id))
+	}
+	if len(chain) > 0 {
+		parts = append(parts, shortAccountID(chain[0]))
+	}
+	return strings.Join(parts, " → ")
+}
+
+// shortAccountID abbreviates an account ID the way templates display it.
+func shortAccountID(id string) string {
+	if len(id) <= 15 {
+		return id
+	}
+	return id[:6] + "..." + id[len(id)-6:]
+}
+
+// encodedDelegation is the JSON structure for delegation form data.
+type encodedDelegation struct {
+	AccountID         string `json:"a"`
+	DelegateTo        string `json:"d,omitempty"`
+	CouncilReady      bool   `json:"cr,omitempty"`
+	CouncilDelegateTo string `json:"cd,omitempty"`
+}
+
+// DecodeOriginalDelegation deserializes base64-encoded data back to DelegationFormData.
+func DecodeOriginalDelegation(encoded string) (model.DelegationFormData, error) {
+	var form model.DelegationFormData
+	if encoded == "" {
+		return form, nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return form, fmt.Errorf("invalid original data encoding")
+	}
+
+	var enc encodedDelegation
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return form, fmt.Errorf("invalid original data format: %w", err)
+	}
+
+	return model.DelegationFormData(enc), nil
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/mtlprog/lore/internal/delegation"
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAccountID3 = "GDMPIWL5AY2QK75IWO4HCMQFBS3LOX4HBYJSONOISRQSIM7HBZYRYRMH"
+
+func TestParseAccountDataToDelegation(t *testing.T) {
+	b64 := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+
+	form := ParseAccountDataToDelegation(testAccountID1, map[string]string{
+		"mtla_delegate":   b64(testAccountID2),
+		"mtla_c_delegate": b64("Ready"),
+	})
+	assert.Equal(t, model.DelegationFormData{AccountID: testAccountID1, DelegateTo: testAccountID2, CouncilReady: true}, form)
+
+	form = ParseAccountDataToDelegation(testAccountID1, map[string]string{
+		"mtla_c_delegate": b64(testAccountID3),
+	})
+	assert.Equal(t, model.DelegationFormData{AccountID: testAccountID1, CouncilDelegateTo: testAccountID3}, form)
+}
+
+func TestValidateDelegationForm(t *testing.T) {
+	tests := []struct {
+		name        string
+		form        model.DelegationFormData
+		errContains string
+	}{
+		{name: "empty form", form: model.DelegationFormData{AccountID: testAccountID1}},
+		{
+			name: "delegate and council candidacy",
+			form: model.DelegationFormData{AccountID: testAccountID1, DelegateTo: testAccountID2, CouncilReady: true},
+		},
+		{
+			name:        "invalid delegate",
+			form:        model.DelegationFormData{AccountID: testAccountID1, DelegateTo: "GINVALID"},
+			errContains: "valid Stellar account ID",
+		},
+		{
+			name:        "self delegation",
+			form:        model.DelegationFormData{AccountID: testAccountID1, CouncilDelegateTo: testAccountID1},
+			errContains: "itself",
+		},
+		{
+			name:        "ready and council delegate",
+			form:        model.DelegationFormData{AccountID: testAccountID1, CouncilReady: true, CouncilDelegateTo: testAccountID2},
+			errContains: "not both",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDelegationForm(tt.form)
+			if tt.errContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestGenerateDelegationXDR(t *testing.T) {
+	builder := NewInitXDRBuilder()
+	original := model.DelegationFormData{AccountID: testAccountID1, DelegateTo: testAccountID2, CouncilDelegateTo: testAccountID2}
+
+	t.Run("switch to council candidacy and remove delegate", func(t *testing.T) {
+		current := model.DelegationFormData{AccountID: testAccountID1, CouncilReady: true}
+
+		txs, err := builder.GenerateDelegationXDR(original, current, 1)
+		require.NoError(t, err)
+		require.Len(t, txs, 1)
+		assert.NotEmpty(t, txs[0].XDR)
+		assert.Equal(t, []model.InitOpSummary{
+			{Action: "Delete", Key: "mtla_delegate"},
+			{Action: "Set", Key: "mtla_c_delegate", Value: "ready"},
+		}, txs[0].Operations)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		_, err := builder.GenerateDelegationXDR(original, original, 1)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no changes")
+	})
+}
+
+func TestCheckDelegation(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	one := decimal.NewFromInt(1)
+
+	accounts := map[string]*delegation.Info{
+		testAccountID1: {AccountID: testAccountID1, MTLAPBalance: one},
+		testAccountID2: {AccountID: testAccountID2, MTLAPBalance: one, DelegateTo: strPtr(testAccountID1), CouncilReady: true},
+		testAccountID3: {AccountID: testAccountID3, CouncilDelegateTo: strPtr(testAccountID1)},
+	}
+
+	t.Run("valid delegation", func(t *testing.T) {
+		check := CheckDelegation(model.DelegationFormData{
+			AccountID:         testAccountID1,
+			CouncilDelegateTo: testAccountID2,
+		}, accounts)
+
+		assert.Empty(t, check.Warnings)
+		assert.Equal(t, []string{testAccountID1, testAccountID2}, check.CouncilChain)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		check := CheckDelegation(model.DelegationFormData{AccountID: testAccountID1, DelegateTo: testAccountID2}, accounts)
+
+		assert.True(t, check.ChainCycle)
+		require.Len(t, check.Warnings, 1)
+		assert.Contains(t, check.Warnings[0], "cycle")
+		assert.Nil(t, accounts[testAccountID1].DelegateTo, "input must not be modified")
+	})
+
+	t.Run("zero MTLAP delegate and unready council chain", func(t *testing.T) {
+		check := CheckDelegation(model.DelegationFormData{
+			AccountID:         testAccountID1,
+			DelegateTo:        testAccountID3,
+			CouncilDelegateTo: testAccountID3,
+		}, accounts)
+
+		assert.True(t, check.CouncilCycle)
+		require.Len(t, check.Warnings, 2)
+		assert.Contains(t, check.Warnings[0], "holds no MTLAP")
+		assert.Contains(t, check.Warnings[1], "cycle")
+	})
+
+	t.Run("council chain without ready account", func(t *testing.T) {
+		check := CheckDelegation(model.DelegationFormData{
+			AccountID:         testAccountID3,
+			CouncilDelegateTo: testAccountID1,
+		}, accounts)
+
+		require.Len(t, check.Warnings, 2)
+		assert.Contains(t, check.Warnings[0], "This account holds no MTLAP")
+		assert.Contains(t, check.Warnings[1], "council-ready")
+	})
+}
+
+func TestEncodeDecodeDelegation(t *testing.T) {
+	form := model.DelegationFormData{AccountID: testAccountID1, DelegateTo: testAccountID2, CouncilReady: true}
+
+	encoded, err := EncodeOriginalData(form)
+	require.NoError(t, err)
+
+	decoded, err := DecodeOriginalDelegation(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, form, decoded)
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/relation"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/txnbuild"
+)
+
+// uncategorizedGroup names the editor group for relation types without a display category (ratings).
+const uncategorizedGroup = "OTHER"
+
+var relationIndexPattern = regexp.MustCompile(`^[0-9]*$`)
+
+// sectionRelation returns the relation type that has its own section in the given form
+// (PartOf for participants, MyPart for corporate accounts) and is skipped by the generic editor.
+func sectionRelation(formType model.InitFormType) string {
+	if formType == model.InitFormCorporate {
+		return "MyPart"
+	}
+	return "PartOf"
+}
+
+// RelationEditorOptions returns the relation types offered by the relationship editor of a form,
+// grouped by category, and the details of every type for rendering editor rows.
+func RelationEditorOptions(types *relation.Registry, formType model.InitFormType) ([]model.RelationTypeGroup, map[string]model.RelationTypeOption) {
+	exclude := sectionRelation(formType)
+
+	var groups []model.RelationTypeGroup
+	groupIndex := make(map[string]int)
+	info := make(map[string]model.RelationTypeOption)
+
+	for _, t := range types.Types() {
+		opt := model.RelationTypeOption{
+			Name:         t.Name,
+			Label:        t.Label(),
+			Confirmation: confirmationType(t),
+		}
+		info[t.Name] = opt
+
+		if t.Name == exclude {
+			continue
+		}
+
+		groupName := t.Category
+		if groupName == "" {
+			groupName = uncategorizedGroup
+		}
+		idx, ok := groupIndex[groupName]
+		if !ok {
+			idx = len(groups)
+			groupIndex[groupName] = idx
+			groups = append(groups, model.RelationTypeGroup{Name: groupName})
+		}
+		groups[idx].Types = append(groups[idx].Types, opt)
+	}
+
+	return groups, info
+}
+
+// confirmationType returns the type the target account must declare back to confirm t,
+// or empty string if t needs no confirmation.
+func confirmationType(t relation.Type) string {
+	if !t.RequiresConfirmation {
+		return ""
+	}
+	if t.PairedWith != "" {
+		return t.PairedWith
+	}
+	return t.Name
+}
+
+// parseRelationFields extracts relations of all registered types from base64-encoded account data,
+// except the type edited in its own form section. Results follow the registry display order, then index.
+func parseRelationFields(data map[string]string, types *relation.Registry, formType model.InitFormType) []model.RelationField {
+	exclude := sectionRelation(formType)
+
+	var fields []model.RelationField
+	for key, raw := range data {
+		value := decodeBase64(raw)
+		if _, err := keypair.ParseAddress(value); err != nil {
+			continue
+		}
+
+		relType, index, ok := splitRelationKey(types, key)
+		if !ok || relType == exclude {
+			continue
+		}
+
+		fields = append(fields, model.RelationField{Type: relType, Index: index, Value: value})
+	}
+
+	order := make(map[string]int, len(types.Types()))
+	for i, t := range types.Types() {
+		order[t.Name] = i
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].Type != fields[j].Type {
+			return order[fields[i].Type] < order[fields[j].Type]
+		}
+		return fields[i].Index < fields[j].Index
+	})
+
+	return fields
+}
+
+// splitRelationKey splits a ManageData key like "Employer001" into its relation type and index.
+// The longest matching type wins, so "OwnerMajority1" is not read as "Owner".
+func splitRelationKey(types *relation.Registry, key string) (relType, index string, ok bool) {
+	for _, prefix := range types.Prefixes() {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		rest := key[len(prefix):]
+		if !relationIndexPattern.MatchString(rest) {
+			continue
+		}
+		return prefix, rest, true
+	}
+	return "", "", false
+}
+
+// ValidateRelations checks relationship editor rows before generating XDR.
+// Rows without a target are ignored, matching how empty numbered fields are treated.
+func ValidateRelations(fields []model.RelationField, types *relation.Registry, formType model.InitFormType) error {
+	exclude := sectionRelation(formType)
+	seen := make(map[string]bool)
+
+	for _, f := range fields {
+		if f.Value == "" {
+			continue
+		}
+
+		key := f.Type + f.Index
+		if !types.IsKnown(f.Type) {
+			return fmt.Errorf("unknown relation type %q", f.Type)
+		}
+		if f.Type == exclude {
+			return fmt.Errorf("%s relations are edited in their own section", exclude)
+		}
+		if !relationIndexPattern.MatchString(f.Index) {
+			return fmt.Errorf("%s: index must contain only digits", key)
+		}
+		if len(key) > maxDataKeyBytes {
+			return fmt.Errorf("%s: key is too long (max %d bytes)", key, maxDataKeyBytes)
+		}
+		if _, err := keypair.ParseAddress(f.Value); err != nil {
+			return fmt.Errorf("%s: invalid target account ID", key)
+		}
+		if seen[key] {
+			return fmt.Errorf("%s is used more than once", key)
+		}
+		seen[key] = true
+	}
+
+	return nil
+}
+
+// diffRelations compares original vs current relationship editor rows and generates operations.
+// Keys are used exactly as written, so unnumbered keys such as "Spouse" are preserved.
+func (b *InitXDRBuilder) diffRelations(original, current []model.RelationField) ([]txnbuild.Operation, []model.InitOpSummary) {
+	originalKeys := make(map[string]string)
+	for _, f := range original {
+		if f.Value != "" {
+			originalKeys[f.Type+f.Index] = f.Value
+		}
+	}
+
+	currentKeys := make(map[string]string)
+	for _, f := range current {
+		if f.Value != "" {
+			currentKeys[f.Type+f.Index] = f.Value
+		}
+	}
+
+	return b.diffDataKeys(originalKeys, currentKeys)
+}
+
+// NextRelationIndex returns the index for a new relation of the given type:
+// empty for the first one, otherwise the next 3-digit index after the highest in use.
+func NextRelationIndex(fields []model.RelationField, relType string) string {
+	found := false
+	maxNum := 0
+	for _, f := range fields {
+		if f.Type != relType {
+			continue
+		}
+		found = true
+		if num, err := strconv.Atoi(f.Index); err == nil && num > maxNum {
+			maxNum = num
+		}
+	}
+	if !found {
+		return ""
+	}
+	return fmt.Sprintf("%03d", maxNum+1)
+}
+
+// ReindexRelations renumbers every relation type that has several rows to 001, 002, ...
+// in the current row order. Types with a single row keep their key.
+func ReindexRelations(fields []model.RelationField) []model.RelationField {
+	counts := make(map[string]int)
+	for _, f := range fields {
+		counts[f.Type]++
+	}
+
+	next := make(map[string]int)
+	result := make([]model.RelationField, len(fields))
+	for i, f := range fields {
+		if counts[f.Type] > 1 {
+			next[f.Type]++
+			f.Index = fmt.Sprintf("%03d", next[f.Type])
+		}
+		result[i] = f
+	}
+	return result
+}
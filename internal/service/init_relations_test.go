@@ -0,0 +1,176 @@
+package service
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/relation/relationtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRelationFields(t *testing.T) {
+	b64 := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+
+	data := map[string]string{
+		"Name":            b64("Alice"),
+		"About":           b64(testAccountID2), // not a relation key despite the "A" prefix
+		"Spouse":          b64(testAccountID2),
+		"Employer002":     b64(testAccountID2),
+		"Employer001":     b64(testAccountID2),
+		"OwnerMajority1":  b64(testAccountID2),
+		"A":               b64(testAccountID2),
+		"PartOf001":       b64(testAccountID2),
+		"RecommendToMTLA": b64("not-an-account"),
+		"TagDeveloper":    b64(testAccountID1),
+	}
+
+	fields := parseRelationFields(data, relationtest.Registry(), model.InitFormParticipant)
+
+	assert.Equal(t, []model.RelationField{
+		{Type: "A", Index: "", Value: testAccountID2},
+		{Type: "Spouse", Index: "", Value: testAccountID2},
+		{Type: "Employer", Index: "001", Value: testAccountID2},
+		{Type: "Employer", Index: "002", Value: testAccountID2},
+		{Type: "OwnerMajority", Index: "1", Value: testAccountID2},
+	}, fields)
+
+	corporate := parseRelationFields(data, relationtest.Registry(), model.InitFormCorporate)
+	assert.Contains(t, corporate, model.RelationField{Type: "PartOf", Index: "001", Value: testAccountID2})
+}
+
+func TestValidateRelations(t *testing.T) {
+	types := relationtest.Registry()
+
+	tests := []struct {
+		name        string
+		fields      []model.RelationField
+		errContains string
+	}{
+		{
+			name:   "valid rows, empty rows ignored",
+			fields: []model.RelationField{{Type: "Spouse", Value: testAccountID2}, {Type: "Employer", Index: "1"}},
+		},
+		{
+			name:        "unknown type",
+			fields:      []model.RelationField{{Type: "Nemesis", Value: testAccountID2}},
+			errContains: "unknown relation type",
+		},
+		{
+			name:        "section type",
+			fields:      []model.RelationField{{Type: "PartOf", Index: "001", Value: testAccountID2}},
+			errContains: "own section",
+		},
+		{
+			name:        "non-numeric index",
+			fields:      []model.RelationField{{Type: "Employer", Index: "x", Value: testAccountID2}},
+			errContains: "digits",
+		},
+		{
+			name:        "invalid target",
+			fields:      []model.RelationField{{Type: "Employer", Value: "GINVALID"}},
+			errContains: "invalid target",
+		},
+		{
+			name: "duplicate key",
+			fields: []model.RelationField{
+				{Type: "Employer", Index: "1", Value: testAccountID2},
+				{Type: "Employer", Index: "1", Value: testAccountID1},
+			},
+			errContains: "more than once",
+		},
+		{
+			name:        "key too long",
+			fields:      []model.RelationField{{Type: "Employer", Index: strings.Repeat("1", 60), Value: testAccountID2}},
+			errContains: "key is too long (max 64 bytes)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRelations(tt.fields, types, model.InitFormParticipant)
+			if tt.errContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestGenerateParticipantXDR_Relations(t *testing.T) {
+	builder := NewInitXDRBuilder(WithRelationTypes(relationtest.Registry()))
+	before := model.ParticipantFormData{
+		AccountID: testAccountID1,
+		Relations: []model.RelationField{
+			{Type: "Spouse", Value: testAccountID2},
+			{Type: "Employer", Index: "001", Value: testAccountID2},
+		},
+	}
+	after := model.ParticipantFormData{
+		AccountID: testAccountID1,
+		Relations: []model.RelationField{
+			{Type: "Spouse", Value: testAccountID1},
+			{Type: "Guardian", Value: testAccountID2},
+		},
+	}
+
+	txs, err := builder.GenerateParticipantXDR(before, after, 1)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.NotEmpty(t, txs[0].XDR)
+	assert.Equal(t, []model.InitOpSummary{
+		{Action: "Delete", Key: "Employer001"},
+		{Action: "Set", Key: "Guardian", Value: testAccountID2},
+		{Action: "Set", Key: "Spouse", Value: testAccountID1},
+	}, txs[0].Operations)
+}
+
+func TestNextRelationIndex(t *testing.T) {
+	fields := []model.RelationField{
+		{Type: "Employer", Index: ""},
+		{Type: "Employer", Index: "7"},
+		{Type: "Spouse", Index: ""},
+	}
+
+	assert.Equal(t, "", NextRelationIndex(fields, "Guardian"))
+	assert.Equal(t, "001", NextRelationIndex(fields, "Spouse"))
+	assert.Equal(t, "008", NextRelationIndex(fields, "Employer"))
+}
+
+func TestReindexRelations(t *testing.T) {
+	fields := []model.RelationField{
+		{Type: "Employer", Index: "5", Value: testAccountID1},
+		{Type: "Spouse", Index: "", Value: testAccountID2},
+		{Type: "Employer", Index: "", Value: testAccountID2},
+	}
+
+	result := ReindexRelations(fields)
+
+	assert.Equal(t, []model.RelationField{
+		{Type: "Employer", Index: "001", Value: testAccountID1},
+		{Type: "Spouse", Index: "", Value: testAccountID2},
+		{Type: "Employer", Index: "002", Value: testAccountID2},
+	}, result)
+	assert.Equal(t, "5", fields[0].Index, "input must not be modified")
+}
+
+func TestRelationEditorOptions(t *testing.T) {
+	groups, info := RelationEditorOptions(relationtest.Registry(), model.InitFormCorporate)
+
+	require.NotEmpty(t, groups)
+	assert.Equal(t, uncategorizedGroup, groups[0].Name)
+	for _, g := range groups {
+		for _, opt := range g.Types {
+			assert.NotEqual(t, "MyPart", opt.Name)
+		}
+	}
+
+	assert.Equal(t, "Ward", info["Guardian"].Confirmation)
+	assert.Equal(t, "Partnership", info["Partnership"].Confirmation)
+	assert.Empty(t, info["RecommendToMTLA"].Confirmation)
+	assert.Equal(t, "My Part", info["MyPart"].Label)
+}
@@ -1,10 +1,17 @@
 package service
 
 import (
+	"encoding/base64"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/relation/relationtest"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Valid test account IDs (from Stellar SDK test fixtures)
@@ -166,11 +173,108 @@ func TestInitXDRBuilder_GenerateParticipantXDR(t *testing.T) {
 			wantErr:     true, // No valid changes
 			errContains: "no changes",
 		},
+		{
+			name:     "set identity fields",
+			original: model.ParticipantFormData{AccountID: testAccountID1},
+			current: model.ParticipantFormData{
+				AccountID:          testAccountID1,
+				TelegramUserID:     "123456789",
+				TimeTokenCode:      "STAS",
+				TimeTokenIssuer:    testAccountID2,
+				TimeTokenDesc:      "One hour of consulting",
+				TimeTokenOfferIPFS: "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG",
+			},
+			sequenceNum: 1,
+			wantOps:     5,
+		},
+		{
+			name:     "value longer than 64 bytes",
+			original: model.ParticipantFormData{AccountID: testAccountID1},
+			current: model.ParticipantFormData{
+				AccountID: testAccountID1,
+				About:     strings.Repeat("я", 33), // 66 bytes in UTF-8
+			},
+			sequenceNum: 1,
+			wantErr:     true,
+			errContains: "About is too long",
+		},
+		{
+			name:     "non-numeric Telegram user ID",
+			original: model.ParticipantFormData{AccountID: testAccountID1},
+			current: model.ParticipantFormData{
+				AccountID:      testAccountID1,
+				TelegramUserID: "@username",
+			},
+			sequenceNum: 1,
+			wantErr:     true,
+			errContains: "TelegramUserID",
+		},
+		{
+			name:     "invalid time token issuer",
+			original: model.ParticipantFormData{AccountID: testAccountID1},
+			current: model.ParticipantFormData{
+				AccountID:       testAccountID1,
+				TimeTokenCode:   "STAS",
+				TimeTokenIssuer: "invalid",
+			},
+			sequenceNum: 1,
+			wantErr:     true,
+			errContains: "TimeTokenIssuer",
+		},
+		{
+			name:     "invalid offer hash",
+			original: model.ParticipantFormData{AccountID: testAccountID1},
+			current: model.ParticipantFormData{
+				AccountID:          testAccountID1,
+				TimeTokenOfferIPFS: "https://example.com/offer",
+			},
+			sequenceNum: 1,
+			wantErr:     true,
+			errContains: "TimeTokenOfferIPFS",
+		},
+		{
+			name: "unchanged legacy value does not block other edits",
+			original: model.ParticipantFormData{
+				AccountID:      testAccountID1,
+				TelegramUserID: "@username",
+			},
+			current: model.ParticipantFormData{
+				AccountID:      testAccountID1,
+				Name:           "Alice",
+				TelegramUserID: "@username",
+			},
+			sequenceNum: 1,
+			wantOps:     1,
+		},
+		{
+			name: "delete legacy value",
+			original: model.ParticipantFormData{
+				AccountID:      testAccountID1,
+				TelegramUserID: "@username",
+			},
+			current:     model.ParticipantFormData{AccountID: testAccountID1},
+			sequenceNum: 1,
+			wantOps:     1,
+		},
+		{
+			name: "changed legacy value is validated",
+			original: model.ParticipantFormData{
+				AccountID:      testAccountID1,
+				TelegramUserID: "@username",
+			},
+			current: model.ParticipantFormData{
+				AccountID:      testAccountID1,
+				TelegramUserID: "@other",
+			},
+			sequenceNum: 1,
+			wantErr:     true,
+			errContains: "TelegramUserID",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			xdr, ops, err := builder.GenerateParticipantXDR(tt.original, tt.current, tt.sequenceNum)
+			txs, err := builder.GenerateParticipantXDR(tt.original, tt.current, tt.sequenceNum)
 
 			if tt.wantErr {
 				if err == nil {
@@ -188,11 +292,15 @@ func TestInitXDRBuilder_GenerateParticipantXDR(t *testing.T) {
 				return
 			}
 
-			if len(ops) != tt.wantOps {
-				t.Errorf("got %d operations, want %d", len(ops), tt.wantOps)
+			if len(txs) != 1 {
+				t.Fatalf("got %d transactions, want 1", len(txs))
+			}
+
+			if len(txs[0].Operations) != tt.wantOps {
+				t.Errorf("got %d operations, want %d", len(txs[0].Operations), tt.wantOps)
 			}
 
-			if xdr == "" {
+			if txs[0].XDR == "" {
 				t.Error("expected non-empty XDR")
 			}
 		})
@@ -232,11 +340,76 @@ func TestInitXDRBuilder_GenerateCorporateXDR(t *testing.T) {
 			sequenceNum: 1,
 			wantOps:     1,
 		},
+		{
+			name:     "set PII standard flag",
+			original: model.CorporateFormData{AccountID: testAccountID1},
+			current: model.CorporateFormData{
+				AccountID:   testAccountID1,
+				PIIStandard: true,
+			},
+			sequenceNum: 1,
+			wantOps:     1,
+		},
+		{
+			name: "clear PII standard flag",
+			original: model.CorporateFormData{
+				AccountID:   testAccountID1,
+				PIIStandard: true,
+			},
+			current:     model.CorporateFormData{AccountID: testAccountID1},
+			sequenceNum: 1,
+			wantOps:     1,
+		},
+		{
+			name:     "set chat and contract",
+			original: model.CorporateFormData{AccountID: testAccountID1},
+			current: model.CorporateFormData{
+				AccountID:          testAccountID1,
+				TelegramPartChatID: "-1001234567890",
+				ContractIPFS:       "ipfs://QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG",
+			},
+			sequenceNum: 1,
+			wantOps:     2,
+		},
+		{
+			name:     "invalid chat ID",
+			original: model.CorporateFormData{AccountID: testAccountID1},
+			current: model.CorporateFormData{
+				AccountID:          testAccountID1,
+				TelegramPartChatID: "t.me/chat",
+			},
+			sequenceNum: 1,
+			wantErr:     true,
+		},
+		{
+			name: "unchanged legacy contract does not block other edits",
+			original: model.CorporateFormData{
+				AccountID:    testAccountID1,
+				ContractIPFS: "https://example.com/contract.pdf",
+			},
+			current: model.CorporateFormData{
+				AccountID:    testAccountID1,
+				Name:         "Company Inc.",
+				ContractIPFS: "https://example.com/contract.pdf",
+			},
+			sequenceNum: 1,
+			wantOps:     1,
+		},
+		{
+			name:     "invalid contract hash",
+			original: model.CorporateFormData{AccountID: testAccountID1},
+			current: model.CorporateFormData{
+				AccountID:    testAccountID1,
+				ContractIPFS: "ipfs://Qm/../contract",
+			},
+			sequenceNum: 1,
+			wantErr:     true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			xdr, ops, err := builder.GenerateCorporateXDR(tt.original, tt.current, tt.sequenceNum)
+			txs, err := builder.GenerateCorporateXDR(tt.original, tt.current, tt.sequenceNum)
 
 			if tt.wantErr {
 				if err == nil {
@@ -250,11 +423,15 @@ func TestInitXDRBuilder_GenerateCorporateXDR(t *testing.T) {
 				return
 			}
 
-			if len(ops) != tt.wantOps {
-				t.Errorf("got %d operations, want %d", len(ops), tt.wantOps)
+			if len(txs) != 1 {
+				t.Fatalf("got %d transactions, want 1", len(txs))
+			}
+
+			if len(txs[0].Operations) != tt.wantOps {
+				t.Errorf("got %d operations, want %d", len(txs[0].Operations), tt.wantOps)
 			}
 
-			if xdr == "" {
+			if txs[0].XDR == "" {
 				t.Error("expected non-empty XDR")
 			}
 		})
@@ -333,7 +510,12 @@ func TestEncodeDecodeParticipant(t *testing.T) {
 		PartOf: []model.NumberedField{
 			{Index: "001", Value: testAccountID2},
 		},
-		Tags: []string{"Developer", "Crypto"},
+		TelegramUserID:     "123456789",
+		TimeTokenCode:      "STAS",
+		TimeTokenIssuer:    testAccountID2,
+		TimeTokenDesc:      "Consulting",
+		TimeTokenOfferIPFS: "QmOffer",
+		Tags:               []string{"Developer", "Crypto"},
 	}
 
 	encoded, err := EncodeOriginalData(original)
@@ -364,18 +546,30 @@ func TestEncodeDecodeParticipant(t *testing.T) {
 	if len(decoded.Tags) != len(original.Tags) {
 		t.Errorf("Tags length mismatch: got %d, want %d", len(decoded.Tags), len(original.Tags))
 	}
+	if decoded.TelegramUserID != original.TelegramUserID {
+		t.Errorf("TelegramUserID mismatch: got %q, want %q", decoded.TelegramUserID, original.TelegramUserID)
+	}
+	if decoded.TimeTokenIssuer != original.TimeTokenIssuer {
+		t.Errorf("TimeTokenIssuer mismatch: got %q, want %q", decoded.TimeTokenIssuer, original.TimeTokenIssuer)
+	}
+	if decoded.TimeTokenOfferIPFS != original.TimeTokenOfferIPFS {
+		t.Errorf("TimeTokenOfferIPFS mismatch: got %q, want %q", decoded.TimeTokenOfferIPFS, original.TimeTokenOfferIPFS)
+	}
 }
 
 func TestEncodeDecodeCorporate(t *testing.T) {
 	original := model.CorporateFormData{
-		AccountID: testAccountID1,
-		Name:      "Company Inc.",
-		About:     "About company",
-		Website:   "https://company.com",
+		AccountID:   testAccountID1,
+		Name:        "Company Inc.",
+		About:       "About company",
+		Website:     "https://company.com",
+		PIIStandard: true,
 		MyPart: []model.NumberedField{
 			{Index: "001", Value: testAccountID2},
 		},
-		Tags: []string{"Business"},
+		TelegramPartChatID: "-1001234567890",
+		ContractIPFS:       "QmContract",
+		Tags:               []string{"Business"},
 	}
 
 	encoded, err := EncodeOriginalData(original)
@@ -394,6 +588,53 @@ func TestEncodeDecodeCorporate(t *testing.T) {
 	if decoded.Name != original.Name {
 		t.Errorf("Name mismatch: got %q, want %q", decoded.Name, original.Name)
 	}
+	if decoded.PIIStandard != original.PIIStandard {
+		t.Errorf("PIIStandard mismatch: got %v, want %v", decoded.PIIStandard, original.PIIStandard)
+	}
+	if decoded.TelegramPartChatID != original.TelegramPartChatID {
+		t.Errorf("TelegramPartChatID mismatch: got %q, want %q", decoded.TelegramPartChatID, original.TelegramPartChatID)
+	}
+	if decoded.ContractIPFS != original.ContractIPFS {
+		t.Errorf("ContractIPFS mismatch: got %q, want %q", decoded.ContractIPFS, original.ContractIPFS)
+	}
+}
+
+func TestParseAccountDataIdentityFields(t *testing.T) {
+	b64 := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+
+	data := map[string]string{
+		"Name":                          b64("Alice"),
+		model.DataKeyTelegramUserID:     b64("123456789"),
+		model.DataKeyTimeTokenCode:      b64("STAS"),
+		model.DataKeyTimeTokenIssuer:    b64(testAccountID2),
+		model.DataKeyTelegramPartChatID: b64("-1001234567890"),
+		model.DataKeyContractIPFS:       b64("QmContract"),
+		model.DataKeyPIIStandard:        b64("1"),
+	}
+
+	participant := ParseAccountDataToParticipant(testAccountID1, data, relationtest.Registry())
+	if participant.TelegramUserID != "123456789" {
+		t.Errorf("TelegramUserID = %q, want %q", participant.TelegramUserID, "123456789")
+	}
+	if participant.TimeTokenCode != "STAS" || participant.TimeTokenIssuer != testAccountID2 {
+		t.Errorf("time token = %q/%q, want STAS/%s", participant.TimeTokenCode, participant.TimeTokenIssuer, testAccountID2)
+	}
+
+	corporate := ParseAccountDataToCorporate(testAccountID1, data, relationtest.Registry())
+	if !corporate.PIIStandard {
+		t.Error("expected PIIStandard to be set when the key is present")
+	}
+	if corporate.TelegramPartChatID != "-1001234567890" {
+		t.Errorf("TelegramPartChatID = %q, want %q", corporate.TelegramPartChatID, "-1001234567890")
+	}
+	if corporate.ContractIPFS != "QmContract" {
+		t.Errorf("ContractIPFS = %q, want %q", corporate.ContractIPFS, "QmContract")
+	}
+
+	delete(data, model.DataKeyPIIStandard)
+	if ParseAccountDataToCorporate(testAccountID1, data, relationtest.Registry()).PIIStandard {
+		t.Error("expected PIIStandard to be unset when the key is absent")
+	}
 }
 
 func TestValidateAccountID(t *testing.T) {
@@ -433,3 +674,64 @@ func TestValidateAccountID(t *testing.T) {
 		})
 	}
 }
+
+func TestInitXDRBuilder_BuildTransactions(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	builder := NewInitXDRBuilder(WithBaseFee(500), WithValidity(time.Hour))
+	builder.now = func() time.Time { return now }
+
+	ops := make([]txnbuild.Operation, 0, 150)
+	summaries := make([]model.InitOpSummary, 0, 150)
+	for i := range 150 {
+		op, summary := builder.manageDataOp(fmt.Sprintf("Tag%03d", i), testAccountID1)
+		ops = append(ops, op)
+		summaries = append(summaries, summary)
+	}
+
+	txs, err := builder.buildTransactions(testAccountID1, 11, ops, summaries)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+
+	for i, want := range []struct {
+		sequence int64
+		ops      int
+		maxFee   string
+	}{
+		{11, 100, "0.0050000"},
+		{12, 50, "0.0025000"},
+	} {
+		assert.Equal(t, want.sequence, txs[i].Sequence)
+		assert.Len(t, txs[i].Operations, want.ops)
+		assert.Equal(t, want.maxFee, txs[i].MaxFee)
+		assert.Equal(t, "2026-03-01 13:00:00", txs[i].ValidBefore)
+
+		decoded, err := DecodeEnvelope(txs[i].XDR, relationtest.Registry())
+		require.NoError(t, err)
+		assert.Equal(t, want.sequence, decoded.Sequence)
+		assert.Len(t, decoded.Operations, want.ops)
+		assert.Equal(t, "2026-03-01 13:00:00", decoded.ValidBefore)
+	}
+	assert.Equal(t, "Tag100", txs[1].Operations[0].Key)
+}
+
+func TestInitXDRBuilder_Options(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		b := NewInitXDRBuilder()
+		assert.Equal(t, int64(txnbuild.MinBaseFee), b.baseFee)
+		assert.Equal(t, DefaultTxValidity, b.validity)
+	})
+
+	t.Run("base fee below minimum is ignored", func(t *testing.T) {
+		assert.Equal(t, int64(txnbuild.MinBaseFee), NewInitXDRBuilder(WithBaseFee(10)).baseFee)
+	})
+
+	t.Run("zero validity leaves transactions unbounded", func(t *testing.T) {
+		b := NewInitXDRBuilder(WithValidity(0))
+		op, summary := b.manageDataOp("Name", "Alice")
+
+		txs, err := b.buildTransactions(testAccountID1, 1, []txnbuild.Operation{op}, []model.InitOpSummary{summary})
+		require.NoError(t, err)
+		require.Len(t, txs, 1)
+		assert.Empty(t, txs[0].ValidBefore)
+	})
+}
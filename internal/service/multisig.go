@@ -0,0 +1,243 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/xdr"
+)
+
+// signerTypeEd25519 is the Horizon type of signers that are account keys.
+const signerTypeEd25519 = "ed25519_public_key"
+
+// ErrTransactionMismatch is returned when an uploaded envelope is a different transaction
+// than the one whose signatures are being collected.
+var ErrTransactionMismatch = errors.New("envelope is a different transaction")
+
+// GetSigningRequirement fetches the signers and medium threshold of an account.
+func (s *StellarService) GetSigningRequirement(ctx context.Context, accountID string) (*model.SigningRequirement, error) {
+	acc, err := s.client.AccountDetail(horizonclient.AccountRequest{AccountID: accountID})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSigningRequirement(accountID, parseSigners(acc.Signers), int32(acc.Thresholds.MedThreshold)), nil
+}
+
+// parseSigners converts Horizon signers, dropping those without weight.
+func parseSigners(signers []horizon.Signer) []model.Signer {
+	result := make([]model.Signer, 0, len(signers))
+	for _, signer := range signers {
+		if signer.Weight > 0 {
+			result = append(result, model.Signer{Key: signer.Key, Type: signer.Type, Weight: signer.Weight})
+		}
+	}
+	return result
+}
+
+// NewSigningRequirement computes who has to sign to reach the medium threshold.
+// Signers without weight are dropped; a threshold of 0 still needs one signature.
+func NewSigningRequirement(accountID string, signers []model.Signer, mediumThreshold int32) *model.SigningRequirement {
+	req := &model.SigningRequirement{
+		AccountID: accountID,
+		Threshold: max(mediumThreshold, 1),
+	}
+
+	for _, signer := range signers {
+		if signer.Weight > 0 {
+			req.Signers = append(req.Signers, signer)
+			req.TotalWeight += signer.Weight
+		}
+	}
+	sort.SliceStable(req.Signers, func(i, j int) bool {
+		if req.Signers[i].Weight != req.Signers[j].Weight {
+			return req.Signers[i].Weight > req.Signers[j].Weight
+		}
+		return req.Signers[i].Key < req.Signers[j].Key
+	})
+
+	if !req.Reachable() {
+		return req
+	}
+
+	// Heaviest signers first give the smallest quorum
+	var weight int32
+	for i, signer := range req.Signers {
+		weight += signer.Weight
+		if weight >= req.Threshold {
+			req.MinSignatures = i + 1
+			break
+		}
+	}
+
+	for _, signer := range req.Signers {
+		if req.TotalWeight-signer.Weight < req.Threshold {
+			req.Required = append(req.Required, signer.Key)
+		}
+	}
+
+	return req
+}
+
+// MarkSigned sets which signers of req have a valid signature on the envelope and the
+// weight they add up to. Only account keys are checked; hash and pre-auth signers never
+// appear as signatures.
+func MarkSigned(req *model.SigningRequirement, envelope string) error {
+	tx, err := parseTransaction(envelope)
+	if err != nil {
+		return err
+	}
+	hash, err := tx.Hash(network.PublicNetworkPassphrase)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+
+	req.SignedWeight = 0
+	for i := range req.Signers {
+		signer := &req.Signers[i]
+		signer.Signed = signedBy(tx.Signatures(), hash[:], *signer)
+		if signer.Signed {
+			req.SignedWeight += signer.Weight
+		}
+	}
+	return nil
+}
+
+// MergeSignatures adds the signatures of signedXDR that belong to one of the signers to
+// envelope. Both must be the same transaction. It returns the merged envelope and the
+// number of signatures added; invalid and duplicate signatures are skipped.
+func MergeSignatures(envelope, signedXDR string, signers []model.Signer) (string, int, error) {
+	base, err := parseTransaction(envelope)
+	if err != nil {
+		return "", 0, fmt.Errorf("collected transaction: %w", err)
+	}
+	signed, err := parseTransaction(signedXDR)
+	if err != nil {
+		return "", 0, err
+	}
+
+	hash, err := base.Hash(network.PublicNetworkPassphrase)
+	if err != nil {
+		return "", 0, fmt.Errorf("collected transaction: %w", err)
+	}
+	signedHash, err := signed.Hash(network.PublicNetworkPassphrase)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	if hash != signedHash {
+		return "", 0, ErrTransactionMismatch
+	}
+
+	var added []xdr.DecoratedSignature
+	present := base.Signatures()
+	for _, sig := range signed.Signatures() {
+		if containsSignature(present, sig) {
+			continue
+		}
+		for _, signer := range signers {
+			if signedBy([]xdr.DecoratedSignature{sig}, hash[:], signer) {
+				added = append(added, sig)
+				present = append(present, sig)
+				break
+			}
+		}
+	}
+	if len(added) == 0 {
+		return envelope, 0, nil
+	}
+
+	merged, err := base.AddSignatureDecorated(added...)
+	if err != nil {
+		return "", 0, fmt.Errorf("add signatures: %w", err)
+	}
+	encoded, err := merged.Base64()
+	if err != nil {
+		return "", 0, fmt.Errorf("encode XDR: %w", err)
+	}
+	return encoded, len(added), nil
+}
+
+// signedBy reports whether one of the signatures is a valid signature of signer over hash.
+func signedBy(signatures []xdr.DecoratedSignature, hash []byte, signer model.Signer) bool {
+	if signer.Type != "" && signer.Type != signerTypeEd25519 {
+		return false
+	}
+	kp, err := keypair.ParseAddress(signer.Key)
+	if err != nil {
+		return false
+	}
+	hint := kp.Hint()
+	for _, sig := range signatures {
+		if sig.Hint == xdr.SignatureHint(hint) && kp.Verify(hash, sig.Signature) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// containsSignature reports whether sig is already among signatures.
+func containsSignature(signatures []xdr.DecoratedSignature, sig xdr.DecoratedSignature) bool {
+	for _, s := range signatures {
+		if s.Hint == sig.Hint && bytes.Equal(s.Signature, sig.Signature) {
+			return true
+		}
+	}
+	return false
+}
+
+// newCollectionID returns a random identifier for a signature collection page.
+// It is all that is needed to view and extend the collection, so it must be unguessable.
+func newCollectionID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("generate collection ID: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// NewSignatureCollection starts a signature collection for an init transaction.
+// Signatures of the envelope are dropped; add them with MergeSignatures so only
+// signatures of the account's signers are kept.
+func NewSignatureCollection(envelope string) (*model.SignatureCollection, error) {
+	tx, err := parseTransaction(envelope)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateInitOperations(tx); err != nil {
+		return nil, err
+	}
+
+	hash, err := tx.HashHex(network.PublicNetworkPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	unsigned, err := tx.ClearSignatures()
+	if err != nil {
+		return nil, fmt.Errorf("clear signatures: %w", err)
+	}
+	encoded, err := unsigned.Base64()
+	if err != nil {
+		return nil, fmt.Errorf("encode XDR: %w", err)
+	}
+	id, err := newCollectionID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SignatureCollection{
+		ID:        id,
+		AccountID: tx.SourceAccount().AccountID,
+		TxHash:    hash,
+		Envelope:  encoded,
+	}, nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSigningRequirement(t *testing.T) {
+	tests := []struct {
+		name          string
+		signers       []model.Signer
+		threshold     int32
+		wantThreshold int32
+		wantKeys      []string
+		wantMin       int
+		wantRequired  []string
+	}{
+		{
+			name:          "single key with zero threshold",
+			signers:       []model.Signer{{Key: "A", Weight: 1}},
+			threshold:     0,
+			wantThreshold: 1,
+			wantKeys:      []string{"A"},
+			wantMin:       1,
+			wantRequired:  []string{"A"},
+		},
+		{
+			name:          "2 of 3",
+			signers:       []model.Signer{{Key: "C", Weight: 1}, {Key: "A", Weight: 1}, {Key: "B", Weight: 1}},
+			threshold:     2,
+			wantThreshold: 2,
+			wantKeys:      []string{"A", "B", "C"},
+			wantMin:       2,
+		},
+		{
+			name:          "disabled master key and heavy director",
+			signers:       []model.Signer{{Key: "M", Weight: 0}, {Key: "B", Weight: 1}, {Key: "D", Weight: 3}, {Key: "C", Weight: 1}},
+			threshold:     4,
+			wantThreshold: 4,
+			wantKeys:      []string{"D", "B", "C"},
+			wantMin:       2,
+			wantRequired:  []string{"D"},
+		},
+		{
+			name:          "unreachable",
+			signers:       []model.Signer{{Key: "A", Weight: 1}, {Key: "B", Weight: 1}},
+			threshold:     5,
+			wantThreshold: 5,
+			wantKeys:      []string{"A", "B"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := NewSigningRequirement(testAccountID1, tt.signers, tt.threshold)
+
+			keys := make([]string, 0, len(req.Signers))
+			for _, s := range req.Signers {
+				keys = append(keys, s.Key)
+			}
+			assert.Equal(t, tt.wantThreshold, req.Threshold)
+			assert.Equal(t, tt.wantKeys, keys)
+			assert.Equal(t, tt.wantMin, req.MinSignatures)
+			assert.Equal(t, tt.wantRequired, req.Required)
+			assert.Equal(t, tt.wantMin > 0, req.Reachable())
+		})
+	}
+}
+
+func TestMergeSignatures(t *testing.T) {
+	company := keypair.MustRandom()
+	alice, bob, stranger := keypair.MustRandom(), keypair.MustRandom(), keypair.MustRandom()
+	signers := []model.Signer{
+		{Key: alice.Address(), Type: "ed25519_public_key", Weight: 1},
+		{Key: bob.Address(), Type: "ed25519_public_key", Weight: 1},
+	}
+
+	tx := buildTestEnvelope(t, company.Address(), &txnbuild.ManageData{Name: "Name", Value: []byte("Acme")})
+	unsigned, err := tx.Base64()
+	require.NoError(t, err)
+
+	c, err := NewSignatureCollection(signXDR(t, unsigned, stranger))
+	require.NoError(t, err)
+	assert.Equal(t, company.Address(), c.AccountID)
+	assert.Len(t, c.ID, 32)
+	assert.Equal(t, unsigned, c.Envelope, "signatures are dropped when a collection starts")
+
+	// Alice's signature and one by someone who is not a signer
+	byAlice := signXDR(t, signXDR(t, unsigned, alice), stranger)
+	merged, added, err := MergeSignatures(c.Envelope, byAlice, signers)
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+
+	// Uploading the same signature again adds nothing
+	again, added, err := MergeSignatures(merged, byAlice, signers)
+	require.NoError(t, err)
+	assert.Equal(t, 0, added)
+	assert.Equal(t, merged, again)
+
+	merged, added, err = MergeSignatures(merged, signXDR(t, unsigned, bob), signers)
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+
+	req := NewSigningRequirement(company.Address(), signers, 2)
+	require.NoError(t, MarkSigned(req, merged))
+	assert.Equal(t, int32(2), req.SignedWeight)
+	assert.True(t, req.Met())
+
+	t.Run("different transaction", func(t *testing.T) {
+		other := buildTestEnvelope(t, company.Address(), &txnbuild.ManageData{Name: "Name", Value: []byte("Other")})
+		otherXDR, err := other.Base64()
+		require.NoError(t, err)
+
+		_, _, err = MergeSignatures(c.Envelope, signXDR(t, otherXDR, alice), signers)
+		assert.ErrorIs(t, err, ErrTransactionMismatch)
+	})
+
+	t.Run("collection only accepts init transactions", func(t *testing.T) {
+		payment := buildTestEnvelope(t, company.Address(),
+			&txnbuild.Payment{Destination: testAccountID2, Amount: "1", Asset: txnbuild.NativeAsset{}})
+		paymentXDR, err := payment.Base64()
+		require.NoError(t, err)
+
+		_, err = NewSignatureCollection(paymentXDR)
+		assert.ErrorIs(t, err, ErrInvalidEnvelope)
+	})
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/skip2/go-qrcode"
+	"github.com/stellar/go/keypair"
+)
+
+const (
+	// sep7Scheme is the SEP-0007 transaction URI prefix.
+	sep7Scheme = "web+stellar:tx?"
+
+	// sep7SignatureMarker follows the 36-byte prefix in the signed payload.
+	sep7SignatureMarker = "stellar.sep.7 - URI Scheme"
+
+	// maxSEP7MessageLength is the maximum msg length allowed by SEP-0007.
+	maxSEP7MessageLength = 300
+
+	// qrCodeSize is the width of rendered QR codes in pixels.
+	qrCodeSize = 384
+)
+
+// TxURIConfig configures SEP-0007 transaction URIs.
+// All fields are optional; without OriginDomain the URIs are unsigned.
+type TxURIConfig struct {
+	OriginDomain string // Domain whose stellar.toml publishes URI_REQUEST_SIGNING_KEY
+	SigningSeed  string // Secret seed of URI_REQUEST_SIGNING_KEY
+	Callback     string // URL the wallet POSTs the signed XDR to instead of submitting it
+}
+
+// TxURIBuilder builds SEP-0007 web+stellar:tx URIs for wallet handoff.
+type TxURIBuilder struct {
+	originDomain string
+	callback     string
+	signer       *keypair.Full
+}
+
+// NewTxURIBuilder creates a URI builder. Origin domain and signing seed must be set together,
+// because SEP-0007 requires a signature whenever origin_domain is present.
+func NewTxURIBuilder(cfg TxURIConfig) (*TxURIBuilder, error) {
+	b := &TxURIBuilder{originDomain: cfg.OriginDomain}
+
+	if (cfg.OriginDomain == "") != (cfg.SigningSeed == "") {
+		return nil, errors.New("origin domain and signing seed must be configured together")
+	}
+	if cfg.OriginDomain != "" {
+		if strings.ContainsAny(cfg.OriginDomain, "/:") {
+			return nil, fmt.Errorf("origin domain must be a bare domain name, got %q", cfg.OriginDomain)
+		}
+		signer, err := keypair.ParseFull(cfg.SigningSeed)
+		if err != nil {
+			return nil, fmt.Errorf("parse signing seed: %w", err)
+		}
+		b.signer = signer
+	}
+
+	if cfg.Callback != "" {
+		u, err := url.Parse(cfg.Callback)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return nil, fmt.Errorf("callback must be an https URL, got %q", cfg.Callback)
+		}
+		b.callback = cfg.Callback
+	}
+
+	return b, nil
+}
+
+// SigningKey returns the public URI_REQUEST_SIGNING_KEY, or empty string if URIs are unsigned.
+func (b *TxURIBuilder) SigningKey() string {
+	if b.signer == nil {
+		return ""
+	}
+	return b.signer.Address()
+}
+
+// TxURI builds a web+stellar:tx URI for the given transaction envelope.
+// msg is shown by the wallet and is limited to 300 characters.
+func (b *TxURIBuilder) TxURI(xdr, msg string) (string, error) {
+	if xdr == "" {
+		return "", errors.New("transaction XDR is required")
+	}
+	if utf8.RuneCountInString(msg) > maxSEP7MessageLength {
+		return "", fmt.Errorf("message is too long (max %d characters)", maxSEP7MessageLength)
+	}
+
+	params := []string{"xdr=" + sep7Escape(xdr)}
+	if b.callback != "" {
+		params = append(params, "callback="+sep7Escape("url:"+b.callback))
+	}
+	if msg != "" {
+		params = append(params, "msg="+sep7Escape(msg))
+	}
+	if b.originDomain != "" {
+		params = append(params, "origin_domain="+sep7Escape(b.originDomain))
+	}
+
+	uri := sep7Scheme + strings.Join(params, "&")
+	if b.signer == nil {
+		return uri, nil
+	}
+
+	// Payload: 35 zero bytes, byte 4, the marker, then the URI without the signature.
+	payload := make([]byte, 36, 36+len(sep7SignatureMarker)+len(uri))
+	payload[35] = 4
+	payload = append(payload, sep7SignatureMarker+uri...)
+
+	signature, err := b.signer.Sign(payload)
+	if err != nil {
+		return "", fmt.Errorf("sign URI: %w", err)
+	}
+	return uri + "&signature=" + sep7Escape(base64.StdEncoding.EncodeToString(signature)), nil
+}
+
+// sep7Escape percent-encodes a URI parameter value, encoding spaces as %20.
+func sep7Escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// QRCodePNG renders content as a PNG QR code and returns it base64-encoded.
+// Large transactions may not fit into a QR code, in which case an error is returned.
+func QRCodePNG(content string) (string, error) {
+	png, err := qrcode.Encode(content, qrcode.Low, qrCodeSize)
+	if err != nil {
+		return "", fmt.Errorf("encode QR code: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(png), nil
+}
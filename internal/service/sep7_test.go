@@ -0,0 +1,104 @@
+package service
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTxURIBuilder(t *testing.T) {
+	seed := keypair.MustRandom().Seed()
+
+	tests := []struct {
+		name        string
+		cfg         TxURIConfig
+		errContains string
+	}{
+		{name: "unsigned", cfg: TxURIConfig{}},
+		{name: "signed with callback", cfg: TxURIConfig{OriginDomain: "lore.example.org", SigningSeed: seed, Callback: "https://lore.example.org/init/submit"}},
+		{name: "domain without seed", cfg: TxURIConfig{OriginDomain: "lore.example.org"}, errContains: "together"},
+		{name: "seed without domain", cfg: TxURIConfig{SigningSeed: seed}, errContains: "together"},
+		{name: "domain with scheme", cfg: TxURIConfig{OriginDomain: "https://lore.example.org", SigningSeed: seed}, errContains: "bare domain"},
+		{name: "public key instead of seed", cfg: TxURIConfig{OriginDomain: "lore.example.org", SigningSeed: testAccountID1}, errContains: "signing seed"},
+		{name: "plain http callback", cfg: TxURIConfig{Callback: "http://lore.example.org/submit"}, errContains: "https"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTxURIBuilder(tt.cfg)
+			if tt.errContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestTxURI(t *testing.T) {
+	const xdr = "AAAAAgAAAAB+/abc=="
+
+	t.Run("unsigned", func(t *testing.T) {
+		b, err := NewTxURIBuilder(TxURIConfig{})
+		require.NoError(t, err)
+		assert.Empty(t, b.SigningKey())
+
+		uri, err := b.TxURI(xdr, "Update data")
+		require.NoError(t, err)
+		assert.Equal(t, "web+stellar:tx?xdr=AAAAAgAAAAB%2B%2Fabc%3D%3D&msg=Update%20data", uri)
+	})
+
+	t.Run("signed URI verifies against the signing key", func(t *testing.T) {
+		kp := keypair.MustRandom()
+		b, err := NewTxURIBuilder(TxURIConfig{
+			OriginDomain: "lore.example.org",
+			SigningSeed:  kp.Seed(),
+			Callback:     "https://lore.example.org/init/submit",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, kp.Address(), b.SigningKey())
+
+		uri, err := b.TxURI(xdr, "Update data")
+		require.NoError(t, err)
+
+		unsigned, sigParam, found := strings.Cut(uri, "&signature=")
+		require.True(t, found)
+		assert.Contains(t, unsigned, "&callback=url%3Ahttps%3A%2F%2Flore.example.org%2Finit%2Fsubmit")
+		assert.Contains(t, unsigned, "&origin_domain=lore.example.org")
+
+		sigB64, err := url.QueryUnescape(sigParam)
+		require.NoError(t, err)
+		signature, err := base64.StdEncoding.DecodeString(sigB64)
+		require.NoError(t, err)
+
+		payload := append(make([]byte, 35), 4)
+		payload = append(payload, "stellar.sep.7 - URI Scheme"+unsigned...)
+		assert.NoError(t, kp.Verify(payload, signature))
+	})
+
+	t.Run("message too long", func(t *testing.T) {
+		b, err := NewTxURIBuilder(TxURIConfig{})
+		require.NoError(t, err)
+
+		_, err = b.TxURI(xdr, strings.Repeat("m", 301))
+		assert.Error(t, err)
+	})
+}
+
+func TestQRCodePNG(t *testing.T) {
+	encoded, err := QRCodePNG("web+stellar:tx?xdr=AAAA")
+	require.NoError(t, err)
+
+	png, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, "\x89PNG", string(png[:4]))
+
+	_, err = QRCodePNG(strings.Repeat("A", 5000))
+	assert.Error(t, err, "content over QR capacity must fail")
+}
@@ -85,6 +85,7 @@ type StellarService struct {
 	httpClient *http.Client // Shared HTTP client for external requests
 	nftCache   *cache       // Caches NFT metadata (1 hour TTL)
 	tomlCache  *cache       // Caches stellar.toml content (1 hour TTL)
+	feeCache   *cache       // Caches Horizon fee_stats (feeStatsTTL)
 }
 
 // NewStellarService creates a new Stellar service with the given Horizon URL.
@@ -101,6 +102,7 @@ func NewStellarService(horizonURL string) *StellarService {
 		},
 		nftCache:  newCache(1 * time.Hour),
 		tomlCache: newCache(1 * time.Hour),
+		feeCache:  newCache(feeStatsTTL),
 	}
 }
 
@@ -212,6 +214,13 @@ func (s *StellarService) GetAccountDetail(ctx context.Context, accountID string)
 		Websites:   websites,
 		Tags:       tags,
 		Trustlines: trustlines,
+		Identity:   parseIdentity(acc.Data),
+		Signers:    parseSigners(acc.Signers),
+		Thresholds: model.Thresholds{
+			Low:  acc.Thresholds.LowThreshold,
+			Med:  acc.Thresholds.MedThreshold,
+			High: acc.Thresholds.HighThreshold,
+		},
 	}, nil
 }
 
@@ -234,6 +243,23 @@ func findAssetBalance(balances []horizon.Balance, code, issuer string) string {
 	return "0"
 }
 
+// parseIdentity extracts the identity profile fields from base64-encoded account data.
+func parseIdentity(data map[string]string) model.Identity {
+	identity := model.Identity{
+		TelegramUserID:     decodeBase64(data[model.DataKeyTelegramUserID]),
+		TimeTokenCode:      decodeBase64(data[model.DataKeyTimeTokenCode]),
+		TimeTokenIssuer:    decodeBase64(data[model.DataKeyTimeTokenIssuer]),
+		TimeTokenDesc:      decodeBase64(data[model.DataKeyTimeTokenDesc]),
+		TimeTokenOfferIPFS: decodeBase64(data[model.DataKeyTimeTokenOfferIPFS]),
+		TelegramPartChatID: decodeBase64(data[model.DataKeyTelegramPartChatID]),
+		ContractIPFS:       decodeBase64(data[model.DataKeyContractIPFS]),
+	}
+	_, identity.PIIStandard = data[model.DataKeyPIIStandard]
+	identity.TimeTokenOfferURL = IPFSURL(identity.TimeTokenOfferIPFS)
+	identity.ContractURL = IPFSURL(identity.ContractIPFS)
+	return identity
+}
+
 // parseTagKeys extracts tag names from "Tag*" keys (e.g., "TagBelgrade" -> "Belgrade").
 // The value of each tag key is an account ID which is ignored for display purposes.
 func parseTagKeys(data map[string]string) []string {
@@ -351,10 +377,10 @@ func (s *StellarService) GetAccountOperations(ctx context.Context, accountID, cu
 		for _, op := range page.Embedded.Records {
 			currentCursor = op.PagingToken()
 
-			converted := convertOperation(op)
+			converted := ConvertOperation(op)
 
 			// Filter spam operations
-			if isSpamOperation(converted) {
+			if IsSpamOperation(converted) {
 				continue
 			}
 
@@ -384,8 +410,8 @@ func (s *StellarService) GetAccountOperations(ctx context.Context, accountID, cu
 	}, nil
 }
 
-// isSpamOperation returns true if the operation should be filtered out.
-func isSpamOperation(op model.Operation) bool {
+// IsSpamOperation returns true if the operation should be filtered out.
+func IsSpamOperation(op model.Operation) bool {
 	// Filter claimable balance operations (spam)
 	if op.Type == "create_claimable_balance" || op.Type == "claim_claimable_balance" {
 		return true
@@ -421,7 +447,7 @@ func (s *StellarService) GetTransactionDetail(ctx context.Context, txHash string
 
 	ops := make([]model.Operation, 0, len(opsPage.Embedded.Records))
 	for _, op := range opsPage.Embedded.Records {
-		ops = append(ops, convertOperation(op))
+		ops = append(ops, ConvertOperation(op))
 	}
 
 	return &model.Transaction{
@@ -444,8 +470,8 @@ func formatStroops(stroops int64) string {
 	return fmt.Sprintf("%.7f", xlm)
 }
 
-// convertOperation converts a Horizon operation to a model.Operation.
-func convertOperation(op operations.Operation) model.Operation {
+// ConvertOperation converts a Horizon operation to a model.Operation.
+func ConvertOperation(op operations.Operation) model.Operation {
 	base := op.GetBase()
 
 	result := model.Operation{
@@ -486,16 +512,20 @@ func convertOperation(op operations.Operation) model.Operation {
 		result.To = typed.To
 		result.SourceAmount = typed.SourceAmount
 		result.SourceAsset = assetCodeDisplay(typed.SourceAssetType, typed.SourceAssetCode)
+		result.SourceAssetIssuer = typed.SourceAssetIssuer
 		result.DestAmount = typed.Amount
 		result.DestAsset = assetCodeDisplay(typed.Asset.Type, typed.Asset.Code)
+		result.DestAssetIssuer = typed.Asset.Issuer
 
 	case operations.PathPayment:
 		result.From = typed.From
 		result.To = typed.To
 		result.SourceAmount = typed.SourceAmount
 		result.SourceAsset = assetCodeDisplay(typed.SourceAssetType, typed.SourceAssetCode)
+		result.SourceAssetIssuer = typed.SourceAssetIssuer
 		result.DestAmount = typed.Amount
 		result.DestAsset = assetCodeDisplay(typed.Asset.Type, typed.Asset.Code)
+		result.DestAssetIssuer = typed.Asset.Issuer
 
 	case operations.ManageSellOffer:
 		result.Selling = assetCodeDisplay(typed.SellingAssetType, typed.SellingAssetCode)
@@ -683,9 +713,32 @@ func (s *StellarService) GetTokenOrderbook(ctx context.Context, code, issuer str
 	}, nil
 }
 
-// ipfsGateway is the IPFS gateway URL for fetching NFT metadata.
+// ipfsGateway is the IPFS gateway URL for fetching NFT metadata and linking IPFS documents.
 const ipfsGateway = "https://ipfs.io/ipfs/"
 
+// ipfsHashPattern matches IPFS CIDs (CIDv0: base58, CIDv1: base32/base36),
+// which only contain alphanumeric characters.
+var ipfsHashPattern = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+
+// ipfsPathPattern matches the optional file path after the CID of an ipfs:// URI.
+var ipfsPathPattern = regexp.MustCompile(`^(/[a-zA-Z0-9_-][a-zA-Z0-9._-]*)*$`)
+
+// IPFSURL converts an IPFS hash or ipfs:// URI to a gateway URL.
+// Returns empty string if ref is neither.
+func IPFSURL(ref string) string {
+	hash, path := ref, ""
+	if rest, ok := strings.CutPrefix(ref, "ipfs://"); ok {
+		hash = rest
+		if i := strings.IndexByte(rest, '/'); i >= 0 {
+			hash, path = rest[:i], rest[i:]
+		}
+	}
+	if !ipfsHashPattern.MatchString(hash) || !ipfsPathPattern.MatchString(path) {
+		return ""
+	}
+	return ipfsGateway + hash + path
+}
+
 // ipfsMetadata represents the JSON structure of SEP-0039 NFT metadata.
 type ipfsMetadata struct {
 	Name            string `json:"name"`
@@ -731,16 +784,15 @@ func (s *StellarService) GetIssuerNFTMetadata(ctx context.Context, issuerID, ass
 		return nil, nil               // Not an NFT
 	}
 
-	// Validate IPFS hash format (CIDv0: base58, CIDv1: base32/base36)
-	// IPFS CIDs only contain alphanumeric characters
-	if !regexp.MustCompile(`^[a-zA-Z0-9]+$`).MatchString(ipfsHash) {
+	// Validate IPFS hash format
+	if !ipfsHashPattern.MatchString(ipfsHash) {
 		slog.Warn("invalid IPFS hash format", "issuer", issuerID, "hash", ipfsHash)
 		s.nftCache.Set(cacheKey, nil)
 		return nil, nil
 	}
 
 	// Fetch metadata from IPFS
-	resp, err := s.httpClient.Get(ipfsGateway + ipfsHash)
+	resp, err := s.httpClient.Get(IPFSURL(ipfsHash))
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch IPFS metadata: %w", err)
 	}
@@ -769,12 +821,12 @@ func (s *StellarService) GetIssuerNFTMetadata(ctx context.Context, issuerID, ass
 	// Convert IPFS URLs to gateway URLs
 	imageURL := meta.Image
 	if strings.HasPrefix(imageURL, "ipfs://") {
-		imageURL = ipfsGateway + strings.TrimPrefix(imageURL, "ipfs://")
+		imageURL = IPFSURL(imageURL)
 	}
 
 	fileURL := meta.File
 	if strings.HasPrefix(fileURL, "ipfs://") {
-		fileURL = ipfsGateway + strings.TrimPrefix(fileURL, "ipfs://")
+		fileURL = IPFSURL(fileURL)
 	}
 
 	result := &model.NFTMetadata{
@@ -164,6 +164,31 @@ func TestParseTagKeys(t *testing.T) {
 	}
 }
 
+func TestIPFSURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		ref      string
+		expected string
+	}{
+		{"bare hash", "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG", "https://ipfs.io/ipfs/QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"},
+		{"ipfs URI with path", "ipfs://QmHash/image.png", "https://ipfs.io/ipfs/QmHash/image.png"},
+		{"empty", "", ""},
+		{"http URL", "https://example.com/file", ""},
+		{"hash with invalid characters", "Qm../etc", ""},
+		{"empty ipfs URI", "ipfs://", ""},
+		{"ipfs URI with invalid hash", "ipfs://Qm\"><script>", ""},
+		{"ipfs URI with query", "ipfs://QmHash?x=1", ""},
+		{"ipfs URI with parent path", "ipfs://QmHash/../etc", ""},
+		{"ipfs URI with trailing slash", "ipfs://QmHash/", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IPFSURL(tt.ref))
+		})
+	}
+}
+
 func TestDecodeBase64(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -418,7 +443,7 @@ func TestIsSpamOperation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := isSpamOperation(tt.op)
+			got := IsSpamOperation(tt.op)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -0,0 +1,427 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/txnbuild"
+)
+
+// ErrInvalidEnvelope is returned when a signed envelope is not an init form transaction.
+var ErrInvalidEnvelope = errors.New("invalid transaction envelope")
+
+// ErrSubmitTimeout is returned when Horizon accepted the transaction but did not
+// see it in a ledger before its timeout. The transaction may still succeed.
+var ErrSubmitTimeout = errors.New("transaction submitted but not confirmed in time")
+
+// ErrStaleSequence is returned when the account has already used the transaction's sequence
+// number, e.g. because another transaction was submitted after this one was generated.
+var ErrStaleSequence = errors.New("transaction is outdated")
+
+// ErrSequenceGap is returned when earlier transactions of a split change were not submitted yet.
+var ErrSequenceGap = errors.New("earlier transactions must be submitted first")
+
+// ErrTxExpired is returned when the transaction's upper time bound has passed.
+var ErrTxExpired = errors.New("transaction has expired")
+
+// CheckSubmittable reports whether a transaction with the given sequence number and upper
+// time bound (0 = unbounded) can still be applied to an account at accountSequence.
+func CheckSubmittable(txSequence, maxTime, accountSequence int64, now time.Time) error {
+	if maxTime > 0 && now.Unix() > maxTime {
+		return fmt.Errorf("%w: it was valid until %s UTC. Generate a new transaction", ErrTxExpired, formatUnixTime(maxTime))
+	}
+	if txSequence <= accountSequence {
+		return fmt.Errorf("%w: the account has changed since it was generated. Generate a new transaction", ErrStaleSequence)
+	}
+	if pending := txSequence - accountSequence - 1; pending > 0 {
+		return fmt.Errorf("%w: %d earlier transaction(s) of this change are still pending", ErrSequenceGap, pending)
+	}
+	return nil
+}
+
+// TxRejectedError is returned when the network rejects a submitted transaction.
+type TxRejectedError struct {
+	TransactionCode string   // e.g. "tx_bad_seq" or "tx_failed"
+	OperationCodes  []string // Per-operation codes when TransactionCode is "tx_failed"
+	Message         string   // Human-readable explanation
+}
+
+func (e *TxRejectedError) Error() string {
+	return fmt.Sprintf("transaction rejected (%s): %s", strings.Join(e.Codes(), ", "), e.Message)
+}
+
+// Codes returns the transaction code followed by the operation codes.
+func (e *TxRejectedError) Codes() []string {
+	return append([]string{e.TransactionCode}, e.OperationCodes...)
+}
+
+// transactionMessages explains transaction-level result codes.
+var transactionMessages = map[string]string{
+	"tx_bad_seq":              "The account's sequence number changed since this transaction was generated. Generate a new transaction and sign it again.",
+	"tx_bad_auth":             "The transaction is missing required signatures or was signed with the wrong key.",
+	"tx_bad_auth_extra":       "The transaction carries signatures that are not needed. Sign it only with the account's keys.",
+	"tx_insufficient_fee":     "The fee is too low for the current network load. Try again later.",
+	"tx_insufficient_balance": "The account does not have enough XLM to pay the fee.",
+	"tx_no_source_account":    "The source account does not exist on the network.",
+	"tx_too_early":            "The transaction is not valid yet.",
+	"tx_too_late":             "The transaction has expired. Generate a new one.",
+	"tx_missing_operation":    "The transaction has no operations.",
+	"tx_internal_error":       "The network hit an internal error. Try again later.",
+}
+
+// operationMessages explains operation-level result codes of ManageData operations.
+var operationMessages = map[string]string{
+	"op_low_reserve":         "The account does not have enough XLM for the reserve of new data entries (0.5 XLM each).",
+	"op_data_name_not_found": "A data entry to delete does not exist anymore. Reload the form and try again.",
+	"op_data_invalid_name":   "A data entry name is invalid.",
+	"op_bad_auth":            "An operation is missing required signatures.",
+	"op_no_source_account":   "The operation source account does not exist.",
+	"op_too_many_subentries": "The account has too many data entries, trustlines and offers.",
+	"op_not_supported":       "An operation is not supported by the network.",
+	"op_not_supported_yet":   "An operation is not supported by the network.",
+	"op_exceeded_work_limit": "An operation exceeded the network work limit.",
+	"op_too_many_sponsoring": "The account sponsors too many entries.",
+}
+
+// describeRejection returns a human-readable explanation for Horizon result codes.
+func describeRejection(txCode string, opCodes []string) string {
+	if txCode == "tx_failed" {
+		for _, code := range opCodes {
+			if code == "op_success" {
+				continue
+			}
+			if msg, ok := operationMessages[code]; ok {
+				return msg
+			}
+			return fmt.Sprintf("An operation failed (%s).", code)
+		}
+	}
+	if msg, ok := transactionMessages[txCode]; ok {
+		return msg
+	}
+	return fmt.Sprintf("The network rejected the transaction (%s).", txCode)
+}
+
+// ValidateSignedEnvelope checks a signed envelope before submission: it must be a signed
+// transaction made only of ManageData operations on its source account, as generated by
+// InitXDRBuilder. If expectedXDR is given, the signed transaction must be exactly that one.
+// Whether the transaction was generated at all is checked by TxSubmitter.
+func ValidateSignedEnvelope(signedXDR, expectedXDR string) (*txnbuild.Transaction, error) {
+	tx, err := parseTransaction(signedXDR)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tx.Signatures()) == 0 {
+		return nil, fmt.Errorf("%w: transaction is not signed", ErrInvalidEnvelope)
+	}
+
+	if err := validateInitOperations(tx); err != nil {
+		return nil, err
+	}
+
+	if expectedXDR == "" {
+		return tx, nil
+	}
+
+	expected, err := parseTransaction(expectedXDR)
+	if err != nil {
+		return nil, fmt.Errorf("generated transaction: %w", err)
+	}
+	signedHash, err := tx.HashHex(network.PublicNetworkPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	expectedHash, err := expected.HashHex(network.PublicNetworkPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("generated transaction: %w", err)
+	}
+	if signedHash != expectedHash {
+		return nil, fmt.Errorf("%w: signed transaction does not match the generated one", ErrInvalidEnvelope)
+	}
+
+	return tx, nil
+}
+
+// validateInitOperations checks that the transaction only has ManageData operations on its
+// source account, like the ones generated by the init forms.
+func validateInitOperations(tx *txnbuild.Transaction) error {
+	ops := tx.Operations()
+	if len(ops) == 0 {
+		return fmt.Errorf("%w: transaction has no operations", ErrInvalidEnvelope)
+	}
+	source := tx.SourceAccount().AccountID
+	for i, op := range ops {
+		md, ok := op.(*txnbuild.ManageData)
+		if !ok {
+			return fmt.Errorf("%w: operation %d is not ManageData", ErrInvalidEnvelope, i+1)
+		}
+		if md.SourceAccount != "" && md.SourceAccount != source {
+			return fmt.Errorf("%w: operation %d changes another account", ErrInvalidEnvelope, i+1)
+		}
+	}
+	return nil
+}
+
+// parseTransaction decodes a base64 envelope of a regular (non fee-bump) transaction.
+func parseTransaction(envelope string) (*txnbuild.Transaction, error) {
+	generic, err := txnbuild.TransactionFromXDR(strings.TrimSpace(envelope))
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot decode XDR", ErrInvalidEnvelope)
+	}
+	tx, ok := generic.Transaction()
+	if !ok {
+		return nil, fmt.Errorf("%w: fee bump transactions are not supported", ErrInvalidEnvelope)
+	}
+	return tx, nil
+}
+
+// SubmitTransaction submits a signed transaction envelope to Horizon.
+// Rejections are returned as *TxRejectedError.
+func (s *StellarService) SubmitTransaction(ctx context.Context, signedXDR string) (*model.SubmitResult, error) {
+	tx, err := s.client.SubmitTransactionXDR(signedXDR)
+	if err != nil {
+		return nil, submitError(err)
+	}
+	return &model.SubmitResult{
+		AccountID: tx.Account,
+		Hash:      tx.Hash,
+		Ledger:    tx.Ledger,
+	}, nil
+}
+
+// submitError converts a Horizon submission error into ErrSubmitTimeout or *TxRejectedError.
+func submitError(err error) error {
+	hErr := horizonclient.GetError(err)
+	if hErr == nil {
+		return fmt.Errorf("submit transaction: %w", err)
+	}
+	if hErr.Problem.Status == http.StatusGatewayTimeout {
+		return ErrSubmitTimeout
+	}
+
+	codes, codesErr := hErr.ResultCodes()
+	if codesErr != nil || codes == nil || codes.TransactionCode == "" {
+		return fmt.Errorf("submit transaction: %w", err)
+	}
+
+	// Fee bump rejections carry the inner transaction's code separately
+	txCode := codes.TransactionCode
+	if codes.InnerTransactionCode != "" {
+		txCode = codes.InnerTransactionCode
+	}
+	return &TxRejectedError{
+		TransactionCode: txCode,
+		OperationCodes:  codes.OperationCodes,
+		Message:         describeRejection(txCode, codes.OperationCodes),
+	}
+}
+
+// TransactionSubmitter sends signed envelopes to the network and reports account sequence numbers.
+type TransactionSubmitter interface {
+	GetAccountSequence(ctx context.Context, accountID string) (int64, error)
+	SubmitTransaction(ctx context.Context, signedXDR string) (*model.SubmitResult, error)
+}
+
+// GeneratedTxStore records the hashes of generated transactions.
+type GeneratedTxStore interface {
+	RecordGenerated(ctx context.Context, accountID string, hashes []string, expiresAt time.Time) error
+	IsGenerated(ctx context.Context, hash string) (bool, error)
+}
+
+// ErrSyncInProgress is returned by AccountSyncer when a sync run holds the
+// database and the account has to be refreshed later.
+var ErrSyncInProgress = errors.New("sync in progress")
+
+// AccountSyncer refreshes a single account in the local database.
+type AccountSyncer interface {
+	SyncAccount(ctx context.Context, accountID string) error
+}
+
+const (
+	// unboundedTxRetention is how long transactions without an upper time bound
+	// can be submitted after they were generated.
+	unboundedTxRetention = 7 * 24 * time.Hour
+
+	// resyncQueueSize bounds the accounts waiting for a refresh. Accounts that do
+	// not fit are refreshed by the next regular sync.
+	resyncQueueSize = 64
+
+	// resyncTimeout bounds the refresh of a single account.
+	resyncTimeout = 2 * time.Minute
+
+	// resyncRetryDelay is how long a resync waits for a sync run in progress
+	// before it is tried again. The run may have read the account before the
+	// transaction was applied, so the resync is not dropped.
+	resyncRetryDelay = 1 * time.Minute
+)
+
+// TxSubmitter validates signed init transactions, submits them and resyncs the account.
+// Only transactions recorded with Remember are submitted. Accounts are resynced one at a
+// time in the background, so submissions do not wait for the database.
+type TxSubmitter struct {
+	network   TransactionSubmitter
+	generated GeneratedTxStore
+	syncer    AccountSyncer
+	now       func() time.Time
+	retry     time.Duration // Delay before retrying a resync that met a sync run
+
+	resyncs   chan string
+	stop      context.CancelFunc
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewTxSubmitter creates a submitter. syncer can be nil, in which case accounts
+// are only refreshed by the next regular sync.
+//
+// Close must be called when shutting down to stop the background resyncs.
+func NewTxSubmitter(network TransactionSubmitter, generated GeneratedTxStore, syncer AccountSyncer) (*TxSubmitter, error) {
+	if network == nil {
+		return nil, errors.New("transaction submitter is required")
+	}
+	if generated == nil {
+		return nil, errors.New("generated transaction store is required")
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+	s := &TxSubmitter{
+		network:   network,
+		generated: generated,
+		syncer:    syncer,
+		now:       time.Now,
+		retry:     resyncRetryDelay,
+		resyncs:   make(chan string, resyncQueueSize),
+		stop:      stop,
+		stopped:   make(chan struct{}),
+	}
+	if syncer != nil {
+		go s.resyncLoop(ctx)
+	} else {
+		close(s.stopped)
+	}
+	return s, nil
+}
+
+// Close stops the background resyncs, canceling the one in progress, and
+// waits for them to return. Safe to call multiple times.
+func (s *TxSubmitter) Close() {
+	s.closeOnce.Do(s.stop)
+	<-s.stopped
+}
+
+// resyncLoop refreshes queued accounts until ctx is canceled.
+func (s *TxSubmitter) resyncLoop(ctx context.Context) {
+	defer close(s.stopped)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case accountID := <-s.resyncs:
+			syncCtx, cancel := context.WithTimeout(ctx, resyncTimeout)
+			err := s.syncer.SyncAccount(syncCtx, accountID)
+			cancel()
+			switch {
+			case errors.Is(err, ErrSyncInProgress):
+				slog.Debug("sync in progress, retrying account resync later", "account_id", accountID)
+				time.AfterFunc(s.retry, func() {
+					if ctx.Err() == nil {
+						s.queueResync(accountID)
+					}
+				})
+			case err != nil:
+				slog.Warn("failed to resync account after submission", "account_id", accountID, "error", err)
+			}
+		}
+	}
+}
+
+// queueResync queues a background resync of accountID. It reports false when
+// the queue is full.
+func (s *TxSubmitter) queueResync(accountID string) bool {
+	select {
+	case s.resyncs <- accountID:
+		return true
+	default:
+		slog.Warn("resync queue is full, account is refreshed by the next sync", "account_id", accountID)
+		return false
+	}
+}
+
+// Remember records generated transactions of accountID, so they can be submitted
+// until their upper time bound, or for unboundedTxRetention if they have none.
+func (s *TxSubmitter) Remember(ctx context.Context, accountID string, txs []model.InitTransaction) error {
+	hashes := make([]string, 0, len(txs))
+	var maxTime int64
+	for _, t := range txs {
+		tx, err := parseTransaction(t.XDR)
+		if err != nil {
+			return fmt.Errorf("generated transaction: %w", err)
+		}
+		hash, err := tx.HashHex(network.PublicNetworkPassphrase)
+		if err != nil {
+			return fmt.Errorf("hash generated transaction: %w", err)
+		}
+		hashes = append(hashes, hash)
+		maxTime = max(maxTime, tx.Timebounds().MaxTime)
+	}
+
+	expiresAt := s.now().Add(unboundedTxRetention)
+	if maxTime > 0 {
+		expiresAt = time.Unix(maxTime, 0)
+	}
+	return s.generated.RecordGenerated(ctx, accountID, hashes, expiresAt)
+}
+
+// Submit validates signedXDR (against expectedXDR when given), checks that Lore generated it
+// and that its sequence number and time bounds are still current, submits it and resyncs the
+// source account in the background. Refreshing is false when no resync could be queued.
+func (s *TxSubmitter) Submit(ctx context.Context, signedXDR, expectedXDR string) (*model.SubmitResult, error) {
+	tx, err := ValidateSignedEnvelope(signedXDR, expectedXDR)
+	if err != nil {
+		return nil, err
+	}
+	accountID := tx.SourceAccount().AccountID
+
+	hash, err := tx.HashHex(network.PublicNetworkPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	generated, err := s.generated.IsGenerated(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("check generated transaction: %w", err)
+	}
+	if !generated {
+		return nil, fmt.Errorf("%w: transaction was not generated by Lore or has expired. Generate a new transaction", ErrInvalidEnvelope)
+	}
+
+	// Catch outdated envelopes before Horizon answers with a bare tx_bad_seq or tx_too_late
+	accountSequence, err := s.network.GetAccountSequence(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch account sequence: %w", err)
+	}
+	if err := CheckSubmittable(tx.SequenceNumber(), tx.Timebounds().MaxTime, accountSequence, s.now()); err != nil {
+		return nil, err
+	}
+
+	result, err := s.network.SubmitTransaction(ctx, signedXDR)
+	if err != nil {
+		return nil, err
+	}
+	result.AccountID = accountID
+
+	if s.syncer != nil {
+		result.Refreshing = s.queueResync(accountID)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,440 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/support/render/problem"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signedDelegationXDR generates a delegation transaction for a random account and signs it.
+func signedDelegationXDR(t *testing.T) (kp *keypair.Full, unsigned, signed string) {
+	t.Helper()
+
+	kp = keypair.MustRandom()
+	txs, err := NewInitXDRBuilder().GenerateDelegationXDR(
+		model.DelegationFormData{AccountID: kp.Address()},
+		model.DelegationFormData{AccountID: kp.Address(), CouncilReady: true},
+		1,
+	)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+
+	unsigned = txs[0].XDR
+	return kp, unsigned, signXDR(t, unsigned, kp)
+}
+
+func signXDR(t *testing.T, envelope string, kp *keypair.Full) string {
+	t.Helper()
+
+	tx, err := parseTransaction(envelope)
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.PublicNetworkPassphrase, kp)
+	require.NoError(t, err)
+	signed, err := tx.Base64()
+	require.NoError(t, err)
+	return signed
+}
+
+func TestValidateSignedEnvelope(t *testing.T) {
+	kp, unsigned, signed := signedDelegationXDR(t)
+	_, otherUnsigned, _ := signedDelegationXDR(t)
+
+	payment, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: kp.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations: []txnbuild.Operation{
+			&txnbuild.Payment{Destination: testAccountID1, Amount: "1", Asset: txnbuild.NativeAsset{}},
+		},
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	paymentXDR, err := payment.Base64()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		signed      string
+		expected    string
+		errContains string
+	}{
+		{name: "signed without expected", signed: signed},
+		{name: "signed matches expected", signed: signed, expected: unsigned},
+		{name: "garbage", signed: "not-xdr", errContains: "cannot decode"},
+		{name: "unsigned", signed: unsigned, errContains: "not signed"},
+		{name: "payment", signed: signXDR(t, paymentXDR, kp), errContains: "not ManageData"},
+		{name: "different transaction", signed: signed, expected: otherUnsigned, errContains: "does not match"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := ValidateSignedEnvelope(tt.signed, tt.expected)
+			if tt.errContains != "" {
+				require.ErrorIs(t, err, ErrInvalidEnvelope)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, kp.Address(), tx.SourceAccount().AccountID)
+		})
+	}
+}
+
+func TestSubmitError(t *testing.T) {
+	resultCodes := func(codes map[string]interface{}) error {
+		return &horizonclient.Error{Problem: problem.P{
+			Status: 400,
+			Extras: map[string]interface{}{"result_codes": codes},
+		}}
+	}
+
+	t.Run("timeout", func(t *testing.T) {
+		err := submitError(&horizonclient.Error{Problem: problem.P{Status: 504}})
+		assert.ErrorIs(t, err, ErrSubmitTimeout)
+	})
+
+	t.Run("bad sequence", func(t *testing.T) {
+		err := submitError(resultCodes(map[string]interface{}{"transaction": "tx_bad_seq"}))
+
+		var rejected *TxRejectedError
+		require.ErrorAs(t, err, &rejected)
+		assert.Equal(t, []string{"tx_bad_seq"}, rejected.Codes())
+		assert.Contains(t, rejected.Message, "sequence number")
+	})
+
+	t.Run("operation failed", func(t *testing.T) {
+		err := submitError(resultCodes(map[string]interface{}{
+			"transaction": "tx_failed",
+			"operations":  []string{"op_success", "op_low_reserve"},
+		}))
+
+		var rejected *TxRejectedError
+		require.ErrorAs(t, err, &rejected)
+		assert.Equal(t, []string{"tx_failed", "op_success", "op_low_reserve"}, rejected.Codes())
+		assert.Contains(t, rejected.Message, "reserve")
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		err := submitError(resultCodes(map[string]interface{}{"transaction": "tx_something_new"}))
+
+		var rejected *TxRejectedError
+		require.ErrorAs(t, err, &rejected)
+		assert.Contains(t, rejected.Message, "tx_something_new")
+	})
+
+	t.Run("network error", func(t *testing.T) {
+		err := submitError(errors.New("connection refused"))
+
+		var rejected *TxRejectedError
+		assert.False(t, errors.As(err, &rejected))
+		assert.NotErrorIs(t, err, ErrSubmitTimeout)
+	})
+}
+
+type fakeNetwork struct {
+	sequence  int64 // Current account sequence
+	submitted string
+	err       error
+}
+
+func (f *fakeNetwork) GetAccountSequence(_ context.Context, _ string) (int64, error) {
+	return f.sequence, nil
+}
+
+func (f *fakeNetwork) SubmitTransaction(_ context.Context, signedXDR string) (*model.SubmitResult, error) {
+	f.submitted = signedXDR
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &model.SubmitResult{Hash: "abc", Ledger: 42}, nil
+}
+
+// fakeGenerated records generated transaction hashes in memory.
+type fakeGenerated struct {
+	hashes    map[string]time.Time
+	accountID string
+}
+
+func (f *fakeGenerated) RecordGenerated(_ context.Context, accountID string, hashes []string, expiresAt time.Time) error {
+	if f.hashes == nil {
+		f.hashes = make(map[string]time.Time)
+	}
+	f.accountID = accountID
+	for _, h := range hashes {
+		f.hashes[h] = expiresAt
+	}
+	return nil
+}
+
+func (f *fakeGenerated) IsGenerated(_ context.Context, hash string) (bool, error) {
+	_, ok := f.hashes[hash]
+	return ok, nil
+}
+
+// rememberedStore returns a store that knows the transaction of unsigned.
+func rememberedStore(t *testing.T, accountID, unsigned string) *fakeGenerated {
+	t.Helper()
+
+	store := &fakeGenerated{}
+	submitter, err := NewTxSubmitter(&fakeNetwork{}, store, nil)
+	require.NoError(t, err)
+	require.NoError(t, submitter.Remember(context.Background(), accountID, []model.InitTransaction{{XDR: unsigned}}))
+	return store
+}
+
+// fakeSyncer reports resynced accounts on synced. If block is set, resyncs wait
+// until it is closed or their context ends. The first busy resyncs fail with
+// ErrSyncInProgress.
+type fakeSyncer struct {
+	synced chan string
+	block  chan struct{}
+	err    error
+	busy   int
+}
+
+func newFakeSyncer() *fakeSyncer {
+	return &fakeSyncer{synced: make(chan string, 2*resyncQueueSize)}
+}
+
+func (f *fakeSyncer) SyncAccount(ctx context.Context, accountID string) error {
+	if f.busy > 0 {
+		f.busy--
+		return ErrSyncInProgress
+	}
+	if f.block != nil {
+		select {
+		case <-f.block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	f.synced <- accountID
+	return f.err
+}
+
+func TestTxSubmitter_Remember(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	kp := keypair.MustRandom()
+	builder := NewInitXDRBuilder(WithValidity(time.Hour))
+	builder.now = func() time.Time { return now }
+	txs, err := builder.GenerateDelegationXDR(
+		model.DelegationFormData{AccountID: kp.Address()},
+		model.DelegationFormData{AccountID: kp.Address(), CouncilReady: true},
+		1,
+	)
+	require.NoError(t, err)
+
+	store := &fakeGenerated{}
+	submitter, err := NewTxSubmitter(&fakeNetwork{}, store, nil)
+	require.NoError(t, err)
+	require.NoError(t, submitter.Remember(context.Background(), kp.Address(), txs))
+
+	tx, err := parseTransaction(txs[0].XDR)
+	require.NoError(t, err)
+	hash, err := tx.HashHex(network.PublicNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{hash: now.Add(time.Hour)}, store.hashes)
+	assert.Equal(t, kp.Address(), store.accountID)
+
+	// Transactions without an upper time bound are kept for a fixed period
+	submitter.now = func() time.Time { return now }
+	unbounded, err := NewInitXDRBuilder(WithValidity(0)).GenerateDelegationXDR(
+		model.DelegationFormData{AccountID: kp.Address()},
+		model.DelegationFormData{AccountID: kp.Address(), CouncilReady: true},
+		2,
+	)
+	require.NoError(t, err)
+	require.NoError(t, submitter.Remember(context.Background(), kp.Address(), unbounded))
+
+	tx, err = parseTransaction(unbounded[0].XDR)
+	require.NoError(t, err)
+	hash, err = tx.HashHex(network.PublicNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(unboundedTxRetention), store.hashes[hash])
+}
+
+func TestTxSubmitter_Submit(t *testing.T) {
+	kp, unsigned, signed := signedDelegationXDR(t)
+	generated := rememberedStore(t, kp.Address(), unsigned)
+
+	t.Run("submits and resyncs in the background", func(t *testing.T) {
+		net, syncer := &fakeNetwork{}, newFakeSyncer()
+		submitter, err := NewTxSubmitter(net, generated, syncer)
+		require.NoError(t, err)
+		defer submitter.Close()
+
+		result, err := submitter.Submit(context.Background(), signed, unsigned)
+		require.NoError(t, err)
+		assert.Equal(t, &model.SubmitResult{AccountID: kp.Address(), Hash: "abc", Ledger: 42, Refreshing: true}, result)
+		assert.Equal(t, signed, net.submitted)
+
+		select {
+		case id := <-syncer.synced:
+			assert.Equal(t, kp.Address(), id)
+		case <-time.After(5 * time.Second):
+			t.Fatal("account was not resynced")
+		}
+	})
+
+	t.Run("resync failure is not fatal", func(t *testing.T) {
+		syncer := newFakeSyncer()
+		syncer.err = errors.New("db down")
+		submitter, err := NewTxSubmitter(&fakeNetwork{}, generated, syncer)
+		require.NoError(t, err)
+		defer submitter.Close()
+
+		_, err = submitter.Submit(context.Background(), signed, "")
+		require.NoError(t, err)
+		<-syncer.synced
+
+		// The worker keeps going after a failure
+		_, err = submitter.Submit(context.Background(), signed, "")
+		require.NoError(t, err)
+		<-syncer.synced
+	})
+
+	t.Run("resync is retried after a sync run", func(t *testing.T) {
+		syncer := newFakeSyncer()
+		syncer.busy = 2
+		submitter, err := NewTxSubmitter(&fakeNetwork{}, generated, syncer)
+		require.NoError(t, err)
+		submitter.retry = 10 * time.Millisecond
+		defer submitter.Close()
+
+		result, err := submitter.Submit(context.Background(), signed, "")
+		require.NoError(t, err)
+		assert.True(t, result.Refreshing)
+
+		select {
+		case id := <-syncer.synced:
+			assert.Equal(t, kp.Address(), id)
+		case <-time.After(5 * time.Second):
+			t.Fatal("account was not resynced after the run")
+		}
+	})
+
+	t.Run("full queue is left to the next sync", func(t *testing.T) {
+		syncer := newFakeSyncer()
+		syncer.block = make(chan struct{})
+		submitter, err := NewTxSubmitter(&fakeNetwork{}, generated, syncer)
+		require.NoError(t, err)
+		defer submitter.Close()
+
+		// One resync runs while the queue fills up
+		refreshing := 0
+		for range resyncQueueSize + 2 {
+			result, err := submitter.Submit(context.Background(), signed, "")
+			require.NoError(t, err)
+			if result.Refreshing {
+				refreshing++
+			}
+		}
+		assert.GreaterOrEqual(t, refreshing, resyncQueueSize)
+		assert.Less(t, refreshing, resyncQueueSize+2)
+	})
+
+	t.Run("close cancels the running resync", func(t *testing.T) {
+		syncer := newFakeSyncer()
+		syncer.block = make(chan struct{})
+		submitter, err := NewTxSubmitter(&fakeNetwork{}, generated, syncer)
+		require.NoError(t, err)
+
+		_, err = submitter.Submit(context.Background(), signed, "")
+		require.NoError(t, err)
+
+		closed := make(chan struct{})
+		go func() {
+			submitter.Close()
+			close(closed)
+		}()
+		select {
+		case <-closed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Close did not return")
+		}
+		submitter.Close()
+	})
+
+	t.Run("invalid envelope is not submitted", func(t *testing.T) {
+		net := &fakeNetwork{}
+		submitter, err := NewTxSubmitter(net, generated, nil)
+		require.NoError(t, err)
+
+		_, err = submitter.Submit(context.Background(), unsigned, "")
+		require.ErrorIs(t, err, ErrInvalidEnvelope)
+		assert.Empty(t, net.submitted)
+	})
+
+	t.Run("transaction not generated by Lore is not submitted", func(t *testing.T) {
+		net := &fakeNetwork{}
+		submitter, err := NewTxSubmitter(net, &fakeGenerated{}, nil)
+		require.NoError(t, err)
+
+		_, err = submitter.Submit(context.Background(), signed, "")
+		require.ErrorIs(t, err, ErrInvalidEnvelope)
+		assert.Contains(t, err.Error(), "not generated by Lore")
+		assert.Empty(t, net.submitted)
+	})
+
+	t.Run("outdated sequence is not submitted", func(t *testing.T) {
+		net := &fakeNetwork{sequence: 1}
+		submitter, err := NewTxSubmitter(net, generated, nil)
+		require.NoError(t, err)
+
+		_, err = submitter.Submit(context.Background(), signed, unsigned)
+		require.ErrorIs(t, err, ErrStaleSequence)
+		assert.Empty(t, net.submitted)
+	})
+
+	t.Run("rejection skips resync", func(t *testing.T) {
+		syncer := newFakeSyncer()
+		submitter, err := NewTxSubmitter(&fakeNetwork{err: &TxRejectedError{TransactionCode: "tx_bad_seq"}}, generated, syncer)
+		require.NoError(t, err)
+
+		_, err = submitter.Submit(context.Background(), signed, unsigned)
+		var rejected *TxRejectedError
+		require.ErrorAs(t, err, &rejected)
+		submitter.Close()
+		assert.Empty(t, syncer.synced)
+	})
+}
+
+func TestCheckSubmittable(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name            string
+		txSequence      int64
+		maxTime         int64
+		accountSequence int64
+		want            error
+	}{
+		{"next sequence", 11, 0, 10, nil},
+		{"within time bounds", 11, now.Unix() + 60, 10, nil},
+		{"already used", 11, 0, 11, ErrStaleSequence},
+		{"account moved past", 11, 0, 15, ErrStaleSequence},
+		{"earlier part pending", 12, 0, 10, ErrSequenceGap},
+		{"expired", 11, now.Unix() - 1, 10, ErrTxExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSubmittable(tt.txSequence, tt.maxTime, tt.accountSequence, now)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"sync"
 
 	"github.com/mtlprog/lore/internal/config"
+	"github.com/mtlprog/lore/internal/relation"
 	"github.com/samber/lo"
 	"github.com/shopspring/decimal"
 	"github.com/stellar/go/clients/horizonclient"
@@ -130,7 +131,7 @@ func (s *Syncer) syncSingleAccount(ctx context.Context, accountID string) error
 		return fmt.Errorf("fetch account detail: %w", err)
 	}
 
-	data := parseAccountData(&acc)
+	data := parseAccountData(&acc, s.relationTypes())
 
 	if err := s.repo.UpsertAccount(ctx, data); err != nil {
 		return fmt.Errorf("upsert account: %w", err)
@@ -148,6 +149,10 @@ func (s *Syncer) syncSingleAccount(ctx context.Context, accountID string) error
 		return fmt.Errorf("upsert relationships: %w", err)
 	}
 
+	if err := s.repo.UpsertSigners(ctx, accountID, data.Signers); err != nil {
+		return fmt.Errorf("upsert signers: %w", err)
+	}
+
 	// Sync LP shares (separate from regular balances)
 	if err := s.syncLPShares(ctx, accountID, &acc); err != nil {
 		return fmt.Errorf("sync LP shares: %w", err)
@@ -157,7 +162,8 @@ func (s *Syncer) syncSingleAccount(ctx context.Context, accountID string) error
 }
 
 // parseAccountData extracts structured data from a Horizon account.
-func parseAccountData(acc *horizon.Account) *AccountData {
+// Relationship keys are recognized using the given relation types.
+func parseAccountData(acc *horizon.Account, types *relation.Registry) *AccountData {
 	data := &AccountData{
 		ID:           acc.ID,
 		CouncilReady: false,
@@ -184,7 +190,17 @@ func parseAccountData(acc *horizon.Account) *AccountData {
 	})
 
 	// Parse ManageData
-	data.Metadata, data.Relationships, data.DelegateTo, data.CouncilDelegateTo, data.CouncilReady = parseManageData(acc.Data)
+	data.Metadata, data.Relationships, data.DelegateTo, data.CouncilDelegateTo, data.CouncilReady = parseManageData(acc.Data, types)
+
+	// Signers with zero weight (e.g. a disabled master key) cannot sign
+	data.Signers = lo.FilterMap(acc.Signers, func(sig horizon.Signer, _ int) (Signer, bool) {
+		return Signer{Key: sig.Key, Type: sig.Type, Weight: sig.Weight}, sig.Weight > 0
+	})
+	data.Thresholds = Thresholds{
+		Low:  acc.Thresholds.LowThreshold,
+		Med:  acc.Thresholds.MedThreshold,
+		High: acc.Thresholds.HighThreshold,
+	}
 
 	// Extract primary name from metadata (key="Name", index="")
 	for _, m := range data.Metadata {
@@ -197,63 +213,8 @@ func parseAccountData(acc *horizon.Account) *AccountData {
 	return data
 }
 
-// relationTypeStrings maps string to RelationType for parsing.
-var relationTypeStrings = map[string]RelationType{
-	"MyPart":            RelationMyPart,
-	"PartOf":            RelationPartOf,
-	"RecommendToMTLA":   RelationRecommendToMTLA,
-	"OneFamily":         RelationOneFamily,
-	"Spouse":            RelationSpouse,
-	"Guardian":          RelationGuardian,
-	"Ward":              RelationWard,
-	"Sympathy":          RelationSympathy,
-	"Love":              RelationLove,
-	"Divorce":           RelationDivorce,
-	"A":                 RelationA,
-	"B":                 RelationB,
-	"C":                 RelationC,
-	"D":                 RelationD,
-	"Employer":          RelationEmployer,
-	"Employee":          RelationEmployee,
-	"Contractor":        RelationContractor,
-	"Client":            RelationClient,
-	"Partnership":       RelationPartnership,
-	"Collaboration":     RelationCollaboration,
-	"OwnershipFull":     RelationOwnershipFull,
-	"OwnershipMajority": RelationOwnershipMajority,
-	"OwnershipMinority": RelationOwnershipMinority,
-	"Owner":             RelationOwner,
-	"OwnerMajority":     RelationOwnerMajority,
-	"OwnerMinority":     RelationOwnerMinority,
-	"WelcomeGuest":      RelationWelcomeGuest,
-	"FactionMember":     RelationFactionMember,
-}
-
-// relationTypePrefixes for parsing relationship keys (ordered by length for proper matching).
-var relationTypePrefixes = []string{
-	"RecommendToMTLA",
-	"OwnershipMajority", "OwnershipMinority", "OwnershipFull",
-	"OwnerMajority", "OwnerMinority",
-	"FactionMember",
-	"Collaboration", "Partnership",
-	"WelcomeGuest",
-	"Contractor",
-	"OneFamily",
-	"Employer", "Employee",
-	"Guardian",
-	"Sympathy",
-	"Divorce",
-	"Client",
-	"Spouse",
-	"MyPart", "PartOf",
-	"Owner",
-	"Love",
-	"Ward",
-	"A", "B", "C", "D",
-}
-
 // parseManageData extracts metadata, relationships, delegate_to, council_delegate_to, and council_ready from account data.
-func parseManageData(rawData map[string]string) ([]Metadata, []Relationship, *string, *string, bool) {
+func parseManageData(rawData map[string]string, types *relation.Registry) ([]Metadata, []Relationship, *string, *string, bool) {
 	var metadata []Metadata
 	var relationships []Relationship
 	var delegateTo *string
@@ -291,7 +252,7 @@ func parseManageData(rawData map[string]string) ([]Metadata, []Relationship, *st
 		}
 
 		// Check for relationship pattern
-		if rel := parseRelationship(key, value); rel != nil {
+		if rel := parseRelationship(types, key, value); rel != nil {
 			relationships = append(relationships, *rel)
 			continue
 		}
@@ -326,14 +287,14 @@ func parseManageData(rawData map[string]string) ([]Metadata, []Relationship, *st
 // Key format: "Type" or "Type0", "Type1", "Type002" (with optional index suffix)
 // Value format: Account ID (56 chars starting with G)
 // RelationIndex preserves the original suffix string (e.g., "002" vs "2") to avoid conflicts.
-func parseRelationship(key, value string) *Relationship {
+func parseRelationship(types *relation.Registry, key, value string) *Relationship {
 	// Value must be a valid Stellar account ID
 	if len(value) != 56 || !strings.HasPrefix(value, "G") {
 		return nil
 	}
 
-	// Try each known relation type as prefix
-	for _, prefix := range relationTypePrefixes {
+	// Try each known relation type as prefix (longest first)
+	for _, prefix := range types.Prefixes() {
 		if !strings.HasPrefix(key, prefix) {
 			continue
 		}
@@ -347,14 +308,9 @@ func parseRelationship(key, value string) *Relationship {
 			}
 		}
 
-		relType, ok := relationTypeStrings[prefix]
-		if !ok {
-			continue
-		}
-
 		return &Relationship{
 			TargetAccountID: value,
-			RelationType:    relType,
+			RelationType:    RelationType(prefix),
 			RelationIndex:   rest, // Keep original suffix string (empty string for no suffix)
 		}
 	}
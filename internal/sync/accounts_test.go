@@ -4,6 +4,8 @@ import (
 	"encoding/base64"
 	"testing"
 
+	"github.com/mtlprog/lore/internal/relation"
+	"github.com/mtlprog/lore/internal/relation/relationtest"
 	"github.com/shopspring/decimal"
 	"github.com/stellar/go/protocols/horizon"
 	"github.com/stellar/go/protocols/horizon/base"
@@ -109,7 +111,7 @@ func TestParseRelationship(t *testing.T) {
 			value: validAccountID,
 			expected: &Relationship{
 				TargetAccountID: validAccountID,
-				RelationType:    RelationPartOf,
+				RelationType:    RelationType("PartOf"),
 				RelationIndex:   "",
 			},
 		},
@@ -119,7 +121,7 @@ func TestParseRelationship(t *testing.T) {
 			value: validAccountID,
 			expected: &Relationship{
 				TargetAccountID: validAccountID,
-				RelationType:    RelationMyPart,
+				RelationType:    RelationType("MyPart"),
 				RelationIndex:   "1",
 			},
 		},
@@ -129,7 +131,7 @@ func TestParseRelationship(t *testing.T) {
 			value: validAccountID,
 			expected: &Relationship{
 				TargetAccountID: validAccountID,
-				RelationType:    RelationA,
+				RelationType:    RelationType("A"),
 				RelationIndex:   "",
 			},
 		},
@@ -139,7 +141,7 @@ func TestParseRelationship(t *testing.T) {
 			value: validAccountID,
 			expected: &Relationship{
 				TargetAccountID: validAccountID,
-				RelationType:    RelationA,
+				RelationType:    RelationType("A"),
 				RelationIndex:   "5",
 			},
 		},
@@ -149,7 +151,7 @@ func TestParseRelationship(t *testing.T) {
 			value: validAccountID,
 			expected: &Relationship{
 				TargetAccountID: validAccountID,
-				RelationType:    RelationPartOf,
+				RelationType:    RelationType("PartOf"),
 				RelationIndex:   "002",
 			},
 		},
@@ -159,7 +161,7 @@ func TestParseRelationship(t *testing.T) {
 			value: validAccountID,
 			expected: &Relationship{
 				TargetAccountID: validAccountID,
-				RelationType:    RelationFactionMember,
+				RelationType:    RelationType("FactionMember"),
 				RelationIndex:   "",
 			},
 		},
@@ -197,12 +199,34 @@ func TestParseRelationship(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := parseRelationship(tt.key, tt.value)
+			result := parseRelationship(relationtest.Registry(), tt.key, tt.value)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
 }
 
+func TestParseRelationshipUsesRegistry(t *testing.T) {
+	validAccountID := "GCNVDZIHGX473FEI7IXCUAEXUJ4BGCKEMHF36VYP5EMS7PX2QBLAMTLA"
+	types := relation.NewRegistry([]relation.Type{
+		{Name: "Mentor", Category: "WORK"},
+		{Name: "MentorOf", Category: "WORK"},
+	})
+
+	t.Run("newly registered type is parsed", func(t *testing.T) {
+		result := parseRelationship(types, "Mentor1", validAccountID)
+		assert.Equal(t, &Relationship{TargetAccountID: validAccountID, RelationType: "Mentor", RelationIndex: "1"}, result)
+	})
+
+	t.Run("longest prefix wins", func(t *testing.T) {
+		result := parseRelationship(types, "MentorOf2", validAccountID)
+		assert.Equal(t, &Relationship{TargetAccountID: validAccountID, RelationType: "MentorOf", RelationIndex: "2"}, result)
+	})
+
+	t.Run("unregistered type stays metadata", func(t *testing.T) {
+		assert.Nil(t, parseRelationship(types, "PartOf", validAccountID))
+	})
+}
+
 func TestParseManageData(t *testing.T) {
 	validAccountID := "GCNVDZIHGX473FEI7IXCUAEXUJ4BGCKEMHF36VYP5EMS7PX2QBLAMTLA"
 
@@ -275,7 +299,7 @@ func TestParseManageData(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			meta, rels, delegate, councilDelegate, council := parseManageData(tt.data)
+			meta, rels, delegate, councilDelegate, council := parseManageData(tt.data, relationtest.Registry())
 			assert.Len(t, meta, tt.expectedMeta)
 			assert.Len(t, rels, tt.expectedRels)
 			assert.Equal(t, tt.expectedDelegate, delegate)
@@ -369,7 +393,7 @@ func TestParseAccountData(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := parseAccountData(tt.account)
+			result := parseAccountData(tt.account, relationtest.Registry())
 
 			assert.Equal(t, tt.expectedID, result.ID)
 			assert.Len(t, result.Balances, tt.expectedBalLen)
@@ -397,6 +421,26 @@ func TestParseAccountData(t *testing.T) {
 	}
 }
 
+func TestParseAccountDataSigners(t *testing.T) {
+	account := &horizon.Account{
+		ID: "GCNVDZIHGX473FEI7IXCUAEXUJ4BGCKEMHF36VYP5EMS7PX2QBLAMTLA",
+		Signers: []horizon.Signer{
+			{Key: "GCNVDZIHGX473FEI7IXCUAEXUJ4BGCKEMHF36VYP5EMS7PX2QBLAMTLA", Type: "ed25519_public_key", Weight: 0},
+			{Key: "GDLTH4KKMA4R2JGKA7XKI5DLHJBUT42D5RHVK6SS6YHZZLHVLCWJAYXI", Type: "ed25519_public_key", Weight: 2},
+			{Key: "GAYQWZGZIBOQUC6B5KEQYDEJH6ZVKC7NO7OMBIU2TLB6QJNMGUNANDVA", Type: "ed25519_public_key", Weight: 1},
+		},
+		Thresholds: horizon.AccountThresholds{LowThreshold: 1, MedThreshold: 2, HighThreshold: 3},
+	}
+
+	result := parseAccountData(account, relationtest.Registry())
+
+	assert.Equal(t, []Signer{
+		{Key: "GDLTH4KKMA4R2JGKA7XKI5DLHJBUT42D5RHVK6SS6YHZZLHVLCWJAYXI", Type: "ed25519_public_key", Weight: 2},
+		{Key: "GAYQWZGZIBOQUC6B5KEQYDEJH6ZVKC7NO7OMBIU2TLB6QJNMGUNANDVA", Type: "ed25519_public_key", Weight: 1},
+	}, result.Signers, "disabled master key is dropped")
+	assert.Equal(t, Thresholds{Low: 1, Med: 2, High: 3}, result.Thresholds)
+}
+
 func TestFindBalance(t *testing.T) {
 	balances := []Balance{
 		{AssetCode: "XLM", AssetIssuer: "", Balance: decimal.RequireFromString("100.0000000")},
@@ -550,7 +594,7 @@ func TestParseManageDataEdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, _, delegate, _, council := parseManageData(tt.data)
+			_, _, delegate, _, council := parseManageData(tt.data, relationtest.Registry())
 			assert.Equal(t, tt.expectedCouncil, council)
 
 			if tt.name == "delegate with invalid account ID (too short)" || tt.name == "delegate with invalid account ID (wrong prefix)" {
@@ -563,7 +607,7 @@ func TestParseManageDataEdgeCases(t *testing.T) {
 		data := map[string]string{
 			"mtla_delegate": base64.StdEncoding.EncodeToString([]byte(validAccountID)),
 		}
-		_, _, delegate, _, _ := parseManageData(data)
+		_, _, delegate, _, _ := parseManageData(data, relationtest.Registry())
 		assert.NotNil(t, delegate)
 		assert.Equal(t, validAccountID, *delegate)
 	})
@@ -572,7 +616,7 @@ func TestParseManageDataEdgeCases(t *testing.T) {
 		data := map[string]string{
 			"mtla_c_delegate": base64.StdEncoding.EncodeToString([]byte(validAccountID)),
 		}
-		_, _, _, councilDelegate, council := parseManageData(data)
+		_, _, _, councilDelegate, council := parseManageData(data, relationtest.Registry())
 		assert.NotNil(t, councilDelegate)
 		assert.Equal(t, validAccountID, *councilDelegate)
 		assert.False(t, council) // Not council-ready, just delegating
@@ -586,7 +630,7 @@ func TestParseManageDataNumberedKeysSorting(t *testing.T) {
 		"Website1": base64.StdEncoding.EncodeToString([]byte("https://second.com")),
 	}
 
-	meta, _, _, _, _ := parseManageData(data)
+	meta, _, _, _, _ := parseManageData(data, relationtest.Registry())
 
 	var websites []Metadata
 	for _, m := range meta {
@@ -0,0 +1,257 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mtlprog/lore/internal/events"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+// Snapshot is the part of the synced data that change events are derived
+// from. Sync takes one before and one after writing and stores the difference.
+type Snapshot struct {
+	Accounts      map[string]AccountState
+	Relationships map[RelationshipKey]struct{}
+	Reputation    map[string]decimal.Decimal // Weighted score by account
+}
+
+// AccountState holds the account columns watched for changes.
+type AccountState struct {
+	Name              string
+	MTLAPBalance      string
+	MTLACBalance      string
+	MTLAXBalance      string
+	DelegateTo        string
+	CouncilDelegateTo string
+	IsCouncilReady    bool
+	ReceivedVotes     int
+	IsMember          bool // Counted as a participant or member company
+}
+
+// RelationshipKey identifies a declared relation regardless of its index.
+type RelationshipKey struct {
+	Source string
+	Target string
+	Type   string
+}
+
+// fieldChange is one changed field of an account.updated event.
+type fieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// accountField is a named, comparable account value.
+type accountField struct {
+	name  string
+	value any
+}
+
+// updatedFields returns the account.updated fields in a fixed order.
+func (a AccountState) updatedFields() []accountField {
+	return []accountField{
+		{"name", a.Name},
+		{"mtlap_balance", a.MTLAPBalance},
+		{"mtlac_balance", a.MTLACBalance},
+		{"mtlax_balance", a.MTLAXBalance},
+		{"delegate_to", a.DelegateTo},
+		{"council_delegate_to", a.CouncilDelegateTo},
+		{"is_council_ready", a.IsCouncilReady},
+	}
+}
+
+// diffSnapshots returns the events turning before into after, ordered by
+// account. Accounts missing from before are compared against a zero state, so
+// new members, votes and scores are reported; accounts missing from after
+// only report member.left, since a failed step must not look like a reset.
+func diffSnapshots(before, after *Snapshot) ([]events.Event, error) {
+	var out []events.Event
+	add := func(t events.Type, account, related string, data any) error {
+		e, err := events.New(t, account, related, data)
+		if err != nil {
+			return err
+		}
+		out = append(out, e)
+		return nil
+	}
+
+	ids := lo.Uniq(append(lo.Keys(before.Accounts), lo.Keys(after.Accounts)...))
+	slices.Sort(ids)
+
+	for _, id := range ids {
+		prev, existed := before.Accounts[id]
+		next, exists := after.Accounts[id]
+
+		if !exists {
+			if prev.IsMember {
+				if err := add(events.TypeMemberLeft, id, "", membership(prev)); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		if next.IsMember && !prev.IsMember {
+			if err := add(events.TypeMemberJoined, id, "", membership(next)); err != nil {
+				return nil, err
+			}
+		}
+
+		if existed {
+			changes := make(map[string]fieldChange)
+			nextFields := next.updatedFields()
+			for i, f := range prev.updatedFields() {
+				if f.value != nextFields[i].value {
+					changes[f.name] = fieldChange{From: f.value, To: nextFields[i].value}
+				}
+			}
+			if len(changes) > 0 {
+				if err := add(events.TypeAccountUpdated, id, "", map[string]any{"changes": changes}); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if next.ReceivedVotes != prev.ReceivedVotes {
+			data := fieldChange{From: prev.ReceivedVotes, To: next.ReceivedVotes}
+			if err := add(events.TypeCouncilVotesChanged, id, "", data); err != nil {
+				return nil, err
+			}
+		}
+
+		if score, ok := after.Reputation[id]; ok && !score.Equal(before.Reputation[id]) {
+			data := fieldChange{From: json.Number(before.Reputation[id].String()), To: json.Number(score.String())}
+			if err := add(events.TypeReputationChanged, id, "", data); err != nil {
+				return nil, err
+			}
+		}
+
+		if prev.IsMember && !next.IsMember {
+			if err := add(events.TypeMemberLeft, id, "", membership(next)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, key := range sortedRelationships(after.Relationships, before.Relationships) {
+		if err := add(events.TypeRelationshipAdded, key.Source, key.Target, map[string]string{"type": key.Type}); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range sortedRelationships(before.Relationships, after.Relationships) {
+		if err := add(events.TypeRelationshipRemoved, key.Source, key.Target, map[string]string{"type": key.Type}); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// membership is the data of member.joined and member.left events.
+func membership(a AccountState) map[string]string {
+	return map[string]string{"mtlap_balance": a.MTLAPBalance, "mtlac_balance": a.MTLACBalance}
+}
+
+// sortedRelationships returns the keys of a that are not in b, sorted.
+func sortedRelationships(a, b map[RelationshipKey]struct{}) []RelationshipKey {
+	var keys []RelationshipKey
+	for key := range a {
+		if _, ok := b[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(x, y RelationshipKey) int {
+		return strings.Compare(x.Source+"\x00"+x.Type+"\x00"+x.Target, y.Source+"\x00"+y.Type+"\x00"+y.Target)
+	})
+	return keys
+}
+
+// publishChanges stores the events between before and the current data and
+// prunes events past their retention. Events are best effort: failures are
+// logged and never fail the sync.
+func (s *Syncer) publishChanges(ctx context.Context, before *Snapshot) {
+	if before == nil {
+		return
+	}
+	after, err := s.repo.Snapshot(ctx, nil)
+	if err == nil {
+		err = s.storeChanges(ctx, before, after)
+	}
+	if err != nil {
+		s.logger.Error("failed to publish change events", "error", err)
+	}
+}
+
+// publishAccountChanges is publishChanges for a resync of accountID, where
+// before only holds that account. previousDelegations are the delegation
+// results the resync changed; other accounts only changed in received votes,
+// so their state before is taken from the current one.
+func (s *Syncer) publishAccountChanges(ctx context.Context, accountID string, before *Snapshot, previousDelegations map[string]DelegationState) {
+	if before == nil {
+		return
+	}
+	ids := append([]string{accountID}, lo.Keys(previousDelegations)...)
+	after, err := s.repo.Snapshot(ctx, lo.Uniq(ids))
+	if err != nil {
+		s.logger.Error("failed to publish change events", "error", err)
+		return
+	}
+
+	for id, prev := range previousDelegations {
+		if _, ok := before.Accounts[id]; ok {
+			continue
+		}
+		if state, ok := after.Accounts[id]; ok {
+			state.ReceivedVotes = prev.ReceivedVotes
+			before.Accounts[id] = state
+		}
+	}
+	for key := range after.Relationships {
+		if key.Source != accountID {
+			delete(after.Relationships, key)
+		}
+	}
+	for id := range after.Reputation {
+		if _, ok := before.Reputation[id]; !ok {
+			before.Reputation[id] = after.Reputation[id]
+		}
+	}
+
+	if err := s.storeChanges(ctx, before, after); err != nil {
+		s.logger.Error("failed to publish change events", "error", err)
+	}
+}
+
+func (s *Syncer) storeChanges(ctx context.Context, before, after *Snapshot) error {
+	changes, err := diffSnapshots(before, after)
+	if err != nil {
+		return err
+	}
+	if err := s.events.Append(ctx, changes); err != nil {
+		return err
+	}
+
+	pruned, err := s.events.Prune(ctx, time.Now().Add(-events.Retention))
+	if err != nil {
+		return fmt.Errorf("prune events: %w", err)
+	}
+	s.logger.Info("published change events", "count", len(changes), "pruned", pruned)
+	return nil
+}
+
+// snapshot takes the snapshot change events are compared against, limited to
+// accountIDs unless nil. A failure only disables events for this sync.
+func (s *Syncer) snapshot(ctx context.Context, accountIDs []string) *Snapshot {
+	snap, err := s.repo.Snapshot(ctx, accountIDs)
+	if err != nil {
+		s.logger.Error("failed to snapshot data for change events", "error", err)
+		return nil
+	}
+	return snap
+}
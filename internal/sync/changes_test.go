@@ -0,0 +1,108 @@
+package sync
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mtlprog/lore/internal/events"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func emptySnapshot() *Snapshot {
+	return &Snapshot{
+		Accounts:      map[string]AccountState{},
+		Relationships: map[RelationshipKey]struct{}{},
+		Reputation:    map[string]decimal.Decimal{},
+	}
+}
+
+func eventTypes(evs []events.Event) []string {
+	types := make([]string, len(evs))
+	for i, e := range evs {
+		types[i] = e.AccountID + " " + string(e.Type)
+	}
+	return types
+}
+
+func TestDiffSnapshots_NoChanges(t *testing.T) {
+	snap := emptySnapshot()
+	snap.Accounts["GA"] = AccountState{Name: "Alice", MTLAPBalance: "1.0000000", IsMember: true, ReceivedVotes: 3}
+	snap.Relationships[RelationshipKey{"GA", "GB", "Partner"}] = struct{}{}
+	snap.Reputation["GA"] = decimal.RequireFromString("3.5")
+
+	evs, err := diffSnapshots(snap, snap)
+	require.NoError(t, err)
+	assert.Empty(t, evs)
+}
+
+func TestDiffSnapshots_Accounts(t *testing.T) {
+	before := emptySnapshot()
+	before.Accounts["GA"] = AccountState{Name: "Alice", MTLAPBalance: "1.0000000", IsMember: true, ReceivedVotes: 2}
+	before.Accounts["GB"] = AccountState{MTLAPBalance: "1.0000000", IsMember: true}
+	before.Accounts["GC"] = AccountState{MTLACBalance: "1.0000000", IsMember: true}
+	before.Reputation["GA"] = decimal.RequireFromString("3.0000")
+
+	after := emptySnapshot()
+	after.Accounts["GA"] = AccountState{Name: "Alice B", MTLAPBalance: "1.0000000", IsMember: true, ReceivedVotes: 5}
+	after.Accounts["GB"] = AccountState{MTLAPBalance: "0.0000000"}
+	after.Accounts["GD"] = AccountState{MTLAPBalance: "2.0000000", IsMember: true, ReceivedVotes: 1}
+	after.Reputation["GA"] = decimal.RequireFromString("3.2500")
+
+	evs, err := diffSnapshots(before, after)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"GA account.updated",
+		"GA council.votes_changed",
+		"GA reputation.changed",
+		"GB account.updated",
+		"GB member.left",
+		"GC member.left",
+		"GD member.joined",
+		"GD council.votes_changed",
+	}, eventTypes(evs))
+
+	assert.JSONEq(t, `{"changes":{"name":{"from":"Alice","to":"Alice B"}}}`, string(evs[0].Data))
+	assert.JSONEq(t, `{"from":2,"to":5}`, string(evs[1].Data))
+	assert.JSONEq(t, `{"from":3,"to":3.25}`, string(evs[2].Data))
+	assert.JSONEq(t, `{"mtlap_balance":"0.0000000","mtlac_balance":""}`, string(evs[4].Data))
+	assert.JSONEq(t, `{"mtlap_balance":"","mtlac_balance":"1.0000000"}`, string(evs[5].Data))
+}
+
+func TestDiffSnapshots_MissingScoreIsNotAReset(t *testing.T) {
+	before := emptySnapshot()
+	before.Accounts["GA"] = AccountState{}
+	before.Reputation["GA"] = decimal.RequireFromString("2")
+
+	after := emptySnapshot()
+	after.Accounts["GA"] = AccountState{}
+
+	evs, err := diffSnapshots(before, after)
+	require.NoError(t, err)
+	assert.Empty(t, evs)
+}
+
+func TestDiffSnapshots_Relationships(t *testing.T) {
+	before := emptySnapshot()
+	before.Relationships[RelationshipKey{"GA", "GB", "Partner"}] = struct{}{}
+	before.Relationships[RelationshipKey{"GA", "GC", "A"}] = struct{}{}
+
+	after := emptySnapshot()
+	after.Relationships[RelationshipKey{"GA", "GB", "Partner"}] = struct{}{}
+	after.Relationships[RelationshipKey{"GB", "GA", "Partner"}] = struct{}{}
+
+	evs, err := diffSnapshots(before, after)
+	require.NoError(t, err)
+	require.Len(t, evs, 2)
+
+	assert.Equal(t, events.TypeRelationshipAdded, evs[0].Type)
+	assert.Equal(t, "GB", evs[0].AccountID)
+	assert.Equal(t, "GA", evs[0].RelatedAccountID)
+
+	assert.Equal(t, events.TypeRelationshipRemoved, evs[1].Type)
+	assert.Equal(t, "GC", evs[1].RelatedAccountID)
+	var data map[string]string
+	require.NoError(t, json.Unmarshal(evs[1].Data, &data))
+	assert.Equal(t, "A", data["type"])
+}
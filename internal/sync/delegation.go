@@ -3,8 +3,9 @@ package sync
 import (
 	"context"
 	"fmt"
+	"slices"
 
-	"github.com/samber/lo"
+	"github.com/mtlprog/lore/internal/delegation"
 	"github.com/shopspring/decimal"
 )
 
@@ -19,19 +20,67 @@ func (s *Syncer) calculateDelegations(ctx context.Context) error {
 		return fmt.Errorf("get delegation info: %w", err)
 	}
 
-	// Build lookup map using lo.KeyBy
-	accountMap := lo.KeyBy(accounts, func(acc DelegationInfo) string {
-		return acc.AccountID
-	})
-	// Convert to pointer map for mutations
-	accountPtrMap := make(map[string]*DelegationInfo, len(accounts))
-	for i := range accounts {
-		accountPtrMap[accounts[i].AccountID] = &accounts[i]
+	// Accounts without results keep the reset values
+	return s.storeDelegations(ctx, computeDelegations(accounts))
+}
+
+// updateDelegations recomputes delegation chains and council votes, like
+// calculateDelegations, but only writes the accounts whose results changed.
+// It is used after a single account was resynced and returns the previous
+// results of the changed accounts.
+func (s *Syncer) updateDelegations(ctx context.Context) (map[string]DelegationState, error) {
+	accounts, err := s.repo.GetAllDelegationInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get delegation info: %w", err)
+	}
+	stored, err := s.repo.GetDelegationStates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get delegation states: %w", err)
+	}
+
+	changed := changedDelegations(stored, computeDelegations(accounts))
+	previous := make(map[string]DelegationState, len(changed))
+	for id := range changed {
+		previous[id] = stored[id]
 	}
+	return previous, s.storeDelegations(ctx, changed)
+}
 
+// storeDelegations writes delegation results. Failed accounts are logged; the
+// calculation fails only when more than 10 accounts could not be written.
+func (s *Syncer) storeDelegations(ctx context.Context, states map[string]DelegationState) error {
 	var dbErrors []string
+	for id, state := range states {
+		if err := s.repo.SetDelegationState(ctx, id, state); err != nil {
+			s.logger.Error("failed to set delegation state", "account_id", id, "error", err)
+			dbErrors = append(dbErrors, id)
+		}
+	}
 
-	// Step 3: For each account that delegates, trace the chain
+	if len(dbErrors) > 0 {
+		s.logger.Error("delegation calculation had database errors",
+			"failed_count", len(dbErrors),
+			"failed_accounts", dbErrors[:min(10, len(dbErrors))],
+		)
+		if len(dbErrors) > 10 {
+			return fmt.Errorf("too many database errors during delegation calculation: %d failures", len(dbErrors))
+		}
+	}
+
+	return nil
+}
+
+// computeDelegations returns the delegation results of accounts. Accounts
+// without errors or votes are left out.
+func computeDelegations(accounts []DelegationInfo) map[string]DelegationState {
+	accountMap := make(map[string]*DelegationInfo, len(accounts))
+	for i := range accounts {
+		accountMap[accounts[i].AccountID] = &accounts[i]
+	}
+
+	states := make(map[string]DelegationState)
+
+	// For each account that delegates, trace the chain
 	for _, acc := range accounts {
 		if acc.DelegateTo == nil {
 			continue
@@ -39,77 +88,55 @@ func (s *Syncer) calculateDelegations(ctx context.Context) error {
 
 		delegateAcc, exists := accountMap[*acc.DelegateTo]
 		if !exists || delegateAcc.MTLAPBalance.IsZero() {
-			if err := s.repo.SetDelegationError(ctx, acc.AccountID, true); err != nil {
-				s.logger.Error("failed to set delegation error", "account_id", acc.AccountID, "error", err)
-				dbErrors = append(dbErrors, acc.AccountID)
-			}
+			state := states[acc.AccountID]
+			state.DelegationError = true
+			states[acc.AccountID] = state
 			continue
 		}
 
-		path, hasCycle := traceDelegationChain(acc.AccountID, accountPtrMap)
+		path, hasCycle := traceDelegationChain(acc.AccountID, accountMap)
 		if hasCycle {
 			for _, cycleAccID := range path {
-				if err := s.repo.SetCycleError(ctx, cycleAccID, path); err != nil {
-					s.logger.Error("failed to set cycle error", "account_id", cycleAccID, "error", err)
-					dbErrors = append(dbErrors, cycleAccID)
-				}
+				state := states[cycleAccID]
+				state.CycleError = true
+				state.CyclePath = path
+				states[cycleAccID] = state
 			}
 		}
 	}
 
-	// Step 4: Calculate received votes for council-ready accounts
-	councilReadyAccounts := lo.Filter(accounts, func(acc DelegationInfo, _ int) bool {
-		return acc.CouncilReady
-	})
-
-	for _, acc := range councilReadyAccounts {
-		votes := calculateReceivedVotes(acc.AccountID, accountPtrMap)
-		if err := s.repo.SetReceivedVotes(ctx, acc.AccountID, votes); err != nil {
-			s.logger.Error("failed to set received votes", "account_id", acc.AccountID, "error", err)
-			dbErrors = append(dbErrors, acc.AccountID)
+	// Calculate received votes for council-ready accounts
+	for _, acc := range accounts {
+		if !acc.CouncilReady {
+			continue
 		}
-	}
-
-	if len(dbErrors) > 0 {
-		s.logger.Error("delegation calculation had database errors",
-			"failed_count", len(dbErrors),
-			"failed_accounts", dbErrors[:min(10, len(dbErrors))],
-		)
-		if len(dbErrors) > 10 {
-			return fmt.Errorf("too many database errors during delegation calculation: %d failures", len(dbErrors))
+		if votes := calculateReceivedVotes(acc.AccountID, accountMap); votes != 0 {
+			state := states[acc.AccountID]
+			state.ReceivedVotes = votes
+			states[acc.AccountID] = state
 		}
 	}
 
-	return nil
+	return states
 }
 
-// traceDelegationChain follows the delegation chain and detects cycles.
-func traceDelegationChain(startID string, accountMap map[string]*DelegationInfo) ([]string, bool) {
-	visited := make(map[string]bool)
-	var path []string
-
-	current := startID
-	for {
-		if visited[current] {
-			cycleStart := lo.IndexOf(path, current)
-			if cycleStart >= 0 {
-				return path[cycleStart:], true
-			}
-			return path, true
+// changedDelegations returns the computed results that differ from the stored
+// ones, including zero results of accounts that no longer have any.
+func changedDelegations(stored, computed map[string]DelegationState) map[string]DelegationState {
+	changed := make(map[string]DelegationState)
+	for id, prev := range stored {
+		next := computed[id]
+		if prev.DelegationError != next.DelegationError || prev.CycleError != next.CycleError ||
+			prev.ReceivedVotes != next.ReceivedVotes || !slices.Equal(prev.CyclePath, next.CyclePath) {
+			changed[id] = next
 		}
-
-		visited[current] = true
-		path = append(path, current)
-
-		acc, exists := accountMap[current]
-		if !exists || acc.DelegateTo == nil {
-			break
-		}
-
-		current = *acc.DelegateTo
 	}
+	return changed
+}
 
-	return path, false
+// traceDelegationChain follows the delegation chain and detects cycles.
+func traceDelegationChain(startID string, accountMap map[string]*DelegationInfo) ([]string, bool) {
+	return delegation.TraceChain(startID, accountMap)
 }
 
 // calculateReceivedVotes sums MTLAP from all accounts that delegate council votes to the target.
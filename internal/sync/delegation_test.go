@@ -272,3 +272,48 @@ func TestGetFinalCouncilDelegationTarget(t *testing.T) {
 		})
 	}
 }
+
+func TestComputeDelegations(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	one := decimal.NewFromInt(1)
+
+	accounts := []DelegationInfo{
+		{AccountID: "A", DelegateTo: strPtr("B"), MTLAPBalance: one},
+		{AccountID: "B", DelegateTo: strPtr("A"), MTLAPBalance: one},
+		{AccountID: "C", DelegateTo: strPtr("X"), MTLAPBalance: one},
+		{AccountID: "D", MTLAPBalance: decimal.NewFromInt(10), CouncilReady: true},
+		{AccountID: "E", CouncilDelegateTo: strPtr("D"), MTLAPBalance: decimal.NewFromInt(5)},
+		{AccountID: "F", MTLAPBalance: one},
+	}
+
+	states := computeDelegations(accounts)
+
+	assert.Len(t, states, 4)
+	assert.True(t, states["A"].CycleError)
+	assert.True(t, states["B"].CycleError)
+	assert.ElementsMatch(t, []string{"A", "B"}, states["A"].CyclePath)
+	assert.Equal(t, DelegationState{DelegationError: true}, states["C"])
+	assert.Equal(t, DelegationState{ReceivedVotes: 5}, states["D"])
+	assert.NotContains(t, states, "E")
+	assert.NotContains(t, states, "F")
+}
+
+func TestChangedDelegations(t *testing.T) {
+	stored := map[string]DelegationState{
+		"A": {CycleError: true, CyclePath: []string{"A", "B"}},
+		"B": {CycleError: true, CyclePath: []string{"A", "B"}},
+		"C": {ReceivedVotes: 5},
+		"D": {},
+	}
+	computed := map[string]DelegationState{
+		"A": {CycleError: true, CyclePath: []string{"A", "B"}},
+		"C": {ReceivedVotes: 8},
+		"D": {DelegationError: true},
+	}
+
+	assert.Equal(t, map[string]DelegationState{
+		"B": {},
+		"C": {ReceivedVotes: 8},
+		"D": {DelegationError: true},
+	}, changedDelegations(stored, computed))
+}
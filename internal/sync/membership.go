@@ -0,0 +1,162 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/mtlprog/lore/internal/config"
+	"github.com/shopspring/decimal"
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/protocols/horizon/base"
+	"github.com/stellar/go/protocols/horizon/operations"
+)
+
+// membershipAssets are the issuer tokens whose operations are indexed.
+var membershipAssets = map[string]bool{
+	config.TokenMTLAP: true,
+	config.TokenMTLAC: true,
+	config.TokenMTLAX: true,
+}
+
+// syncMembershipEvents indexes the issuer's payment, clawback and
+// set_trust_line_flags operations on membership tokens. It resumes after the
+// last processed issuer operation, so only new operations are fetched on each run.
+func (s *Syncer) syncMembershipEvents(ctx context.Context) error {
+	cursor, err := s.repo.GetMembershipCursor(ctx)
+	if err != nil {
+		return fmt.Errorf("get membership cursor: %w", err)
+	}
+
+	balances, err := s.repo.GetMembershipBalances(ctx)
+	if err != nil {
+		return fmt.Errorf("get membership balances: %w", err)
+	}
+
+	var total int
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		page, err := s.horizon.Operations(horizonclient.OperationRequest{
+			ForAccount: config.TokenIssuer,
+			Cursor:     cursor,
+			Limit:      horizonPageLimit,
+			Order:      horizonclient.OrderAsc,
+		})
+		if err != nil {
+			return fmt.Errorf("fetch issuer operations: %w", err)
+		}
+
+		var events []MembershipEvent
+		for _, op := range page.Embedded.Records {
+			cursor = op.PagingToken()
+
+			event, ok, err := classifyMembershipOperation(op, config.TokenIssuer, balances)
+			if err != nil {
+				s.logger.Warn("skipping issuer operation", "operation_id", op.GetID(), "error", err)
+				continue
+			}
+			if ok {
+				events = append(events, event)
+			}
+		}
+
+		if err := s.repo.InsertMembershipEvents(ctx, events, cursor); err != nil {
+			return fmt.Errorf("insert membership events: %w", err)
+		}
+		total += len(events)
+
+		if len(page.Embedded.Records) < horizonPageLimit {
+			break
+		}
+	}
+
+	s.logger.Info("indexed membership events", "count", total)
+	return nil
+}
+
+// classifyMembershipOperation turns an issuer operation into a membership event.
+// It returns false for operations on other assets, failed transactions and
+// payments that do not move tokens between the issuer and a holder.
+// balances holds the running balance per account and asset and is updated in place.
+func classifyMembershipOperation(op operations.Operation, issuer string, balances map[string]decimal.Decimal) (MembershipEvent, bool, error) {
+	var (
+		event  MembershipEvent
+		asset  base.Asset
+		amount string
+	)
+
+	switch o := op.(type) {
+	case operations.Payment:
+		asset, amount = o.Asset, o.Amount
+		switch {
+		case o.From == issuer && o.To != issuer:
+			event.AccountID, event.Type = o.To, EventLevelChange
+		case o.To == issuer && o.From != issuer:
+			event.AccountID, event.Type = o.From, EventReturned
+		default:
+			return event, false, nil
+		}
+	case operations.Clawback:
+		asset, amount = o.Asset, o.Amount
+		event.AccountID, event.Type = o.From, EventClawback
+	case operations.SetTrustLineFlags:
+		asset = o.Asset
+		event.AccountID = o.Trustor
+		switch {
+		case slices.Contains(o.SetFlagsS, "authorized"):
+			event.Type = EventAuthorized
+		case slices.Contains(o.ClearFlagsS, "authorized"):
+			event.Type = EventDeauthorized
+		default:
+			return event, false, nil
+		}
+	default:
+		return event, false, nil
+	}
+
+	if asset.Issuer != issuer || !membershipAssets[asset.Code] || !op.IsTransactionSuccessful() {
+		return event, false, nil
+	}
+
+	id, err := strconv.ParseInt(op.GetID(), 10, 64)
+	if err != nil {
+		return event, false, fmt.Errorf("parse operation id: %w", err)
+	}
+
+	if amount != "" {
+		event.Amount, err = decimal.NewFromString(amount)
+		if err != nil {
+			return event, false, fmt.Errorf("parse amount: %w", err)
+		}
+	}
+
+	key := event.AccountID + ":" + asset.Code
+	event.BalanceBefore = balances[key]
+	switch event.Type {
+	case EventLevelChange:
+		event.BalanceAfter = event.BalanceBefore.Add(event.Amount)
+		if event.BalanceBefore.IsZero() {
+			event.Type = EventReceived
+		}
+	case EventReturned, EventClawback:
+		// History before the first indexed operation is unknown; never go negative.
+		event.BalanceAfter = decimal.Max(event.BalanceBefore.Sub(event.Amount), decimal.Zero)
+	default:
+		event.BalanceAfter = event.BalanceBefore
+	}
+	balances[key] = event.BalanceAfter
+
+	b := op.GetBase()
+	event.OperationID = id
+	event.AssetCode = asset.Code
+	event.TxHash = op.GetTransactionHash()
+	event.CreatedAt = b.LedgerCloseTime
+
+	return event, true, nil
+}
@@ -0,0 +1,79 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stellar/go/protocols/horizon/base"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyMembershipOperation(t *testing.T) {
+	const (
+		issuer = "GISSUER"
+		member = "GMEMBER"
+	)
+	mtlap := base.Asset{Type: "credit_alphanum12", Code: "MTLAP", Issuer: issuer}
+	opBase := func(id string) operations.Base {
+		return operations.Base{ID: id, TransactionSuccessful: true, TransactionHash: "hash" + id, LedgerCloseTime: time.Unix(1700000000, 0)}
+	}
+
+	balances := make(map[string]decimal.Decimal)
+	classify := func(op operations.Operation) (MembershipEvent, bool) {
+		t.Helper()
+		event, ok, err := classifyMembershipOperation(op, issuer, balances)
+		require.NoError(t, err)
+		return event, ok
+	}
+
+	event, ok := classify(operations.Payment{Base: opBase("1"), Asset: mtlap, From: issuer, To: member, Amount: "1.0000000"})
+	require.True(t, ok)
+	assert.Equal(t, EventReceived, event.Type)
+	assert.Equal(t, int64(1), event.OperationID)
+	assert.Equal(t, member, event.AccountID)
+	assert.Equal(t, "hash1", event.TxHash)
+	assert.True(t, event.BalanceAfter.Equal(decimal.NewFromInt(1)))
+
+	event, ok = classify(operations.Payment{Base: opBase("2"), Asset: mtlap, From: issuer, To: member, Amount: "2"})
+	require.True(t, ok)
+	assert.Equal(t, EventLevelChange, event.Type)
+	assert.True(t, event.BalanceBefore.Equal(decimal.NewFromInt(1)))
+	assert.True(t, event.BalanceAfter.Equal(decimal.NewFromInt(3)))
+
+	event, ok = classify(operations.Payment{Base: opBase("3"), Asset: mtlap, From: member, To: issuer, Amount: "1"})
+	require.True(t, ok)
+	assert.Equal(t, EventReturned, event.Type)
+	assert.True(t, event.BalanceAfter.Equal(decimal.NewFromInt(2)))
+
+	event, ok = classify(operations.Clawback{Base: opBase("4"), Asset: mtlap, From: member, Amount: "5"})
+	require.True(t, ok)
+	assert.Equal(t, EventClawback, event.Type)
+	assert.True(t, event.BalanceAfter.IsZero(), "balance never goes negative")
+
+	event, ok = classify(operations.SetTrustLineFlags{Base: opBase("5"), Asset: mtlap, Trustor: member, ClearFlagsS: []string{"authorized"}})
+	require.True(t, ok)
+	assert.Equal(t, EventDeauthorized, event.Type)
+	assert.True(t, event.Amount.IsZero())
+
+	event, ok = classify(operations.Payment{Base: opBase("6"), Asset: mtlap, From: issuer, To: member, Amount: "1"})
+	require.True(t, ok)
+	assert.Equal(t, EventReceived, event.Type, "receiving again after a clawback counts as joining")
+
+	t.Run("ignored operations", func(t *testing.T) {
+		failed := opBase("7")
+		failed.TransactionSuccessful = false
+		for name, op := range map[string]operations.Operation{
+			"other asset":        operations.Payment{Base: opBase("8"), Asset: base.Asset{Code: "EURMTL", Issuer: issuer}, From: issuer, To: member, Amount: "1"},
+			"foreign issuer":     operations.Payment{Base: opBase("9"), Asset: base.Asset{Code: "MTLAP", Issuer: "GOTHER"}, From: issuer, To: member, Amount: "1"},
+			"failed transaction": operations.Payment{Base: failed, Asset: mtlap, From: issuer, To: member, Amount: "1"},
+			"unrelated flags":    operations.SetTrustLineFlags{Base: opBase("10"), Asset: mtlap, Trustor: member, SetFlagsS: []string{"clawback_enabled"}},
+			"other operation":    operations.CreateAccount{Base: opBase("11")},
+		} {
+			_, ok := classify(op)
+			assert.False(t, ok, name)
+		}
+	})
+}
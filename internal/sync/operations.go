@@ -0,0 +1,143 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/service"
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"golang.org/x/sync/semaphore"
+)
+
+const (
+	// operationsBackfillPages limits how much history is fetched, newest first,
+	// for an account that has no indexed operations yet.
+	operationsBackfillPages = 5
+	// operationsMaxPages limits how many new pages are fetched per account and run.
+	// An account that is further behind catches up on the next runs.
+	operationsMaxPages = 25
+)
+
+// syncOperations indexes new operations for the given accounts concurrently.
+// Failures are logged per account; the number of failed accounts is returned.
+func (s *Syncer) syncOperations(ctx context.Context, accountIDs []string) int {
+	sem := semaphore.NewWeighted(concurrentLimit)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failed int
+
+	for _, id := range accountIDs {
+		if err := sem.Acquire(ctx, 1); err != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(accountID string) {
+			defer wg.Done()
+			defer sem.Release(1)
+
+			if err := s.syncAccountOperations(ctx, accountID); err != nil {
+				s.logger.Error("failed to index operations", "account", accountID, "error", err)
+				mu.Lock()
+				failed++
+				mu.Unlock()
+			}
+		}(id)
+	}
+
+	wg.Wait()
+	return failed
+}
+
+// syncAccountOperations fetches the operations of an account that are newer than
+// the last indexed one. Without indexed operations the most recent history is
+// backfilled instead.
+func (s *Syncer) syncAccountOperations(ctx context.Context, accountID string) error {
+	cursor, err := s.repo.GetOperationsCursor(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("get operations cursor: %w", err)
+	}
+
+	order, maxPages := horizonclient.OrderAsc, operationsMaxPages
+	if cursor == "" {
+		order, maxPages = horizonclient.OrderDesc, operationsBackfillPages
+	}
+
+	for range maxPages {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		page, err := s.horizon.Operations(horizonclient.OperationRequest{
+			ForAccount: accountID,
+			Cursor:     cursor,
+			Limit:      horizonPageLimit,
+			Order:      order,
+		})
+		if err != nil {
+			return fmt.Errorf("fetch operations page: %w", err)
+		}
+
+		ops := make([]AccountOperation, 0, len(page.Embedded.Records))
+		for _, op := range page.Embedded.Records {
+			cursor = op.PagingToken()
+
+			indexed, err := newAccountOperation(accountID, op)
+			if err != nil {
+				s.logger.Warn("skipping operation", "account", accountID, "operation_id", op.GetID(), "error", err)
+				continue
+			}
+			ops = append(ops, indexed)
+		}
+
+		if err := s.repo.InsertOperations(ctx, accountID, ops); err != nil {
+			return fmt.Errorf("insert operations: %w", err)
+		}
+
+		if len(page.Embedded.Records) < horizonPageLimit {
+			break
+		}
+	}
+
+	return nil
+}
+
+// newAccountOperation converts a Horizon operation the same way the account page
+// does and derives the fields used for filtering.
+func newAccountOperation(accountID string, op operations.Operation) (AccountOperation, error) {
+	id, err := strconv.ParseInt(op.GetID(), 10, 64)
+	if err != nil {
+		return AccountOperation{}, fmt.Errorf("parse operation id: %w", err)
+	}
+
+	converted := service.ConvertOperation(op)
+	return AccountOperation{
+		Operation:    converted,
+		OperationID:  id,
+		CreatedAt:    op.GetBase().LedgerCloseTime,
+		Counterparty: operationCounterparty(accountID, converted),
+		Spam:         service.IsSpamOperation(converted),
+	}, nil
+}
+
+// operationCounterparty returns the other account involved in an operation:
+// the other side of a transfer, or the source account when someone else acted
+// on the account. It is empty for the account's own non-transfer operations.
+func operationCounterparty(accountID string, op model.Operation) string {
+	switch {
+	case op.From == accountID && op.To != "":
+		return op.To
+	case op.To == accountID && op.From != "":
+		return op.From
+	case op.SourceAccount != accountID:
+		return op.SourceAccount
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,84 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/stellar/go/protocols/horizon/base"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationCounterparty(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       model.Operation
+		expected string
+	}{
+		{"outgoing payment", model.Operation{SourceAccount: "GME", From: "GME", To: "GBOB"}, "GBOB"},
+		{"incoming payment", model.Operation{SourceAccount: "GBOB", From: "GBOB", To: "GME"}, "GBOB"},
+		{"own trustline", model.Operation{SourceAccount: "GME"}, ""},
+		{"flags set by issuer", model.Operation{SourceAccount: "GISSUER"}, "GISSUER"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, operationCounterparty("GME", tt.op))
+		})
+	}
+}
+
+func TestNewAccountOperation(t *testing.T) {
+	closed := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	payment := operations.Payment{
+		Base: operations.Base{
+			ID: "123456", Type: "payment", SourceAccount: "GBOB",
+			LedgerCloseTime: closed, TransactionHash: "abc",
+		},
+		Asset:  base.Asset{Type: "native"},
+		From:   "GBOB",
+		To:     "GME",
+		Amount: "0.0100000",
+	}
+
+	op, err := newAccountOperation("GME", payment)
+	require.NoError(t, err)
+	assert.Equal(t, int64(123456), op.OperationID)
+	assert.Equal(t, closed, op.CreatedAt)
+	assert.Equal(t, "GBOB", op.Counterparty)
+	assert.Equal(t, "XLM", op.AssetCode)
+	assert.Equal(t, "Payment", op.TypeDisplay)
+	assert.True(t, op.Spam, "XLM payments below 1 are spam")
+
+	payment.ID = "not-a-number"
+	_, err = newAccountOperation("GME", payment)
+	assert.Error(t, err)
+}
+
+func TestNewAccountOperation_PathPaymentIssuers(t *testing.T) {
+	pathPayment := operations.PathPaymentStrictSend{
+		Payment: operations.Payment{
+			Base: operations.Base{
+				ID: "123457", Type: "path_payment_strict_send", SourceAccount: "GME",
+				LedgerCloseTime: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+			},
+			Asset:  base.Asset{Type: "credit_alphanum12", Code: "EURMTL", Issuer: "GEURISSUER"},
+			From:   "GME",
+			To:     "GBOB",
+			Amount: "10.0000000",
+		},
+		SourceAmount:      "25.0000000",
+		SourceAssetType:   "credit_alphanum4",
+		SourceAssetCode:   "MTL",
+		SourceAssetIssuer: "GMTLISSUER",
+	}
+
+	op, err := newAccountOperation("GME", pathPayment)
+	require.NoError(t, err)
+	assert.Equal(t, "EURMTL", op.DestAsset)
+	assert.Equal(t, "GEURISSUER", op.DestAssetIssuer)
+	assert.Equal(t, "MTL", op.SourceAsset)
+	assert.Equal(t, "GMTLISSUER", op.SourceAssetIssuer)
+}
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/mtlprog/lore/internal/database"
 	"github.com/shopspring/decimal"
@@ -26,6 +28,59 @@ func NewRepository(pool *pgxpool.Pool) (*Repository, error) {
 	return &Repository{pool: pool}, nil
 }
 
+// syncLockID is the advisory lock held by runs and single-account resyncs, so
+// their before and after snapshots never interleave and every change is
+// published once.
+const syncLockID = 0x6c6f7273 // "lors"
+
+// Lock waits for the sync lock, held across processes by the session of a
+// dedicated connection. The returned function releases it.
+func (r *Repository) Lock(ctx context.Context) (func(), error) {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire connection: %w", err)
+	}
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", syncLockID); err != nil {
+		// The lock may have been granted just before the wait was canceled;
+		// closing the session is the only way to be sure it is released
+		_ = conn.Conn().Close(context.Background())
+		conn.Release()
+		return nil, fmt.Errorf("acquire sync lock: %w", err)
+	}
+
+	return func() {
+		if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", syncLockID); err != nil {
+			_ = conn.Conn().Close(context.Background())
+		}
+		conn.Release()
+	}, nil
+}
+
+// TryLock takes the sync lock if it is free. ok is false when a run or another
+// resync holds it; the returned function releases the lock otherwise.
+func (r *Repository) TryLock(ctx context.Context) (unlock func(), ok bool, err error) {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("acquire connection: %w", err)
+	}
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", syncLockID).Scan(&ok); err != nil {
+		_ = conn.Conn().Close(context.Background())
+		conn.Release()
+		return nil, false, fmt.Errorf("try sync lock: %w", err)
+	}
+	if !ok {
+		conn.Release()
+		return nil, false, nil
+	}
+
+	return func() {
+		if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", syncLockID); err != nil {
+			_ = conn.Conn().Close(context.Background())
+		}
+		conn.Release()
+	}, true, nil
+}
+
 // Pool returns the underlying database pool.
 func (r *Repository) Pool() *pgxpool.Pool {
 	return r.pool
@@ -36,6 +91,7 @@ var allowedTruncateTables = map[string]bool{
 	"reputation_scores": true,
 	"association_tags":  true,
 	"relationships":     true,
+	"account_signers":   true,
 	"account_metadata":  true,
 	"account_balances":  true,
 	"token_prices":      true,
@@ -44,12 +100,14 @@ var allowedTruncateTables = map[string]bool{
 	"account_lp_shares": true,
 }
 
-// Truncate clears all syncable tables (preserves settings tables).
+// Truncate clears all syncable tables (preserves settings tables and the
+// append-only membership_events and operations history, which is never re-fetched).
 func (r *Repository) Truncate(ctx context.Context) error {
 	tables := []string{
 		"reputation_scores",
 		"association_tags",
 		"relationships",
+		"account_signers",
 		"account_metadata",
 		"account_balances",
 		"account_lp_shares",
@@ -90,6 +148,9 @@ func (r *Repository) UpsertAccount(ctx context.Context, data *AccountData) error
 			"delegate_to",
 			"council_delegate_to",
 			"is_council_ready",
+			"low_threshold",
+			"med_threshold",
+			"high_threshold",
 			"updated_at",
 		).
 		Values(
@@ -102,6 +163,9 @@ func (r *Repository) UpsertAccount(ctx context.Context, data *AccountData) error
 			data.DelegateTo,
 			data.CouncilDelegateTo,
 			data.CouncilReady,
+			data.Thresholds.Low,
+			data.Thresholds.Med,
+			data.Thresholds.High,
 			"NOW()",
 		).
 		Suffix(`ON CONFLICT (account_id) DO UPDATE SET
@@ -113,6 +177,9 @@ func (r *Repository) UpsertAccount(ctx context.Context, data *AccountData) error
 			delegate_to = EXCLUDED.delegate_to,
 			council_delegate_to = EXCLUDED.council_delegate_to,
 			is_council_ready = EXCLUDED.is_council_ready,
+			low_threshold = EXCLUDED.low_threshold,
+			med_threshold = EXCLUDED.med_threshold,
+			high_threshold = EXCLUDED.high_threshold,
 			updated_at = NOW()`).
 		ToSql()
 	if err != nil {
@@ -250,6 +317,208 @@ func (r *Repository) UpsertRelationships(ctx context.Context, accountID string,
 	return nil
 }
 
+// UpsertSigners replaces the signers of an account within a transaction.
+// Unlike other account data the old rows are removed even if the account has no
+// signers left, so a removed key stops showing up as controlling the account.
+func (r *Repository) UpsertSigners(ctx context.Context, accountID string, signers []Signer) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx, "DELETE FROM account_signers WHERE account_id = $1", accountID)
+	if err != nil {
+		return fmt.Errorf("delete existing signers: %w", err)
+	}
+
+	if len(signers) > 0 {
+		query := database.QB.Insert("account_signers").
+			Columns("account_id", "signer_key", "signer_type", "weight")
+
+		for _, signer := range signers {
+			query = query.Values(accountID, signer.Key, signer.Type, signer.Weight)
+		}
+
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return fmt.Errorf("build insert query: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("exec insert: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// membershipCursorName is the sync_cursors row of the membership indexer.
+const membershipCursorName = "membership_events"
+
+// GetMembershipCursor returns the paging token to resume indexing issuer operations
+// from, or an empty string if nothing is indexed yet. Databases indexed before
+// sync_cursors existed resume after the last stored event.
+func (r *Repository) GetMembershipCursor(ctx context.Context) (string, error) {
+	var cursor string
+	err := r.pool.QueryRow(ctx, "SELECT paging_token FROM sync_cursors WHERE name = $1", membershipCursorName).Scan(&cursor)
+	if err == nil {
+		return cursor, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("query membership cursor: %w", err)
+	}
+
+	var last *int64
+	if err := r.pool.QueryRow(ctx, "SELECT MAX(operation_id) FROM membership_events").Scan(&last); err != nil {
+		return "", fmt.Errorf("query last membership event: %w", err)
+	}
+	if last == nil {
+		return "", nil
+	}
+	return strconv.FormatInt(*last, 10), nil
+}
+
+// GetMembershipBalances returns the balance after the latest membership event,
+// keyed by "account_id:asset_code".
+func (r *Repository) GetMembershipBalances(ctx context.Context) (map[string]decimal.Decimal, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT DISTINCT ON (account_id, asset_code) account_id, asset_code, balance_after
+		FROM membership_events
+		ORDER BY account_id, asset_code, operation_id DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query membership balances: %w", err)
+	}
+	defer rows.Close()
+
+	balances := make(map[string]decimal.Decimal)
+	for rows.Next() {
+		var accountID, assetCode string
+		var balance decimal.Decimal
+		if err := rows.Scan(&accountID, &assetCode, &balance); err != nil {
+			return nil, fmt.Errorf("scan membership balance: %w", err)
+		}
+		balances[accountID+":"+assetCode] = balance
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate membership balances: %w", err)
+	}
+
+	return balances, nil
+}
+
+// InsertMembershipEvents stores the membership events of a page of issuer
+// operations and moves the membership cursor to the page's last paging token in
+// the same transaction. Events that are already stored are skipped, so
+// re-indexing a page is harmless.
+func (r *Repository) InsertMembershipEvents(ctx context.Context, events []MembershipEvent, cursor string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if len(events) > 0 {
+		query := database.QB.Insert("membership_events").
+			Columns("operation_id", "account_id", "asset_code", "event_type", "amount",
+				"balance_before", "balance_after", "tx_hash", "created_at")
+
+		for _, e := range events {
+			query = query.Values(e.OperationID, e.AccountID, e.AssetCode, string(e.Type), e.Amount,
+				e.BalanceBefore, e.BalanceAfter, e.TxHash, e.CreatedAt)
+		}
+
+		sql, args, err := query.Suffix("ON CONFLICT (operation_id) DO NOTHING").ToSql()
+		if err != nil {
+			return fmt.Errorf("build insert query: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			return fmt.Errorf("exec insert: %w", err)
+		}
+	}
+
+	if cursor != "" {
+		sql, args, err := database.QB.Insert("sync_cursors").
+			Columns("name", "paging_token").
+			Values(membershipCursorName, cursor).
+			Suffix("ON CONFLICT (name) DO UPDATE SET paging_token = EXCLUDED.paging_token, updated_at = NOW()").
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("build cursor query: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			return fmt.Errorf("update membership cursor: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetOperationsCursor returns the paging token of the newest indexed operation
+// of an account, or an empty string if none is indexed yet.
+func (r *Repository) GetOperationsCursor(ctx context.Context, accountID string) (string, error) {
+	var last *int64
+	err := r.pool.QueryRow(ctx, "SELECT MAX(operation_id) FROM operations WHERE account_id = $1", accountID).Scan(&last)
+	if err != nil {
+		return "", fmt.Errorf("query last operation: %w", err)
+	}
+	if last == nil {
+		return "", nil
+	}
+	return strconv.FormatInt(*last, 10), nil
+}
+
+// InsertOperations stores indexed operations of an account, skipping ones that
+// are already stored.
+func (r *Repository) InsertOperations(ctx context.Context, accountID string, ops []AccountOperation) error {
+	if len(ops) == 0 {
+		return nil
+	}
+
+	query := database.QB.Insert("operations").
+		Columns("account_id", "operation_id", "op_type", "type_display", "type_category",
+			"created_at", "tx_hash", "source_account", "counterparty", "is_spam",
+			"amount", "asset_code", "asset_issuer", "from_account", "to_account",
+			"data_name", "data_value", "starting_balance", "trust_limit",
+			"source_amount", "source_asset", "source_asset_issuer",
+			"dest_amount", "dest_asset", "dest_asset_issuer",
+			"selling", "buying", "price", "offer_id")
+
+	for _, op := range ops {
+		query = query.Values(accountID, op.OperationID, op.Type, op.TypeDisplay, op.TypeCategory,
+			op.CreatedAt, op.TransactionHash, op.SourceAccount, op.Counterparty, op.Spam,
+			op.Amount, op.AssetCode, op.AssetIssuer, op.From, op.To,
+			op.DataName, op.DataValue, op.StartingBalance, op.TrustLimit,
+			op.SourceAmount, op.SourceAsset, op.SourceAssetIssuer,
+			op.DestAmount, op.DestAsset, op.DestAssetIssuer,
+			op.Selling, op.Buying, op.Price, op.OfferID)
+	}
+
+	sql, args, err := query.Suffix("ON CONFLICT (account_id, operation_id) DO NOTHING").ToSql()
+	if err != nil {
+		return fmt.Errorf("build insert query: %w", err)
+	}
+
+	if _, err := r.pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("exec insert: %w", err)
+	}
+
+	return nil
+}
+
 // GetUniqueAssets returns all unique assets from account_balances.
 func (r *Repository) GetUniqueAssets(ctx context.Context) ([]Asset, error) {
 	rows, err := r.pool.Query(ctx, `
@@ -347,11 +616,13 @@ func (r *Repository) ResetDelegations(ctx context.Context) error {
 	return nil
 }
 
-// GetAllDelegationInfo returns delegation info for all accounts.
+// GetAllDelegationInfo returns delegation info for all accounts, ordered by
+// account ID so cycle paths come out the same on every calculation.
 func (r *Repository) GetAllDelegationInfo(ctx context.Context) ([]DelegationInfo, error) {
 	rows, err := r.pool.Query(ctx, `
 		SELECT account_id, delegate_to, council_delegate_to, mtlap_balance, is_council_ready
 		FROM accounts
+		ORDER BY account_id
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("query delegation info: %w", err)
@@ -374,35 +645,50 @@ func (r *Repository) GetAllDelegationInfo(ctx context.Context) ([]DelegationInfo
 	return infos, nil
 }
 
-// SetDelegationError marks an account as having a delegation error.
-func (r *Repository) SetDelegationError(ctx context.Context, accountID string, hasError bool) error {
-	_, err := r.pool.Exec(ctx, `
-		UPDATE accounts SET has_delegation_error = $1 WHERE account_id = $2
-	`, hasError, accountID)
+// GetDelegationStates returns the stored delegation results of all accounts.
+func (r *Repository) GetDelegationStates(ctx context.Context) (map[string]DelegationState, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT account_id, COALESCE(has_delegation_error, FALSE), COALESCE(has_cycle_error, FALSE),
+			COALESCE(cycle_path, '{}'), COALESCE(received_votes, 0)
+		FROM accounts
+	`)
 	if err != nil {
-		return fmt.Errorf("set delegation error: %w", err)
+		return nil, fmt.Errorf("query delegation states: %w", err)
 	}
-	return nil
-}
+	defer rows.Close()
 
-// SetCycleError marks an account as having a cycle error.
-func (r *Repository) SetCycleError(ctx context.Context, accountID string, path []string) error {
-	_, err := r.pool.Exec(ctx, `
-		UPDATE accounts SET has_cycle_error = TRUE, cycle_path = $1 WHERE account_id = $2
-	`, path, accountID)
-	if err != nil {
-		return fmt.Errorf("set cycle error: %w", err)
+	states := make(map[string]DelegationState)
+	for rows.Next() {
+		var id string
+		var state DelegationState
+		if err := rows.Scan(&id, &state.DelegationError, &state.CycleError, &state.CyclePath, &state.ReceivedVotes); err != nil {
+			return nil, fmt.Errorf("scan delegation state: %w", err)
+		}
+		if len(state.CyclePath) == 0 {
+			state.CyclePath = nil
+		}
+		states[id] = state
 	}
-	return nil
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate delegation states: %w", err)
+	}
+
+	return states, nil
 }
 
-// SetReceivedVotes updates the received_votes for an account.
-func (r *Repository) SetReceivedVotes(ctx context.Context, accountID string, votes int) error {
+// SetDelegationState stores the delegation results of an account.
+func (r *Repository) SetDelegationState(ctx context.Context, accountID string, state DelegationState) error {
 	_, err := r.pool.Exec(ctx, `
-		UPDATE accounts SET received_votes = $1 WHERE account_id = $2
-	`, votes, accountID)
+		UPDATE accounts SET
+			has_delegation_error = $1,
+			has_cycle_error = $2,
+			cycle_path = $3,
+			received_votes = $4
+		WHERE account_id = $5
+	`, state.DelegationError, state.CycleError, state.CyclePath, state.ReceivedVotes, accountID)
 	if err != nil {
-		return fmt.Errorf("set received votes: %w", err)
+		return fmt.Errorf("set delegation state: %w", err)
 	}
 	return nil
 }
@@ -425,6 +711,155 @@ func (r *Repository) GetSyncStats(ctx context.Context) (*SyncStats, error) {
 	return &stats, nil
 }
 
+// Snapshot reads the accounts, relationships and reputation scores that change
+// events are derived from. accountIDs limits it to those accounts and the
+// relationships they declare; nil reads everything.
+func (r *Repository) Snapshot(ctx context.Context, accountIDs []string) (*Snapshot, error) {
+	snap := &Snapshot{
+		Accounts:      make(map[string]AccountState),
+		Relationships: make(map[RelationshipKey]struct{}),
+		Reputation:    make(map[string]decimal.Decimal),
+	}
+
+	// scope limits a query to accountIDs, matched against column
+	scope := func(q sq.SelectBuilder, column string) sq.SelectBuilder {
+		if accountIDs == nil {
+			return q
+		}
+		return q.Where(sq.Eq{column: accountIDs})
+	}
+
+	query, args, err := scope(database.QB.
+		Select(
+			"account_id",
+			"COALESCE(name, '')",
+			"COALESCE(mtlap_balance::text, '')",
+			"COALESCE(mtlac_balance::text, '')",
+			"COALESCE(mtlax_balance::text, '')",
+			"COALESCE(delegate_to, '')",
+			"COALESCE(council_delegate_to, '')",
+			"COALESCE(is_council_ready, FALSE)",
+			"COALESCE(received_votes, 0)",
+			"COALESCE((mtlap_balance > 0 AND mtlap_balance <= 5) OR (mtlac_balance > 0 AND mtlac_balance <= 4), FALSE)",
+		).
+		From("accounts"), "account_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build account snapshot query: %w", err)
+	}
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query account snapshot: %w", err)
+	}
+	for rows.Next() {
+		var id string
+		var a AccountState
+		if err := rows.Scan(&id, &a.Name, &a.MTLAPBalance, &a.MTLACBalance, &a.MTLAXBalance,
+			&a.DelegateTo, &a.CouncilDelegateTo, &a.IsCouncilReady, &a.ReceivedVotes, &a.IsMember); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan account snapshot: %w", err)
+		}
+		snap.Accounts[id] = a
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate account snapshot: %w", err)
+	}
+
+	query, args, err = scope(database.QB.
+		Select("source_account_id", "target_account_id", "relation_type").
+		Distinct().
+		From("relationships"), "source_account_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build relationship snapshot query: %w", err)
+	}
+	rows, err = r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query relationship snapshot: %w", err)
+	}
+	for rows.Next() {
+		var key RelationshipKey
+		if err := rows.Scan(&key.Source, &key.Target, &key.Type); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan relationship snapshot: %w", err)
+		}
+		snap.Relationships[key] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate relationship snapshot: %w", err)
+	}
+
+	query, args, err = scope(database.QB.
+		Select("account_id", "weighted_score").
+		From("reputation_scores"), "account_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build reputation snapshot query: %w", err)
+	}
+	rows, err = r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query reputation snapshot: %w", err)
+	}
+	for rows.Next() {
+		var id string
+		var score decimal.Decimal
+		if err := rows.Scan(&id, &score); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan reputation snapshot: %w", err)
+		}
+		snap.Reputation[id] = score
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reputation snapshot: %w", err)
+	}
+
+	return snap, nil
+}
+
+// RunsChannel is the PostgreSQL notification channel a completed run is
+// announced on, with the run ID as payload.
+const RunsChannel = "lore_sync_runs"
+
+// RecordSyncRun stores a completed run, notifies RunsChannel and returns the
+// run's ID. Servers derive cache validators from the latest run and drop cached
+// data on the notification, so this must be the last write of a run.
+// Single-account resyncs recorded before the run are superseded by it and
+// deleted in the same statement.
+func (r *Repository) RecordSyncRun(ctx context.Context, full bool, startedAt time.Time) (int64, error) {
+	return r.recordRun(ctx, database.QB.
+		Insert("sync_runs").
+		Prefix("WITH pruned AS (DELETE FROM sync_runs WHERE account_id IS NOT NULL)").
+		Columns("full_sync", "started_at").
+		Values(full, startedAt))
+}
+
+// RecordAccountSync stores a completed resync of a single account like a run,
+// so cached responses about the account are dropped as after a full run. The
+// row is kept until the next run is recorded.
+func (r *Repository) RecordAccountSync(ctx context.Context, accountID string, startedAt time.Time) (int64, error) {
+	return r.recordRun(ctx, database.QB.
+		Insert("sync_runs").
+		Columns("account_id", "started_at").
+		Values(accountID, startedAt))
+}
+
+func (r *Repository) recordRun(ctx context.Context, insert sq.InsertBuilder) (int64, error) {
+	query, args, err := insert.Suffix("RETURNING id").ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("build record sync run query: %w", err)
+	}
+
+	var id int64
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		return 0, fmt.Errorf("record sync run: %w", err)
+	}
+
+	if _, err := r.pool.Exec(ctx, "SELECT pg_notify($1, $2)", RunsChannel, strconv.FormatInt(id, 10)); err != nil {
+		return id, fmt.Errorf("notify sync run: %w", err)
+	}
+	return id, nil
+}
+
 // UpsertLPPool inserts or updates a liquidity pool.
 func (r *Repository) UpsertLPPool(ctx context.Context, pool *LPPoolData) error {
 	query, args, err := database.QB.
@@ -5,10 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/mtlprog/lore/internal/config"
+	"github.com/mtlprog/lore/internal/events"
+	"github.com/mtlprog/lore/internal/relation"
 	"github.com/mtlprog/lore/internal/reputation"
+	"github.com/mtlprog/lore/internal/service"
 	"github.com/samber/lo"
 	"github.com/stellar/go/clients/horizonclient"
 )
@@ -17,8 +21,10 @@ import (
 type Syncer struct {
 	horizon          *horizonclient.Client
 	repo             *Repository
+	events           *events.Repository
 	logger           *slog.Logger
 	failureThreshold float64
+	relations        *relation.Registry
 }
 
 // SyncerOption is a functional option for configuring a Syncer.
@@ -39,6 +45,15 @@ func WithLogger(logger *slog.Logger) SyncerOption {
 	}
 }
 
+// WithRelationTypes sets the relation types used to parse ManageData.
+// By default they are loaded from relation_type_settings by the first Run or
+// SyncAccount.
+func WithRelationTypes(types *relation.Registry) SyncerOption {
+	return func(s *Syncer) {
+		s.relations = types
+	}
+}
+
 // New creates a new Syncer instance.
 // Returns error if pool is nil or horizonURL is empty.
 func New(pool *pgxpool.Pool, horizonURL string, opts ...SyncerOption) (*Syncer, error) {
@@ -50,10 +65,15 @@ func New(pool *pgxpool.Pool, horizonURL string, opts ...SyncerOption) (*Syncer,
 	if err != nil {
 		return nil, fmt.Errorf("create repository: %w", err)
 	}
+	eventRepo, err := events.NewRepository(pool)
+	if err != nil {
+		return nil, fmt.Errorf("create event repository: %w", err)
+	}
 
 	s := &Syncer{
 		horizon:          &horizonclient.Client{HorizonURL: horizonURL},
 		repo:             repo,
+		events:           eventRepo,
 		logger:           slog.Default(),
 		failureThreshold: DefaultFailureThreshold,
 	}
@@ -70,6 +90,18 @@ func New(pool *pgxpool.Pool, horizonURL string, opts ...SyncerOption) (*Syncer,
 func (s *Syncer) Run(ctx context.Context, full bool) (*SyncResult, error) {
 	s.logger.Info("starting sync", "full", full)
 
+	// Wait for other runs and account resyncs, which diff their own snapshots
+	unlock, err := s.repo.Lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	startedAt := time.Now()
+
+	// Change events compare the data before the run (and before a full
+	// sync's truncation) with the data after it
+	before := s.snapshot(ctx, nil)
+
 	if full {
 		s.logger.Info("truncating tables for full sync")
 		if err := s.repo.Truncate(ctx); err != nil {
@@ -77,6 +109,10 @@ func (s *Syncer) Run(ctx context.Context, full bool) (*SyncResult, error) {
 		}
 	}
 
+	if err := s.loadRelationTypes(ctx); err != nil {
+		return nil, err
+	}
+
 	// Step 1: Collect all unique account IDs from MTLAP and MTLAC holders
 	s.logger.Info("fetching MTLAP holders")
 	mtlapHolders, err := s.fetchAllAssetHolders(ctx, config.TokenMTLAP, config.TokenIssuer)
@@ -99,8 +135,9 @@ func (s *Syncer) Run(ctx context.Context, full bool) (*SyncResult, error) {
 	}
 	s.logger.Info("fetched MTLAX holders", "count", len(mtlaxHolders))
 
-	// Merge into unique list using lo.Uniq
-	accountIDs := lo.Uniq(lo.Flatten([][]string{mtlapHolders, mtlacHolders, mtlaxHolders}))
+	// Merge into unique list using lo.Uniq. The issuer holds none of its tokens but
+	// is synced for its signers, which are shown on the control graph.
+	accountIDs := lo.Uniq(lo.Flatten([][]string{mtlapHolders, mtlacHolders, mtlaxHolders, {config.TokenIssuer}}))
 	s.logger.Info("unique accounts to sync", "count", len(accountIDs))
 
 	// Step 2: Fetch and store details for each account
@@ -142,7 +179,21 @@ func (s *Syncer) Run(ctx context.Context, full bool) (*SyncResult, error) {
 		return result, fmt.Errorf("sync association tags: %w", err)
 	}
 
-	// Step 7: Calculate reputation scores
+	// Step 7: Index membership events from issuer operations
+	s.logger.Info("indexing membership events")
+	if err := s.syncMembershipEvents(ctx); err != nil {
+		// Log error but don't fail sync - the next run resumes from the last stored event
+		s.logger.Error("failed to index membership events", "error", err)
+	}
+
+	// Step 8: Index new operations of tracked accounts
+	s.logger.Info("indexing account operations")
+	if failed := s.syncOperations(ctx, accountIDs); failed > 0 {
+		// Non-critical: accounts that failed catch up on the next run
+		s.logger.Warn("failed to index operations for some accounts", "failed", failed, "total", len(accountIDs))
+	}
+
+	// Step 9: Calculate reputation scores
 	s.logger.Info("calculating reputation scores")
 	if err := s.calculateReputationScores(ctx); err != nil {
 		// Log error but don't fail sync - reputation is non-critical
@@ -156,7 +207,16 @@ func (s *Syncer) Run(ctx context.Context, full bool) (*SyncResult, error) {
 	}
 	result.Stats = stats
 
+	s.publishChanges(ctx, before)
+
+	// Publish the run last: its ID changes the ETags served for synced data.
+	result.RunID, err = s.repo.RecordSyncRun(ctx, full, startedAt)
+	if err != nil {
+		return result, fmt.Errorf("record sync run: %w", err)
+	}
+
 	s.logger.Info("sync completed",
+		"run", result.RunID,
 		"accounts", stats.TotalAccounts,
 		"persons", stats.TotalPersons,
 		"synthetic", stats.TotalSynthetic,
@@ -167,6 +227,76 @@ func (s *Syncer) Run(ctx context.Context, full bool) (*SyncResult, error) {
 	return result, nil
 }
 
+// SyncAccount refreshes a single account from Horizon outside of a full run,
+// e.g. right after it submitted a transaction through Lore.
+// Delegations are recalculated since the account may have changed mtla_delegate,
+// and its new operations are indexed so they show up on the account page.
+// The resulting changes are published as events, and the resync is recorded
+// as a run so servers drop cached responses, as after a full run.
+// It returns service.ErrSyncInProgress without waiting when a run holds the
+// sync lock.
+func (s *Syncer) SyncAccount(ctx context.Context, accountID string) error {
+	unlock, ok, err := s.repo.TryLock(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return service.ErrSyncInProgress
+	}
+	defer unlock()
+	if err := s.loadRelationTypes(ctx); err != nil {
+		return err
+	}
+	startedAt := time.Now()
+	before := s.snapshot(ctx, []string{accountID})
+
+	if err := s.syncSingleAccount(ctx, accountID); err != nil {
+		return err
+	}
+
+	previous, err := s.updateDelegations(ctx)
+	if err != nil {
+		return fmt.Errorf("update delegations: %w", err)
+	}
+
+	s.publishAccountChanges(ctx, accountID, before, previous)
+
+	// The account itself changed even if its operations could not be indexed
+	opsErr := s.syncAccountOperations(ctx, accountID)
+
+	if _, err := s.repo.RecordAccountSync(ctx, accountID, startedAt); err != nil {
+		return fmt.Errorf("record account sync: %w", err)
+	}
+	if opsErr != nil {
+		return fmt.Errorf("index operations: %w", opsErr)
+	}
+	return nil
+}
+
+// loadRelationTypes loads the relation types from relation_type_settings
+// unless they were set with WithRelationTypes or loaded before.
+func (s *Syncer) loadRelationTypes(ctx context.Context) error {
+	if s.relations != nil {
+		return nil
+	}
+	types, err := relation.NewRepository(s.repo.pool)
+	if err != nil {
+		return fmt.Errorf("create relation type repository: %w", err)
+	}
+	s.relations, err = types.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("load relation types: %w", err)
+	}
+	s.logger.Info("loaded relation types", "count", len(s.relations.Types()))
+	return nil
+}
+
+// relationTypes returns the relation types used to parse ManageData.
+// They are loaded by Run and SyncAccount.
+func (s *Syncer) relationTypes() *relation.Registry {
+	return s.relations
+}
+
 // calculateReputationScores computes and stores weighted reputation scores for all accounts.
 func (s *Syncer) calculateReputationScores(ctx context.Context) error {
 	// Create reputation repository
@@ -1,41 +1,17 @@
 package sync
 
-import "github.com/shopspring/decimal"
+import (
+	"time"
+
+	"github.com/mtlprog/lore/internal/delegation"
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/shopspring/decimal"
+)
 
 // RelationType represents the type of relationship between accounts.
+// Known types are defined in relation_type_settings.
 type RelationType string
 
-const (
-	RelationMyPart            RelationType = "MyPart"
-	RelationPartOf            RelationType = "PartOf"
-	RelationRecommendToMTLA   RelationType = "RecommendToMTLA"
-	RelationOneFamily         RelationType = "OneFamily"
-	RelationSpouse            RelationType = "Spouse"
-	RelationGuardian          RelationType = "Guardian"
-	RelationWard              RelationType = "Ward"
-	RelationSympathy          RelationType = "Sympathy"
-	RelationLove              RelationType = "Love"
-	RelationDivorce           RelationType = "Divorce"
-	RelationA                 RelationType = "A"
-	RelationB                 RelationType = "B"
-	RelationC                 RelationType = "C"
-	RelationD                 RelationType = "D"
-	RelationEmployer          RelationType = "Employer"
-	RelationEmployee          RelationType = "Employee"
-	RelationContractor        RelationType = "Contractor"
-	RelationClient            RelationType = "Client"
-	RelationPartnership       RelationType = "Partnership"
-	RelationCollaboration     RelationType = "Collaboration"
-	RelationOwnershipFull     RelationType = "OwnershipFull"
-	RelationOwnershipMajority RelationType = "OwnershipMajority"
-	RelationOwnershipMinority RelationType = "OwnershipMinority"
-	RelationOwner             RelationType = "Owner"
-	RelationOwnerMajority     RelationType = "OwnerMajority"
-	RelationOwnerMinority     RelationType = "OwnerMinority"
-	RelationWelcomeGuest      RelationType = "WelcomeGuest"
-	RelationFactionMember     RelationType = "FactionMember"
-)
-
 // TagName represents an association tag type.
 type TagName string
 
@@ -66,6 +42,55 @@ type Relationship struct {
 	RelationIndex   string // Keep original suffix (e.g., "002" vs "2") to avoid conflicts
 }
 
+// Signer represents a key that can sign for an account.
+type Signer struct {
+	Key    string
+	Type   string // Horizon signer type, e.g. "ed25519_public_key"
+	Weight int32
+}
+
+// Thresholds holds the low, medium and high thresholds of an account.
+type Thresholds struct {
+	Low  uint8
+	Med  uint8
+	High uint8
+}
+
+// MembershipEventType classifies an issuer operation on a membership token.
+type MembershipEventType string
+
+const (
+	EventReceived     MembershipEventType = "received"     // first tokens sent to an account with no balance
+	EventLevelChange  MembershipEventType = "level_change" // further tokens sent by the issuer
+	EventReturned     MembershipEventType = "returned"     // tokens paid back to the issuer
+	EventClawback     MembershipEventType = "clawback"     // tokens clawed back by the issuer
+	EventAuthorized   MembershipEventType = "authorized"   // trustline authorized
+	EventDeauthorized MembershipEventType = "deauthorized" // trustline authorization revoked
+)
+
+// MembershipEvent is one issuer operation on an MTLAP, MTLAC or MTLAX holder.
+// Balances are reconstructed from the issuer's operations in ledger order.
+type MembershipEvent struct {
+	OperationID   int64
+	AccountID     string
+	AssetCode     string
+	Type          MembershipEventType
+	Amount        decimal.Decimal
+	BalanceBefore decimal.Decimal
+	BalanceAfter  decimal.Decimal
+	TxHash        string
+	CreatedAt     time.Time
+}
+
+// AccountOperation is an operation indexed for one tracked account.
+type AccountOperation struct {
+	model.Operation
+	OperationID  int64
+	CreatedAt    time.Time
+	Counterparty string // Other account involved, empty if none
+	Spam         bool   // Hidden by default, see service.IsSpamOperation
+}
+
 // AssociationTag represents an association tag.
 type AssociationTag struct {
 	TagName         TagName
@@ -84,6 +109,7 @@ type SyncStats struct {
 
 // SyncResult holds the result of a sync operation.
 type SyncResult struct {
+	RunID           int64 // ID of the recorded sync_runs row, 0 if the run did not complete
 	Stats           *SyncStats
 	FailedAccounts  []string
 	FailedPrices    []string
@@ -104,18 +130,22 @@ type AccountData struct {
 	Balances          []Balance
 	Metadata          []Metadata
 	Relationships     []Relationship
-	DelegateTo        *string // mtla_delegate - general delegation
-	CouncilDelegateTo *string // mtla_c_delegate when it's an account ID
-	CouncilReady      bool    // mtla_c_delegate == "ready"
+	DelegateTo        *string  // mtla_delegate - general delegation
+	CouncilDelegateTo *string  // mtla_c_delegate when it's an account ID
+	CouncilReady      bool     // mtla_c_delegate == "ready"
+	Signers           []Signer // Signers with non-zero weight
+	Thresholds        Thresholds
 }
 
 // DelegationInfo holds delegation data for an account.
-type DelegationInfo struct {
-	AccountID         string
-	DelegateTo        *string // mtla_delegate
-	CouncilDelegateTo *string // mtla_c_delegate when it's an account ID
-	MTLAPBalance      decimal.Decimal
-	CouncilReady      bool // mtla_c_delegate == "ready"
+type DelegationInfo = delegation.Info
+
+// DelegationState holds the delegation results stored for an account.
+type DelegationState struct {
+	DelegationError bool     // Delegates to an unknown account or one without MTLAP
+	CycleError      bool     // Part of a delegation cycle
+	CyclePath       []string // The cycle, if CycleError
+	ReceivedVotes   int      // Council votes, for council-ready accounts
 }
 
 // DefaultFailureThreshold is the default maximum failure rate (10%)
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/microcosm-cc/bluemonday"
+	"github.com/mtlprog/lore/internal/delegation"
 	"github.com/russross/blackfriday/v2"
 	"github.com/samber/lo"
 )
@@ -76,16 +77,7 @@ var funcMap = template.FuncMap{
 		}
 		return result.String()
 	},
-	"votePower": func(totalVotes float64) int {
-		if totalVotes <= 0 {
-			return 0
-		}
-		if totalVotes <= 10 {
-			return 1
-		}
-		// Logarithmic scaling: 11-100 → 2, 101-1000 → 3, etc.
-		return int(math.Ceil(math.Log10(totalVotes)))
-	},
+	"votePower": delegation.VotePower,
 	"trustBarWidth": func(percent int) string {
 		return fmt.Sprintf("%d%%", percent)
 	},
@@ -95,6 +87,13 @@ var funcMap = template.FuncMap{
 		}
 		return "←"
 	},
+	"stellarURI": func(s string) template.URL {
+		// html/template rejects non-http schemes; only pass through SEP-0007 URIs
+		if strings.HasPrefix(s, "web+stellar:") {
+			return template.URL(s)
+		}
+		return "#"
+	},
 	"isStellarID": func(s string) bool {
 		return len(s) == 56 && (s[0] == 'G' || s[0] == 'M')
 	},
@@ -173,7 +172,7 @@ func New() (*Templates, error) {
 	}
 
 	// Page templates to parse with base
-	pageNames := []string{"home.html", "account.html", "transaction.html", "search.html", "token.html", "reputation.html", "init.html"}
+	pageNames := []string{"home.html", "account.html", "transaction.html", "search.html", "token.html", "reputation.html", "init.html", "xdr.html", "control.html", "council.html", "economy.html"}
 
 	for _, name := range pageNames {
 		// Clone base template for each page
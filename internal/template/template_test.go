@@ -2,8 +2,11 @@ package template
 
 import (
 	"bytes"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/mtlprog/lore/internal/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -322,6 +325,11 @@ func TestRender(t *testing.T) {
 	})
 
 	t.Run("home template renders successfully", func(t *testing.T) {
+		type pager struct {
+			Start int
+			Next  string
+			Prev  string
+		}
 		var buf bytes.Buffer
 		data := struct {
 			Stats struct {
@@ -331,18 +339,13 @@ func TestRender(t *testing.T) {
 				TotalSynthetic int
 				TotalXLMValue  float64
 			}
-			Persons             []any
-			Corporate           []any
-			Synthetic           []any
-			PersonsOffset       int
-			CorporateOffset     int
-			SyntheticOffset     int
-			NextPersonsOffset   int
-			NextCorporateOffset int
-			NextSyntheticOffset int
-			HasMorePersons      bool
-			HasMoreCorporate    bool
-			HasMoreSynthetic    bool
+			Persons        []any
+			Corporate      []any
+			Synthetic      []any
+			PersonsPager   pager
+			CorporatePager pager
+			SyntheticPager pager
+			NewMembers     []model.MembershipEvent
 		}{
 			Stats: struct {
 				TotalAccounts  int
@@ -357,12 +360,12 @@ func TestRender(t *testing.T) {
 				TotalSynthetic: 10,
 				TotalXLMValue:  1000000.0,
 			},
-			Persons:          []any{},
-			Corporate:        []any{},
-			Synthetic:        []any{},
-			HasMorePersons:   false,
-			HasMoreCorporate: false,
-			HasMoreSynthetic: false,
+			Persons:   []any{},
+			Corporate: []any{},
+			Synthetic: []any{},
+			NewMembers: []model.MembershipEvent{
+				{AccountID: "GNEWMEMBER1234567890123456789012345678901234567890ABCD", AccountName: "Newcomer", AssetCode: "MTLAC", CreatedAt: time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC)},
+			},
 		}
 
 		err := tmpl.Render(&buf, "home.html", data)
@@ -374,6 +377,9 @@ func TestRender(t *testing.T) {
 		assert.Contains(t, output, "50")  // TotalPersons
 		assert.Contains(t, output, "25")  // TotalCompanies
 		assert.Contains(t, output, "Synthetic")
+		assert.Contains(t, output, "New Members")
+		assert.Contains(t, output, "Newcomer")
+		assert.Contains(t, output, "2026-10-03")
 	})
 
 	t.Run("account template renders successfully", func(t *testing.T) {
@@ -438,6 +444,9 @@ func TestRender(t *testing.T) {
 				}
 				TotalXLMValue float64
 				IsCorporate   bool
+				Identity      model.Identity
+				Control       *model.AccountControl
+				Membership    []model.MembershipEvent
 			}
 			Operations *struct {
 				Operations []struct {
@@ -523,6 +532,9 @@ func TestRender(t *testing.T) {
 				}
 				TotalXLMValue float64
 				IsCorporate   bool
+				Identity      model.Identity
+				Control       *model.AccountControl
+				Membership    []model.MembershipEvent
 			}{
 				ID:       "GTEST1234567890",
 				Name:     "Test Account",
@@ -548,6 +560,23 @@ func TestRender(t *testing.T) {
 				TrustRating:   nil,
 				TotalXLMValue: 0,
 				IsCorporate:   false,
+				Identity: model.Identity{
+					TelegramUserID: "123456789",
+					ContractIPFS:   "QmTestContract",
+					ContractURL:    "https://ipfs.io/ipfs/QmTestContract",
+					PIIStandard:    true,
+				},
+				Control: &model.AccountControl{
+					Thresholds: model.Thresholds{Low: 1, Med: 2, High: 3},
+					Signers: []model.ControlEdge{
+						{SignerID: "GOWNER", SignerName: "Owner Name", Weight: 2, Sole: true, Status: model.ControlDeclared, Ownership: "OwnershipFull"},
+						{SignerID: "GSTRANGER", SignerName: "Stranger", Weight: 1, Status: model.ControlUndeclared},
+					},
+				},
+				Membership: []model.MembershipEvent{
+					{AssetCode: "MTLAP", Type: model.MembershipReceived, Amount: 1, BalanceAfter: 1, TxHash: "abc123", CreatedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+					{AssetCode: "MTLAP", Type: model.MembershipLevelChange, Amount: 2, BalanceBefore: 1, BalanceAfter: 3, CreatedAt: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)},
+				},
 			},
 			Operations:      nil,
 			AccountNames:    nil,
@@ -563,6 +592,107 @@ func TestRender(t *testing.T) {
 		assert.Contains(t, output, "This is a test account")
 		assert.Contains(t, output, "https://example.com")
 		assert.Contains(t, output, "MTLAP")
+		assert.Contains(t, output, "123456789")
+		assert.Contains(t, output, `href="https://ipfs.io/ipfs/QmTestContract"`)
+		assert.Contains(t, output, "PII Standard")
+		assert.Contains(t, output, "medium 2")
+		assert.Contains(t, output, "1 mismatch(es)")
+		assert.Contains(t, output, "signs alone")
+		assert.Contains(t, output, `control-badge undeclared`)
+		assert.Contains(t, output, "Membership Timeline")
+		assert.Contains(t, output, "2024-03-01")
+		assert.Contains(t, output, "Level changed")
+		assert.Contains(t, output, "balance 1 &rarr; 3")
+	})
+
+	t.Run("economy template renders periods and flows", func(t *testing.T) {
+		var buf bytes.Buffer
+		data := model.EconomyPageData{
+			Asset:  model.FlowAsset{Code: "EURMTL", Issuer: "GEURMTLISSUER"},
+			Assets: []model.FlowAsset{{Code: "EURMTL", Issuer: "GEURMTLISSUER"}, {Code: "XLM"}},
+			Periods: []model.FlowPeriod{
+				{Month: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), AssetCode: "EURMTL", AssetIssuer: "GEURMTLISSUER", Volume: 12500, Payments: 40, Payers: 12},
+			},
+			Edges: []model.FlowEdge{
+				{FromID: "GALICE", FromName: "Alice", ToID: "GACME", ToName: "Acme", AssetCode: "EURMTL", AssetIssuer: "GEURMTLISSUER", Volume: 3000, Payments: 3},
+			},
+		}
+
+		err := tmpl.Render(&buf, "economy.html", data)
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "INTERNAL ECONOMY")
+		assert.Contains(t, output, "2026-09")
+		assert.Contains(t, output, "12 500")
+		assert.Contains(t, output, `href="/economy?asset=EURMTL%3aGEURMTLISSUER" class="active"`)
+		assert.Contains(t, output, `href="/economy?asset=XLM">XLM</a>`)
+		assert.Contains(t, output, `<span class="economy-issuer">GEURMTLISSUER</span>`)
+		assert.Contains(t, output, "Alice")
+		assert.Contains(t, output, "Acme")
+	})
+
+	t.Run("council template renders changes and XDR", func(t *testing.T) {
+		var buf bytes.Buffer
+		data := model.CouncilPageData{
+			Reconciliation: &model.CouncilReconciliation{
+				IssuerID:   "GISSUER",
+				Size:       20,
+				Thresholds: model.Thresholds{Low: 1, Med: 2, High: 3},
+				Changes: []model.CouncilChange{
+					{AccountID: "GALICE", Name: "Alice", Rank: 1, Votes: 120, TargetWeight: 3, Action: model.CouncilAdd},
+					{AccountID: "GBOB", Name: "Bob", CurrentWeight: 2, Action: model.CouncilRemove},
+				},
+				CurrentWeight: 2,
+				TargetWeight:  3,
+			},
+			Transactions: []model.InitTransaction{{
+				XDR:        "AAAAtestxdr",
+				Sequence:   42,
+				Operations: []model.InitOpSummary{{Action: "Remove signer", Key: "GBOB"}},
+				MaxFee:     "0.0000100",
+				LabLink:    "https://lab.stellar.org/transaction/cli-sign?xdr=AAAAtestxdr",
+			}},
+		}
+
+		err := tmpl.Render(&buf, "council.html", data)
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "COUNCIL SIGNERS")
+		assert.Contains(t, output, "2 signer change(s) needed")
+		assert.Contains(t, output, "weight 2 &rarr; 0")
+		assert.Contains(t, output, "AAAAtestxdr")
+		assert.NotContains(t, output, "Generate SetOptions XDR", "the form is hidden once XDR was generated")
+	})
+
+	t.Run("control template renders mismatches", func(t *testing.T) {
+		var buf bytes.Buffer
+		data := model.ControlPageData{
+			Accounts: []model.ControlledAccount{{
+				AccountID:    "GCOMPANY",
+				AccountName:  "Acme",
+				IsIssuer:     true,
+				MedThreshold: 2,
+				Edges: []model.ControlEdge{
+					{SignerID: "GALICE", SignerName: "Alice", Weight: 1, Status: model.ControlUndeclared},
+					{SignerID: "GBOB", SignerName: "Bob", Status: model.ControlNotSigner, Ownership: "OwnershipMinority"},
+				},
+				Mismatches: 2,
+			}},
+			Mismatches: 2,
+		}
+
+		err := tmpl.Render(&buf, "control.html", data)
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "CONTROL GRAPH")
+		assert.Contains(t, output, `href="/control?issues=1"`)
+		assert.Contains(t, output, ">Alice</a>")
+		assert.Contains(t, output, "OwnershipMinority, not a signer")
+		assert.Contains(t, output, "cannot sign")
+		assert.Contains(t, output, ">issuer<")
 	})
 
 	t.Run("transaction template renders successfully", func(t *testing.T) {
@@ -664,6 +794,222 @@ func TestRender(t *testing.T) {
 		assert.Contains(t, output, "12345678")
 	})
 
+	t.Run("init delegation page renders chain and warnings", func(t *testing.T) {
+		var buf bytes.Buffer
+		data := model.InitDelegationData{
+			Page: "delegation",
+			FormData: model.DelegationFormData{
+				AccountID:    "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7",
+				DelegateTo:   "GB7TAYRUZGE6TVT7NHP5SMIZRNQA6PLM423EYISAOAP3MKYIQMVYP2JO",
+				CouncilReady: true,
+			},
+			Chain: []model.DelegationHop{
+				{AccountID: "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7", Name: "Alice", MTLAPBalance: "3", Known: true},
+				{AccountID: "GB7TAYRUZGE6TVT7NHP5SMIZRNQA6PLM423EYISAOAP3MKYIQMVYP2JO", MTLAPBalance: "0"},
+			},
+			Warnings:     []string{"Delegate holds no MTLAP"},
+			NeedsConfirm: true,
+			FormAction:   "/init/delegation",
+		}
+
+		err := tmpl.Render(&buf, "init.html", data)
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "Vote Delegation")
+		assert.Contains(t, output, "Alice")
+		assert.Contains(t, output, "3 MTLAP")
+		assert.Contains(t, output, "not synced")
+		assert.Contains(t, output, "Delegate holds no MTLAP")
+		assert.Contains(t, output, `name="confirm_warnings"`)
+		assert.Contains(t, output, `value="ready" checked`)
+	})
+
+	t.Run("init preview renders wallet link and QR code", func(t *testing.T) {
+		var buf bytes.Buffer
+		data := model.InitPreviewData{
+			Page:      "preview",
+			AccountID: "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7",
+			Transactions: []model.InitTransaction{{
+				XDR:         "AAAA+/==",
+				Sequence:    101,
+				Operations:  []model.InitOpSummary{{Action: "Set", Key: "Name", Value: "Alice"}},
+				MaxFee:      "0.0000100",
+				ValidBefore: "2026-03-01 13:00:00",
+				LabLink:     "https://lab.stellar.org/transaction/cli-sign?xdr=AAAA",
+				WalletURI:   "web+stellar:tx?xdr=AAAA%2B%2F%3D%3D&msg=Update%20data",
+				QRCode:      "iVBORw0KGgo+/=",
+			}},
+		}
+
+		err := tmpl.Render(&buf, "init.html", data)
+		require.NoError(t, err)
+
+		output := buf.String()
+		// "+" is HTML-escaped inside attributes and decoded back by the browser
+		assert.Contains(t, output, `href="web&#43;stellar:tx?xdr=AAAA%2B%2F%3D%3D&amp;msg=Update%20data"`)
+		assert.Contains(t, output, `src="data:image/png;base64,iVBORw0KGgo&#43;/="`)
+		assert.Contains(t, output, "Valid until 2026-03-01 13:00:00 UTC")
+		assert.NotContains(t, output, "Transaction 1 of")
+		assert.NotContains(t, output, "ZgotmplZ")
+	})
+
+	t.Run("init preview renders split transactions in order", func(t *testing.T) {
+		var buf bytes.Buffer
+		data := model.InitPreviewData{
+			Page:      "preview",
+			AccountID: "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7",
+			CanSubmit: true,
+			Transactions: []model.InitTransaction{
+				{XDR: "FIRST", Sequence: 101, MaxFee: "0.0010000"},
+				{XDR: "SECOND", Sequence: 102, MaxFee: "0.0005000"},
+			},
+		}
+
+		err := tmpl.Render(&buf, "init.html", data)
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "split into 2 transactions")
+		assert.Contains(t, output, "Transaction 2 of 2")
+		assert.Less(t, strings.Index(output, `value="FIRST"`), strings.Index(output, `value="SECOND"`))
+	})
+
+	t.Run("init preview lists multisig signers", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := tmpl.Render(&buf, "init.html", model.InitPreviewData{
+			Page:       "preview",
+			AccountID:  "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7",
+			CanCollect: true,
+			Signing: &model.SigningRequirement{
+				Threshold: 3,
+				Signers: []model.Signer{
+					{Key: "GB7TAYRUZGE6TVT7NHP5SMIZRNQA6PLM423EYISAOAP3MKYIQMVYP2JO", Weight: 2, Name: "Alice"},
+					{Key: "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7", Weight: 1},
+				},
+				TotalWeight:   3,
+				MinSignatures: 2,
+				Required:      []string{"GB7TAYRUZGE6TVT7NHP5SMIZRNQA6PLM423EYISAOAP3MKYIQMVYP2JO"},
+			},
+			Transactions: []model.InitTransaction{{XDR: "AAAA", Sequence: 101, MaxFee: "0.0000100"}},
+		})
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "Signatures Required")
+		assert.Contains(t, output, "At least 2 signatures are needed")
+		assert.Contains(t, output, "Alice")
+		assert.Contains(t, output, "required")
+		assert.Contains(t, output, `action="/init/collect"`)
+	})
+
+	t.Run("init collect page renders signatures and upload form", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := tmpl.Render(&buf, "init.html", model.InitCollectData{
+			Page:       "collect",
+			Collection: &model.SignatureCollection{ID: "abc123", AccountID: "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7", Envelope: "AAAA"},
+			Transaction: &model.DecodedTransaction{
+				Sequence:   101,
+				MaxFee:     "0.0000100",
+				Operations: []model.DecodedOperation{{Action: "sets Name to", TargetValue: "Acme"}},
+			},
+			Requirement: &model.SigningRequirement{
+				Threshold:     2,
+				Signers:       []model.Signer{{Key: "GB7TAYRUZGE6TVT7NHP5SMIZRNQA6PLM423EYISAOAP3MKYIQMVYP2JO", Weight: 2, Name: "Alice", Signed: true}},
+				TotalWeight:   2,
+				MinSignatures: 1,
+				SignedWeight:  2,
+			},
+			CanSubmit: true,
+			Message:   "Added 1 signature(s).",
+		})
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "Collect Signatures")
+		assert.Contains(t, output, "Signatures (2 of 2)")
+		assert.Contains(t, output, "The threshold is met")
+		assert.Contains(t, output, `action="/init/collect/abc123"`)
+		assert.Contains(t, output, `action="/init/submit"`)
+		assert.Contains(t, output, "signed</span>")
+	})
+
+	t.Run("init submit page renders result and rejection codes", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := tmpl.Render(&buf, "init.html", model.InitSubmitData{
+			Page:      "submit",
+			AccountID: "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7",
+			Result:    &model.SubmitResult{Hash: "abc123", Ledger: 4242, Refreshing: true},
+		})
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "ledger 4242")
+		assert.Contains(t, output, `href="/transactions/abc123"`)
+		assert.Contains(t, output, "updated in a few seconds")
+
+		buf.Reset()
+		err = tmpl.Render(&buf, "init.html", model.InitSubmitData{
+			Page:        "submit",
+			SignedXDR:   "AAAA",
+			Error:       "Sequence changed.",
+			ResultCodes: []string{"tx_failed", "op_low_reserve"},
+		})
+		require.NoError(t, err)
+
+		output = buf.String()
+		assert.Contains(t, output, "Sequence changed.")
+		assert.Contains(t, output, "tx_failed, op_low_reserve")
+		assert.Contains(t, output, `name="xdr"`)
+	})
+
+	t.Run("xdr inspector renders actions and risks", func(t *testing.T) {
+		var buf bytes.Buffer
+		const (
+			sourceID = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+			targetID = "GB7TAYRUZGE6TVT7NHP5SMIZRNQA6PLM423EYISAOAP3MKYIQMVYP2JO"
+		)
+		data := struct {
+			XDR          string
+			Transaction  *model.DecodedTransaction
+			AccountNames map[string]string
+			Error        string
+		}{
+			XDR: "AAAA",
+			Transaction: &model.DecodedTransaction{
+				Hash:          "abc123",
+				SourceAccount: sourceID,
+				MaxFee:        "0.0000200",
+				MemoType:      "none",
+				RiskCount:     1,
+				Operations: []model.DecodedOperation{
+					{
+						Operation:     model.Operation{Type: "manage_data", TypeDisplay: "Manage Data", TypeCategory: "data", SourceAccount: sourceID},
+						Action:        "adds Employer relation to",
+						TargetAccount: targetID,
+					},
+					{
+						Operation:     model.Operation{Type: "set_options", TypeDisplay: "Set Options", TypeCategory: "account", SourceAccount: sourceID},
+						Action:        "sets signer with weight 1",
+						TargetAccount: targetID,
+						Risk:          "changes who can sign for the account",
+					},
+				},
+			},
+			AccountNames: map[string]string{sourceID: "Alice", targetID: "Bob"},
+		}
+
+		err := tmpl.Render(&buf, "xdr.html", data)
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "XDR INSPECTOR")
+		assert.Contains(t, output, "adds Employer relation to")
+		assert.Contains(t, output, `>Bob</a>`)
+		assert.Contains(t, output, "changes who can sign for the account")
+		assert.Contains(t, output, "1 of 2 operations")
+	})
+
 	t.Run("search template renders with query and tags", func(t *testing.T) {
 		var buf bytes.Buffer
 		data := struct {
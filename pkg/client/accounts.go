@@ -0,0 +1,243 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PageParams selects a page of a list. Keyset pagination stays stable while
+// accounts are re-synced but does not count the total; it is used when Keyset
+// is set or Cursor is not empty. Otherwise pages are selected by Offset.
+type PageParams struct {
+	Limit  int    // Page size; zero uses the server default of 20, at most 100
+	Offset int    // Offset pagination only
+	Cursor string // Pagination.NextCursor or PrevCursor of a keyset page
+	Keyset bool   // Request the first keyset page when Cursor is empty
+}
+
+func (p PageParams) apply(q url.Values) {
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Keyset || p.Cursor != "" {
+		q.Set("cursor", p.Cursor)
+	} else if p.Offset > 0 {
+		q.Set("offset", strconv.Itoa(p.Offset))
+	}
+}
+
+// NextCursor returns the cursor of the following keyset page, or "" on the
+// last page and for offset pages.
+func (p Pagination) NextCursor() string {
+	return linkCursor(p.Next)
+}
+
+// PrevCursor returns the cursor of the preceding keyset page, or "" on the
+// first page and for offset pages.
+func (p Pagination) PrevCursor() string {
+	return linkCursor(p.Prev)
+}
+
+// linkCursor extracts the cursor parameter of a pagination link.
+func linkCursor(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("cursor")
+}
+
+// ListAccountsParams filters ListAccounts.
+type ListAccountsParams struct {
+	Type string // "person", "corporate", "synthetic" or empty for all
+	PageParams
+}
+
+// Stats returns aggregate statistics and monthly membership growth over the
+// last year.
+func (c *Client) Stats(ctx context.Context) (*StatsResponse, error) {
+	var resp StatsResponse
+	if err := c.get(ctx, "/api/v1/stats", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListAccounts returns one page of accounts.
+func (c *Client) ListAccounts(ctx context.Context, params ListAccountsParams) (*AccountPage, error) {
+	q := url.Values{}
+	if params.Type != "" {
+		q.Set("type", params.Type)
+	}
+	params.apply(q)
+
+	var resp AccountPage
+	if err := c.get(ctx, "/api/v1/accounts", q, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Accounts iterates over all accounts matching params, fetching keyset pages
+// of params.Limit accounts as needed, starting at params.Cursor. Iteration
+// stops after the first error.
+func (c *Client) Accounts(ctx context.Context, params ListAccountsParams) iter.Seq2[AccountListItem, error] {
+	params.Keyset = true
+	return paginate(params.Cursor, func(cursor string) (*AccountPage, error) {
+		params.Cursor = cursor
+		return c.ListAccounts(ctx, params)
+	})
+}
+
+// GetAccount returns the full detail of an account. Accounts Lore does not
+// track yield an error for which IsNotFound is true.
+func (c *Client) GetAccount(ctx context.Context, id string) (*AccountDetailResponse, error) {
+	var resp AccountDetailResponse
+	if err := c.get(ctx, accountPath(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// BatchAccounts returns the summary and reputation of up to 500 accounts in
+// request order; untracked IDs are listed in NotFound.
+func (c *Client) BatchAccounts(ctx context.Context, ids []string) (*BatchAccountsResponse, error) {
+	var resp BatchAccountsResponse
+	if err := c.post(ctx, "/api/v1/accounts:batch", BatchAccountsRequest{IDs: ids}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetReputation returns the reputation graph of an account: its direct
+// raters and their raters.
+func (c *Client) GetReputation(ctx context.Context, id string) (*ReputationGraphResponse, error) {
+	var resp ReputationGraphResponse
+	if err := c.get(ctx, accountPath(id)+"/reputation", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RelationshipsParams filters GetRelationships.
+type RelationshipsParams struct {
+	Type      string // Relationship type, e.g. "Spouse" or "Employer"
+	Confirmed bool   // Only confirmed relationships
+	Mutual    bool   // Only mutual relationships
+}
+
+// GetRelationships returns the relationships of an account grouped by
+// category.
+func (c *Client) GetRelationships(ctx context.Context, id string, params RelationshipsParams) ([]RelationshipCategoryResponse, error) {
+	q := url.Values{}
+	if params.Type != "" {
+		q.Set("type", params.Type)
+	}
+	if params.Confirmed {
+		q.Set("confirmed", "true")
+	}
+	if params.Mutual {
+		q.Set("mutual", "true")
+	}
+
+	var resp []RelationshipCategoryResponse
+	if err := c.get(ctx, accountPath(id)+"/relationships", q, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// IssuesParams filters GetIssues.
+type IssuesParams struct {
+	Severity string // "error", "warning" or "info"
+	Category string // e.g. "contradiction" or "unknown_target"
+}
+
+// GetIssues returns the relationship and metadata inconsistencies of an
+// account.
+func (c *Client) GetIssues(ctx context.Context, id string, params IssuesParams) (*IssuesResponse, error) {
+	q := url.Values{}
+	if params.Severity != "" {
+		q.Set("severity", params.Severity)
+	}
+	if params.Category != "" {
+		q.Set("category", params.Category)
+	}
+
+	var resp IssuesResponse
+	if err := c.get(ctx, accountPath(id)+"/issues", q, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SearchParams selects Search results. At least Query, with two or more
+// characters, or Tags must be set for any results.
+type SearchParams struct {
+	Query string
+	Tags  []string
+	Sort  string // "balance" (default) or "reputation"
+	PageParams
+}
+
+// Search searches accounts by name or account ID, optionally filtered by
+// tags, and returns one page of results.
+func (c *Client) Search(ctx context.Context, params SearchParams) (*AccountPage, error) {
+	q := url.Values{}
+	if params.Query != "" {
+		q.Set("q", params.Query)
+	}
+	if len(params.Tags) > 0 {
+		q.Set("tags", strings.Join(params.Tags, ","))
+	}
+	if params.Sort != "" {
+		q.Set("sort", params.Sort)
+	}
+	params.apply(q)
+
+	var resp AccountPage
+	if err := c.get(ctx, "/api/v1/search", q, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SearchAll iterates over all search results, fetching keyset pages as
+// needed. Iteration stops after the first error.
+func (c *Client) SearchAll(ctx context.Context, params SearchParams) iter.Seq2[AccountListItem, error] {
+	params.Keyset = true
+	return paginate(params.Cursor, func(cursor string) (*AccountPage, error) {
+		params.Cursor = cursor
+		return c.Search(ctx, params)
+	})
+}
+
+// paginate yields the accounts of consecutive keyset pages starting at
+// cursor.
+func paginate(cursor string, fetch func(cursor string) (*AccountPage, error)) iter.Seq2[AccountListItem, error] {
+	return func(yield func(AccountListItem, error) bool) {
+		for {
+			page, err := fetch(cursor)
+			if err != nil {
+				yield(AccountListItem{}, err)
+				return
+			}
+			for _, item := range page.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			cursor = page.Pagination.NextCursor()
+			if cursor == "" {
+				return
+			}
+		}
+	}
+}
+
+func accountPath(id string) string {
+	return "/api/v1/accounts/" + url.PathEscape(id)
+}
@@ -0,0 +1,304 @@
+// Package client is the Go client of the Lore REST API under /api/v1.
+//
+// Every endpoint has a typed method taking a context; response types mirror
+// the JSON models of the API. Requests rejected by the rate limiter (429) are
+// retried after the delay the server sends in Retry-After, as are GET requests
+// failing with 502, 503 or 504 and GET requests whose connection fails.
+//
+//	c, err := client.New("https://lore.mtlprog.xyz", client.WithAPIKey(token))
+//	if err != nil {
+//		return err
+//	}
+//	for account, err := range c.Accounts(ctx, client.ListAccountsParams{Type: "person"}) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(account.ID, account.Name)
+//	}
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is how many times a failed request is retried.
+	DefaultMaxRetries = 3
+	// DefaultMaxRetryWait is the longest delay waited before a retry. Responses
+	// asking for a longer Retry-After, such as an exhausted daily quota, are
+	// returned as errors instead.
+	DefaultMaxRetryWait = time.Minute
+
+	// retryBackoff is the first delay of retries without Retry-After; it
+	// doubles with every attempt.
+	retryBackoff = 500 * time.Millisecond
+	// maxErrorBody limits how much of an error response is read.
+	maxErrorBody = 64 << 10
+)
+
+// Client calls the Lore API. It is safe for concurrent use.
+type Client struct {
+	baseURL      *url.URL
+	httpClient   *http.Client
+	apiKey       string
+	userAgent    string
+	maxRetries   int
+	maxRetryWait time.Duration
+	sleep        func(ctx context.Context, d time.Duration) error
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests. Its Timeout also
+// bounds event streams, so leave it zero when using Events.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithAPIKey authenticates requests with an API key, sent as a bearer token.
+// Keyed requests use the key's rate limit and daily quota instead of the
+// per-IP limit; Export requires a key with the export scope.
+func WithAPIKey(token string) Option {
+	return func(c *Client) {
+		c.apiKey = token
+	}
+}
+
+// WithRetries sets how many times a failed request is retried (0 disables
+// retries) and the longest delay waited before a retry.
+func WithRetries(maxRetries int, maxWait time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.maxRetryWait = maxWait
+	}
+}
+
+// WithUserAgent sets the User-Agent header of requests.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// New creates a client for the Lore instance at baseURL, e.g.
+// "https://lore.mtlprog.xyz".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme must be http or https", baseURL)
+	}
+
+	c := &Client{
+		baseURL:      u,
+		httpClient:   http.DefaultClient,
+		userAgent:    "lore-go-client",
+		maxRetries:   DefaultMaxRetries,
+		maxRetryWait: DefaultMaxRetryWait,
+		sleep:        sleepContext,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.httpClient == nil {
+		return nil, errors.New("HTTP client is required")
+	}
+	if c.maxRetries < 0 {
+		return nil, fmt.Errorf("max retries must not be negative, got %d", c.maxRetries)
+	}
+	return c, nil
+}
+
+// Error is returned for API responses with a non-2xx status.
+type Error struct {
+	StatusCode  int
+	Message     string
+	ResultCodes []string      // Horizon result codes of a rejected transaction
+	RetryAfter  time.Duration // Delay requested by the server, zero if none
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("lore: %s (HTTP %d)", e.Message, e.StatusCode)
+}
+
+// IsNotFound reports whether err is an API error with status 404.
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// errorBody is the JSON error body of the API. The rate limiter answers in
+// plain text instead.
+type errorBody struct {
+	Error       string   `json:"error"`
+	ResultCodes []string `json:"result_codes"`
+}
+
+// get sends a GET request and decodes the JSON response into out.
+func (c *Client) get(ctx context.Context, path string, query url.Values, out any) error {
+	resp, err := c.do(ctx, http.MethodGet, path, query, nil, "application/json")
+	if err != nil {
+		return err
+	}
+	return decodeJSON(resp, out)
+}
+
+// post sends body as JSON and decodes the JSON response into out.
+func (c *Client) post(ctx context.Context, path string, body, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encode request: %w", err)
+	}
+	resp, err := c.do(ctx, http.MethodPost, path, nil, data, "application/json")
+	if err != nil {
+		return err
+	}
+	return decodeJSON(resp, out)
+}
+
+func decodeJSON(resp *http.Response, out any) error {
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode %s response: %w", resp.Request.URL.Path, err)
+	}
+	return nil
+}
+
+// do sends a request, retrying it as described in the package documentation,
+// and returns the successful response. Other statuses are returned as *Error.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body []byte, accept string) (*http.Response, error) {
+	u := c.baseURL.JoinPath(path)
+	u.RawQuery = query.Encode()
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, u.String(), body, accept)
+
+		var wait time.Duration
+		switch {
+		case err != nil:
+			if ctx.Err() != nil || method != http.MethodGet {
+				return nil, err
+			}
+			wait = backoff(attempt)
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			return resp, nil
+		default:
+			apiErr := readError(resp)
+			if !retryable(method, resp.StatusCode) {
+				return nil, apiErr
+			}
+			wait = apiErr.RetryAfter
+			if wait == 0 {
+				wait = backoff(attempt)
+			}
+			err = apiErr
+		}
+
+		if attempt >= c.maxRetries || wait > c.maxRetryWait {
+			return nil, err
+		}
+		if err := c.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, u string, body []byte, accept string) (*http.Response, error) {
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, r)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", c.userAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	return c.httpClient.Do(req)
+}
+
+// retryable reports whether a response status is worth retrying. Rate
+// limited requests never reached the API; server errors are only retried for
+// GET, since a POST may already have taken effect.
+func retryable(method string, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return method == http.MethodGet
+	}
+	return false
+}
+
+// readError reads an error response and closes its body.
+func readError(resp *http.Response) *Error {
+	defer resp.Body.Close()
+
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+	var body errorBody
+	if err := json.Unmarshal(data, &body); err == nil && body.Error != "" {
+		apiErr.Message = body.Error
+		apiErr.ResultCodes = body.ResultCodes
+		return apiErr
+	}
+	apiErr.Message = strings.TrimSpace(string(data))
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+	return apiErr
+}
+
+// parseRetryAfter parses a Retry-After value in seconds or as an HTTP date.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
+
+func backoff(attempt int) time.Duration {
+	return retryBackoff << min(attempt, 6)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,713 @@
+package client
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mtlprog/lore/internal/api"
+	"github.com/mtlprog/lore/internal/apikey"
+	"github.com/mtlprog/lore/internal/export"
+	"github.com/mtlprog/lore/internal/middleware"
+	"github.com/mtlprog/lore/internal/model"
+	"github.com/mtlprog/lore/internal/relation/relationtest"
+	"github.com/mtlprog/lore/internal/repository"
+	"github.com/mtlprog/lore/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accountID returns a well-formed Stellar account ID ending in suffix.
+func accountID(suffix string) string {
+	return "G" + strings.Repeat("A", 55-len(suffix)) + suffix
+}
+
+var (
+	alice = accountID("ALICE")
+	bob   = accountID("BOB")
+	carol = accountID("CAROL")
+	dave  = accountID("DAVE")
+	erin  = accountID("ERIN")
+)
+
+// fakeAccounts serves a fixed set of accounts. Keyset cursors are row indexes.
+type fakeAccounts struct {
+	rows []repository.AllAccountRow
+}
+
+func newFakeAccounts() *fakeAccounts {
+	return &fakeAccounts{rows: []repository.AllAccountRow{
+		{AccountID: alice, Name: "Alice", MTLAPBalance: 5, IsCouncilReady: true, ReceivedVotes: 3},
+		{AccountID: bob, Name: "Bob", MTLAPBalance: 4},
+		{AccountID: carol, Name: "Carol Corp", MTLACBalance: 2, TotalXLMValue: 1000},
+		{AccountID: dave, Name: "Dave Marr", MTLAPBalance: 1, ReputationScore: 3.5},
+		{AccountID: erin, Name: "Erin", MTLAXBalance: 1},
+	}}
+}
+
+func (f *fakeAccounts) find(id string) (repository.AllAccountRow, bool) {
+	i := slices.IndexFunc(f.rows, func(r repository.AllAccountRow) bool { return r.AccountID == id })
+	if i < 0 {
+		return repository.AllAccountRow{}, false
+	}
+	return f.rows[i], true
+}
+
+func (f *fakeAccounts) AccountExists(_ context.Context, id string) (bool, error) {
+	_, ok := f.find(id)
+	return ok, nil
+}
+
+func (f *fakeAccounts) GetStats(context.Context) (*repository.Stats, error) {
+	return &repository.Stats{TotalAccounts: len(f.rows), TotalPersons: 3, TotalCompanies: 1, TotalSynthetic: 1, TotalXLMValue: 1000}, nil
+}
+
+func (f *fakeAccounts) GetPersons(context.Context, int, int) ([]repository.PersonRow, error) {
+	return nil, nil
+}
+
+func (f *fakeAccounts) GetCorporate(context.Context, int, int) ([]repository.CorporateRow, error) {
+	return nil, nil
+}
+
+func (f *fakeAccounts) GetSynthetic(context.Context, int, int) ([]repository.SyntheticRow, error) {
+	return nil, nil
+}
+
+func (f *fakeAccounts) GetAllAccounts(_ context.Context, limit, offset int) ([]repository.AllAccountRow, error) {
+	return f.rows[min(offset, len(f.rows)):min(offset+limit, len(f.rows))], nil
+}
+
+func (f *fakeAccounts) GetPersonsPage(context.Context, string, int) ([]repository.PersonRow, repository.KeysetPage, error) {
+	return nil, repository.KeysetPage{}, nil
+}
+
+func (f *fakeAccounts) GetCorporatePage(context.Context, string, int) ([]repository.CorporateRow, repository.KeysetPage, error) {
+	return nil, repository.KeysetPage{}, nil
+}
+
+func (f *fakeAccounts) GetSyntheticPage(context.Context, string, int) ([]repository.SyntheticRow, repository.KeysetPage, error) {
+	return nil, repository.KeysetPage{}, nil
+}
+
+func (f *fakeAccounts) GetAllAccountsPage(_ context.Context, cursor string, limit int) ([]repository.AllAccountRow, repository.KeysetPage, error) {
+	start := 0
+	if cursor != "" {
+		var err error
+		if start, err = strconv.Atoi(cursor); err != nil || start < 0 || start > len(f.rows) {
+			return nil, repository.KeysetPage{}, repository.ErrInvalidCursor
+		}
+	}
+	end := min(start+limit, len(f.rows))
+	page := repository.KeysetPage{Start: start}
+	if end < len(f.rows) {
+		page.Next = strconv.Itoa(end)
+	}
+	if start > 0 {
+		page.Prev = strconv.Itoa(max(start-limit, 0))
+	}
+	return f.rows[start:end], page, nil
+}
+
+func (f *fakeAccounts) GetAccountsByIDs(_ context.Context, ids []string) ([]repository.AllAccountRow, error) {
+	var rows []repository.AllAccountRow
+	for _, id := range ids {
+		if row, ok := f.find(id); ok {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (f *fakeAccounts) GetRelationships(_ context.Context, id string) ([]repository.RelationshipRow, error) {
+	if id != alice {
+		return nil, nil
+	}
+	return []repository.RelationshipRow{
+		{SourceAccountID: alice, TargetAccountID: bob, TargetName: "Bob", RelationType: "Spouse", RelationIndex: "1", Direction: "outgoing"},
+		{SourceAccountID: alice, TargetAccountID: carol, TargetName: "Carol Corp", RelationType: "Employer", RelationIndex: "1", Direction: "outgoing"},
+	}, nil
+}
+
+func (f *fakeAccounts) GetTrustRatings(context.Context, string) (*repository.TrustRating, error) {
+	return &repository.TrustRating{}, nil
+}
+
+func (f *fakeAccounts) GetConfirmedRelationships(context.Context, string) (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
+
+func (f *fakeAccounts) GetAccountInfo(_ context.Context, id string) (*repository.AccountInfo, error) {
+	row, _ := f.find(id)
+	return &repository.AccountInfo{TotalXLMValue: row.TotalXLMValue, MTLACBalance: row.MTLACBalance}, nil
+}
+
+func (f *fakeAccounts) GetAccountNames(_ context.Context, ids []string) (map[string]string, error) {
+	names := make(map[string]string)
+	for _, id := range ids {
+		if row, ok := f.find(id); ok {
+			names[id] = row.Name
+		}
+	}
+	return names, nil
+}
+
+func (f *fakeAccounts) GetAccountBalances(context.Context, string) ([]repository.BalanceRow, error) {
+	return nil, nil
+}
+
+func (f *fakeAccounts) SearchAccounts(_ context.Context, query string, _ []string, limit, offset int, _ repository.SearchSortOrder) ([]repository.SearchAccountRow, error) {
+	rows := f.search(query)
+	return rows[min(offset, len(rows)):min(offset+limit, len(rows))], nil
+}
+
+func (f *fakeAccounts) SearchAccountsPage(_ context.Context, query string, _ []string, cursor string, limit int, _ repository.SearchSortOrder) ([]repository.SearchAccountRow, repository.KeysetPage, error) {
+	rows := f.search(query)
+	start, _ := strconv.Atoi(cursor)
+	end := min(start+limit, len(rows))
+	page := repository.KeysetPage{Start: start}
+	if end < len(rows) {
+		page.Next = strconv.Itoa(end)
+	}
+	return rows[min(start, end):end], page, nil
+}
+
+func (f *fakeAccounts) CountSearchAccounts(_ context.Context, query string, _ []string) (int, error) {
+	return len(f.search(query)), nil
+}
+
+// search matches names containing query, case-insensitively.
+func (f *fakeAccounts) search(query string) []repository.SearchAccountRow {
+	var rows []repository.SearchAccountRow
+	for _, r := range f.rows {
+		if strings.Contains(strings.ToLower(r.Name), strings.ToLower(query)) {
+			rows = append(rows, repository.SearchAccountRow{AccountID: r.AccountID, Name: r.Name, MTLAPBalance: r.MTLAPBalance, MTLACBalance: r.MTLACBalance})
+		}
+	}
+	return rows
+}
+
+func (f *fakeAccounts) GetLPShares(context.Context, string) ([]repository.LPShareRow, error) {
+	return nil, nil
+}
+
+func (f *fakeAccounts) CountPersons(context.Context) (int, error)   { return 0, nil }
+func (f *fakeAccounts) CountCorporate(context.Context) (int, error) { return 0, nil }
+func (f *fakeAccounts) CountSynthetic(context.Context) (int, error) { return 0, nil }
+
+func (f *fakeAccounts) GetAccountMetadata(_ context.Context, id string) (*repository.AccountMetadata, error) {
+	row, _ := f.find(id)
+	return &repository.AccountMetadata{Name: row.Name, About: "About " + row.Name, Tags: []string{"Belgrade"}}, nil
+}
+
+func (f *fakeAccounts) GetMembershipGrowth(context.Context, time.Time) ([]model.MembershipGrowth, error) {
+	return []model.MembershipGrowth{{Month: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), AssetCode: "MTLAP", Joined: 4, Left: 1}}, nil
+}
+
+// GetAccountOperations serves three operations, one per page; the cursor is
+// the index of the next operation.
+func (f *fakeAccounts) GetAccountOperations(_ context.Context, _ string, _ model.OperationFilter, cursor string, _ int) (*model.OperationsPage, error) {
+	i, _ := strconv.Atoi(cursor)
+	page := &model.OperationsPage{Operations: []model.Operation{{ID: strconv.Itoa(100 + i), Type: "payment"}}}
+	if i < 2 {
+		page.NextCursor = strconv.Itoa(i + 1)
+		page.HasMore = true
+	}
+	return page, nil
+}
+
+func (f *fakeAccounts) GetAccountFlows(context.Context, string, model.FlowFilter, int) ([]model.CounterpartyFlow, error) {
+	return []model.CounterpartyFlow{{AccountID: bob, Name: "Bob", AssetCode: "EURMTL", Sent: 10, Received: 5, Payments: 3}}, nil
+}
+
+func (f *fakeAccounts) GetFlowEdges(_ context.Context, filter model.FlowFilter, _ int) ([]model.FlowEdge, error) {
+	return []model.FlowEdge{{FromID: alice, FromName: "Alice", ToID: bob, ToName: "Bob", AssetCode: filter.AssetCode, Volume: 10, Payments: 1}}, nil
+}
+
+func (f *fakeAccounts) GetFlowPeriods(context.Context, model.FlowFilter) ([]model.FlowPeriod, error) {
+	return nil, nil
+}
+
+// fakeReputation rates alice only.
+type fakeReputation struct{}
+
+func (fakeReputation) GetScore(_ context.Context, id string) (*model.ReputationScore, error) {
+	if id != alice {
+		return nil, nil
+	}
+	return &model.ReputationScore{WeightedScore: 3.5, Grade: "B+", TotalRatings: 2}, nil
+}
+
+func (r fakeReputation) GetScores(ctx context.Context, ids []string) (map[string]*model.ReputationScore, error) {
+	scores := make(map[string]*model.ReputationScore)
+	for _, id := range ids {
+		if score, _ := r.GetScore(ctx, id); score != nil {
+			scores[id] = score
+		}
+	}
+	return scores, nil
+}
+
+func (r fakeReputation) GetGraph(ctx context.Context, id string) (*model.ReputationGraph, error) {
+	if id != alice {
+		return nil, nil
+	}
+	score, _ := r.GetScore(ctx, id)
+	return &model.ReputationGraph{
+		TargetAccountID: alice,
+		TargetName:      "Alice",
+		Score:           score,
+		Level1Nodes:     []model.ReputationNode{{AccountID: bob, Name: "Bob", Rating: "A", Distance: 1}},
+	}, nil
+}
+
+// fakeSubmitter rejects every transaction with a bad sequence.
+type fakeSubmitter struct{}
+
+func (fakeSubmitter) Submit(context.Context, string, string) (*model.SubmitResult, error) {
+	return nil, &service.TxRejectedError{TransactionCode: "tx_bad_seq", Message: "sequence changed"}
+}
+
+// fakeExporter exports a single file.
+type fakeExporter struct{}
+
+func (fakeExporter) Export(_ context.Context, opts export.Options, sink export.Sink) (*export.Manifest, error) {
+	w, err := sink.Create("accounts." + string(opts.Format))
+	if err != nil {
+		return nil, err
+	}
+	_, err = io.WriteString(w, "account_id\n"+alice+"\n")
+	return &export.Manifest{}, err
+}
+
+// fakeKeys accepts the tokens in keys.
+type fakeKeys map[string]*apikey.Key
+
+func (f fakeKeys) Authenticate(_ context.Context, token string) (*apikey.Key, error) {
+	if key, ok := f[token]; ok {
+		return key, nil
+	}
+	return nil, apikey.ErrInvalidKey
+}
+
+func (f fakeKeys) RecordUsage(context.Context, *apikey.Key) (int64, error) {
+	return 1, nil
+}
+
+// newTestAPI serves the API handler with the test relation types, wrapped by
+// wrap if not nil.
+func newTestAPI(t *testing.T, wrap func(http.Handler) http.Handler, opts ...api.Option) *httptest.Server {
+	t.Helper()
+	opts = append([]api.Option{api.WithRelationTypes(relationtest.Registry())}, opts...)
+	h, err := api.New(newFakeAccounts(), fakeReputation{}, nil, fakeSubmitter{}, opts...)
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	var handler http.Handler = mux
+	if wrap != nil {
+		handler = wrap(mux)
+	}
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newTestClient creates a client whose retries record their delay instead of
+// sleeping.
+func newTestClient(t *testing.T, baseURL string, opts ...Option) (*Client, *[]time.Duration) {
+	t.Helper()
+	c, err := New(baseURL, opts...)
+	require.NoError(t, err)
+	var waits []time.Duration
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return c, &waits
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("ftp://lore.example.org")
+	assert.Error(t, err)
+	_, err = New("https://lore.example.org", WithRetries(-1, 0))
+	assert.Error(t, err)
+	_, err = New("https://lore.example.org", WithHTTPClient(nil))
+	assert.Error(t, err)
+
+	c, err := New("https://lore.example.org/")
+	require.NoError(t, err)
+	assert.Equal(t, "https://lore.example.org", c.baseURL.String())
+}
+
+func TestClient_Stats(t *testing.T) {
+	srv := newTestAPI(t, nil)
+	c, _ := newTestClient(t, srv.URL)
+
+	stats, err := c.Stats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 5, stats.TotalAccounts)
+	assert.Equal(t, []MembershipGrowthResponse{{Month: "2026-09", AssetCode: "MTLAP", Joined: 4, Left: 1, Net: 3}}, stats.Growth)
+}
+
+func TestClient_ListAccounts(t *testing.T) {
+	srv := newTestAPI(t, nil)
+	c, _ := newTestClient(t, srv.URL)
+	ctx := context.Background()
+
+	page, err := c.ListAccounts(ctx, ListAccountsParams{PageParams: PageParams{Limit: 2, Offset: 2}})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 2)
+	assert.Equal(t, "Carol Corp", page.Data[0].Name)
+	assert.Equal(t, "corporate", page.Data[0].Type)
+	require.NotNil(t, page.Pagination.Total)
+	assert.Equal(t, 5, *page.Pagination.Total)
+	assert.Empty(t, page.Pagination.NextCursor(), "offset pages have no cursor")
+
+	page, err = c.ListAccounts(ctx, ListAccountsParams{PageParams: PageParams{Limit: 2, Keyset: true}})
+	require.NoError(t, err)
+	assert.Nil(t, page.Pagination.Total)
+	assert.Equal(t, "2", page.Pagination.NextCursor())
+	assert.Empty(t, page.Pagination.PrevCursor())
+
+	_, err = c.ListAccounts(ctx, ListAccountsParams{Type: "robot"})
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Contains(t, apiErr.Message, "invalid type")
+}
+
+func TestClient_Accounts(t *testing.T) {
+	srv := newTestAPI(t, nil)
+	c, _ := newTestClient(t, srv.URL)
+	ctx := context.Background()
+
+	var ids []string
+	for account, err := range c.Accounts(ctx, ListAccountsParams{PageParams: PageParams{Limit: 2}}) {
+		require.NoError(t, err)
+		ids = append(ids, account.ID)
+	}
+	assert.Equal(t, []string{alice, bob, carol, dave, erin}, ids)
+
+	// Breaking early stops fetching
+	ids = nil
+	for account, err := range c.Accounts(ctx, ListAccountsParams{PageParams: PageParams{Limit: 2, Cursor: "3"}}) {
+		require.NoError(t, err)
+		ids = append(ids, account.ID)
+		break
+	}
+	assert.Equal(t, []string{dave}, ids)
+
+	var errs int
+	for _, err := range c.Accounts(ctx, ListAccountsParams{PageParams: PageParams{Cursor: "bogus"}}) {
+		assert.ErrorContains(t, err, "invalid cursor")
+		errs++
+	}
+	assert.Equal(t, 1, errs)
+}
+
+func TestClient_GetAccount(t *testing.T) {
+	srv := newTestAPI(t, nil)
+	c, _ := newTestClient(t, srv.URL)
+	ctx := context.Background()
+
+	account, err := c.GetAccount(ctx, alice)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", account.Name)
+	assert.Equal(t, "About Alice", account.About)
+	require.NotNil(t, account.Reputation)
+	assert.Equal(t, "B+", account.Reputation.Grade)
+	assert.NotEmpty(t, account.Categories)
+
+	_, err = c.GetAccount(ctx, accountID("NOBODY"))
+	assert.True(t, IsNotFound(err))
+
+	_, err = c.GetAccount(ctx, "not-an-account")
+	assert.False(t, IsNotFound(err))
+	assert.ErrorContains(t, err, "invalid Stellar account ID format")
+}
+
+func TestClient_BatchAccounts(t *testing.T) {
+	srv := newTestAPI(t, nil)
+	c, _ := newTestClient(t, srv.URL)
+
+	missing := accountID("NOBODY")
+	resp, err := c.BatchAccounts(context.Background(), []string{bob, missing, alice})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 2)
+	assert.Equal(t, bob, resp.Data[0].ID)
+	assert.Equal(t, alice, resp.Data[1].ID)
+	require.NotNil(t, resp.Data[1].Reputation)
+	assert.Equal(t, []string{missing}, resp.NotFound)
+}
+
+func TestClient_GetRelationships(t *testing.T) {
+	srv := newTestAPI(t, nil)
+	c, _ := newTestClient(t, srv.URL)
+
+	categories, err := c.GetRelationships(context.Background(), alice, RelationshipsParams{Type: "Employer"})
+	require.NoError(t, err)
+	var rels []RelationshipResponse
+	for _, cat := range categories {
+		rels = append(rels, cat.Relationships...)
+	}
+	require.Len(t, rels, 1)
+	assert.Equal(t, carol, rels[0].TargetID)
+}
+
+func TestClient_GetReputation(t *testing.T) {
+	srv := newTestAPI(t, nil)
+	c, _ := newTestClient(t, srv.URL)
+
+	graph, err := c.GetReputation(context.Background(), alice)
+	require.NoError(t, err)
+	assert.Equal(t, alice, graph.TargetAccountID)
+	require.Len(t, graph.Level1Nodes, 1)
+	assert.Equal(t, bob, graph.Level1Nodes[0].AccountID)
+	assert.Empty(t, graph.Level2Nodes)
+}
+
+func TestClient_GetIssues(t *testing.T) {
+	// The linter is disabled in the test API; the 503 is retried like any
+	// unavailable GET and then returned
+	srv := newTestAPI(t, nil)
+	c, waits := newTestClient(t, srv.URL, WithRetries(2, time.Minute))
+
+	_, err := c.GetIssues(context.Background(), alice, IssuesParams{Severity: "error"})
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, time.Second}, *waits)
+}
+
+func TestClient_Search(t *testing.T) {
+	srv := newTestAPI(t, nil)
+	c, _ := newTestClient(t, srv.URL)
+	ctx := context.Background()
+
+	page, err := c.Search(ctx, SearchParams{Query: "ol"})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, carol, page.Data[0].ID)
+
+	var names []string
+	for account, err := range c.SearchAll(ctx, SearchParams{Query: "ar", PageParams: PageParams{Limit: 1}}) {
+		require.NoError(t, err)
+		names = append(names, account.Name)
+	}
+	assert.Equal(t, []string{"Carol Corp", "Dave Marr"}, names)
+
+	_, err = c.Search(ctx, SearchParams{Query: strings.Repeat("x", 101)})
+	assert.ErrorContains(t, err, "search query too long")
+}
+
+func TestClient_Operations(t *testing.T) {
+	srv := newTestAPI(t, nil)
+	c, _ := newTestClient(t, srv.URL)
+	ctx := context.Background()
+
+	page, err := c.GetOperations(ctx, alice, OperationsParams{Type: "payment", DateRange: DateRange{Since: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}})
+	require.NoError(t, err)
+	assert.True(t, page.HasMore)
+	assert.Equal(t, "1", page.NextCursor)
+
+	var ids []string
+	for op, err := range c.Operations(ctx, alice, OperationsParams{}) {
+		require.NoError(t, err)
+		ids = append(ids, op.ID)
+	}
+	assert.Equal(t, []string{"100", "101", "102"}, ids)
+
+	_, err = c.GetOperations(ctx, alice, OperationsParams{Counterparty: "nope"})
+	assert.ErrorContains(t, err, "invalid counterparty")
+}
+
+func TestClient_Flows(t *testing.T) {
+	srv := newTestAPI(t, nil)
+	c, _ := newTestClient(t, srv.URL)
+	ctx := context.Background()
+
+	flows, err := c.GetAccountFlows(ctx, alice, FlowsParams{Asset: "EURMTL"})
+	require.NoError(t, err)
+	require.Len(t, flows, 1)
+	assert.Equal(t, 15.0, flows[0].Volume)
+
+	resp, err := c.GetFlows(ctx, FlowsParams{Asset: "EURMTL", Limit: 5})
+	require.NoError(t, err)
+	require.Len(t, resp.Edges, 1)
+	assert.Equal(t, "EURMTL", resp.Edges[0].AssetCode)
+}
+
+func TestClient_SubmitTransaction(t *testing.T) {
+	srv := newTestAPI(t, nil)
+	c, waits := newTestClient(t, srv.URL)
+
+	_, err := c.SubmitTransaction(context.Background(), SubmitRequest{XDR: "AAAA"})
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	assert.Equal(t, "sequence changed", apiErr.Message)
+	assert.Equal(t, []string{"tx_bad_seq"}, apiErr.ResultCodes)
+	assert.Empty(t, *waits)
+}
+
+func TestClient_DecodeXDR(t *testing.T) {
+	srv := newTestAPI(t, nil)
+	c, _ := newTestClient(t, srv.URL)
+
+	_, err := c.DecodeXDR(context.Background(), "not base64")
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
+
+func TestClient_RetryAfter(t *testing.T) {
+	// The first two requests are turned away like the rate limiter does
+	rejected := 0
+	limit := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if rejected < 2 {
+				rejected++
+				w.Header().Set("Retry-After", "7")
+				http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+	srv := newTestAPI(t, limit)
+
+	t.Run("waits as asked", func(t *testing.T) {
+		c, waits := newTestClient(t, srv.URL)
+		_, err := c.BatchAccounts(context.Background(), []string{alice})
+		require.NoError(t, err)
+		assert.Equal(t, []time.Duration{7 * time.Second, 7 * time.Second}, *waits)
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		rejected = 0
+		c, waits := newTestClient(t, srv.URL, WithRetries(1, time.Minute))
+		_, err := c.Stats(context.Background())
+		var apiErr *Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+		assert.Equal(t, "Rate limit exceeded", apiErr.Message)
+		assert.Equal(t, 7*time.Second, apiErr.RetryAfter)
+		assert.Len(t, *waits, 1)
+	})
+
+	t.Run("stops at canceled context", func(t *testing.T) {
+		rejected = 0
+		c, err := New(srv.URL)
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		c.sleep = func(ctx context.Context, _ time.Duration) error {
+			cancel()
+			return ctx.Err()
+		}
+		_, err = c.Stats(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestClient_RateLimiter(t *testing.T) {
+	limiter, err := middleware.New(1, nil)
+	require.NoError(t, err)
+	t.Cleanup(limiter.Close)
+	srv := newTestAPI(t, limiter.Middleware)
+	c, waits := newTestClient(t, srv.URL, WithRetries(3, 10*time.Second))
+	ctx := context.Background()
+
+	_, err = c.Stats(ctx)
+	require.NoError(t, err)
+
+	// The limiter asks to wait for the rest of its minute, longer than allowed
+	_, err = c.Stats(ctx)
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+	assert.Greater(t, apiErr.RetryAfter, 10*time.Second)
+	assert.Empty(t, *waits)
+}
+
+func TestClient_APIKey(t *testing.T) {
+	keys := fakeKeys{
+		"lore_read":   {ID: 1, Scopes: []apikey.Scope{apikey.ScopeRead}},
+		"lore_export": {ID: 2, Scopes: []apikey.Scope{apikey.ScopeRead, apikey.ScopeExport}},
+	}
+	limiter, err := middleware.New(100, nil, middleware.WithAPIKeys(keys))
+	require.NoError(t, err)
+	t.Cleanup(limiter.Close)
+	srv := newTestAPI(t, limiter.Middleware, api.WithExporter(fakeExporter{}))
+	ctx := context.Background()
+
+	t.Run("invalid key", func(t *testing.T) {
+		c, _ := newTestClient(t, srv.URL, WithAPIKey("lore_bogus"))
+		_, err := c.Stats(ctx)
+		var apiErr *Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	})
+
+	t.Run("missing scope", func(t *testing.T) {
+		c, _ := newTestClient(t, srv.URL, WithAPIKey("lore_read"))
+		_, err := c.Stats(ctx)
+		require.NoError(t, err)
+
+		_, err = c.Export(ctx, ExportParams{})
+		var apiErr *Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+		assert.Equal(t, "API key lacks the export scope", apiErr.Message)
+	})
+
+	t.Run("export", func(t *testing.T) {
+		c, _ := newTestClient(t, srv.URL, WithAPIKey("lore_export"))
+		body, err := c.Export(ctx, ExportParams{Format: "jsonl", ExcludePersonal: true})
+		require.NoError(t, err)
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		require.NoError(t, body.Close())
+
+		archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		require.Len(t, archive.File, 1)
+		assert.Equal(t, "accounts.jsonl", archive.File[0].Name)
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"30", 30 * time.Second},
+		{"-5", 0},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%q", tt.value), func(t *testing.T) {
+			assert.Equal(t, tt.want, parseRetryAfter(tt.value, now))
+		})
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	assert.True(t, retryable(http.MethodPost, http.StatusTooManyRequests))
+	assert.True(t, retryable(http.MethodGet, http.StatusServiceUnavailable))
+	assert.False(t, retryable(http.MethodPost, http.StatusGatewayTimeout), "a timed out submission may still be applied")
+	assert.False(t, retryable(http.MethodGet, http.StatusInternalServerError))
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mtlprog/lore/internal/api"
+	"github.com/mtlprog/lore/internal/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEvents stores events in memory.
+type fakeEvents struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func (f *fakeEvents) add(t events.Type, account string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, events.Event{
+		ID:        int64(len(f.events) + 1),
+		Type:      t,
+		AccountID: account,
+		Data:      json.RawMessage(`{"mtlap_balance":"1"}`),
+		CreatedAt: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+	})
+}
+
+func (f *fakeEvents) After(_ context.Context, after int64, filter events.Filter, limit int) ([]events.Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []events.Event
+	for _, e := range f.events {
+		if e.ID > after && filter.Match(e) && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeEvents) LatestID(context.Context) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return int64(len(f.events)), nil
+}
+
+func TestClient_Events(t *testing.T) {
+	store := &fakeEvents{}
+	store.add(events.TypeMemberJoined, alice)
+	store.add(events.TypeMemberJoined, bob)
+	store.add(events.TypeMemberLeft, alice)
+
+	hub, err := events.NewHub(store, 10)
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, hub.Start(ctx))
+	t.Cleanup(hub.Close)
+
+	srv := newTestAPI(t, nil, api.WithEvents(hub))
+	c, _ := newTestClient(t, srv.URL)
+
+	from := int64(0)
+	stream, err := c.Events(ctx, EventsParams{Accounts: []string{alice}, LastEventID: &from})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	// Stored events are replayed first
+	e, err := stream.Next()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), e.ID)
+	assert.Equal(t, "member.joined", e.Type)
+	assert.JSONEq(t, `{"mtlap_balance":"1"}`, string(e.Data))
+
+	e, err = stream.Next()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), e.ID)
+	assert.Equal(t, int64(3), stream.LastEventID())
+
+	// Then new events as they are published
+	store.add(events.TypeAccountUpdated, bob)
+	store.add(events.TypeAccountUpdated, alice)
+	require.NoError(t, hub.Poll(ctx))
+
+	e, err = stream.Next()
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), e.ID)
+	assert.Equal(t, "account.updated", e.Type)
+
+	// Closing the hub ends the stream
+	hub.Close()
+	_, err = stream.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestClient_EventsInvalidFilter(t *testing.T) {
+	hub, err := events.NewHub(&fakeEvents{}, 10)
+	require.NoError(t, err)
+	t.Cleanup(hub.Close)
+	srv := newTestAPI(t, nil, api.WithEvents(hub))
+	c, _ := newTestClient(t, srv.URL)
+
+	_, err = c.Events(context.Background(), EventsParams{Types: []string{"member.renamed"}})
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
+
+func TestEventStream_Next(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "retry: 5000\n\n"+
+			": ping\n\n"+
+			"id: 7\r\nevent: member.left\r\ndata: {\"id\":7,\r\ndata: \"type\":\"member.left\"}\r\n\r\n"+
+			"id: 8\ndata: {\"id\":8")
+	}))
+	t.Cleanup(srv.Close)
+	c, _ := newTestClient(t, srv.URL)
+
+	stream, err := c.Events(context.Background(), EventsParams{})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	e, err := stream.Next()
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), e.ID)
+	assert.Equal(t, "member.left", e.Type)
+
+	// A stream cut off mid-event is not a clean end
+	_, err = stream.Next()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Equal(t, int64(7), stream.LastEventID())
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ExportParams selects the dataset export.
+type ExportParams struct {
+	Format          string // "csv" (default), "jsonl" or "parquet"
+	ExcludePersonal bool   // Leave out Telegram IDs and contract links
+}
+
+// Export streams the dataset as a zip archive with one file per table and a
+// manifest.json. It needs an API key with the read and export scopes. The
+// caller must close the returned reader; a read error means the export failed
+// midway and the archive is incomplete.
+func (c *Client) Export(ctx context.Context, params ExportParams) (io.ReadCloser, error) {
+	q := url.Values{}
+	if params.Format != "" {
+		q.Set("format", params.Format)
+	}
+	if params.ExcludePersonal {
+		q.Set("exclude_personal", "true")
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, "/api/v1/export", q, nil, "application/zip")
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
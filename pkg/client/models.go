@@ -0,0 +1,356 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AccountPage is a page of accounts from ListAccounts or Search.
+type AccountPage struct {
+	Data       []AccountListItem `json:"data"`
+	Pagination Pagination        `json:"pagination"`
+}
+
+// Pagination holds pagination metadata. Next and Prev link the neighbouring
+// pages. Total is only counted for offset pagination; with a cursor, Offset is
+// the approximate position of the first item.
+type Pagination struct {
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+	Total  *int   `json:"total,omitempty"`
+	Next   string `json:"next,omitempty"`
+	Prev   string `json:"prev,omitempty"`
+}
+
+// AccountListItem represents an account in list responses.
+type AccountListItem struct {
+	ID              string  `json:"id"`
+	Name            string  `json:"name"`
+	Type            string  `json:"type"` // "person", "corporate", "synthetic"
+	MTLAPBalance    float64 `json:"mtlap_balance"`
+	MTLACBalance    float64 `json:"mtlac_balance"`
+	MTLAXBalance    float64 `json:"mtlax_balance"`
+	TotalXLMValue   float64 `json:"total_xlm_value"`
+	ReputationScore float64 `json:"reputation_score,omitempty"`
+	ReputationGrade string  `json:"reputation_grade,omitempty"`
+	IsCouncilReady  bool    `json:"is_council_ready,omitempty"`
+	ReceivedVotes   int     `json:"received_votes,omitempty"`
+}
+
+// BatchAccountsRequest is the body of a bulk account lookup.
+type BatchAccountsRequest struct {
+	IDs []string `json:"ids"` // Stellar account IDs, duplicates are ignored
+}
+
+// BatchAccountItem is an account list item with its full reputation score.
+type BatchAccountItem struct {
+	AccountListItem
+	Reputation *ReputationResponse `json:"reputation,omitempty"`
+}
+
+// BatchAccountsResponse holds the requested accounts in request order and the
+// IDs that are not tracked.
+type BatchAccountsResponse struct {
+	Data     []BatchAccountItem `json:"data"`
+	NotFound []string           `json:"not_found"`
+}
+
+// AccountDetailResponse represents full account detail.
+type AccountDetailResponse struct {
+	ID            string                         `json:"id"`
+	Name          string                         `json:"name"`
+	About         string                         `json:"about,omitempty"`
+	Websites      []string                       `json:"websites,omitempty"`
+	Tags          []string                       `json:"tags,omitempty"`
+	Identity      *IdentityResponse              `json:"identity,omitempty"`
+	IsCorporate   bool                           `json:"is_corporate"`
+	TotalXLMValue float64                        `json:"total_xlm_value"`
+	Trustlines    []TrustlineResponse            `json:"trustlines,omitempty"`
+	LPShares      []LPShareResponse              `json:"lp_shares,omitempty"`
+	TrustRating   *TrustRatingResponse           `json:"trust_rating,omitempty"`
+	Reputation    *ReputationResponse            `json:"reputation,omitempty"`
+	Categories    []RelationshipCategoryResponse `json:"categories,omitempty"`
+}
+
+// IdentityResponse holds the identity profile fields published in account ManageData.
+type IdentityResponse struct {
+	TelegramUserID     string `json:"telegram_user_id,omitempty"`
+	TimeTokenCode      string `json:"time_token_code,omitempty"`
+	TimeTokenIssuer    string `json:"time_token_issuer,omitempty"`
+	TimeTokenDesc      string `json:"time_token_desc,omitempty"`
+	TimeTokenOfferIPFS string `json:"time_token_offer_ipfs,omitempty"`
+	TimeTokenOfferURL  string `json:"time_token_offer_url,omitempty"`
+	TelegramPartChatID string `json:"telegram_part_chat_id,omitempty"`
+	ContractIPFS       string `json:"contract_ipfs,omitempty"`
+	ContractURL        string `json:"contract_url,omitempty"`
+	PIIStandard        bool   `json:"pii_standard,omitempty"`
+}
+
+// TrustlineResponse represents a single asset trustline.
+type TrustlineResponse struct {
+	AssetCode   string `json:"asset_code"`
+	AssetIssuer string `json:"asset_issuer"`
+	Balance     string `json:"balance"`
+	Limit       string `json:"limit,omitempty"`
+}
+
+// LPShareResponse represents a liquidity pool share.
+type LPShareResponse struct {
+	PoolID       string          `json:"pool_id"`
+	ShareBalance string          `json:"share_balance"`
+	SharePercent string          `json:"share_percent"`
+	ReserveA     ReserveResponse `json:"reserve_a"`
+	ReserveB     ReserveResponse `json:"reserve_b"`
+	XLMValue     float64         `json:"xlm_value"`
+}
+
+// ReserveResponse represents a liquidity pool reserve.
+type ReserveResponse struct {
+	AssetCode   string `json:"asset_code"`
+	AssetIssuer string `json:"asset_issuer"`
+	Amount      string `json:"amount"`
+}
+
+// TrustRatingResponse represents aggregated trust ratings.
+type TrustRatingResponse struct {
+	CountA int     `json:"count_a"`
+	CountB int     `json:"count_b"`
+	CountC int     `json:"count_c"`
+	CountD int     `json:"count_d"`
+	Total  int     `json:"total"`
+	Score  float64 `json:"score"`
+	Grade  string  `json:"grade"`
+}
+
+// ReputationResponse represents a weighted reputation score.
+type ReputationResponse struct {
+	WeightedScore float64 `json:"weighted_score"`
+	BaseScore     float64 `json:"base_score"`
+	Grade         string  `json:"grade"`
+	RatingCountA  int     `json:"rating_count_a"`
+	RatingCountB  int     `json:"rating_count_b"`
+	RatingCountC  int     `json:"rating_count_c"`
+	RatingCountD  int     `json:"rating_count_d"`
+	TotalRatings  int     `json:"total_ratings"`
+	TotalWeight   float64 `json:"total_weight"`
+}
+
+// ReputationGraphResponse represents a 2-level reputation graph.
+type ReputationGraphResponse struct {
+	TargetAccountID string                   `json:"target_account_id"`
+	TargetName      string                   `json:"target_name"`
+	Score           *ReputationResponse      `json:"score,omitempty"`
+	Level1Nodes     []ReputationNodeResponse `json:"level1_nodes"`
+	Level2Nodes     []ReputationNodeResponse `json:"level2_nodes"`
+}
+
+// ReputationNodeResponse represents a node in the reputation graph.
+type ReputationNodeResponse struct {
+	AccountID    string  `json:"account_id"`
+	Name         string  `json:"name"`
+	Rating       string  `json:"rating"`
+	Weight       float64 `json:"weight"`
+	PortfolioXLM float64 `json:"portfolio_xlm"`
+	Connections  int     `json:"connections"`
+	OwnScore     float64 `json:"own_score"`
+	Distance     int     `json:"distance"`
+}
+
+// RelationshipCategoryResponse groups relationships by category.
+type RelationshipCategoryResponse struct {
+	Name          string                 `json:"name"`
+	Color         string                 `json:"color"`
+	Relationships []RelationshipResponse `json:"relationships"`
+}
+
+// RelationshipResponse represents a single relationship.
+type RelationshipResponse struct {
+	Type        string `json:"type"`
+	TypeName    string `json:"type_name"`
+	TargetID    string `json:"target_id"`
+	TargetName  string `json:"target_name"`
+	Direction   string `json:"direction"`
+	IsMutual    bool   `json:"is_mutual"`
+	IsConfirmed bool   `json:"is_confirmed"`
+}
+
+// IssuesResponse lists relationship and metadata inconsistencies for an account.
+type IssuesResponse struct {
+	AccountID string          `json:"account_id"`
+	Total     int             `json:"total"`
+	Issues    []IssueResponse `json:"issues"`
+}
+
+// IssueResponse represents a single lint finding with a suggested fix.
+type IssueResponse struct {
+	Rule         string `json:"rule"`
+	Category     string `json:"category"` // "contradiction", "confirmation", "self_reference", "duplicate", "unknown_target", "malformed"
+	Severity     string `json:"severity"` // "error", "warning", "info"
+	Key          string `json:"key,omitempty"`
+	RelationType string `json:"relation_type,omitempty"`
+	TargetID     string `json:"target_id,omitempty"`
+	Message      string `json:"message"`
+	Suggestion   string `json:"suggestion"`
+	FixURL       string `json:"fix_url"`
+}
+
+// SubmitRequest is the body of a signed transaction submission.
+type SubmitRequest struct {
+	XDR         string `json:"xdr"`                    // Signed transaction envelope (base64)
+	UnsignedXDR string `json:"unsigned_xdr,omitempty"` // Unsigned XDR from the init form; when set, the signed envelope must match it
+}
+
+// SubmitResponse describes a transaction accepted by the network.
+type SubmitResponse struct {
+	AccountID  string `json:"account_id"`
+	Hash       string `json:"hash"`
+	Ledger     int32  `json:"ledger"`
+	Refreshing bool   `json:"refreshing"` // Account data in Lore is being refreshed in the background
+}
+
+// DecodeRequest is the body of an XDR decode request.
+type DecodeRequest struct {
+	XDR string `json:"xdr"` // TransactionEnvelope or FeeBumpTransactionEnvelope (base64)
+}
+
+// DecodeResponse describes a decoded transaction envelope.
+type DecodeResponse struct {
+	Hash           string                     `json:"hash"`                 // Envelope hash on the public network
+	InnerHash      string                     `json:"inner_hash,omitempty"` // Inner transaction hash (fee bump only)
+	FeeBump        bool                       `json:"fee_bump"`
+	FeeAccount     string                     `json:"fee_account,omitempty"`
+	SourceAccount  string                     `json:"source_account"`
+	Sequence       int64                      `json:"sequence"`
+	MaxFee         string                     `json:"max_fee"` // In XLM
+	MemoType       string                     `json:"memo_type"`
+	Memo           string                     `json:"memo,omitempty"`
+	ValidAfter     string                     `json:"valid_after,omitempty"`
+	ValidBefore    string                     `json:"valid_before,omitempty"`
+	SignatureCount int                        `json:"signature_count"`
+	RiskCount      int                        `json:"risk_count"` // Operations that change account control
+	Operations     []DecodedOperationResponse `json:"operations"`
+	AccountNames   map[string]string          `json:"account_names"` // Names of involved accounts known to Lore
+}
+
+// DecodedOperationResponse describes one operation of a decoded envelope.
+type DecodedOperationResponse struct {
+	Type          string `json:"type"`         // Horizon operation type, e.g. "manage_data"
+	TypeDisplay   string `json:"type_display"` // e.g. "Manage Data"
+	SourceAccount string `json:"source_account"`
+	Action        string `json:"action"` // Plain-language summary, e.g. "adds Employer relation to"
+	TargetAccount string `json:"target_account,omitempty"`
+	TargetValue   string `json:"target_value,omitempty"`
+	Risk          string `json:"risk,omitempty"` // Why the operation deserves attention
+	Amount        string `json:"amount,omitempty"`
+	AssetCode     string `json:"asset_code,omitempty"`
+	AssetIssuer   string `json:"asset_issuer,omitempty"`
+	DataName      string `json:"data_name,omitempty"`
+	DataValue     string `json:"data_value,omitempty"`
+}
+
+// StatsResponse represents aggregate statistics.
+type StatsResponse struct {
+	TotalAccounts  int     `json:"total_accounts"`
+	TotalPersons   int     `json:"total_persons"`
+	TotalCompanies int     `json:"total_companies"`
+	TotalSynthetic int     `json:"total_synthetic"`
+	TotalXLMValue  float64 `json:"total_xlm_value"`
+	// Membership growth per month and token over the last year, oldest month first
+	Growth []MembershipGrowthResponse `json:"growth"`
+}
+
+// MembershipGrowthResponse counts accounts that joined or left in one month.
+type MembershipGrowthResponse struct {
+	Month     string `json:"month"` // YYYY-MM
+	AssetCode string `json:"asset_code"`
+	Joined    int    `json:"joined"`
+	Left      int    `json:"left"`
+	Net       int    `json:"net"`
+}
+
+// OperationsResponse is a page of indexed account operations, newest first.
+type OperationsResponse struct {
+	Data       []OperationResponse `json:"data"`
+	NextCursor string              `json:"next_cursor,omitempty"` // Pass as cursor to get the next page
+	HasMore    bool                `json:"has_more"`
+}
+
+// OperationResponse represents an account operation. Type-specific fields are
+// omitted when empty.
+type OperationResponse struct {
+	ID              string `json:"id"`
+	Type            string `json:"type"`
+	TypeDisplay     string `json:"type_display"`
+	CreatedAt       string `json:"created_at"`
+	TransactionHash string `json:"transaction_hash"`
+	SourceAccount   string `json:"source_account"`
+	Amount          string `json:"amount,omitempty"`
+	AssetCode       string `json:"asset_code,omitempty"`
+	AssetIssuer     string `json:"asset_issuer,omitempty"`
+	From            string `json:"from,omitempty"`
+	To              string `json:"to,omitempty"`
+	DataName        string `json:"data_name,omitempty"`
+	DataValue       string `json:"data_value,omitempty"`
+	StartingBalance string `json:"starting_balance,omitempty"`
+	TrustLimit      string `json:"trust_limit,omitempty"`
+	SourceAmount    string `json:"source_amount,omitempty"`
+	SourceAsset     string `json:"source_asset,omitempty"`
+	DestAmount      string `json:"dest_amount,omitempty"`
+	DestAsset       string `json:"dest_asset,omitempty"`
+	Selling         string `json:"selling,omitempty"`
+	Buying          string `json:"buying,omitempty"`
+	Price           string `json:"price,omitempty"`
+	OfferID         string `json:"offer_id,omitempty"`
+}
+
+// CounterpartyFlowResponse aggregates payments between an account and one
+// tracked counterparty in one asset.
+type CounterpartyFlowResponse struct {
+	AccountID   string  `json:"account_id"`
+	Name        string  `json:"name"`
+	AssetCode   string  `json:"asset_code"`
+	AssetIssuer string  `json:"asset_issuer,omitempty"` // Empty for native
+	Sent        float64 `json:"sent"`
+	Received    float64 `json:"received"`
+	Volume      float64 `json:"volume"`
+	Payments    int     `json:"payments"`
+}
+
+// FlowsResponse describes the internal economy: monthly volume per asset and
+// the largest flows between tracked accounts.
+type FlowsResponse struct {
+	Periods []FlowPeriodResponse `json:"periods"`
+	Edges   []FlowEdgeResponse   `json:"edges"`
+}
+
+// FlowPeriodResponse is the internal payment volume of one asset in one month.
+type FlowPeriodResponse struct {
+	Month       string  `json:"month"` // YYYY-MM
+	AssetCode   string  `json:"asset_code"`
+	AssetIssuer string  `json:"asset_issuer,omitempty"` // Empty for native
+	Volume      float64 `json:"volume"`
+	Payments    int     `json:"payments"`
+	Payers      int     `json:"payers"`
+}
+
+// FlowEdgeResponse aggregates payments from one tracked account to another in one asset.
+type FlowEdgeResponse struct {
+	From        string  `json:"from"`
+	FromName    string  `json:"from_name"`
+	To          string  `json:"to"`
+	ToName      string  `json:"to_name"`
+	AssetCode   string  `json:"asset_code"`
+	AssetIssuer string  `json:"asset_issuer,omitempty"` // Empty for native
+	Volume      float64 `json:"volume"`
+	Payments    int     `json:"payments"`
+}
+
+// EventResponse is the data of one Server-Sent Event on /api/v1/events.
+type EventResponse struct {
+	ID               int64           `json:"id"`
+	Type             string          `json:"type"`
+	AccountID        string          `json:"account_id"`
+	RelatedAccountID string          `json:"related_account_id,omitempty"` // Relationship target
+	Data             json.RawMessage `json:"data"`                         // Type-specific details, e.g. {"from":2,"to":5}
+	CreatedAt        time.Time       `json:"created_at"`
+}
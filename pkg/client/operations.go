@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DateRange limits operations and flows to a time span. Zero times leave the
+// range open.
+type DateRange struct {
+	Since time.Time // Inclusive
+	Until time.Time // Exclusive
+}
+
+func (d DateRange) apply(q url.Values) {
+	if !d.Since.IsZero() {
+		q.Set("since", d.Since.Format(time.RFC3339))
+	}
+	if !d.Until.IsZero() {
+		q.Set("until", d.Until.Format(time.RFC3339))
+	}
+}
+
+// OperationsParams filters GetOperations.
+type OperationsParams struct {
+	Type         string // Horizon operation type, e.g. "payment"
+	Asset        string // Asset code, "XLM" for native
+	Counterparty string // Other account involved
+	DateRange
+	Cursor string // OperationsResponse.NextCursor of the previous page
+	Limit  int    // Page size; zero uses the server default of 20, at most 100
+}
+
+// GetOperations returns one page of the indexed operations of an account,
+// newest first.
+func (c *Client) GetOperations(ctx context.Context, id string, params OperationsParams) (*OperationsResponse, error) {
+	q := url.Values{}
+	if params.Type != "" {
+		q.Set("type", params.Type)
+	}
+	if params.Asset != "" {
+		q.Set("asset", params.Asset)
+	}
+	if params.Counterparty != "" {
+		q.Set("counterparty", params.Counterparty)
+	}
+	params.DateRange.apply(q)
+	if params.Cursor != "" {
+		q.Set("cursor", params.Cursor)
+	}
+	if params.Limit > 0 {
+		q.Set("limit", strconv.Itoa(params.Limit))
+	}
+
+	var resp OperationsResponse
+	if err := c.get(ctx, accountPath(id)+"/operations", q, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Operations iterates over all operations of an account matching params,
+// newest first, fetching pages as needed. Iteration stops after the first
+// error.
+func (c *Client) Operations(ctx context.Context, id string, params OperationsParams) iter.Seq2[OperationResponse, error] {
+	return func(yield func(OperationResponse, error) bool) {
+		for {
+			page, err := c.GetOperations(ctx, id, params)
+			if err != nil {
+				yield(OperationResponse{}, err)
+				return
+			}
+			for _, op := range page.Data {
+				if !yield(op, nil) {
+					return
+				}
+			}
+			if !page.HasMore || page.NextCursor == "" {
+				return
+			}
+			params.Cursor = page.NextCursor
+		}
+	}
+}
+
+// FlowsParams filters GetAccountFlows and GetFlows.
+type FlowsParams struct {
+	Asset string // Asset code, "XLM" for native, or "CODE:ISSUER" for one issuer
+	DateRange
+	Limit int // Number of counterparties or flows; zero uses the server default of 20, at most 100
+}
+
+func (p FlowsParams) query() url.Values {
+	q := url.Values{}
+	if p.Asset != "" {
+		q.Set("asset", p.Asset)
+	}
+	p.DateRange.apply(q)
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return q
+}
+
+// GetAccountFlows returns the largest counterparties of an account among
+// tracked accounts, per asset.
+func (c *Client) GetAccountFlows(ctx context.Context, id string, params FlowsParams) ([]CounterpartyFlowResponse, error) {
+	var resp []CounterpartyFlowResponse
+	if err := c.get(ctx, accountPath(id)+"/flows", params.query(), &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetFlows returns the monthly payment volume between tracked accounts per
+// asset and the largest flows.
+func (c *Client) GetFlows(ctx context.Context, params FlowsParams) (*FlowsResponse, error) {
+	var resp FlowsResponse
+	if err := c.get(ctx, "/api/v1/flows", params.query(), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,25 @@
+package client
+
+import "context"
+
+// SubmitTransaction submits a signed transaction from the init form. A
+// transaction rejected by the network yields an *Error with status 422 and
+// the Horizon result codes. Submissions are not retried on server errors: a
+// 504 means the transaction may still be applied.
+func (c *Client) SubmitTransaction(ctx context.Context, req SubmitRequest) (*SubmitResponse, error) {
+	var resp SubmitResponse
+	if err := c.post(ctx, "/api/v1/init/submit", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DecodeXDR decodes a transaction envelope into a readable summary of its
+// operations, naming the accounts known to Lore.
+func (c *Client) DecodeXDR(ctx context.Context, xdr string) (*DecodeResponse, error) {
+	var resp DecodeResponse
+	if err := c.post(ctx, "/api/v1/xdr/decode", DecodeRequest{XDR: xdr}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,2360 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: lore/v1/lore.proto
+
+// Package lore.v1 mirrors the read endpoints of the REST API under /api/v1.
+
+package lorev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AccountType is inferred from the MTLAP, MTLAC and MTLAX balances.
+type AccountType int32
+
+const (
+	AccountType_ACCOUNT_TYPE_UNSPECIFIED AccountType = 0 // No filter in requests
+	AccountType_ACCOUNT_TYPE_PERSON      AccountType = 1
+	AccountType_ACCOUNT_TYPE_CORPORATE   AccountType = 2
+	AccountType_ACCOUNT_TYPE_SYNTHETIC   AccountType = 3
+)
+
+// Enum value maps for AccountType.
+var (
+	AccountType_name = map[int32]string{
+		0: "ACCOUNT_TYPE_UNSPECIFIED",
+		1: "ACCOUNT_TYPE_PERSON",
+		2: "ACCOUNT_TYPE_CORPORATE",
+		3: "ACCOUNT_TYPE_SYNTHETIC",
+	}
+	AccountType_value = map[string]int32{
+		"ACCOUNT_TYPE_UNSPECIFIED": 0,
+		"ACCOUNT_TYPE_PERSON":      1,
+		"ACCOUNT_TYPE_CORPORATE":   2,
+		"ACCOUNT_TYPE_SYNTHETIC":   3,
+	}
+)
+
+func (x AccountType) Enum() *AccountType {
+	p := new(AccountType)
+	*p = x
+	return p
+}
+
+func (x AccountType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccountType) Descriptor() protoreflect.EnumDescriptor {
+	return file_lore_v1_lore_proto_enumTypes[0].Descriptor()
+}
+
+func (AccountType) Type() protoreflect.EnumType {
+	return &file_lore_v1_lore_proto_enumTypes[0]
+}
+
+func (x AccountType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccountType.Descriptor instead.
+func (AccountType) EnumDescriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{0}
+}
+
+// SearchSort orders search results.
+type SearchSort int32
+
+const (
+	SearchSort_SEARCH_SORT_UNSPECIFIED SearchSort = 0 // Same as SEARCH_SORT_BALANCE
+	SearchSort_SEARCH_SORT_BALANCE     SearchSort = 1
+	SearchSort_SEARCH_SORT_REPUTATION  SearchSort = 2
+)
+
+// Enum value maps for SearchSort.
+var (
+	SearchSort_name = map[int32]string{
+		0: "SEARCH_SORT_UNSPECIFIED",
+		1: "SEARCH_SORT_BALANCE",
+		2: "SEARCH_SORT_REPUTATION",
+	}
+	SearchSort_value = map[string]int32{
+		"SEARCH_SORT_UNSPECIFIED": 0,
+		"SEARCH_SORT_BALANCE":     1,
+		"SEARCH_SORT_REPUTATION":  2,
+	}
+)
+
+func (x SearchSort) Enum() *SearchSort {
+	p := new(SearchSort)
+	*p = x
+	return p
+}
+
+func (x SearchSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_lore_v1_lore_proto_enumTypes[1].Descriptor()
+}
+
+func (SearchSort) Type() protoreflect.EnumType {
+	return &file_lore_v1_lore_proto_enumTypes[1]
+}
+
+func (x SearchSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchSort.Descriptor instead.
+func (SearchSort) EnumDescriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{1}
+}
+
+// PageRequest selects a page. Set cursor, empty for the first page, for keyset
+// pagination: pages stay stable while accounts are re-synced and the total is
+// not counted. Otherwise offset is used.
+type PageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // Default 20, at most 100
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Cursor        *string                `protobuf:"bytes,3,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageRequest) Reset() {
+	*x = PageRequest{}
+	mi := &file_lore_v1_lore_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageRequest) ProtoMessage() {}
+
+func (x *PageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageRequest.ProtoReflect.Descriptor instead.
+func (*PageRequest) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PageRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *PageRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *PageRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+// Page describes the returned page.
+type Page struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Total         *int32                 `protobuf:"varint,3,opt,name=total,proto3,oneof" json:"total,omitempty"`                      // Offset pagination only
+	NextCursor    string                 `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Keyset pagination only, empty on the last page
+	PrevCursor    string                 `protobuf:"bytes,5,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"` // Keyset pagination only, empty on the first page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Page) Reset() {
+	*x = Page{}
+	mi := &file_lore_v1_lore_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Page) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Page) ProtoMessage() {}
+
+func (x *Page) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Page.ProtoReflect.Descriptor instead.
+func (*Page) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Page) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *Page) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Page) GetTotal() int32 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+func (x *Page) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *Page) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_lore_v1_lore_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{2}
+}
+
+type GetStatsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TotalAccounts  int32                  `protobuf:"varint,1,opt,name=total_accounts,json=totalAccounts,proto3" json:"total_accounts,omitempty"`
+	TotalPersons   int32                  `protobuf:"varint,2,opt,name=total_persons,json=totalPersons,proto3" json:"total_persons,omitempty"`
+	TotalCompanies int32                  `protobuf:"varint,3,opt,name=total_companies,json=totalCompanies,proto3" json:"total_companies,omitempty"`
+	TotalSynthetic int32                  `protobuf:"varint,4,opt,name=total_synthetic,json=totalSynthetic,proto3" json:"total_synthetic,omitempty"`
+	TotalXlmValue  float64                `protobuf:"fixed64,5,opt,name=total_xlm_value,json=totalXlmValue,proto3" json:"total_xlm_value,omitempty"`
+	Growth         []*MembershipGrowth    `protobuf:"bytes,6,rep,name=growth,proto3" json:"growth,omitempty"` // Last 12 months including the current one
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_lore_v1_lore_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetStatsResponse) GetTotalAccounts() int32 {
+	if x != nil {
+		return x.TotalAccounts
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetTotalPersons() int32 {
+	if x != nil {
+		return x.TotalPersons
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetTotalCompanies() int32 {
+	if x != nil {
+		return x.TotalCompanies
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetTotalSynthetic() int32 {
+	if x != nil {
+		return x.TotalSynthetic
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetTotalXlmValue() float64 {
+	if x != nil {
+		return x.TotalXlmValue
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetGrowth() []*MembershipGrowth {
+	if x != nil {
+		return x.Growth
+	}
+	return nil
+}
+
+// MembershipGrowth counts MTLAP or MTLAC holders joining and leaving in a month.
+type MembershipGrowth struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Month         string                 `protobuf:"bytes,1,opt,name=month,proto3" json:"month,omitempty"` // YYYY-MM
+	AssetCode     string                 `protobuf:"bytes,2,opt,name=asset_code,json=assetCode,proto3" json:"asset_code,omitempty"`
+	Joined        int32                  `protobuf:"varint,3,opt,name=joined,proto3" json:"joined,omitempty"`
+	Left          int32                  `protobuf:"varint,4,opt,name=left,proto3" json:"left,omitempty"`
+	Net           int32                  `protobuf:"varint,5,opt,name=net,proto3" json:"net,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MembershipGrowth) Reset() {
+	*x = MembershipGrowth{}
+	mi := &file_lore_v1_lore_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MembershipGrowth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MembershipGrowth) ProtoMessage() {}
+
+func (x *MembershipGrowth) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MembershipGrowth.ProtoReflect.Descriptor instead.
+func (*MembershipGrowth) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MembershipGrowth) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *MembershipGrowth) GetAssetCode() string {
+	if x != nil {
+		return x.AssetCode
+	}
+	return ""
+}
+
+func (x *MembershipGrowth) GetJoined() int32 {
+	if x != nil {
+		return x.Joined
+	}
+	return 0
+}
+
+func (x *MembershipGrowth) GetLeft() int32 {
+	if x != nil {
+		return x.Left
+	}
+	return 0
+}
+
+func (x *MembershipGrowth) GetNet() int32 {
+	if x != nil {
+		return x.Net
+	}
+	return 0
+}
+
+type ListAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          AccountType            `protobuf:"varint,1,opt,name=type,proto3,enum=lore.v1.AccountType" json:"type,omitempty"`
+	Page          *PageRequest           `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsRequest) Reset() {
+	*x = ListAccountsRequest{}
+	mi := &file_lore_v1_lore_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsRequest) ProtoMessage() {}
+
+func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAccountsRequest) GetType() AccountType {
+	if x != nil {
+		return x.Type
+	}
+	return AccountType_ACCOUNT_TYPE_UNSPECIFIED
+}
+
+func (x *ListAccountsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListAccountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accounts      []*Account             `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	Page          *Page                  `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsResponse) Reset() {
+	*x = ListAccountsResponse{}
+	mi := &file_lore_v1_lore_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsResponse) ProtoMessage() {}
+
+func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListAccountsResponse) GetAccounts() []*Account {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+func (x *ListAccountsResponse) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+// Account is an account list item.
+type Account struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type            AccountType            `protobuf:"varint,3,opt,name=type,proto3,enum=lore.v1.AccountType" json:"type,omitempty"`
+	MtlapBalance    float64                `protobuf:"fixed64,4,opt,name=mtlap_balance,json=mtlapBalance,proto3" json:"mtlap_balance,omitempty"`
+	MtlacBalance    float64                `protobuf:"fixed64,5,opt,name=mtlac_balance,json=mtlacBalance,proto3" json:"mtlac_balance,omitempty"`
+	MtlaxBalance    float64                `protobuf:"fixed64,6,opt,name=mtlax_balance,json=mtlaxBalance,proto3" json:"mtlax_balance,omitempty"`
+	TotalXlmValue   float64                `protobuf:"fixed64,7,opt,name=total_xlm_value,json=totalXlmValue,proto3" json:"total_xlm_value,omitempty"`
+	ReputationScore float64                `protobuf:"fixed64,8,opt,name=reputation_score,json=reputationScore,proto3" json:"reputation_score,omitempty"`
+	ReputationGrade string                 `protobuf:"bytes,9,opt,name=reputation_grade,json=reputationGrade,proto3" json:"reputation_grade,omitempty"`
+	IsCouncilReady  bool                   `protobuf:"varint,10,opt,name=is_council_ready,json=isCouncilReady,proto3" json:"is_council_ready,omitempty"`
+	ReceivedVotes   int32                  `protobuf:"varint,11,opt,name=received_votes,json=receivedVotes,proto3" json:"received_votes,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_lore_v1_lore_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Account) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Account) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Account) GetType() AccountType {
+	if x != nil {
+		return x.Type
+	}
+	return AccountType_ACCOUNT_TYPE_UNSPECIFIED
+}
+
+func (x *Account) GetMtlapBalance() float64 {
+	if x != nil {
+		return x.MtlapBalance
+	}
+	return 0
+}
+
+func (x *Account) GetMtlacBalance() float64 {
+	if x != nil {
+		return x.MtlacBalance
+	}
+	return 0
+}
+
+func (x *Account) GetMtlaxBalance() float64 {
+	if x != nil {
+		return x.MtlaxBalance
+	}
+	return 0
+}
+
+func (x *Account) GetTotalXlmValue() float64 {
+	if x != nil {
+		return x.TotalXlmValue
+	}
+	return 0
+}
+
+func (x *Account) GetReputationScore() float64 {
+	if x != nil {
+		return x.ReputationScore
+	}
+	return 0
+}
+
+func (x *Account) GetReputationGrade() string {
+	if x != nil {
+		return x.ReputationGrade
+	}
+	return ""
+}
+
+func (x *Account) GetIsCouncilReady() bool {
+	if x != nil {
+		return x.IsCouncilReady
+	}
+	return false
+}
+
+func (x *Account) GetReceivedVotes() int32 {
+	if x != nil {
+		return x.ReceivedVotes
+	}
+	return 0
+}
+
+type GetAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountRequest) Reset() {
+	*x = GetAccountRequest{}
+	mi := &file_lore_v1_lore_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountRequest) ProtoMessage() {}
+
+func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountRequest) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetAccountRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *AccountDetail         `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountResponse) Reset() {
+	*x = GetAccountResponse{}
+	mi := &file_lore_v1_lore_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountResponse) ProtoMessage() {}
+
+func (x *GetAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountResponse) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetAccountResponse) GetAccount() *AccountDetail {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+// AccountDetail is the full account profile.
+type AccountDetail struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Id            string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	About         string                  `protobuf:"bytes,3,opt,name=about,proto3" json:"about,omitempty"`
+	Websites      []string                `protobuf:"bytes,4,rep,name=websites,proto3" json:"websites,omitempty"`
+	Tags          []string                `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Identity      *Identity               `protobuf:"bytes,6,opt,name=identity,proto3" json:"identity,omitempty"` // Unset when the account declares no identity data
+	IsCorporate   bool                    `protobuf:"varint,7,opt,name=is_corporate,json=isCorporate,proto3" json:"is_corporate,omitempty"`
+	TotalXlmValue float64                 `protobuf:"fixed64,8,opt,name=total_xlm_value,json=totalXlmValue,proto3" json:"total_xlm_value,omitempty"`
+	Trustlines    []*Trustline            `protobuf:"bytes,9,rep,name=trustlines,proto3" json:"trustlines,omitempty"`
+	LpShares      []*LPShare              `protobuf:"bytes,10,rep,name=lp_shares,json=lpShares,proto3" json:"lp_shares,omitempty"`
+	TrustRating   *TrustRating            `protobuf:"bytes,11,opt,name=trust_rating,json=trustRating,proto3" json:"trust_rating,omitempty"`
+	Reputation    *ReputationScore        `protobuf:"bytes,12,opt,name=reputation,proto3" json:"reputation,omitempty"`
+	Categories    []*RelationshipCategory `protobuf:"bytes,13,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountDetail) Reset() {
+	*x = AccountDetail{}
+	mi := &file_lore_v1_lore_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountDetail) ProtoMessage() {}
+
+func (x *AccountDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountDetail.ProtoReflect.Descriptor instead.
+func (*AccountDetail) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AccountDetail) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AccountDetail) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AccountDetail) GetAbout() string {
+	if x != nil {
+		return x.About
+	}
+	return ""
+}
+
+func (x *AccountDetail) GetWebsites() []string {
+	if x != nil {
+		return x.Websites
+	}
+	return nil
+}
+
+func (x *AccountDetail) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *AccountDetail) GetIdentity() *Identity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+func (x *AccountDetail) GetIsCorporate() bool {
+	if x != nil {
+		return x.IsCorporate
+	}
+	return false
+}
+
+func (x *AccountDetail) GetTotalXlmValue() float64 {
+	if x != nil {
+		return x.TotalXlmValue
+	}
+	return 0
+}
+
+func (x *AccountDetail) GetTrustlines() []*Trustline {
+	if x != nil {
+		return x.Trustlines
+	}
+	return nil
+}
+
+func (x *AccountDetail) GetLpShares() []*LPShare {
+	if x != nil {
+		return x.LpShares
+	}
+	return nil
+}
+
+func (x *AccountDetail) GetTrustRating() *TrustRating {
+	if x != nil {
+		return x.TrustRating
+	}
+	return nil
+}
+
+func (x *AccountDetail) GetReputation() *ReputationScore {
+	if x != nil {
+		return x.Reputation
+	}
+	return nil
+}
+
+func (x *AccountDetail) GetCategories() []*RelationshipCategory {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+// Identity holds the identity metadata declared by the account.
+type Identity struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TelegramUserId     string                 `protobuf:"bytes,1,opt,name=telegram_user_id,json=telegramUserId,proto3" json:"telegram_user_id,omitempty"`
+	TimeTokenCode      string                 `protobuf:"bytes,2,opt,name=time_token_code,json=timeTokenCode,proto3" json:"time_token_code,omitempty"`
+	TimeTokenIssuer    string                 `protobuf:"bytes,3,opt,name=time_token_issuer,json=timeTokenIssuer,proto3" json:"time_token_issuer,omitempty"`
+	TimeTokenDesc      string                 `protobuf:"bytes,4,opt,name=time_token_desc,json=timeTokenDesc,proto3" json:"time_token_desc,omitempty"`
+	TimeTokenOfferIpfs string                 `protobuf:"bytes,5,opt,name=time_token_offer_ipfs,json=timeTokenOfferIpfs,proto3" json:"time_token_offer_ipfs,omitempty"`
+	TimeTokenOfferUrl  string                 `protobuf:"bytes,6,opt,name=time_token_offer_url,json=timeTokenOfferUrl,proto3" json:"time_token_offer_url,omitempty"`
+	TelegramPartChatId string                 `protobuf:"bytes,7,opt,name=telegram_part_chat_id,json=telegramPartChatId,proto3" json:"telegram_part_chat_id,omitempty"`
+	ContractIpfs       string                 `protobuf:"bytes,8,opt,name=contract_ipfs,json=contractIpfs,proto3" json:"contract_ipfs,omitempty"`
+	ContractUrl        string                 `protobuf:"bytes,9,opt,name=contract_url,json=contractUrl,proto3" json:"contract_url,omitempty"`
+	PiiStandard        bool                   `protobuf:"varint,10,opt,name=pii_standard,json=piiStandard,proto3" json:"pii_standard,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Identity) Reset() {
+	*x = Identity{}
+	mi := &file_lore_v1_lore_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Identity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Identity) ProtoMessage() {}
+
+func (x *Identity) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Identity.ProtoReflect.Descriptor instead.
+func (*Identity) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Identity) GetTelegramUserId() string {
+	if x != nil {
+		return x.TelegramUserId
+	}
+	return ""
+}
+
+func (x *Identity) GetTimeTokenCode() string {
+	if x != nil {
+		return x.TimeTokenCode
+	}
+	return ""
+}
+
+func (x *Identity) GetTimeTokenIssuer() string {
+	if x != nil {
+		return x.TimeTokenIssuer
+	}
+	return ""
+}
+
+func (x *Identity) GetTimeTokenDesc() string {
+	if x != nil {
+		return x.TimeTokenDesc
+	}
+	return ""
+}
+
+func (x *Identity) GetTimeTokenOfferIpfs() string {
+	if x != nil {
+		return x.TimeTokenOfferIpfs
+	}
+	return ""
+}
+
+func (x *Identity) GetTimeTokenOfferUrl() string {
+	if x != nil {
+		return x.TimeTokenOfferUrl
+	}
+	return ""
+}
+
+func (x *Identity) GetTelegramPartChatId() string {
+	if x != nil {
+		return x.TelegramPartChatId
+	}
+	return ""
+}
+
+func (x *Identity) GetContractIpfs() string {
+	if x != nil {
+		return x.ContractIpfs
+	}
+	return ""
+}
+
+func (x *Identity) GetContractUrl() string {
+	if x != nil {
+		return x.ContractUrl
+	}
+	return ""
+}
+
+func (x *Identity) GetPiiStandard() bool {
+	if x != nil {
+		return x.PiiStandard
+	}
+	return false
+}
+
+type Trustline struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetCode     string                 `protobuf:"bytes,1,opt,name=asset_code,json=assetCode,proto3" json:"asset_code,omitempty"`
+	AssetIssuer   string                 `protobuf:"bytes,2,opt,name=asset_issuer,json=assetIssuer,proto3" json:"asset_issuer,omitempty"`
+	Balance       string                 `protobuf:"bytes,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Trustline) Reset() {
+	*x = Trustline{}
+	mi := &file_lore_v1_lore_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trustline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trustline) ProtoMessage() {}
+
+func (x *Trustline) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trustline.ProtoReflect.Descriptor instead.
+func (*Trustline) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Trustline) GetAssetCode() string {
+	if x != nil {
+		return x.AssetCode
+	}
+	return ""
+}
+
+func (x *Trustline) GetAssetIssuer() string {
+	if x != nil {
+		return x.AssetIssuer
+	}
+	return ""
+}
+
+func (x *Trustline) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+// LPShare is the account's share of a liquidity pool.
+type LPShare struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PoolId        string                 `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	ShareBalance  string                 `protobuf:"bytes,2,opt,name=share_balance,json=shareBalance,proto3" json:"share_balance,omitempty"`
+	SharePercent  string                 `protobuf:"bytes,3,opt,name=share_percent,json=sharePercent,proto3" json:"share_percent,omitempty"`
+	ReserveA      *Reserve               `protobuf:"bytes,4,opt,name=reserve_a,json=reserveA,proto3" json:"reserve_a,omitempty"`
+	ReserveB      *Reserve               `protobuf:"bytes,5,opt,name=reserve_b,json=reserveB,proto3" json:"reserve_b,omitempty"`
+	XlmValue      float64                `protobuf:"fixed64,6,opt,name=xlm_value,json=xlmValue,proto3" json:"xlm_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LPShare) Reset() {
+	*x = LPShare{}
+	mi := &file_lore_v1_lore_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LPShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LPShare) ProtoMessage() {}
+
+func (x *LPShare) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LPShare.ProtoReflect.Descriptor instead.
+func (*LPShare) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LPShare) GetPoolId() string {
+	if x != nil {
+		return x.PoolId
+	}
+	return ""
+}
+
+func (x *LPShare) GetShareBalance() string {
+	if x != nil {
+		return x.ShareBalance
+	}
+	return ""
+}
+
+func (x *LPShare) GetSharePercent() string {
+	if x != nil {
+		return x.SharePercent
+	}
+	return ""
+}
+
+func (x *LPShare) GetReserveA() *Reserve {
+	if x != nil {
+		return x.ReserveA
+	}
+	return nil
+}
+
+func (x *LPShare) GetReserveB() *Reserve {
+	if x != nil {
+		return x.ReserveB
+	}
+	return nil
+}
+
+func (x *LPShare) GetXlmValue() float64 {
+	if x != nil {
+		return x.XlmValue
+	}
+	return 0
+}
+
+// Reserve is the account's part of one pool reserve.
+type Reserve struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetCode     string                 `protobuf:"bytes,1,opt,name=asset_code,json=assetCode,proto3" json:"asset_code,omitempty"`
+	AssetIssuer   string                 `protobuf:"bytes,2,opt,name=asset_issuer,json=assetIssuer,proto3" json:"asset_issuer,omitempty"`
+	Amount        string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reserve) Reset() {
+	*x = Reserve{}
+	mi := &file_lore_v1_lore_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reserve) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reserve) ProtoMessage() {}
+
+func (x *Reserve) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reserve.ProtoReflect.Descriptor instead.
+func (*Reserve) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Reserve) GetAssetCode() string {
+	if x != nil {
+		return x.AssetCode
+	}
+	return ""
+}
+
+func (x *Reserve) GetAssetIssuer() string {
+	if x != nil {
+		return x.AssetIssuer
+	}
+	return ""
+}
+
+func (x *Reserve) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+// TrustRating counts the A-D ratings the account received.
+type TrustRating struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CountA        int32                  `protobuf:"varint,1,opt,name=count_a,json=countA,proto3" json:"count_a,omitempty"`
+	CountB        int32                  `protobuf:"varint,2,opt,name=count_b,json=countB,proto3" json:"count_b,omitempty"`
+	CountC        int32                  `protobuf:"varint,3,opt,name=count_c,json=countC,proto3" json:"count_c,omitempty"`
+	CountD        int32                  `protobuf:"varint,4,opt,name=count_d,json=countD,proto3" json:"count_d,omitempty"`
+	Total         int32                  `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	Score         float64                `protobuf:"fixed64,6,opt,name=score,proto3" json:"score,omitempty"`
+	Grade         string                 `protobuf:"bytes,7,opt,name=grade,proto3" json:"grade,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrustRating) Reset() {
+	*x = TrustRating{}
+	mi := &file_lore_v1_lore_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrustRating) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrustRating) ProtoMessage() {}
+
+func (x *TrustRating) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrustRating.ProtoReflect.Descriptor instead.
+func (*TrustRating) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TrustRating) GetCountA() int32 {
+	if x != nil {
+		return x.CountA
+	}
+	return 0
+}
+
+func (x *TrustRating) GetCountB() int32 {
+	if x != nil {
+		return x.CountB
+	}
+	return 0
+}
+
+func (x *TrustRating) GetCountC() int32 {
+	if x != nil {
+		return x.CountC
+	}
+	return 0
+}
+
+func (x *TrustRating) GetCountD() int32 {
+	if x != nil {
+		return x.CountD
+	}
+	return 0
+}
+
+func (x *TrustRating) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *TrustRating) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *TrustRating) GetGrade() string {
+	if x != nil {
+		return x.Grade
+	}
+	return ""
+}
+
+// ReputationScore is the weighted reputation of an account.
+type ReputationScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WeightedScore float64                `protobuf:"fixed64,1,opt,name=weighted_score,json=weightedScore,proto3" json:"weighted_score,omitempty"`
+	BaseScore     float64                `protobuf:"fixed64,2,opt,name=base_score,json=baseScore,proto3" json:"base_score,omitempty"`
+	Grade         string                 `protobuf:"bytes,3,opt,name=grade,proto3" json:"grade,omitempty"`
+	RatingCountA  int32                  `protobuf:"varint,4,opt,name=rating_count_a,json=ratingCountA,proto3" json:"rating_count_a,omitempty"`
+	RatingCountB  int32                  `protobuf:"varint,5,opt,name=rating_count_b,json=ratingCountB,proto3" json:"rating_count_b,omitempty"`
+	RatingCountC  int32                  `protobuf:"varint,6,opt,name=rating_count_c,json=ratingCountC,proto3" json:"rating_count_c,omitempty"`
+	RatingCountD  int32                  `protobuf:"varint,7,opt,name=rating_count_d,json=ratingCountD,proto3" json:"rating_count_d,omitempty"`
+	TotalRatings  int32                  `protobuf:"varint,8,opt,name=total_ratings,json=totalRatings,proto3" json:"total_ratings,omitempty"`
+	TotalWeight   float64                `protobuf:"fixed64,9,opt,name=total_weight,json=totalWeight,proto3" json:"total_weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReputationScore) Reset() {
+	*x = ReputationScore{}
+	mi := &file_lore_v1_lore_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReputationScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReputationScore) ProtoMessage() {}
+
+func (x *ReputationScore) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReputationScore.ProtoReflect.Descriptor instead.
+func (*ReputationScore) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReputationScore) GetWeightedScore() float64 {
+	if x != nil {
+		return x.WeightedScore
+	}
+	return 0
+}
+
+func (x *ReputationScore) GetBaseScore() float64 {
+	if x != nil {
+		return x.BaseScore
+	}
+	return 0
+}
+
+func (x *ReputationScore) GetGrade() string {
+	if x != nil {
+		return x.Grade
+	}
+	return ""
+}
+
+func (x *ReputationScore) GetRatingCountA() int32 {
+	if x != nil {
+		return x.RatingCountA
+	}
+	return 0
+}
+
+func (x *ReputationScore) GetRatingCountB() int32 {
+	if x != nil {
+		return x.RatingCountB
+	}
+	return 0
+}
+
+func (x *ReputationScore) GetRatingCountC() int32 {
+	if x != nil {
+		return x.RatingCountC
+	}
+	return 0
+}
+
+func (x *ReputationScore) GetRatingCountD() int32 {
+	if x != nil {
+		return x.RatingCountD
+	}
+	return 0
+}
+
+func (x *ReputationScore) GetTotalRatings() int32 {
+	if x != nil {
+		return x.TotalRatings
+	}
+	return 0
+}
+
+func (x *ReputationScore) GetTotalWeight() float64 {
+	if x != nil {
+		return x.TotalWeight
+	}
+	return 0
+}
+
+type RelationshipCategory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Color         string                 `protobuf:"bytes,2,opt,name=color,proto3" json:"color,omitempty"`
+	Relationships []*Relationship        `protobuf:"bytes,3,rep,name=relationships,proto3" json:"relationships,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelationshipCategory) Reset() {
+	*x = RelationshipCategory{}
+	mi := &file_lore_v1_lore_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationshipCategory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationshipCategory) ProtoMessage() {}
+
+func (x *RelationshipCategory) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationshipCategory.ProtoReflect.Descriptor instead.
+func (*RelationshipCategory) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RelationshipCategory) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RelationshipCategory) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *RelationshipCategory) GetRelationships() []*Relationship {
+	if x != nil {
+		return x.Relationships
+	}
+	return nil
+}
+
+type Relationship struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	TypeName      string                 `protobuf:"bytes,2,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	TargetId      string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	TargetName    string                 `protobuf:"bytes,4,opt,name=target_name,json=targetName,proto3" json:"target_name,omitempty"`
+	Direction     string                 `protobuf:"bytes,5,opt,name=direction,proto3" json:"direction,omitempty"`
+	IsMutual      bool                   `protobuf:"varint,6,opt,name=is_mutual,json=isMutual,proto3" json:"is_mutual,omitempty"`
+	IsConfirmed   bool                   `protobuf:"varint,7,opt,name=is_confirmed,json=isConfirmed,proto3" json:"is_confirmed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Relationship) Reset() {
+	*x = Relationship{}
+	mi := &file_lore_v1_lore_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Relationship) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Relationship) ProtoMessage() {}
+
+func (x *Relationship) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Relationship.ProtoReflect.Descriptor instead.
+func (*Relationship) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Relationship) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Relationship) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *Relationship) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *Relationship) GetTargetName() string {
+	if x != nil {
+		return x.TargetName
+	}
+	return ""
+}
+
+func (x *Relationship) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *Relationship) GetIsMutual() bool {
+	if x != nil {
+		return x.IsMutual
+	}
+	return false
+}
+
+func (x *Relationship) GetIsConfirmed() bool {
+	if x != nil {
+		return x.IsConfirmed
+	}
+	return false
+}
+
+type GetRelationshipsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`            // Only relationships of this type, e.g. Spouse or Employer
+	Confirmed     bool                   `protobuf:"varint,3,opt,name=confirmed,proto3" json:"confirmed,omitempty"` // Only confirmed relationships
+	Mutual        bool                   `protobuf:"varint,4,opt,name=mutual,proto3" json:"mutual,omitempty"`       // Only mutual relationships
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelationshipsRequest) Reset() {
+	*x = GetRelationshipsRequest{}
+	mi := &file_lore_v1_lore_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelationshipsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelationshipsRequest) ProtoMessage() {}
+
+func (x *GetRelationshipsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelationshipsRequest.ProtoReflect.Descriptor instead.
+func (*GetRelationshipsRequest) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetRelationshipsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetRelationshipsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GetRelationshipsRequest) GetConfirmed() bool {
+	if x != nil {
+		return x.Confirmed
+	}
+	return false
+}
+
+func (x *GetRelationshipsRequest) GetMutual() bool {
+	if x != nil {
+		return x.Mutual
+	}
+	return false
+}
+
+type GetRelationshipsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Categories    []*RelationshipCategory `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelationshipsResponse) Reset() {
+	*x = GetRelationshipsResponse{}
+	mi := &file_lore_v1_lore_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelationshipsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelationshipsResponse) ProtoMessage() {}
+
+func (x *GetRelationshipsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelationshipsResponse.ProtoReflect.Descriptor instead.
+func (*GetRelationshipsResponse) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetRelationshipsResponse) GetCategories() []*RelationshipCategory {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type GetReputationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReputationRequest) Reset() {
+	*x = GetReputationRequest{}
+	mi := &file_lore_v1_lore_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReputationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReputationRequest) ProtoMessage() {}
+
+func (x *GetReputationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReputationRequest.ProtoReflect.Descriptor instead.
+func (*GetReputationRequest) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetReputationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetReputationResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TargetAccountId string                 `protobuf:"bytes,1,opt,name=target_account_id,json=targetAccountId,proto3" json:"target_account_id,omitempty"`
+	TargetName      string                 `protobuf:"bytes,2,opt,name=target_name,json=targetName,proto3" json:"target_name,omitempty"`
+	Score           *ReputationScore       `protobuf:"bytes,3,opt,name=score,proto3" json:"score,omitempty"`
+	Level1Nodes     []*ReputationNode      `protobuf:"bytes,4,rep,name=level1_nodes,json=level1Nodes,proto3" json:"level1_nodes,omitempty"` // Direct raters
+	Level2Nodes     []*ReputationNode      `protobuf:"bytes,5,rep,name=level2_nodes,json=level2Nodes,proto3" json:"level2_nodes,omitempty"` // Raters of the direct raters
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetReputationResponse) Reset() {
+	*x = GetReputationResponse{}
+	mi := &file_lore_v1_lore_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReputationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReputationResponse) ProtoMessage() {}
+
+func (x *GetReputationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReputationResponse.ProtoReflect.Descriptor instead.
+func (*GetReputationResponse) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetReputationResponse) GetTargetAccountId() string {
+	if x != nil {
+		return x.TargetAccountId
+	}
+	return ""
+}
+
+func (x *GetReputationResponse) GetTargetName() string {
+	if x != nil {
+		return x.TargetName
+	}
+	return ""
+}
+
+func (x *GetReputationResponse) GetScore() *ReputationScore {
+	if x != nil {
+		return x.Score
+	}
+	return nil
+}
+
+func (x *GetReputationResponse) GetLevel1Nodes() []*ReputationNode {
+	if x != nil {
+		return x.Level1Nodes
+	}
+	return nil
+}
+
+func (x *GetReputationResponse) GetLevel2Nodes() []*ReputationNode {
+	if x != nil {
+		return x.Level2Nodes
+	}
+	return nil
+}
+
+type ReputationNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Rating        string                 `protobuf:"bytes,3,opt,name=rating,proto3" json:"rating,omitempty"`
+	Weight        float64                `protobuf:"fixed64,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	PortfolioXlm  float64                `protobuf:"fixed64,5,opt,name=portfolio_xlm,json=portfolioXlm,proto3" json:"portfolio_xlm,omitempty"`
+	Connections   int32                  `protobuf:"varint,6,opt,name=connections,proto3" json:"connections,omitempty"`
+	OwnScore      float64                `protobuf:"fixed64,7,opt,name=own_score,json=ownScore,proto3" json:"own_score,omitempty"`
+	Distance      int32                  `protobuf:"varint,8,opt,name=distance,proto3" json:"distance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReputationNode) Reset() {
+	*x = ReputationNode{}
+	mi := &file_lore_v1_lore_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReputationNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReputationNode) ProtoMessage() {}
+
+func (x *ReputationNode) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReputationNode.ProtoReflect.Descriptor instead.
+func (*ReputationNode) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReputationNode) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ReputationNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReputationNode) GetRating() string {
+	if x != nil {
+		return x.Rating
+	}
+	return ""
+}
+
+func (x *ReputationNode) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *ReputationNode) GetPortfolioXlm() float64 {
+	if x != nil {
+		return x.PortfolioXlm
+	}
+	return 0
+}
+
+func (x *ReputationNode) GetConnections() int32 {
+	if x != nil {
+		return x.Connections
+	}
+	return 0
+}
+
+func (x *ReputationNode) GetOwnScore() float64 {
+	if x != nil {
+		return x.OwnScore
+	}
+	return 0
+}
+
+func (x *ReputationNode) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type SearchAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"` // At least 2 and at most 100 characters; shorter queries are ignored
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Sort          SearchSort             `protobuf:"varint,3,opt,name=sort,proto3,enum=lore.v1.SearchSort" json:"sort,omitempty"`
+	Page          *PageRequest           `protobuf:"bytes,4,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchAccountsRequest) Reset() {
+	*x = SearchAccountsRequest{}
+	mi := &file_lore_v1_lore_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAccountsRequest) ProtoMessage() {}
+
+func (x *SearchAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAccountsRequest.ProtoReflect.Descriptor instead.
+func (*SearchAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SearchAccountsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchAccountsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SearchAccountsRequest) GetSort() SearchSort {
+	if x != nil {
+		return x.Sort
+	}
+	return SearchSort_SEARCH_SORT_UNSPECIFIED
+}
+
+func (x *SearchAccountsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type SearchAccountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accounts      []*Account             `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	Page          *Page                  `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchAccountsResponse) Reset() {
+	*x = SearchAccountsResponse{}
+	mi := &file_lore_v1_lore_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAccountsResponse) ProtoMessage() {}
+
+func (x *SearchAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lore_v1_lore_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAccountsResponse.ProtoReflect.Descriptor instead.
+func (*SearchAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_lore_v1_lore_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SearchAccountsResponse) GetAccounts() []*Account {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+func (x *SearchAccountsResponse) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+var File_lore_v1_lore_proto protoreflect.FileDescriptor
+
+const file_lore_v1_lore_proto_rawDesc = "" +
+	"\n" +
+	"\x12lore/v1/lore.proto\x12\alore.v1\"c\n" +
+	"\vPageRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x1b\n" +
+	"\x06cursor\x18\x03 \x01(\tH\x00R\x06cursor\x88\x01\x01B\t\n" +
+	"\a_cursor\"\x9b\x01\n" +
+	"\x04Page\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x19\n" +
+	"\x05total\x18\x03 \x01(\x05H\x00R\x05total\x88\x01\x01\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\x05 \x01(\tR\n" +
+	"prevCursorB\b\n" +
+	"\x06_total\"\x11\n" +
+	"\x0fGetStatsRequest\"\x8b\x02\n" +
+	"\x10GetStatsResponse\x12%\n" +
+	"\x0etotal_accounts\x18\x01 \x01(\x05R\rtotalAccounts\x12#\n" +
+	"\rtotal_persons\x18\x02 \x01(\x05R\ftotalPersons\x12'\n" +
+	"\x0ftotal_companies\x18\x03 \x01(\x05R\x0etotalCompanies\x12'\n" +
+	"\x0ftotal_synthetic\x18\x04 \x01(\x05R\x0etotalSynthetic\x12&\n" +
+	"\x0ftotal_xlm_value\x18\x05 \x01(\x01R\rtotalXlmValue\x121\n" +
+	"\x06growth\x18\x06 \x03(\v2\x19.lore.v1.MembershipGrowthR\x06growth\"\x85\x01\n" +
+	"\x10MembershipGrowth\x12\x14\n" +
+	"\x05month\x18\x01 \x01(\tR\x05month\x12\x1d\n" +
+	"\n" +
+	"asset_code\x18\x02 \x01(\tR\tassetCode\x12\x16\n" +
+	"\x06joined\x18\x03 \x01(\x05R\x06joined\x12\x12\n" +
+	"\x04left\x18\x04 \x01(\x05R\x04left\x12\x10\n" +
+	"\x03net\x18\x05 \x01(\x05R\x03net\"i\n" +
+	"\x13ListAccountsRequest\x12(\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x14.lore.v1.AccountTypeR\x04type\x12(\n" +
+	"\x04page\x18\x02 \x01(\v2\x14.lore.v1.PageRequestR\x04page\"g\n" +
+	"\x14ListAccountsResponse\x12,\n" +
+	"\baccounts\x18\x01 \x03(\v2\x10.lore.v1.AccountR\baccounts\x12!\n" +
+	"\x04page\x18\x02 \x01(\v2\r.lore.v1.PageR\x04page\"\x95\x03\n" +
+	"\aAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12(\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x14.lore.v1.AccountTypeR\x04type\x12#\n" +
+	"\rmtlap_balance\x18\x04 \x01(\x01R\fmtlapBalance\x12#\n" +
+	"\rmtlac_balance\x18\x05 \x01(\x01R\fmtlacBalance\x12#\n" +
+	"\rmtlax_balance\x18\x06 \x01(\x01R\fmtlaxBalance\x12&\n" +
+	"\x0ftotal_xlm_value\x18\a \x01(\x01R\rtotalXlmValue\x12)\n" +
+	"\x10reputation_score\x18\b \x01(\x01R\x0freputationScore\x12)\n" +
+	"\x10reputation_grade\x18\t \x01(\tR\x0freputationGrade\x12(\n" +
+	"\x10is_council_ready\x18\n" +
+	" \x01(\bR\x0eisCouncilReady\x12%\n" +
+	"\x0ereceived_votes\x18\v \x01(\x05R\rreceivedVotes\"#\n" +
+	"\x11GetAccountRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"F\n" +
+	"\x12GetAccountResponse\x120\n" +
+	"\aaccount\x18\x01 \x01(\v2\x16.lore.v1.AccountDetailR\aaccount\"\x88\x04\n" +
+	"\rAccountDetail\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05about\x18\x03 \x01(\tR\x05about\x12\x1a\n" +
+	"\bwebsites\x18\x04 \x03(\tR\bwebsites\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12-\n" +
+	"\bidentity\x18\x06 \x01(\v2\x11.lore.v1.IdentityR\bidentity\x12!\n" +
+	"\fis_corporate\x18\a \x01(\bR\visCorporate\x12&\n" +
+	"\x0ftotal_xlm_value\x18\b \x01(\x01R\rtotalXlmValue\x122\n" +
+	"\n" +
+	"trustlines\x18\t \x03(\v2\x12.lore.v1.TrustlineR\n" +
+	"trustlines\x12-\n" +
+	"\tlp_shares\x18\n" +
+	" \x03(\v2\x10.lore.v1.LPShareR\blpShares\x127\n" +
+	"\ftrust_rating\x18\v \x01(\v2\x14.lore.v1.TrustRatingR\vtrustRating\x128\n" +
+	"\n" +
+	"reputation\x18\f \x01(\v2\x18.lore.v1.ReputationScoreR\n" +
+	"reputation\x12=\n" +
+	"\n" +
+	"categories\x18\r \x03(\v2\x1d.lore.v1.RelationshipCategoryR\n" +
+	"categories\"\xb2\x03\n" +
+	"\bIdentity\x12(\n" +
+	"\x10telegram_user_id\x18\x01 \x01(\tR\x0etelegramUserId\x12&\n" +
+	"\x0ftime_token_code\x18\x02 \x01(\tR\rtimeTokenCode\x12*\n" +
+	"\x11time_token_issuer\x18\x03 \x01(\tR\x0ftimeTokenIssuer\x12&\n" +
+	"\x0ftime_token_desc\x18\x04 \x01(\tR\rtimeTokenDesc\x121\n" +
+	"\x15time_token_offer_ipfs\x18\x05 \x01(\tR\x12timeTokenOfferIpfs\x12/\n" +
+	"\x14time_token_offer_url\x18\x06 \x01(\tR\x11timeTokenOfferUrl\x121\n" +
+	"\x15telegram_part_chat_id\x18\a \x01(\tR\x12telegramPartChatId\x12#\n" +
+	"\rcontract_ipfs\x18\b \x01(\tR\fcontractIpfs\x12!\n" +
+	"\fcontract_url\x18\t \x01(\tR\vcontractUrl\x12!\n" +
+	"\fpii_standard\x18\n" +
+	" \x01(\bR\vpiiStandard\"g\n" +
+	"\tTrustline\x12\x1d\n" +
+	"\n" +
+	"asset_code\x18\x01 \x01(\tR\tassetCode\x12!\n" +
+	"\fasset_issuer\x18\x02 \x01(\tR\vassetIssuer\x12\x18\n" +
+	"\abalance\x18\x03 \x01(\tR\abalance\"\xe7\x01\n" +
+	"\aLPShare\x12\x17\n" +
+	"\apool_id\x18\x01 \x01(\tR\x06poolId\x12#\n" +
+	"\rshare_balance\x18\x02 \x01(\tR\fshareBalance\x12#\n" +
+	"\rshare_percent\x18\x03 \x01(\tR\fsharePercent\x12-\n" +
+	"\treserve_a\x18\x04 \x01(\v2\x10.lore.v1.ReserveR\breserveA\x12-\n" +
+	"\treserve_b\x18\x05 \x01(\v2\x10.lore.v1.ReserveR\breserveB\x12\x1b\n" +
+	"\txlm_value\x18\x06 \x01(\x01R\bxlmValue\"c\n" +
+	"\aReserve\x12\x1d\n" +
+	"\n" +
+	"asset_code\x18\x01 \x01(\tR\tassetCode\x12!\n" +
+	"\fasset_issuer\x18\x02 \x01(\tR\vassetIssuer\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\"\xb3\x01\n" +
+	"\vTrustRating\x12\x17\n" +
+	"\acount_a\x18\x01 \x01(\x05R\x06countA\x12\x17\n" +
+	"\acount_b\x18\x02 \x01(\x05R\x06countB\x12\x17\n" +
+	"\acount_c\x18\x03 \x01(\x05R\x06countC\x12\x17\n" +
+	"\acount_d\x18\x04 \x01(\x05R\x06countD\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\x01R\x05score\x12\x14\n" +
+	"\x05grade\x18\a \x01(\tR\x05grade\"\xcd\x02\n" +
+	"\x0fReputationScore\x12%\n" +
+	"\x0eweighted_score\x18\x01 \x01(\x01R\rweightedScore\x12\x1d\n" +
+	"\n" +
+	"base_score\x18\x02 \x01(\x01R\tbaseScore\x12\x14\n" +
+	"\x05grade\x18\x03 \x01(\tR\x05grade\x12$\n" +
+	"\x0erating_count_a\x18\x04 \x01(\x05R\fratingCountA\x12$\n" +
+	"\x0erating_count_b\x18\x05 \x01(\x05R\fratingCountB\x12$\n" +
+	"\x0erating_count_c\x18\x06 \x01(\x05R\fratingCountC\x12$\n" +
+	"\x0erating_count_d\x18\a \x01(\x05R\fratingCountD\x12#\n" +
+	"\rtotal_ratings\x18\b \x01(\x05R\ftotalRatings\x12!\n" +
+	"\ftotal_weight\x18\t \x01(\x01R\vtotalWeight\"}\n" +
+	"\x14RelationshipCategory\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x02 \x01(\tR\x05color\x12;\n" +
+	"\rrelationships\x18\x03 \x03(\v2\x15.lore.v1.RelationshipR\rrelationships\"\xdb\x01\n" +
+	"\fRelationship\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1b\n" +
+	"\ttype_name\x18\x02 \x01(\tR\btypeName\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\tR\btargetId\x12\x1f\n" +
+	"\vtarget_name\x18\x04 \x01(\tR\n" +
+	"targetName\x12\x1c\n" +
+	"\tdirection\x18\x05 \x01(\tR\tdirection\x12\x1b\n" +
+	"\tis_mutual\x18\x06 \x01(\bR\bisMutual\x12!\n" +
+	"\fis_confirmed\x18\a \x01(\bR\visConfirmed\"s\n" +
+	"\x17GetRelationshipsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1c\n" +
+	"\tconfirmed\x18\x03 \x01(\bR\tconfirmed\x12\x16\n" +
+	"\x06mutual\x18\x04 \x01(\bR\x06mutual\"Y\n" +
+	"\x18GetRelationshipsResponse\x12=\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x1d.lore.v1.RelationshipCategoryR\n" +
+	"categories\"&\n" +
+	"\x14GetReputationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x8c\x02\n" +
+	"\x15GetReputationResponse\x12*\n" +
+	"\x11target_account_id\x18\x01 \x01(\tR\x0ftargetAccountId\x12\x1f\n" +
+	"\vtarget_name\x18\x02 \x01(\tR\n" +
+	"targetName\x12.\n" +
+	"\x05score\x18\x03 \x01(\v2\x18.lore.v1.ReputationScoreR\x05score\x12:\n" +
+	"\flevel1_nodes\x18\x04 \x03(\v2\x17.lore.v1.ReputationNodeR\vlevel1Nodes\x12:\n" +
+	"\flevel2_nodes\x18\x05 \x03(\v2\x17.lore.v1.ReputationNodeR\vlevel2Nodes\"\xf3\x01\n" +
+	"\x0eReputationNode\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06rating\x18\x03 \x01(\tR\x06rating\x12\x16\n" +
+	"\x06weight\x18\x04 \x01(\x01R\x06weight\x12#\n" +
+	"\rportfolio_xlm\x18\x05 \x01(\x01R\fportfolioXlm\x12 \n" +
+	"\vconnections\x18\x06 \x01(\x05R\vconnections\x12\x1b\n" +
+	"\town_score\x18\a \x01(\x01R\bownScore\x12\x1a\n" +
+	"\bdistance\x18\b \x01(\x05R\bdistance\"\x94\x01\n" +
+	"\x15SearchAccountsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12'\n" +
+	"\x04sort\x18\x03 \x01(\x0e2\x13.lore.v1.SearchSortR\x04sort\x12(\n" +
+	"\x04page\x18\x04 \x01(\v2\x14.lore.v1.PageRequestR\x04page\"i\n" +
+	"\x16SearchAccountsResponse\x12,\n" +
+	"\baccounts\x18\x01 \x03(\v2\x10.lore.v1.AccountR\baccounts\x12!\n" +
+	"\x04page\x18\x02 \x01(\v2\r.lore.v1.PageR\x04page*|\n" +
+	"\vAccountType\x12\x1c\n" +
+	"\x18ACCOUNT_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13ACCOUNT_TYPE_PERSON\x10\x01\x12\x1a\n" +
+	"\x16ACCOUNT_TYPE_CORPORATE\x10\x02\x12\x1a\n" +
+	"\x16ACCOUNT_TYPE_SYNTHETIC\x10\x03*^\n" +
+	"\n" +
+	"SearchSort\x12\x1b\n" +
+	"\x17SEARCH_SORT_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13SEARCH_SORT_BALANCE\x10\x01\x12\x1a\n" +
+	"\x16SEARCH_SORT_REPUTATION\x10\x022\xde\x03\n" +
+	"\vLoreService\x12?\n" +
+	"\bGetStats\x12\x18.lore.v1.GetStatsRequest\x1a\x19.lore.v1.GetStatsResponse\x12K\n" +
+	"\fListAccounts\x12\x1c.lore.v1.ListAccountsRequest\x1a\x1d.lore.v1.ListAccountsResponse\x12E\n" +
+	"\n" +
+	"GetAccount\x12\x1a.lore.v1.GetAccountRequest\x1a\x1b.lore.v1.GetAccountResponse\x12W\n" +
+	"\x10GetRelationships\x12 .lore.v1.GetRelationshipsRequest\x1a!.lore.v1.GetRelationshipsResponse\x12N\n" +
+	"\rGetReputation\x12\x1d.lore.v1.GetReputationRequest\x1a\x1e.lore.v1.GetReputationResponse\x12Q\n" +
+	"\x0eSearchAccounts\x12\x1e.lore.v1.SearchAccountsRequest\x1a\x1f.lore.v1.SearchAccountsResponseB+Z)github.com/mtlprog/lore/pkg/lorev1;lorev1b\x06proto3"
+
+var (
+	file_lore_v1_lore_proto_rawDescOnce sync.Once
+	file_lore_v1_lore_proto_rawDescData []byte
+)
+
+func file_lore_v1_lore_proto_rawDescGZIP() []byte {
+	file_lore_v1_lore_proto_rawDescOnce.Do(func() {
+		file_lore_v1_lore_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_lore_v1_lore_proto_rawDesc), len(file_lore_v1_lore_proto_rawDesc)))
+	})
+	return file_lore_v1_lore_proto_rawDescData
+}
+
+var file_lore_v1_lore_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_lore_v1_lore_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_lore_v1_lore_proto_goTypes = []any{
+	(AccountType)(0),                 // 0: lore.v1.AccountType
+	(SearchSort)(0),                  // 1: lore.v1.SearchSort
+	(*PageRequest)(nil),              // 2: lore.v1.PageRequest
+	(*Page)(nil),                     // 3: lore.v1.Page
+	(*GetStatsRequest)(nil),          // 4: lore.v1.GetStatsRequest
+	(*GetStatsResponse)(nil),         // 5: lore.v1.GetStatsResponse
+	(*MembershipGrowth)(nil),         // 6: lore.v1.MembershipGrowth
+	(*ListAccountsRequest)(nil),      // 7: lore.v1.ListAccountsRequest
+	(*ListAccountsResponse)(nil),     // 8: lore.v1.ListAccountsResponse
+	(*Account)(nil),                  // 9: lore.v1.Account
+	(*GetAccountRequest)(nil),        // 10: lore.v1.GetAccountRequest
+	(*GetAccountResponse)(nil),       // 11: lore.v1.GetAccountResponse
+	(*AccountDetail)(nil),            // 12: lore.v1.AccountDetail
+	(*Identity)(nil),                 // 13: lore.v1.Identity
+	(*Trustline)(nil),                // 14: lore.v1.Trustline
+	(*LPShare)(nil),                  // 15: lore.v1.LPShare
+	(*Reserve)(nil),                  // 16: lore.v1.Reserve
+	(*TrustRating)(nil),              // 17: lore.v1.TrustRating
+	(*ReputationScore)(nil),          // 18: lore.v1.ReputationScore
+	(*RelationshipCategory)(nil),     // 19: lore.v1.RelationshipCategory
+	(*Relationship)(nil),             // 20: lore.v1.Relationship
+	(*GetRelationshipsRequest)(nil),  // 21: lore.v1.GetRelationshipsRequest
+	(*GetRelationshipsResponse)(nil), // 22: lore.v1.GetRelationshipsResponse
+	(*GetReputationRequest)(nil),     // 23: lore.v1.GetReputationRequest
+	(*GetReputationResponse)(nil),    // 24: lore.v1.GetReputationResponse
+	(*ReputationNode)(nil),           // 25: lore.v1.ReputationNode
+	(*SearchAccountsRequest)(nil),    // 26: lore.v1.SearchAccountsRequest
+	(*SearchAccountsResponse)(nil),   // 27: lore.v1.SearchAccountsResponse
+}
+var file_lore_v1_lore_proto_depIdxs = []int32{
+	6,  // 0: lore.v1.GetStatsResponse.growth:type_name -> lore.v1.MembershipGrowth
+	0,  // 1: lore.v1.ListAccountsRequest.type:type_name -> lore.v1.AccountType
+	2,  // 2: lore.v1.ListAccountsRequest.page:type_name -> lore.v1.PageRequest
+	9,  // 3: lore.v1.ListAccountsResponse.accounts:type_name -> lore.v1.Account
+	3,  // 4: lore.v1.ListAccountsResponse.page:type_name -> lore.v1.Page
+	0,  // 5: lore.v1.Account.type:type_name -> lore.v1.AccountType
+	12, // 6: lore.v1.GetAccountResponse.account:type_name -> lore.v1.AccountDetail
+	13, // 7: lore.v1.AccountDetail.identity:type_name -> lore.v1.Identity
+	14, // 8: lore.v1.AccountDetail.trustlines:type_name -> lore.v1.Trustline
+	15, // 9: lore.v1.AccountDetail.lp_shares:type_name -> lore.v1.LPShare
+	17, // 10: lore.v1.AccountDetail.trust_rating:type_name -> lore.v1.TrustRating
+	18, // 11: lore.v1.AccountDetail.reputation:type_name -> lore.v1.ReputationScore
+	19, // 12: lore.v1.AccountDetail.categories:type_name -> lore.v1.RelationshipCategory
+	16, // 13: lore.v1.LPShare.reserve_a:type_name -> lore.v1.Reserve
+	16, // 14: lore.v1.LPShare.reserve_b:type_name -> lore.v1.Reserve
+	20, // 15: lore.v1.RelationshipCategory.relationships:type_name -> lore.v1.Relationship
+	19, // 16: lore.v1.GetRelationshipsResponse.categories:type_name -> lore.v1.RelationshipCategory
+	18, // 17: lore.v1.GetReputationResponse.score:type_name -> lore.v1.ReputationScore
+	25, // 18: lore.v1.GetReputationResponse.level1_nodes:type_name -> lore.v1.ReputationNode
+	25, // 19: lore.v1.GetReputationResponse.level2_nodes:type_name -> lore.v1.ReputationNode
+	1,  // 20: lore.v1.SearchAccountsRequest.sort:type_name -> lore.v1.SearchSort
+	2,  // 21: lore.v1.SearchAccountsRequest.page:type_name -> lore.v1.PageRequest
+	9,  // 22: lore.v1.SearchAccountsResponse.accounts:type_name -> lore.v1.Account
+	3,  // 23: lore.v1.SearchAccountsResponse.page:type_name -> lore.v1.Page
+	4,  // 24: lore.v1.LoreService.GetStats:input_type -> lore.v1.GetStatsRequest
+	7,  // 25: lore.v1.LoreService.ListAccounts:input_type -> lore.v1.ListAccountsRequest
+	10, // 26: lore.v1.LoreService.GetAccount:input_type -> lore.v1.GetAccountRequest
+	21, // 27: lore.v1.LoreService.GetRelationships:input_type -> lore.v1.GetRelationshipsRequest
+	23, // 28: lore.v1.LoreService.GetReputation:input_type -> lore.v1.GetReputationRequest
+	26, // 29: lore.v1.LoreService.SearchAccounts:input_type -> lore.v1.SearchAccountsRequest
+	5,  // 30: lore.v1.LoreService.GetStats:output_type -> lore.v1.GetStatsResponse
+	8,  // 31: lore.v1.LoreService.ListAccounts:output_type -> lore.v1.ListAccountsResponse
+	11, // 32: lore.v1.LoreService.GetAccount:output_type -> lore.v1.GetAccountResponse
+	22, // 33: lore.v1.LoreService.GetRelationships:output_type -> lore.v1.GetRelationshipsResponse
+	24, // 34: lore.v1.LoreService.GetReputation:output_type -> lore.v1.GetReputationResponse
+	27, // 35: lore.v1.LoreService.SearchAccounts:output_type -> lore.v1.SearchAccountsResponse
+	30, // [30:36] is the sub-list for method output_type
+	24, // [24:30] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
+}
+
+func init() { file_lore_v1_lore_proto_init() }
+func file_lore_v1_lore_proto_init() {
+	if File_lore_v1_lore_proto != nil {
+		return
+	}
+	file_lore_v1_lore_proto_msgTypes[0].OneofWrappers = []any{}
+	file_lore_v1_lore_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_lore_v1_lore_proto_rawDesc), len(file_lore_v1_lore_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_lore_v1_lore_proto_goTypes,
+		DependencyIndexes: file_lore_v1_lore_proto_depIdxs,
+		EnumInfos:         file_lore_v1_lore_proto_enumTypes,
+		MessageInfos:      file_lore_v1_lore_proto_msgTypes,
+	}.Build()
+	File_lore_v1_lore_proto = out.File
+	file_lore_v1_lore_proto_goTypes = nil
+	file_lore_v1_lore_proto_depIdxs = nil
+}